@@ -30,6 +30,189 @@ CREATE DATABASE /*!32312 IF NOT EXISTS*/ `miniblog` /*!40100 DEFAULT CHARACTER S
 
 USE `miniblog`;
 
+--
+-- Table structure for table `audit_log`
+--
+
+DROP TABLE IF EXISTS `audit_log`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `audit_log` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `operator` varchar(255) NOT NULL,
+  `action` varchar(64) NOT NULL,
+  `resource` varchar(255) NOT NULL,
+  `detail` text DEFAULT NULL,
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `idx_resource` (`resource`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `category`
+--
+
+DROP TABLE IF EXISTS `category`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `category` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(64) NOT NULL,
+  `parentID` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updatedAt` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_parentID_name` (`parentID`,`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `comment`
+--
+
+DROP TABLE IF EXISTS `comment`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `comment` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `commentID` varchar(256) NOT NULL,
+  `postID` varchar(256) NOT NULL,
+  `username` varchar(255) NOT NULL,
+  `rootID` varchar(256) NOT NULL DEFAULT '',
+  `parentID` varchar(256) NOT NULL DEFAULT '',
+  `content` text NOT NULL,
+  `status` varchar(16) NOT NULL DEFAULT 'approved',
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updatedAt` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  `deletedAt` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `commentID` (`commentID`),
+  KEY `idx_postID_rootID_createdAt` (`postID`,`rootID`,`createdAt`,`id`),
+  KEY `idx_username` (`username`),
+  KEY `idx_deletedAt` (`deletedAt`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `event_outbox`
+--
+
+DROP TABLE IF EXISTS `event_outbox`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `event_outbox` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `eventID` varchar(256) NOT NULL,
+  `type` varchar(64) NOT NULL,
+  `payload` longtext NOT NULL,
+  `status` varchar(16) NOT NULL,
+  `attempts` int(11) NOT NULL DEFAULT 0,
+  `nextAttemptAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  `error` varchar(1024) NOT NULL DEFAULT '',
+  `dispatchedAt` timestamp NULL DEFAULT NULL,
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updatedAt` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_eventID` (`eventID`),
+  KEY `idx_status_nextAttemptAt` (`status`,`nextAttemptAt`),
+  KEY `idx_createdAt` (`createdAt`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `follow`
+--
+
+DROP TABLE IF EXISTS `follow`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `follow` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `username` varchar(255) NOT NULL,
+  `followee` varchar(255) NOT NULL,
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_username_followee` (`username`,`followee`),
+  KEY `idx_username_createdAt` (`username`,`createdAt`,`id`),
+  KEY `idx_followee_createdAt` (`followee`,`createdAt`,`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `media`
+--
+
+DROP TABLE IF EXISTS `media`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `media` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `mediaID` varchar(256) NOT NULL,
+  `username` varchar(255) NOT NULL,
+  `filename` varchar(255) NOT NULL,
+  `contentType` varchar(64) NOT NULL,
+  `size` bigint(20) NOT NULL,
+  `width` int(11) NOT NULL DEFAULT 0,
+  `height` int(11) NOT NULL DEFAULT 0,
+  `blobKey` varchar(512) NOT NULL,
+  `thumbnailKey` varchar(512) NOT NULL DEFAULT '',
+  `thumbnailType` varchar(64) NOT NULL DEFAULT '',
+  `thumbnailSize` bigint(20) NOT NULL DEFAULT 0,
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_mediaID` (`mediaID`),
+  KEY `idx_username_createdAt` (`username`,`createdAt`),
+  KEY `idx_createdAt` (`createdAt`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `notification`
+--
+
+DROP TABLE IF EXISTS `notification`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `notification` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `notificationID` varchar(256) NOT NULL,
+  `username` varchar(255) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `actor` varchar(255) NOT NULL,
+  `resource` varchar(256) NOT NULL,
+  `postID` varchar(256) NOT NULL DEFAULT '',
+  `commentID` varchar(256) NOT NULL DEFAULT '',
+  `readAt` timestamp NULL DEFAULT NULL,
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_notificationID` (`notificationID`),
+  UNIQUE KEY `idx_username_type_resource` (`username`,`type`,`resource`),
+  KEY `idx_username_id` (`username`,`id`),
+  KEY `idx_actor` (`actor`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `notification_preference`
+--
+
+DROP TABLE IF EXISTS `notification_preference`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `notification_preference` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `username` varchar(255) NOT NULL,
+  `mention` tinyint(1) NOT NULL DEFAULT 1,
+  `follow` tinyint(1) NOT NULL DEFAULT 1,
+  `comment` tinyint(1) NOT NULL DEFAULT 1,
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updatedAt` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_username` (`username`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
 --
 -- Table structure for table `post`
 --
@@ -41,16 +224,208 @@ CREATE TABLE `post` (
   `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
   `username` varchar(255) NOT NULL,
   `postID` varchar(256) NOT NULL,
+  `slug` varchar(256) NOT NULL,
   `title` varchar(256) NOT NULL,
   `content` longtext NOT NULL,
+  `contentFormat` varchar(16) NOT NULL DEFAULT 'plain',
+  `contentHTML` longtext,
+  `status` varchar(16) NOT NULL DEFAULT 'published',
+  `visibility` varchar(16) NOT NULL DEFAULT 'public',
+  `publishAt` timestamp NULL DEFAULT NULL,
+  `categoryID` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `commentPolicy` varchar(16) NOT NULL DEFAULT 'open',
   `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
   `updatedAt` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  `deletedAt` timestamp NULL DEFAULT NULL,
   PRIMARY KEY (`id`),
   UNIQUE KEY `postID` (`postID`),
-  KEY `idx_username` (`username`)
+  UNIQUE KEY `idx_username_slug` (`username`,`slug`),
+  KEY `idx_username` (`username`),
+  KEY `idx_username_createdAt` (`username`,`createdAt`,`id`),
+  KEY `idx_username_updatedAt` (`username`,`updatedAt`,`id`),
+  KEY `idx_username_title` (`username`,`title`,`id`),
+  KEY `idx_deletedAt` (`deletedAt`),
+  KEY `idx_status_publishAt` (`status`,`publishAt`),
+  KEY `idx_status_visibility_createdAt` (`status`,`visibility`,`createdAt`,`id`),
+  KEY `idx_categoryID` (`categoryID`),
+  FULLTEXT KEY `ft_title_content` (`title`,`content`)
 ) ENGINE=InnoDB AUTO_INCREMENT=141 DEFAULT CHARSET=utf8;
 /*!40101 SET character_set_client = @saved_cs_client */;
 
+--
+-- Table structure for table `post_import`
+--
+
+DROP TABLE IF EXISTS `post_import`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `post_import` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `importID` varchar(256) NOT NULL,
+  `username` varchar(255) NOT NULL,
+  `format` varchar(16) NOT NULL,
+  `status` varchar(16) NOT NULL,
+  `total` int(11) NOT NULL DEFAULT 0,
+  `processed` int(11) NOT NULL DEFAULT 0,
+  `created` int(11) NOT NULL DEFAULT 0,
+  `skipped` int(11) NOT NULL DEFAULT 0,
+  `failed` int(11) NOT NULL DEFAULT 0,
+  `items` longtext,
+  `error` varchar(1024) NOT NULL DEFAULT '',
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updatedAt` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  `leaseExpiresAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_importID` (`importID`),
+  KEY `idx_username` (`username`),
+  KEY `idx_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `post_media`
+--
+
+DROP TABLE IF EXISTS `post_media`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `post_media` (
+  `postID` varchar(256) NOT NULL,
+  `mediaID` varchar(256) NOT NULL,
+  PRIMARY KEY (`postID`,`mediaID`),
+  KEY `idx_mediaID` (`mediaID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `post_revision`
+--
+
+DROP TABLE IF EXISTS `post_revision`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `post_revision` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `postID` varchar(256) NOT NULL,
+  `revision` bigint(20) unsigned NOT NULL,
+  `username` varchar(255) NOT NULL,
+  `title` varchar(256) NOT NULL,
+  `content` longtext NOT NULL,
+  `contentFormat` varchar(16) NOT NULL DEFAULT 'plain',
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_postID_revision` (`postID`,`revision`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `post_slug`
+--
+
+DROP TABLE IF EXISTS `post_slug`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `post_slug` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `username` varchar(255) NOT NULL,
+  `slug` varchar(256) NOT NULL,
+  `postID` varchar(256) NOT NULL,
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_username_slug` (`username`,`slug`),
+  KEY `idx_postID` (`postID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `post_tag`
+--
+
+DROP TABLE IF EXISTS `post_tag`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `post_tag` (
+  `postID` varchar(256) NOT NULL,
+  `tagID` bigint(20) unsigned NOT NULL,
+  PRIMARY KEY (`postID`,`tagID`),
+  KEY `idx_tagID` (`tagID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `reaction`
+--
+
+DROP TABLE IF EXISTS `reaction`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `reaction` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `username` varchar(255) NOT NULL,
+  `targetType` varchar(16) NOT NULL,
+  `targetID` varchar(256) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_username_targetID_type` (`username`,`targetID`,`type`),
+  KEY `idx_username_targetType_type_createdAt` (`username`,`targetType`,`type`,`createdAt`,`id`),
+  KEY `idx_targetID` (`targetID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `reaction_count`
+--
+
+DROP TABLE IF EXISTS `reaction_count`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `reaction_count` (
+  `targetID` varchar(256) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `count` bigint(20) NOT NULL DEFAULT 0,
+  PRIMARY KEY (`targetID`,`type`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `tag`
+--
+
+DROP TABLE IF EXISTS `tag`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `tag` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(32) NOT NULL,
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updatedAt` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `timeline`
+--
+
+DROP TABLE IF EXISTS `timeline`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `timeline` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `username` varchar(255) NOT NULL,
+  `postID` varchar(256) NOT NULL,
+  `author` varchar(255) NOT NULL,
+  `publishAt` timestamp NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_username_postID` (`username`,`postID`),
+  KEY `idx_username_publishAt` (`username`,`publishAt`,`id`),
+  KEY `idx_username_author` (`username`,`author`),
+  KEY `idx_postID` (`postID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
 --
 -- Table structure for table `user`
 --
@@ -65,11 +440,102 @@ CREATE TABLE `user` (
   `nickname` varchar(30) NOT NULL,
   `email` varchar(256) NOT NULL,
   `phone` varchar(16) NOT NULL,
+  `bio` varchar(2048) NOT NULL DEFAULT '',
+  `avatar` varchar(64) NOT NULL DEFAULT '',
+  `links` varchar(1536) NOT NULL DEFAULT '',
+  `timezone` varchar(64) NOT NULL DEFAULT '',
+  `locale` varchar(35) NOT NULL DEFAULT '',
+  `showEmail` tinyint(1) NOT NULL DEFAULT 0,
+  `showPhone` tinyint(1) NOT NULL DEFAULT 0,
+  `showTimezone` tinyint(1) NOT NULL DEFAULT 0,
+  `state` varchar(16) NOT NULL DEFAULT 'active',
+  `stateReason` varchar(255) NOT NULL DEFAULT '',
+  `stateExpiresAt` timestamp NULL DEFAULT NULL,
+  `tokensRevokedAt` timestamp NULL DEFAULT NULL,
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updatedAt` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `username` (`username`),
+  KEY `idx_createdAt` (`createdAt`,`id`),
+  KEY `idx_state_createdAt` (`state`,`createdAt`,`id`)
+) ENGINE=InnoDB AUTO_INCREMENT=27 DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `user_alias`
+--
+
+DROP TABLE IF EXISTS `user_alias`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `user_alias` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `alias` varchar(255) NOT NULL,
+  `username` varchar(255) NOT NULL,
+  `expiresAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_alias` (`alias`),
+  KEY `idx_username` (`username`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `webhook`
+--
+
+DROP TABLE IF EXISTS `webhook`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `webhook` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `webhookID` varchar(256) NOT NULL,
+  `username` varchar(255) NOT NULL,
+  `url` varchar(2048) NOT NULL,
+  `secret` varchar(256) NOT NULL,
+  `events` varchar(1024) NOT NULL,
+  `active` tinyint(1) NOT NULL DEFAULT 1,
+  `disabledReason` varchar(1024) NOT NULL DEFAULT '',
+  `consecutiveFailures` int(11) NOT NULL DEFAULT 0,
+  `lastDeliveryAt` timestamp NULL DEFAULT NULL,
+  `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updatedAt` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_webhookID` (`webhookID`),
+  KEY `idx_username` (`username`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `webhook_delivery`
+--
+
+DROP TABLE IF EXISTS `webhook_delivery`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `webhook_delivery` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `deliveryID` varchar(256) NOT NULL,
+  `webhookID` varchar(256) NOT NULL,
+  `username` varchar(255) NOT NULL,
+  `event` varchar(64) NOT NULL,
+  `payload` longtext NOT NULL,
+  `status` varchar(16) NOT NULL,
+  `attempts` int(11) NOT NULL DEFAULT 0,
+  `nextAttemptAt` timestamp NOT NULL DEFAULT current_timestamp(),
+  `statusCode` int(11) NOT NULL DEFAULT 0,
+  `response` varchar(1024) NOT NULL DEFAULT '',
+  `error` varchar(1024) NOT NULL DEFAULT '',
+  `deliveredAt` timestamp NULL DEFAULT NULL,
   `createdAt` timestamp NOT NULL DEFAULT current_timestamp(),
   `updatedAt` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
   PRIMARY KEY (`id`),
-  UNIQUE KEY `username` (`username`)
-) ENGINE=MyISAM AUTO_INCREMENT=27 DEFAULT CHARSET=utf8;
+  UNIQUE KEY `idx_deliveryID` (`deliveryID`),
+  KEY `idx_webhookID_id` (`webhookID`,`id`),
+  KEY `idx_status_nextAttemptAt` (`status`,`nextAttemptAt`),
+  KEY `idx_username` (`username`),
+  KEY `idx_createdAt` (`createdAt`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
 /*!40101 SET character_set_client = @saved_cs_client */;
 /*!40103 SET TIME_ZONE=@OLD_TIME_ZONE */;
 
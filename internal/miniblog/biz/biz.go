@@ -8,8 +8,16 @@ package biz
 //go:generate mockgen -destination mock_biz.go -package biz github.com/marmotedu/miniblog/internal/miniblog/biz IBiz
 
 import (
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/category"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/comment"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/media"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/notification"
 	"github.com/marmotedu/miniblog/internal/miniblog/biz/post"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/reaction"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/stream"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/tag"
 	"github.com/marmotedu/miniblog/internal/miniblog/biz/user"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/webhook"
 	"github.com/marmotedu/miniblog/internal/miniblog/store"
 )
 
@@ -17,6 +25,14 @@ import (
 type IBiz interface {
 	Users() user.UserBiz
 	Posts() post.PostBiz
+	Tags() tag.TagBiz
+	Categories() category.CategoryBiz
+	Comments() comment.CommentBiz
+	Reactions() reaction.ReactionBiz
+	Media() media.MediaBiz
+	Webhooks() webhook.WebhookBiz
+	Notifications() notification.NotificationBiz
+	Streams() stream.StreamBiz
 }
 
 // 确保 biz 实现了 IBiz 接口.
@@ -44,3 +60,43 @@ func (b *biz) Users() user.UserBiz {
 func (b *biz) Posts() post.PostBiz {
 	return post.New(b.ds)
 }
+
+// Tags 返回一个实现了 TagBiz 接口的实例.
+func (b *biz) Tags() tag.TagBiz {
+	return tag.New(b.ds)
+}
+
+// Categories 返回一个实现了 CategoryBiz 接口的实例.
+func (b *biz) Categories() category.CategoryBiz {
+	return category.New(b.ds)
+}
+
+// Comments 返回一个实现了 CommentBiz 接口的实例.
+func (b *biz) Comments() comment.CommentBiz {
+	return comment.New(b.ds)
+}
+
+// Reactions 返回一个实现了 ReactionBiz 接口的实例.
+func (b *biz) Reactions() reaction.ReactionBiz {
+	return reaction.New(b.ds)
+}
+
+// Media 返回一个实现了 MediaBiz 接口的实例.
+func (b *biz) Media() media.MediaBiz {
+	return media.New(b.ds)
+}
+
+// Webhooks 返回一个实现了 WebhookBiz 接口的实例.
+func (b *biz) Webhooks() webhook.WebhookBiz {
+	return webhook.New(b.ds)
+}
+
+// Notifications 返回一个实现了 NotificationBiz 接口的实例.
+func (b *biz) Notifications() notification.NotificationBiz {
+	return notification.New(b.ds)
+}
+
+// Streams 返回一个实现了 StreamBiz 接口的实例.
+func (b *biz) Streams() stream.StreamBiz {
+	return stream.New(b.ds)
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package category
+
+//go:generate mockgen -destination mock_category.go -package category github.com/marmotedu/miniblog/internal/miniblog/biz/category CategoryBiz
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// CategoryBiz defines functions used to handle category request.
+type CategoryBiz interface {
+	Create(ctx context.Context, r *v1.CreateCategoryRequest) (*v1.CreateCategoryResponse, error)
+	List(ctx context.Context) (*v1.ListCategoryResponse, error)
+}
+
+// The implementation of CategoryBiz interface.
+type categoryBiz struct {
+	ds store.IStore
+}
+
+// Make sure that categoryBiz implements the CategoryBiz interface.
+var _ CategoryBiz = (*categoryBiz)(nil)
+
+func New(ds store.IStore) *categoryBiz {
+	return &categoryBiz{ds: ds}
+}
+
+// Create is the implementation of the `Create` method in CategoryBiz interface.
+func (b *categoryBiz) Create(ctx context.Context, r *v1.CreateCategoryRequest) (*v1.CreateCategoryResponse, error) {
+	if r.ParentID != 0 {
+		if _, err := b.ds.Categories().Get(ctx, r.ParentID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errno.ErrCategoryNotFound
+			}
+
+			return nil, err
+		}
+	}
+
+	categoryM := model.CategoryM{Name: r.Name, ParentID: r.ParentID}
+	if err := b.ds.Categories().Create(ctx, &categoryM); err != nil {
+		if match, _ := regexp.MatchString("Duplicate entry '.*' for key", err.Error()); match {
+			return nil, errno.ErrCategoryAlreadyExist
+		}
+
+		return nil, err
+	}
+
+	return &v1.CreateCategoryResponse{ID: categoryM.ID}, nil
+}
+
+// List is the implementation of the `List` method in CategoryBiz interface.
+// It returns all categories as a tree.
+func (b *categoryBiz) List(ctx context.Context) (*v1.ListCategoryResponse, error) {
+	list, err := b.ds.Categories().List(ctx)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to list categories from storage", "err", err)
+		return nil, err
+	}
+
+	return &v1.ListCategoryResponse{Categories: buildTree(list)}, nil
+}
+
+// buildTree builds the category tree and returns the top level categories.
+// The categories whose parent does not exist are treated as top level categories.
+func buildTree(list []*model.CategoryM) []*v1.CategoryInfo {
+	nodes := make(map[int64]*v1.CategoryInfo, len(list))
+	for _, item := range list {
+		nodes[item.ID] = &v1.CategoryInfo{ID: item.ID, Name: item.Name, ParentID: item.ParentID}
+	}
+
+	roots := make([]*v1.CategoryInfo, 0)
+	for _, item := range list {
+		node := nodes[item.ID]
+		if parent, ok := nodes[item.ParentID]; ok && item.ParentID != item.ID {
+			parent.Children = append(parent.Children, node)
+			continue
+		}
+
+		roots = append(roots, node)
+	}
+
+	return roots
+}
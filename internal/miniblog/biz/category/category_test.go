@@ -0,0 +1,33 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package category
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+func Test_buildTree(t *testing.T) {
+	list := []*model.CategoryM{
+		{ID: 1, Name: "tech"},
+		{ID: 3, Name: "life"},
+		{ID: 2, Name: "go", ParentID: 1},
+		{ID: 4, Name: "orphan", ParentID: 99},
+		{ID: 5, Name: "generics", ParentID: 2},
+	}
+
+	roots := buildTree(list)
+	assert.Len(t, roots, 3)
+	assert.Equal(t, "tech", roots[0].Name)
+	assert.Equal(t, "life", roots[1].Name)
+	assert.Equal(t, "orphan", roots[2].Name)
+	assert.Len(t, roots[0].Children, 1)
+	assert.Equal(t, "go", roots[0].Children[0].Name)
+	assert.Equal(t, "generics", roots[0].Children[0].Children[0].Name)
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/marmotedu/miniblog/internal/miniblog/biz/category (interfaces: CategoryBiz)
+
+// Package category is a generated GoMock package.
+package category
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// MockCategoryBiz is a mock of CategoryBiz interface.
+type MockCategoryBiz struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryBizMockRecorder
+}
+
+// MockCategoryBizMockRecorder is the mock recorder for MockCategoryBiz.
+type MockCategoryBizMockRecorder struct {
+	mock *MockCategoryBiz
+}
+
+// NewMockCategoryBiz creates a new mock instance.
+func NewMockCategoryBiz(ctrl *gomock.Controller) *MockCategoryBiz {
+	mock := &MockCategoryBiz{ctrl: ctrl}
+	mock.recorder = &MockCategoryBizMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryBiz) EXPECT() *MockCategoryBizMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCategoryBiz) Create(arg0 context.Context, arg1 *v1.CreateCategoryRequest) (*v1.CreateCategoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*v1.CreateCategoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCategoryBizMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategoryBiz)(nil).Create), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockCategoryBiz) List(arg0 context.Context) (*v1.ListCategoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].(*v1.ListCategoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCategoryBizMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCategoryBiz)(nil).List), arg0)
+}
@@ -0,0 +1,333 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package comment
+
+//go:generate mockgen -destination mock_comment.go -package comment github.com/marmotedu/miniblog/internal/miniblog/biz/comment CommentBiz
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/event"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// CommentBiz defines functions used to handle comment request.
+type CommentBiz interface {
+	Create(ctx context.Context, username, postID string, r *v1.CreateCommentRequest) (*v1.CreateCommentResponse, error)
+	Update(ctx context.Context, username, postID, commentID string, r *v1.UpdateCommentRequest) error
+	Delete(ctx context.Context, username, postID, commentID string) error
+	List(ctx context.Context, username, postID string, r *v1.ListCommentRequest) (*v1.ListCommentResponse, error)
+	ListPublished(ctx context.Context, postID string, r *v1.ListCommentRequest) (*v1.ListCommentResponse, error)
+	Approve(ctx context.Context, username, postID, commentID string) error
+	Hide(ctx context.Context, username, postID, commentID string) error
+}
+
+// The implementation of CommentBiz interface.
+type commentBiz struct {
+	ds store.IStore
+}
+
+// Make sure that commentBiz implements the CommentBiz interface.
+var _ CommentBiz = (*commentBiz)(nil)
+
+func New(ds store.IStore) *commentBiz {
+	return &commentBiz{ds: ds}
+}
+
+// Create is the implementation of the `Create` method in CommentBiz interface.
+// Comments on a moderated post are pending until approved by the owner of the post.
+func (b *commentBiz) Create(ctx context.Context, username, postID string, r *v1.CreateCommentRequest) (*v1.CreateCommentResponse, error) {
+	post, err := b.getPost(ctx, username, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.CommentPolicy == model.PostCommentClosed {
+		return nil, errno.ErrCommentClosed
+	}
+
+	commentM := model.CommentM{
+		PostID:   postID,
+		Username: username,
+		Content:  r.Content,
+		Status:   model.CommentStatusApproved,
+	}
+
+	if r.ParentID != "" {
+		parent, err := b.getComment(ctx, postID, r.ParentID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only the comments which can be seen by the user can be replied.
+		if !Visible(parent, post, username) {
+			return nil, errno.ErrCommentNotFound
+		}
+
+		commentM.ParentID = parent.CommentID
+		commentM.RootID = parent.RootID
+		if commentM.RootID == "" {
+			commentM.RootID = parent.CommentID
+		}
+	}
+
+	if post.CommentPolicy == model.PostCommentModerated && username != post.Username {
+		commentM.Status = model.CommentStatusPending
+	}
+
+	err = b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.Comments().Create(ctx, &commentM); err != nil {
+			return err
+		}
+
+		return event.Publish(ctx, b.ds, event.CommentCreated, event.Comment{Username: username, PostID: postID, CommentID: commentM.CommentID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.CreateCommentResponse{CommentID: commentM.CommentID, Status: commentM.Status}, nil
+}
+
+// Update is the implementation of the `Update` method in CommentBiz interface.
+// Only the author can edit a comment. An edited comment on a moderated post needs to be approved again.
+func (b *commentBiz) Update(ctx context.Context, username, postID, commentID string, r *v1.UpdateCommentRequest) error {
+	post, err := b.getPost(ctx, username, postID)
+	if err != nil {
+		return err
+	}
+
+	commentM, err := b.getComment(ctx, postID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if commentM.Username != username {
+		return errno.ErrUnauthorized
+	}
+
+	commentM.Content = r.Content
+	if post.CommentPolicy == model.PostCommentModerated && username != post.Username && commentM.Status == model.CommentStatusApproved {
+		commentM.Status = model.CommentStatusPending
+	}
+
+	return b.ds.Comments().Update(ctx, commentM)
+}
+
+// Delete is the implementation of the `Delete` method in CommentBiz interface.
+// A comment can be deleted by its author or the owner of the post. Deleting the first comment of a thread deletes the whole thread.
+func (b *commentBiz) Delete(ctx context.Context, username, postID, commentID string) error {
+	post, err := b.getPost(ctx, username, postID)
+	if err != nil {
+		return err
+	}
+
+	commentM, err := b.getComment(ctx, postID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if commentM.Username != username && post.Username != username {
+		return errno.ErrUnauthorized
+	}
+
+	return b.ds.Comments().Delete(ctx, postID, commentID)
+}
+
+// Approve is the implementation of the `Approve` method in CommentBiz interface.
+func (b *commentBiz) Approve(ctx context.Context, username, postID, commentID string) error {
+	return b.moderate(ctx, username, postID, commentID, model.CommentStatusApproved)
+}
+
+// Hide is the implementation of the `Hide` method in CommentBiz interface.
+// Hiding the first comment of a thread hides the whole thread from the users other than the owner of the post.
+func (b *commentBiz) Hide(ctx context.Context, username, postID, commentID string) error {
+	return b.moderate(ctx, username, postID, commentID, model.CommentStatusHidden)
+}
+
+// moderate changes the status of a comment, which can only be done by the owner of the post.
+func (b *commentBiz) moderate(ctx context.Context, username, postID, commentID, status string) error {
+	post, err := b.getPost(ctx, username, postID)
+	if err != nil {
+		return err
+	}
+
+	if post.Username != username {
+		return errno.ErrUnauthorized
+	}
+
+	commentM, err := b.getComment(ctx, postID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if commentM.Status == status {
+		return nil
+	}
+
+	commentM.Status = status
+
+	return b.ds.Comments().Update(ctx, commentM)
+}
+
+// List is the implementation of the `List` method in CommentBiz interface.
+// The owner of the post can see all comments, the other users can only see the approved comments and their own pending comments.
+func (b *commentBiz) List(ctx context.Context, username, postID string, r *v1.ListCommentRequest) (*v1.ListCommentResponse, error) {
+	post, err := b.getPost(ctx, username, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &store.CommentFilter{Viewer: username}
+	if post.Username == username {
+		filter = &store.CommentFilter{Status: r.Status}
+	}
+
+	return b.list(ctx, postID, filter, r)
+}
+
+// ListPublished is the implementation of the `ListPublished` method in CommentBiz interface.
+// It lists the approved comments of a published post which is not private.
+func (b *commentBiz) ListPublished(ctx context.Context, postID string, r *v1.ListCommentRequest) (*v1.ListCommentResponse, error) {
+	if _, err := b.getPost(ctx, "", postID); err != nil {
+		return nil, err
+	}
+
+	return b.list(ctx, postID, &store.CommentFilter{Status: model.CommentStatusApproved}, r)
+}
+
+// list lists a page of threads of the post, the replies of each thread are nested in its first comment.
+func (b *commentBiz) list(ctx context.Context, postID string, filter *store.CommentFilter, r *v1.ListCommentRequest) (*v1.ListCommentResponse, error) {
+	opts, err := store.NewListOptions(r.Offset, r.Limit, r.PageToken, r.SkipTotalCount)
+	if err != nil {
+		return nil, errno.ErrPageTokenInvalid
+	}
+
+	// Threads are listed from the oldest to the newest.
+	opts.Ascending = true
+
+	count, roots, err := b.ds.Comments().ListThreads(ctx, postID, filter, opts)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidCursor) {
+			return nil, errno.ErrPageTokenInvalid
+		}
+
+		log.C(ctx).Errorw("Failed to list comments from storage", "err", err)
+		return nil, err
+	}
+
+	// The storage returns one more thread than the page size if there is a next page.
+	var nextPageToken string
+	if len(roots) > opts.PageSize() {
+		roots = roots[:opts.PageSize()]
+		last := roots[len(roots)-1]
+		nextPageToken = store.NewCursor(opts, last.CreatedAt, last.ID).Encode()
+	}
+
+	rootIDs := make([]string, 0, len(roots))
+	for _, root := range roots {
+		rootIDs = append(rootIDs, root.CommentID)
+	}
+
+	replies, err := b.ds.Comments().ListReplies(ctx, postID, rootIDs, filter)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to list comment replies from storage", "err", err)
+		return nil, err
+	}
+
+	commentIDs := make([]string, 0, len(roots)+len(replies))
+	for _, c := range append(roots, replies...) {
+		commentIDs = append(commentIDs, c.CommentID)
+	}
+
+	reactions, err := b.ds.Reactions().Counts(ctx, commentIDs)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to count comment reactions from storage", "err", err)
+		return nil, err
+	}
+
+	threads := make(map[string]*v1.CommentInfo, len(roots))
+	comments := make([]*v1.CommentInfo, 0, len(roots))
+	for _, root := range roots {
+		info := commentInfo(root)
+		info.Reactions = reactions[root.CommentID]
+		threads[root.CommentID] = info
+		comments = append(comments, info)
+	}
+
+	for _, reply := range replies {
+		if thread, ok := threads[reply.RootID]; ok {
+			info := commentInfo(reply)
+			info.Reactions = reactions[reply.CommentID]
+			thread.Replies = append(thread.Replies, info)
+		}
+	}
+
+	return &v1.ListCommentResponse{TotalCount: count, Comments: comments, NextPageToken: nextPageToken}, nil
+}
+
+// getPost returns the post which can be commented by the user. Only the owner can see the posts
+// which are not published or private, which are reported as not found to the other users.
+func (b *commentBiz) getPost(ctx context.Context, username, postID string) (*model.PostM, error) {
+	post, err := b.ds.Posts().GetByPostID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrPostNotFound
+		}
+
+		return nil, err
+	}
+
+	if post.Username != username && (post.Status != model.PostStatusPublished || post.Visibility == model.PostVisibilityPrivate) {
+		return nil, errno.ErrPostNotFound
+	}
+
+	return post, nil
+}
+
+// getComment returns the comment of the post.
+func (b *commentBiz) getComment(ctx context.Context, postID, commentID string) (*model.CommentM, error) {
+	comment, err := b.ds.Comments().Get(ctx, postID, commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrCommentNotFound
+		}
+
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// Visible reports whether the comment can be seen by the user.
+func Visible(comment *model.CommentM, post *model.PostM, username string) bool {
+	switch {
+	case post.Username == username, comment.Status == model.CommentStatusApproved:
+		return true
+	default:
+		return comment.Status == model.CommentStatusPending && comment.Username == username
+	}
+}
+
+// commentInfo converts a comment to v1.CommentInfo.
+func commentInfo(comment *model.CommentM) *v1.CommentInfo {
+	return &v1.CommentInfo{
+		CommentID: comment.CommentID,
+		Username:  comment.Username,
+		ParentID:  comment.ParentID,
+		Content:   comment.Content,
+		Status:    comment.Status,
+		CreatedAt: comment.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: comment.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
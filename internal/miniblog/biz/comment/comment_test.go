@@ -0,0 +1,124 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package comment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+func Test_commentBiz_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	posts := map[string]*model.PostM{
+		"post-open":      {PostID: "post-open", Username: "colin", Status: model.PostStatusPublished, Visibility: model.PostVisibilityPublic, CommentPolicy: model.PostCommentOpen},
+		"post-moderated": {PostID: "post-moderated", Username: "colin", Status: model.PostStatusPublished, Visibility: model.PostVisibilityPublic, CommentPolicy: model.PostCommentModerated},
+		"post-closed":    {PostID: "post-closed", Username: "colin", Status: model.PostStatusPublished, Visibility: model.PostVisibilityPublic, CommentPolicy: model.PostCommentClosed},
+		"post-draft":     {PostID: "post-draft", Username: "colin", Status: model.PostStatusDraft, Visibility: model.PostVisibilityPublic, CommentPolicy: model.PostCommentOpen},
+	}
+	comments := map[string]*model.CommentM{
+		"comment-root":    {PostID: "post-open", CommentID: "comment-root", Username: "belm", Status: model.CommentStatusApproved},
+		"comment-reply":   {PostID: "post-open", CommentID: "comment-reply", RootID: "comment-root", ParentID: "comment-root", Username: "belm", Status: model.CommentStatusApproved},
+		"comment-pending": {PostID: "post-open", CommentID: "comment-pending", Username: "belm", Status: model.CommentStatusPending},
+	}
+
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().GetByPostID(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, postID string) (*model.PostM, error) {
+			if post, ok := posts[postID]; ok {
+				return post, nil
+			}
+
+			return nil, gorm.ErrRecordNotFound
+		},
+	).AnyTimes()
+
+	var created *model.CommentM
+	mockCommentStore := store.NewMockCommentStore(ctrl)
+	mockCommentStore.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, postID, commentID string) (*model.CommentM, error) {
+			if comment, ok := comments[commentID]; ok && comment.PostID == postID {
+				return comment, nil
+			}
+
+			return nil, gorm.ErrRecordNotFound
+		},
+	).AnyTimes()
+	mockCommentStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, comment *model.CommentM) error {
+			created = comment
+
+			return nil
+		},
+	).AnyTimes()
+
+	// Every created comment publishes a comment.created event.
+	var events int
+	mockEventStore := store.NewMockEventStore(ctrl)
+	mockEventStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, e *model.EventM) error {
+			assert.Equal(t, "comment.created", e.Type)
+			events++
+
+			return nil
+		},
+	).AnyTimes()
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+	mockStore.EXPECT().Comments().AnyTimes().Return(mockCommentStore)
+	mockStore.EXPECT().Events().AnyTimes().Return(mockEventStore)
+	mockStore.EXPECT().TX(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+
+	tests := []struct {
+		name       string
+		username   string
+		postID     string
+		parentID   string
+		wantErr    error
+		wantStatus string
+		wantRootID string
+	}{
+		{name: "open", username: "belm", postID: "post-open", wantStatus: model.CommentStatusApproved},
+		{name: "moderated", username: "belm", postID: "post-moderated", wantStatus: model.CommentStatusPending},
+		{name: "moderated by owner", username: "colin", postID: "post-moderated", wantStatus: model.CommentStatusApproved},
+		{name: "closed", username: "belm", postID: "post-closed", wantErr: errno.ErrCommentClosed},
+		{name: "draft", username: "belm", postID: "post-draft", wantErr: errno.ErrPostNotFound},
+		{name: "reply to root", username: "colin", postID: "post-open", parentID: "comment-root", wantStatus: model.CommentStatusApproved, wantRootID: "comment-root"},
+		{name: "reply to reply", username: "colin", postID: "post-open", parentID: "comment-reply", wantStatus: model.CommentStatusApproved, wantRootID: "comment-root"},
+		{name: "reply to pending comment of others", username: "alice", postID: "post-open", parentID: "comment-pending", wantErr: errno.ErrCommentNotFound},
+		{name: "reply to comment of another post", username: "belm", postID: "post-moderated", parentID: "comment-root", wantErr: errno.ErrCommentNotFound},
+	}
+
+	b := New(mockStore)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created = nil
+			got, err := b.Create(context.Background(), tt.username, tt.postID, &v1.CreateCommentRequest{Content: "hi", ParentID: tt.parentID})
+			assert.Equal(t, tt.wantErr, err)
+			if err == nil {
+				assert.Equal(t, tt.wantStatus, got.Status)
+				assert.Equal(t, tt.wantRootID, created.RootID)
+				assert.Equal(t, tt.parentID, created.ParentID)
+			}
+		})
+	}
+	assert.Equal(t, 5, events)
+}
@@ -0,0 +1,143 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/marmotedu/miniblog/internal/miniblog/biz/comment (interfaces: CommentBiz)
+
+// Package comment is a generated GoMock package.
+package comment
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// MockCommentBiz is a mock of CommentBiz interface.
+type MockCommentBiz struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentBizMockRecorder
+}
+
+// MockCommentBizMockRecorder is the mock recorder for MockCommentBiz.
+type MockCommentBizMockRecorder struct {
+	mock *MockCommentBiz
+}
+
+// NewMockCommentBiz creates a new mock instance.
+func NewMockCommentBiz(ctrl *gomock.Controller) *MockCommentBiz {
+	mock := &MockCommentBiz{ctrl: ctrl}
+	mock.recorder = &MockCommentBizMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentBiz) EXPECT() *MockCommentBizMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockCommentBiz) Approve(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockCommentBizMockRecorder) Approve(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockCommentBiz)(nil).Approve), arg0, arg1, arg2, arg3)
+}
+
+// Create mocks base method.
+func (m *MockCommentBiz) Create(arg0 context.Context, arg1, arg2 string, arg3 *v1.CreateCommentRequest) (*v1.CreateCommentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.CreateCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentBizMockRecorder) Create(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentBiz)(nil).Create), arg0, arg1, arg2, arg3)
+}
+
+// Delete mocks base method.
+func (m *MockCommentBiz) Delete(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentBizMockRecorder) Delete(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentBiz)(nil).Delete), arg0, arg1, arg2, arg3)
+}
+
+// Hide mocks base method.
+func (m *MockCommentBiz) Hide(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hide", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Hide indicates an expected call of Hide.
+func (mr *MockCommentBizMockRecorder) Hide(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hide", reflect.TypeOf((*MockCommentBiz)(nil).Hide), arg0, arg1, arg2, arg3)
+}
+
+// List mocks base method.
+func (m *MockCommentBiz) List(arg0 context.Context, arg1, arg2 string, arg3 *v1.ListCommentRequest) (*v1.ListCommentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.ListCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCommentBizMockRecorder) List(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCommentBiz)(nil).List), arg0, arg1, arg2, arg3)
+}
+
+// ListPublished mocks base method.
+func (m *MockCommentBiz) ListPublished(arg0 context.Context, arg1 string, arg2 *v1.ListCommentRequest) (*v1.ListCommentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPublished", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.ListCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPublished indicates an expected call of ListPublished.
+func (mr *MockCommentBizMockRecorder) ListPublished(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublished", reflect.TypeOf((*MockCommentBiz)(nil).ListPublished), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockCommentBiz) Update(arg0 context.Context, arg1, arg2, arg3 string, arg4 *v1.UpdateCommentRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCommentBizMockRecorder) Update(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCommentBiz)(nil).Update), arg0, arg1, arg2, arg3, arg4)
+}
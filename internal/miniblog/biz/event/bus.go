@@ -0,0 +1,181 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package event
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	"github.com/marmotedu/miniblog/pkg/util/retry"
+)
+
+const (
+	// MaxAttempts is the number of attempts to dispatch an event before it fails.
+	MaxAttempts = 10
+
+	// initialBackoff is the delay before the first retry of an event, it is doubled for each further retry.
+	initialBackoff = 10 * time.Second
+
+	// maxBackoff is the maximum delay between two attempts to dispatch an event.
+	maxBackoff = time.Hour
+
+	// claimLease is how long a claimed event is reserved for the process which claimed it. The event is
+	// dispatched again after the lease if the process exits before recording the result.
+	claimLease = 5 * time.Minute
+
+	// dispatchBatchSize is the number of due events fetched at a time by Dispatch.
+	dispatchBatchSize = 100
+
+	// maxErrorLength is the maximum number of bytes of the error kept in the outbox.
+	maxErrorLength = 1024
+)
+
+// Handler handles an event. Events are delivered at least once and a retried event may be handled after
+// the events which follow it, so handlers must be idempotent and should not rely on the order of events.
+type Handler func(ctx context.Context, e *Event) error
+
+// subscription is a handler registered for a type of events.
+type subscription struct {
+	name    string
+	handler Handler
+}
+
+// Bus dispatches the events in the outbox to the handlers subscribing to them. The handlers of an event are
+// called in the order of subscription, and the event is retried with all its handlers if any of them fails.
+type Bus struct {
+	mu            sync.RWMutex
+	subscriptions map[string][]subscription
+	notify        chan struct{}
+}
+
+// Default is the bus used by the server, Publish notifies it of the new events.
+var Default = NewBus()
+
+// NewBus creates a bus without any handler.
+func NewBus() *Bus {
+	return &Bus{subscriptions: map[string][]subscription{}, notify: make(chan struct{}, 1)}
+}
+
+// Subscribe registers handler for the events of the given types on the default bus.
+func Subscribe(name string, handler Handler, types ...string) {
+	Default.Subscribe(name, handler, types...)
+}
+
+// Subscribe registers handler for the events of the given types, name identifies the handler in the logs
+// and in the errors of the events.
+func (bus *Bus) Subscribe(name string, handler Handler, types ...string) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	for _, typ := range types {
+		bus.subscriptions[typ] = append(bus.subscriptions[typ], subscription{name: name, handler: handler})
+	}
+}
+
+// Notify tells the dispatcher that new events are waiting, it never blocks.
+func (bus *Bus) Notify() {
+	select {
+	case bus.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Notified returns the channel which receives a value after Notify is called.
+func (bus *Bus) Notified() <-chan struct{} {
+	return bus.notify
+}
+
+// Dispatch dispatches the due events in the outbox to their handlers, and returns the number of dispatched
+// events, including the ones which failed and will be retried.
+func (bus *Bus) Dispatch(ctx context.Context, ds store.IStore) (int64, error) {
+	var count int64
+	for {
+		list, err := ds.Events().ListDue(ctx, time.Now(), dispatchBatchSize)
+		if err != nil {
+			return count, err
+		}
+
+		// The events are handled one by one, so that they are handled in order unless they are retried.
+		for _, eventM := range list {
+			claimed, err := ds.Events().Claim(ctx, eventM, time.Now().Add(claimLease))
+			if err != nil {
+				return count, err
+			}
+
+			if !claimed {
+				continue
+			}
+
+			count++
+			if err := bus.dispatch(ctx, ds, eventM); err != nil {
+				log.C(ctx).Errorw("Failed to record event dispatch", "eventID", eventM.EventID, "err", err)
+			}
+		}
+
+		if len(list) < dispatchBatchSize || ctx.Err() != nil {
+			return count, nil
+		}
+	}
+}
+
+// dispatch calls the handlers of a claimed event and records the result.
+func (bus *Bus) dispatch(ctx context.Context, ds store.IStore, eventM *model.EventM) error {
+	e := &Event{ID: eventM.EventID, Type: eventM.Type, Payload: []byte(eventM.Payload), CreatedAt: eventM.CreatedAt}
+
+	bus.mu.RLock()
+	subscriptions := bus.subscriptions[e.Type]
+	bus.mu.RUnlock()
+
+	var err error
+	for _, sub := range subscriptions {
+		if err = handle(ctx, sub, e); err != nil {
+			break
+		}
+	}
+
+	// The server is stopping, leave the event to be dispatched again after the lease.
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	now := time.Now()
+	switch {
+	case err == nil:
+		eventM.Status = model.EventStatusDispatched
+		eventM.Error = ""
+		eventM.DispatchedAt = &now
+	case eventM.Attempts >= MaxAttempts:
+		log.C(ctx).Errorw("Failed to dispatch event, giving up", "eventID", e.ID, "type", e.Type, "err", err)
+		eventM.Status = model.EventStatusFailed
+		eventM.Error = retry.Truncate(err.Error(), maxErrorLength)
+	default:
+		log.C(ctx).Warnw("Failed to dispatch event, will retry", "eventID", e.ID, "type", e.Type, "err", err)
+		eventM.Error = retry.Truncate(err.Error(), maxErrorLength)
+		eventM.NextAttemptAt = now.Add(retry.Backoff(eventM.Attempts, initialBackoff, maxBackoff))
+	}
+
+	return ds.Events().Update(ctx, eventM)
+}
+
+// handle calls the handler of sub, a panic of the handler is returned as an error.
+func handle(ctx context.Context, sub subscription, e *Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s: panic: %v", sub.name, r)
+		}
+	}()
+
+	if err := sub.handler(ctx, e); err != nil {
+		return fmt.Errorf("%s: %w", sub.name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	"github.com/marmotedu/miniblog/pkg/util/retry"
+)
+
+func Test_backoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, retry.Backoff(1, initialBackoff, maxBackoff))
+	assert.Equal(t, 20*time.Second, retry.Backoff(2, initialBackoff, maxBackoff))
+	assert.Equal(t, 640*time.Second, retry.Backoff(7, initialBackoff, maxBackoff))
+	assert.Equal(t, maxBackoff, retry.Backoff(10, initialBackoff, maxBackoff))
+}
+
+func TestPublish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEventStore := store.NewMockEventStore(ctrl)
+	mockEventStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, e *model.EventM) error {
+			assert.Equal(t, PostCreated, e.Type)
+			assert.Equal(t, `{"username":"belm","postID":"post-1"}`, e.Payload)
+			assert.Equal(t, model.EventStatusPending, e.Status)
+
+			return nil
+		},
+	).Times(1)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Events().AnyTimes().Return(mockEventStore)
+
+	assert.Nil(t, Publish(context.Background(), mockStore, PostCreated, Post{Username: "belm", PostID: "post-1"}))
+
+	// The default bus is notified when there is no transaction.
+	select {
+	case <-Default.Notified():
+	default:
+		t.Error("the default bus is not notified")
+	}
+}
+
+func TestBus_Dispatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	events := []*model.EventM{
+		{ID: 1, EventID: "event-1", Type: PostCreated, Payload: `{"username":"belm","postID":"post-1"}`, Status: model.EventStatusPending},
+		{ID: 2, EventID: "event-2", Type: PostDeleted, Payload: `{"username":"belm","postID":"post-2"}`, Status: model.EventStatusPending},
+		{ID: 3, EventID: "event-3", Type: PostDeleted, Payload: `{"username":"belm","postID":"post-3"}`, Status: model.EventStatusPending, Attempts: MaxAttempts - 1},
+		{ID: 4, EventID: "event-4", Type: UserUpdated, Payload: `{"username":"belm"}`, Status: model.EventStatusPending},
+		{ID: 5, EventID: "event-5", Type: UserCreated, Payload: `{"username":"colin"}`, Status: model.EventStatusPending},
+		{ID: 6, EventID: "event-6", Type: UserCreated, Payload: `{"username":"jack"}`, Status: model.EventStatusPending},
+	}
+
+	mockEventStore := store.NewMockEventStore(ctrl)
+	mockEventStore.EXPECT().ListDue(gomock.Any(), gomock.Any(), dispatchBatchSize).Return(events, nil).Times(1)
+	mockEventStore.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, e *model.EventM, until time.Time) (bool, error) {
+			// event-6 is claimed by another instance.
+			if e.EventID == "event-6" {
+				return false, nil
+			}
+
+			e.Attempts++
+			e.NextAttemptAt = until
+
+			return true, nil
+		},
+	).Times(6)
+	mockEventStore.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(5)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Events().AnyTimes().Return(mockEventStore)
+
+	var handled []string
+	bus := NewBus()
+	bus.Subscribe("Record", func(ctx context.Context, e *Event) error {
+		var p Post
+		if err := e.Decode(&p); err != nil {
+			return err
+		}
+
+		handled = append(handled, e.Type+" "+p.PostID)
+
+		return nil
+	}, PostCreated, PostDeleted)
+	bus.Subscribe("Fail", func(ctx context.Context, e *Event) error {
+		return errors.New("index unavailable")
+	}, PostDeleted)
+	bus.Subscribe("Panic", func(ctx context.Context, e *Event) error {
+		panic("boom")
+	}, UserCreated)
+
+	start := time.Now()
+	count, err := bus.Dispatch(context.Background(), mockStore)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), count)
+	assert.Equal(t, []string{"post.created post-1", "post.deleted post-2", "post.deleted post-3"}, handled)
+
+	assert.Equal(t, model.EventStatusDispatched, events[0].Status)
+	assert.NotNil(t, events[0].DispatchedAt)
+
+	// A failed event is retried with back-off.
+	assert.Equal(t, model.EventStatusPending, events[1].Status)
+	assert.Equal(t, "Fail: index unavailable", events[1].Error)
+	assert.True(t, events[1].NextAttemptAt.After(start.Add(initialBackoff-time.Second)))
+
+	// The event fails after the last attempt.
+	assert.Equal(t, model.EventStatusFailed, events[2].Status)
+
+	// An event without handler is dispatched.
+	assert.Equal(t, model.EventStatusDispatched, events[3].Status)
+
+	// A panic of a handler fails the event.
+	assert.Equal(t, model.EventStatusPending, events[4].Status)
+	assert.Equal(t, "Panic: panic: boom", events[4].Error)
+
+	assert.Equal(t, 0, events[5].Attempts)
+}
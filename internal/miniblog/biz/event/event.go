@@ -0,0 +1,118 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Package event implements the domain events of miniblog with a transactional outbox. The biz layer
+// publishes an event in the transaction which makes the change, the event is saved with the change
+// and later dispatched at least once to the handlers subscribing to it, see Bus.
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// The types of the domain events. The post and follow events have the same names as the webhook events.
+const (
+	UserCreated    = "user.created"
+	UserUpdated    = "user.updated"
+	UserDeleted    = "user.deleted"
+	UserRenamed    = "user.renamed"
+	UserFollowed   = "user.followed"
+	UserUnfollowed = "user.unfollowed"
+	PostCreated    = "post.created"
+	PostUpdated    = "post.updated"
+	PostPublished  = "post.published"
+	PostDeleted    = "post.deleted"
+	PostRestored   = "post.restored"
+	CommentCreated = "comment.created"
+
+	NotificationCreated = "notification.created"
+)
+
+// User is the payload of the user events. Heir is only set in UserDeleted, it is the user who receives
+// the posts of the deleted user, and is empty if the posts are deleted.
+type User struct {
+	Username string `json:"username"`
+	Heir     string `json:"heir,omitempty"`
+}
+
+// Rename is the payload of UserRenamed, Username is the old username of the user.
+type Rename struct {
+	Username    string `json:"username"`
+	NewUsername string `json:"newUsername"`
+}
+
+// Post is the payload of the post events. The events only identify the post, handlers which need the
+// post read its current state, as it may have changed again since the event.
+type Post struct {
+	Username string `json:"username"`
+	PostID   string `json:"postID"`
+}
+
+// Follow is the payload of UserFollowed and UserUnfollowed, Username is the follower.
+type Follow struct {
+	Username string `json:"username"`
+	Followee string `json:"followee"`
+}
+
+// Comment is the payload of the comment events, Username is the author of the comment.
+type Comment struct {
+	Username  string `json:"username"`
+	PostID    string `json:"postID"`
+	CommentID string `json:"commentID"`
+}
+
+// Notification is the payload of NotificationCreated, Username is the recipient of the notification.
+type Notification struct {
+	Username       string `json:"username"`
+	NotificationID string `json:"notificationID"`
+}
+
+// Event is a domain event passed to the handlers.
+type Event struct {
+	ID        string
+	Type      string
+	Payload   json.RawMessage
+	CreatedAt time.Time
+}
+
+// Decode decodes the payload of the event into v.
+func (e *Event) Decode(v interface{}) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+// Publish saves an event of type typ with payload in the outbox. It must be called in the transaction
+// which makes the change of the event, so that the event is saved if and only if the change is committed.
+// The default bus is notified after the commit to dispatch the event without waiting for the next poll.
+func Publish(ctx context.Context, ds store.IStore, typ string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	eventM := &model.EventM{
+		Type:          typ,
+		Payload:       string(data),
+		Status:        model.EventStatusPending,
+		NextAttemptAt: time.Now(),
+	}
+	if err := ds.Events().Create(ctx, eventM); err != nil {
+		return err
+	}
+
+	store.AfterCommit(ctx, Default.Notify)
+
+	return nil
+}
+
+// Purge deletes the dispatched and failed events created more than retention ago, and returns the number
+// of deleted events.
+func Purge(ctx context.Context, ds store.IStore, retention time.Duration) (int64, error) {
+	return ds.Events().DeleteBefore(ctx, time.Now().Add(-retention))
+}
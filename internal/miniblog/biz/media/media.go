@@ -0,0 +1,428 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package media
+
+//go:generate mockgen -destination mock_media.go -package media github.com/marmotedu/miniblog/internal/miniblog/biz/media MediaBiz
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/thumbnail"
+	"github.com/marmotedu/miniblog/pkg/util/id"
+)
+
+const (
+	// DefaultMaxSize is the default maximum size of an uploaded file.
+	DefaultMaxSize = 10 << 20
+
+	// DefaultQuota is the default storage quota of each user.
+	DefaultQuota = 200 << 20
+
+	// ThumbnailSize is the length in pixels of the longer side of the thumbnails.
+	ThumbnailSize = 320
+
+	// URLPrefix is the prefix of the public URLs of media.
+	URLPrefix = "/v1/public/media/"
+
+	// maxFilenameLength is the maximum length in bytes of the stored filename.
+	maxFilenameLength = 255
+
+	// gcBatchSize is the number of unreferenced media deleted in a batch by CollectGarbage.
+	gcBatchSize = 100
+)
+
+// supportedTypes are the content types accepted for upload, detected from the content of the file.
+var supportedTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// limits holds the upload limits, which can be changed with Init.
+var limits = struct {
+	maxSize int64
+	quota   int64
+}{DefaultMaxSize, DefaultQuota}
+
+// Init sets the maximum size of an uploaded file and the storage quota of each user,
+// a value which is not positive keeps the default.
+func Init(maxSize, quota int64) {
+	if maxSize > 0 {
+		limits.maxSize = maxSize
+	}
+
+	if quota > 0 {
+		limits.quota = quota
+	}
+}
+
+// MaxSize returns the maximum size of an uploaded file.
+func MaxSize() int64 {
+	return limits.maxSize
+}
+
+// MediaBiz defines functions used to handle media request.
+type MediaBiz interface {
+	Upload(ctx context.Context, username, filename string, r io.Reader) (*v1.MediaInfo, error)
+	List(ctx context.Context, username string, r *v1.ListMediaRequest) (*v1.ListMediaResponse, error)
+	Delete(ctx context.Context, username, mediaID string) error
+	Open(ctx context.Context, mediaID string, thumbnail bool) (*Object, error)
+	CollectGarbage(ctx context.Context, grace time.Duration) (int64, error)
+}
+
+// Object is the content of a media file or its thumbnail, the caller must close it.
+type Object struct {
+	io.ReadCloser
+	Filename    string
+	ContentType string
+	Size        int64
+}
+
+// The implementation of MediaBiz interface.
+type mediaBiz struct {
+	ds store.IStore
+}
+
+// Make sure that mediaBiz implements the MediaBiz interface.
+var _ MediaBiz = (*mediaBiz)(nil)
+
+func New(ds store.IStore) *mediaBiz {
+	return &mediaBiz{ds: ds}
+}
+
+// Upload is the implementation of the `Upload` method in MediaBiz interface.
+// The type of the file is detected from its content instead of trusting the client, and a thumbnail is generated
+// for images larger than ThumbnailSize.
+func (b *mediaBiz) Upload(ctx context.Context, username, filename string, r io.Reader) (*v1.MediaInfo, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limits.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > limits.maxSize {
+		return nil, errno.ErrMediaTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if !supportedTypes[contentType] {
+		return nil, errno.ErrMediaTypeUnsupported
+	}
+
+	mediaID := "media-" + id.GenShortID()
+	media := &model.MediaM{
+		MediaID:     mediaID,
+		Username:    username,
+		Filename:    cleanFilename(filename),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		BlobKey:     "media/" + mediaID,
+	}
+
+	// WebP images can not be decoded by the standard library, they are stored without size and thumbnail.
+	var thumb []byte
+	if contentType != "image/webp" {
+		if _, media.Width, media.Height, err = thumbnail.DecodeConfig(data); err != nil {
+			return nil, errno.ErrMediaTypeUnsupported
+		}
+
+		// Images with too many pixels to decode safely are stored without thumbnail, the original image is served instead.
+		thumb, media.ThumbnailType, err = thumbnail.Make(data, ThumbnailSize)
+		if errors.Is(err, thumbnail.ErrTooLarge) {
+			log.C(ctx).Warnw("Image is too large to generate thumbnail", "mediaID", mediaID, "width", media.Width, "height", media.Height)
+		} else if err != nil {
+			return nil, errno.ErrMediaTypeUnsupported
+		}
+
+		if thumb != nil {
+			media.ThumbnailKey, media.ThumbnailSize = "thumbnails/"+mediaID, int64(len(thumb))
+		}
+	}
+
+	// Reject the upload early before saving the files, the quota is checked again when the record is inserted
+	if err := b.checkQuota(ctx, media); err != nil {
+		return nil, err
+	}
+
+	if err := b.ds.Blobs().Put(ctx, media.BlobKey, bytes.NewReader(data), media.Size, contentType); err != nil {
+		return nil, err
+	}
+
+	if thumb != nil {
+		if err := b.ds.Blobs().Put(ctx, media.ThumbnailKey, bytes.NewReader(thumb), media.ThumbnailSize, media.ThumbnailType); err != nil {
+			b.deleteBlobs(ctx, media)
+			return nil, err
+		}
+	}
+
+	// The user's record is locked so that concurrent uploads of the user can not exceed the quota together
+	err = b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.Users().Lock(ctx, username); err != nil {
+			return err
+		}
+
+		if err := b.checkQuota(ctx, media); err != nil {
+			return err
+		}
+
+		return b.ds.Media().Create(ctx, media)
+	})
+	if err != nil {
+		b.deleteBlobs(ctx, media)
+		return nil, err
+	}
+
+	return mediaInfo(media), nil
+}
+
+// checkQuota returns errno.ErrMediaQuotaExceeded if storing the media exceeds the quota of its owner.
+func (b *mediaBiz) checkQuota(ctx context.Context, media *model.MediaM) error {
+	used, err := b.ds.Media().Usage(ctx, media.Username)
+	if err != nil {
+		return err
+	}
+
+	if used+media.Size+media.ThumbnailSize > limits.quota {
+		return errno.ErrMediaQuotaExceeded
+	}
+
+	return nil
+}
+
+// List is the implementation of the `List` method in MediaBiz interface.
+func (b *mediaBiz) List(ctx context.Context, username string, r *v1.ListMediaRequest) (*v1.ListMediaResponse, error) {
+	count, list, err := b.ds.Media().List(ctx, username, r.Offset, r.Limit)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to list media from storage", "err", err)
+		return nil, err
+	}
+
+	used, err := b.ds.Media().Usage(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	media := make([]*v1.MediaInfo, 0, len(list))
+	for _, item := range list {
+		media = append(media, mediaInfo(item))
+	}
+
+	return &v1.ListMediaResponse{TotalCount: count, UsedBytes: used, QuotaBytes: limits.quota, Media: media}, nil
+}
+
+// Delete is the implementation of the `Delete` method in MediaBiz interface.
+// The media is deleted even if it is referenced by posts, the references are removed as well.
+func (b *mediaBiz) Delete(ctx context.Context, username, mediaID string) error {
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		media, err := b.lock(ctx, mediaID)
+		if err != nil {
+			return err
+		}
+
+		if media.Username != username {
+			return errno.ErrMediaNotFound
+		}
+
+		return b.remove(ctx, media)
+	})
+}
+
+// Open is the implementation of the `Open` method in MediaBiz interface.
+// The original file is returned if the thumbnail is requested but the media has no thumbnail.
+func (b *mediaBiz) Open(ctx context.Context, mediaID string, thumbnail bool) (*Object, error) {
+	media, err := b.get(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &Object{Filename: media.Filename, ContentType: media.ContentType, Size: media.Size}
+	key := media.BlobKey
+	if thumbnail && media.ThumbnailKey != "" {
+		key, obj.ContentType, obj.Size = media.ThumbnailKey, media.ThumbnailType, media.ThumbnailSize
+	}
+
+	if obj.ReadCloser, err = b.ds.Blobs().Get(ctx, key); err != nil {
+		if errors.Is(err, store.ErrBlobNotFound) {
+			return nil, errno.ErrMediaNotFound
+		}
+
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// CollectGarbage is the implementation of the `CollectGarbage` method in MediaBiz interface.
+// It deletes the media which are not referenced by any post and were uploaded more than grace ago,
+// the grace period leaves time to reference a newly uploaded media. It returns the number of deleted media.
+func (b *mediaBiz) CollectGarbage(ctx context.Context, grace time.Duration) (int64, error) {
+	before := time.Now().Add(-grace)
+
+	var count int64
+	for {
+		list, err := b.ds.Media().ListUnreferenced(ctx, before, gcBatchSize)
+		if err != nil {
+			return count, err
+		}
+
+		for _, item := range list {
+			deleted, err := b.removeUnreferenced(ctx, item.MediaID)
+			if err != nil {
+				return count, err
+			}
+
+			if deleted {
+				count++
+			}
+		}
+
+		if len(list) < gcBatchSize {
+			return count, nil
+		}
+	}
+}
+
+// get gets the media by mediaID, errno.ErrMediaNotFound is returned if it does not exist.
+func (b *mediaBiz) get(ctx context.Context, mediaID string) (*model.MediaM, error) {
+	media, err := b.ds.Media().Get(ctx, mediaID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrMediaNotFound
+		}
+
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// lock gets the media by mediaID and locks it until the end of the transaction,
+// errno.ErrMediaNotFound is returned if it does not exist.
+func (b *mediaBiz) lock(ctx context.Context, mediaID string) (*model.MediaM, error) {
+	media, err := b.ds.Media().Lock(ctx, mediaID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrMediaNotFound
+		}
+
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// removeUnreferenced deletes the media if it still exists and is not referenced by any post. The media is listed
+// before it is locked, so a post may have referenced it in the meantime. It reports whether the media is deleted.
+func (b *mediaBiz) removeUnreferenced(ctx context.Context, mediaID string) (bool, error) {
+	var deleted bool
+	err := b.ds.TX(ctx, func(ctx context.Context) error {
+		media, err := b.lock(ctx, mediaID)
+		if err != nil {
+			if errors.Is(err, errno.ErrMediaNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		referenced, err := b.ds.Media().Referenced(ctx, mediaID)
+		if err != nil || referenced {
+			return err
+		}
+
+		deleted = true
+
+		return b.remove(ctx, media)
+	})
+
+	return deleted && err == nil, err
+}
+
+// remove deletes the record of the locked media, and its files once the transaction is committed,
+// so that the files of a media are never deleted while its record is kept by a rolled back transaction.
+func (b *mediaBiz) remove(ctx context.Context, media *model.MediaM) error {
+	if err := b.ds.Media().Delete(ctx, media.MediaID); err != nil {
+		return err
+	}
+
+	store.AfterCommit(ctx, func() { b.deleteBlobs(ctx, media) })
+
+	return nil
+}
+
+// deleteBlobs deletes the files of a media which is deleted or whose upload failed, errors are only logged.
+func (b *mediaBiz) deleteBlobs(ctx context.Context, media *model.MediaM) {
+	for _, key := range []string{media.BlobKey, media.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+
+		if err := b.ds.Blobs().Delete(ctx, key); err != nil {
+			log.C(ctx).Errorw("Failed to delete blob of media", "mediaID", media.MediaID, "key", key, "err", err)
+		}
+	}
+}
+
+// mediaInfo converts a media record to the response.
+func mediaInfo(media *model.MediaM) *v1.MediaInfo {
+	info := &v1.MediaInfo{
+		MediaID:      media.MediaID,
+		Filename:     media.Filename,
+		ContentType:  media.ContentType,
+		Size:         media.Size,
+		Width:        media.Width,
+		Height:       media.Height,
+		URL:          URLPrefix + media.MediaID,
+		ThumbnailURL: URLPrefix + media.MediaID,
+		CreatedAt:    media.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if media.ThumbnailKey != "" {
+		info.ThumbnailURL += "/thumbnail"
+	}
+
+	return info
+}
+
+// cleanFilename keeps the base name of the uploaded filename without control characters,
+// and truncates it to maxFilenameLength bytes.
+func cleanFilename(filename string) string {
+	filename = path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	filename = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+
+		return r
+	}, filename))
+
+	for len(filename) > maxFilenameLength {
+		_, size := utf8.DecodeLastRuneInString(filename)
+		filename = filename[:len(filename)-size]
+	}
+
+	if filename == "" || filename == "." || filename == "/" {
+		return "upload"
+	}
+
+	return filename
+}
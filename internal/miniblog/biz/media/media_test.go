@@ -0,0 +1,257 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package media
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+func fakePNG(width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, img)
+
+	return buf.Bytes()
+}
+
+// mediaFixture returns a store whose media records are kept in media and whose files are saved in a temporary directory.
+// The media in referenced are referenced by posts.
+func mediaFixture(t *testing.T, ctrl *gomock.Controller, media map[string]*model.MediaM, referenced map[string]bool, used int64) (*store.MockIStore, store.BlobStore) {
+	blobs, err := store.NewLocalBlobStore(t.TempDir())
+	assert.Nil(t, err)
+
+	mockMediaStore := store.NewMockMediaStore(ctrl)
+	mockMediaStore.EXPECT().Usage(gomock.Any(), gomock.Any()).Return(used, nil).AnyTimes()
+	mockMediaStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, m *model.MediaM) error {
+		media[m.MediaID] = m
+		return nil
+	}).AnyTimes()
+	get := func(ctx context.Context, mediaID string) (*model.MediaM, error) {
+		if m, ok := media[mediaID]; ok {
+			return m, nil
+		}
+
+		return nil, gorm.ErrRecordNotFound
+	}
+	mockMediaStore.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(get).AnyTimes()
+	mockMediaStore.EXPECT().Lock(gomock.Any(), gomock.Any()).DoAndReturn(get).AnyTimes()
+	mockMediaStore.EXPECT().Referenced(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, mediaID string) (bool, error) {
+		return referenced[mediaID], nil
+	}).AnyTimes()
+	mockMediaStore.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, mediaID string) error {
+		delete(media, mediaID)
+		return nil
+	}).AnyTimes()
+
+	mockUserStore := store.NewMockUserStore(ctrl)
+	mockUserStore.EXPECT().Lock(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Media().AnyTimes().Return(mockMediaStore)
+	mockStore.EXPECT().Users().AnyTimes().Return(mockUserStore)
+	mockStore.EXPECT().Blobs().AnyTimes().Return(blobs)
+	mockStore.EXPECT().TX(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+
+	return mockStore, blobs
+}
+
+func readAll(t *testing.T, obj *Object) []byte {
+	defer obj.Close()
+
+	data, err := io.ReadAll(obj)
+	assert.Nil(t, err)
+
+	return data
+}
+
+func Test_mediaBiz_Upload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	media := map[string]*model.MediaM{}
+	mockStore, _ := mediaFixture(t, ctrl, media, nil, 0)
+	b := New(mockStore)
+	ctx := context.Background()
+
+	data := fakePNG(640, 320)
+	info, err := b.Upload(ctx, "belm", `C:\photos\cat.png`, bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.Equal(t, "cat.png", info.Filename)
+	assert.Equal(t, "image/png", info.ContentType)
+	assert.Equal(t, int64(len(data)), info.Size)
+	assert.Equal(t, 640, info.Width)
+	assert.Equal(t, 320, info.Height)
+	assert.Equal(t, URLPrefix+info.MediaID, info.URL)
+	assert.Equal(t, URLPrefix+info.MediaID+"/thumbnail", info.ThumbnailURL)
+	assert.Equal(t, "belm", media[info.MediaID].Username)
+
+	obj, err := b.Open(ctx, info.MediaID, false)
+	assert.Nil(t, err)
+	assert.Equal(t, data, readAll(t, obj))
+
+	obj, err = b.Open(ctx, info.MediaID, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "image/jpeg", obj.ContentType)
+	assert.Equal(t, media[info.MediaID].ThumbnailSize, int64(len(readAll(t, obj))))
+
+	// Small images have no thumbnail, the original image is served instead.
+	info, err = b.Upload(ctx, "belm", "icon.png", bytes.NewReader(fakePNG(16, 16)))
+	assert.Nil(t, err)
+	assert.Equal(t, info.URL, info.ThumbnailURL)
+
+	obj, err = b.Open(ctx, info.MediaID, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "image/png", obj.ContentType)
+	obj.Close()
+
+	_, err = b.Upload(ctx, "belm", "cat.png", strings.NewReader("<html><script>alert(1)</script></html>"))
+	assert.Equal(t, errno.ErrMediaTypeUnsupported, err)
+
+	// The content looks like a PNG image but can not be decoded.
+	_, err = b.Upload(ctx, "belm", "broken.png", bytes.NewReader(data[:64]))
+	assert.Equal(t, errno.ErrMediaTypeUnsupported, err)
+
+	_, err = b.Open(ctx, "media-none", false)
+	assert.Equal(t, errno.ErrMediaNotFound, err)
+}
+
+func Test_mediaBiz_Upload_limits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	saved := limits
+	defer func() { limits = saved }()
+
+	data := fakePNG(64, 64)
+	limits.maxSize, limits.quota = int64(len(data)), int64(len(data))*2
+
+	media := map[string]*model.MediaM{}
+	mockStore, _ := mediaFixture(t, ctrl, media, nil, int64(len(data)))
+	b := New(mockStore)
+
+	_, err := b.Upload(context.Background(), "belm", "big.png", bytes.NewReader(append(data, 0)))
+	assert.Equal(t, errno.ErrMediaTooLarge, err)
+
+	_, err = b.Upload(context.Background(), "belm", "ok.png", bytes.NewReader(data))
+	assert.Nil(t, err)
+
+	mockStore, _ = mediaFixture(t, ctrl, media, nil, int64(len(data))+1)
+	_, err = New(mockStore).Upload(context.Background(), "belm", "over.png", bytes.NewReader(data))
+	assert.Equal(t, errno.ErrMediaQuotaExceeded, err)
+	assert.Len(t, media, 1)
+}
+
+func Test_mediaBiz_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	media := map[string]*model.MediaM{}
+	mockStore, blobs := mediaFixture(t, ctrl, media, nil, 0)
+	b := New(mockStore)
+	ctx := context.Background()
+
+	info, err := b.Upload(ctx, "belm", "cat.png", bytes.NewReader(fakePNG(640, 320)))
+	assert.Nil(t, err)
+	m := *media[info.MediaID]
+
+	assert.Equal(t, errno.ErrMediaNotFound, b.Delete(ctx, "colin", info.MediaID))
+	assert.Nil(t, b.Delete(ctx, "belm", info.MediaID))
+	assert.Empty(t, media)
+
+	for _, key := range []string{m.BlobKey, m.ThumbnailKey} {
+		_, err := blobs.Get(ctx, key)
+		assert.Equal(t, store.ErrBlobNotFound, err)
+	}
+}
+
+func Test_mediaBiz_CollectGarbage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	media := map[string]*model.MediaM{}
+	referenced := map[string]bool{}
+	mockStore, blobs := mediaFixture(t, ctrl, media, referenced, 0)
+	b := New(mockStore)
+	ctx := context.Background()
+
+	var unreferenced []*model.MediaM
+	for i := 0; i < 2; i++ {
+		info, err := b.Upload(ctx, "belm", "cat.png", bytes.NewReader(fakePNG(32, 32)))
+		assert.Nil(t, err)
+		unreferenced = append(unreferenced, media[info.MediaID])
+	}
+
+	kept, err := b.Upload(ctx, "belm", "kept.png", bytes.NewReader(fakePNG(32, 32)))
+	assert.Nil(t, err)
+
+	// The media is referenced by a post after it is listed, and a listed media has been deleted by its owner.
+	listed := append([]*model.MediaM{media[kept.MediaID], {MediaID: "media-deleted"}}, unreferenced...)
+	referenced[kept.MediaID] = true
+
+	mockMediaStore := mockStore.Media().(*store.MockMediaStore)
+	mockMediaStore.EXPECT().ListUnreferenced(gomock.Any(), gomock.Any(), gcBatchSize).DoAndReturn(
+		func(ctx context.Context, before time.Time, limit int) ([]*model.MediaM, error) {
+			assert.WithinDuration(t, time.Now().Add(-time.Hour), before, time.Minute)
+			return listed, nil
+		}).Times(1)
+
+	count, err := b.CollectGarbage(ctx, time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Len(t, media, 1)
+
+	_, err = blobs.Get(ctx, unreferenced[0].BlobKey)
+	assert.Equal(t, store.ErrBlobNotFound, err)
+
+	obj, err := b.Open(ctx, kept.MediaID, false)
+	assert.Nil(t, err)
+	obj.Close()
+}
+
+func Test_mediaBiz_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore, _ := mediaFixture(t, ctrl, map[string]*model.MediaM{}, nil, 1024)
+	mockMediaStore := mockStore.Media().(*store.MockMediaStore)
+	mockMediaStore.EXPECT().List(gomock.Any(), "belm", 0, 10).Return(int64(1), []*model.MediaM{
+		{MediaID: "media-1", Filename: "cat.png", ContentType: "image/png", Size: 1000, ThumbnailKey: "thumbnails/media-1"},
+	}, nil).Times(1)
+
+	resp, err := New(mockStore).List(context.Background(), "belm", &v1.ListMediaRequest{Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), resp.TotalCount)
+	assert.Equal(t, int64(1024), resp.UsedBytes)
+	assert.Equal(t, limits.quota, resp.QuotaBytes)
+	assert.Equal(t, "/v1/public/media/media-1/thumbnail", resp.Media[0].ThumbnailURL)
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/marmotedu/miniblog/internal/miniblog/biz/media (interfaces: MediaBiz)
+
+// Package media is a generated GoMock package.
+package media
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// MockMediaBiz is a mock of MediaBiz interface.
+type MockMediaBiz struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaBizMockRecorder
+}
+
+// MockMediaBizMockRecorder is the mock recorder for MockMediaBiz.
+type MockMediaBizMockRecorder struct {
+	mock *MockMediaBiz
+}
+
+// NewMockMediaBiz creates a new mock instance.
+func NewMockMediaBiz(ctrl *gomock.Controller) *MockMediaBiz {
+	mock := &MockMediaBiz{ctrl: ctrl}
+	mock.recorder = &MockMediaBizMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaBiz) EXPECT() *MockMediaBizMockRecorder {
+	return m.recorder
+}
+
+// CollectGarbage mocks base method.
+func (m *MockMediaBiz) CollectGarbage(arg0 context.Context, arg1 time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectGarbage", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectGarbage indicates an expected call of CollectGarbage.
+func (mr *MockMediaBizMockRecorder) CollectGarbage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectGarbage", reflect.TypeOf((*MockMediaBiz)(nil).CollectGarbage), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockMediaBiz) Delete(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMediaBizMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMediaBiz)(nil).Delete), arg0, arg1, arg2)
+}
+
+// List mocks base method.
+func (m *MockMediaBiz) List(arg0 context.Context, arg1 string, arg2 *v1.ListMediaRequest) (*v1.ListMediaResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.ListMediaResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMediaBizMockRecorder) List(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMediaBiz)(nil).List), arg0, arg1, arg2)
+}
+
+// Open mocks base method.
+func (m *MockMediaBiz) Open(arg0 context.Context, arg1 string, arg2 bool) (*Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockMediaBizMockRecorder) Open(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockMediaBiz)(nil).Open), arg0, arg1, arg2)
+}
+
+// Upload mocks base method.
+func (m *MockMediaBiz) Upload(arg0 context.Context, arg1, arg2 string, arg3 io.Reader) (*v1.MediaInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.MediaInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockMediaBizMockRecorder) Upload(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockMediaBiz)(nil).Upload), arg0, arg1, arg2, arg3)
+}
@@ -14,8 +14,16 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 
+	category "github.com/marmotedu/miniblog/internal/miniblog/biz/category"
+	comment "github.com/marmotedu/miniblog/internal/miniblog/biz/comment"
+	media "github.com/marmotedu/miniblog/internal/miniblog/biz/media"
+	notification "github.com/marmotedu/miniblog/internal/miniblog/biz/notification"
 	post "github.com/marmotedu/miniblog/internal/miniblog/biz/post"
+	reaction "github.com/marmotedu/miniblog/internal/miniblog/biz/reaction"
+	stream "github.com/marmotedu/miniblog/internal/miniblog/biz/stream"
+	tag "github.com/marmotedu/miniblog/internal/miniblog/biz/tag"
 	user "github.com/marmotedu/miniblog/internal/miniblog/biz/user"
+	webhook "github.com/marmotedu/miniblog/internal/miniblog/biz/webhook"
 )
 
 // MockIBiz is a mock of IBiz interface.
@@ -41,6 +49,62 @@ func (m *MockIBiz) EXPECT() *MockIBizMockRecorder {
 	return m.recorder
 }
 
+// Categories mocks base method.
+func (m *MockIBiz) Categories() category.CategoryBiz {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Categories")
+	ret0, _ := ret[0].(category.CategoryBiz)
+	return ret0
+}
+
+// Categories indicates an expected call of Categories.
+func (mr *MockIBizMockRecorder) Categories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Categories", reflect.TypeOf((*MockIBiz)(nil).Categories))
+}
+
+// Comments mocks base method.
+func (m *MockIBiz) Comments() comment.CommentBiz {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Comments")
+	ret0, _ := ret[0].(comment.CommentBiz)
+	return ret0
+}
+
+// Comments indicates an expected call of Comments.
+func (mr *MockIBizMockRecorder) Comments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Comments", reflect.TypeOf((*MockIBiz)(nil).Comments))
+}
+
+// Media mocks base method.
+func (m *MockIBiz) Media() media.MediaBiz {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Media")
+	ret0, _ := ret[0].(media.MediaBiz)
+	return ret0
+}
+
+// Media indicates an expected call of Media.
+func (mr *MockIBizMockRecorder) Media() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Media", reflect.TypeOf((*MockIBiz)(nil).Media))
+}
+
+// Notifications mocks base method.
+func (m *MockIBiz) Notifications() notification.NotificationBiz {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notifications")
+	ret0, _ := ret[0].(notification.NotificationBiz)
+	return ret0
+}
+
+// Notifications indicates an expected call of Notifications.
+func (mr *MockIBizMockRecorder) Notifications() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notifications", reflect.TypeOf((*MockIBiz)(nil).Notifications))
+}
+
 // Posts mocks base method.
 func (m *MockIBiz) Posts() post.PostBiz {
 	m.ctrl.T.Helper()
@@ -55,6 +119,48 @@ func (mr *MockIBizMockRecorder) Posts() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Posts", reflect.TypeOf((*MockIBiz)(nil).Posts))
 }
 
+// Reactions mocks base method.
+func (m *MockIBiz) Reactions() reaction.ReactionBiz {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reactions")
+	ret0, _ := ret[0].(reaction.ReactionBiz)
+	return ret0
+}
+
+// Reactions indicates an expected call of Reactions.
+func (mr *MockIBizMockRecorder) Reactions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reactions", reflect.TypeOf((*MockIBiz)(nil).Reactions))
+}
+
+// Streams mocks base method.
+func (m *MockIBiz) Streams() stream.StreamBiz {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Streams")
+	ret0, _ := ret[0].(stream.StreamBiz)
+	return ret0
+}
+
+// Streams indicates an expected call of Streams.
+func (mr *MockIBizMockRecorder) Streams() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Streams", reflect.TypeOf((*MockIBiz)(nil).Streams))
+}
+
+// Tags mocks base method.
+func (m *MockIBiz) Tags() tag.TagBiz {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tags")
+	ret0, _ := ret[0].(tag.TagBiz)
+	return ret0
+}
+
+// Tags indicates an expected call of Tags.
+func (mr *MockIBizMockRecorder) Tags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tags", reflect.TypeOf((*MockIBiz)(nil).Tags))
+}
+
 // Users mocks base method.
 func (m *MockIBiz) Users() user.UserBiz {
 	m.ctrl.T.Helper()
@@ -68,3 +174,17 @@ func (mr *MockIBizMockRecorder) Users() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockIBiz)(nil).Users))
 }
+
+// Webhooks mocks base method.
+func (m *MockIBiz) Webhooks() webhook.WebhookBiz {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Webhooks")
+	ret0, _ := ret[0].(webhook.WebhookBiz)
+	return ret0
+}
+
+// Webhooks indicates an expected call of Webhooks.
+func (mr *MockIBizMockRecorder) Webhooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Webhooks", reflect.TypeOf((*MockIBiz)(nil).Webhooks))
+}
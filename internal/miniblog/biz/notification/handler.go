@@ -0,0 +1,213 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/event"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// MaxMentions is the maximum number of users notified of the mentions in a post.
+const MaxMentions = 20
+
+var (
+	// mentionPattern matches a mention of a user, the `@` must not follow a character of a word, an email
+	// address or a URL, so that `jane@example.com` is not a mention of example.
+	mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_@./])@([A-Za-z0-9]+)`)
+
+	// codePattern matches the fenced code blocks and the inline code of Markdown, which do not contain mentions.
+	codePattern = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`")
+)
+
+// Subscribe registers the handlers which create the notifications from the domain events on the default bus.
+// The notifications are deduplicated by the storage, so the events can be handled more than once.
+func Subscribe(ds store.IStore) {
+	b := New(ds)
+
+	event.Subscribe("NotifyMentions", b.notifyMentions, event.PostCreated, event.PostUpdated, event.PostPublished)
+	event.Subscribe("NotifyFollow", b.notifyFollow, event.UserFollowed)
+	event.Subscribe("NotifyComment", b.notifyComment, event.CommentCreated)
+}
+
+// Mentions returns the distinct usernames mentioned in content, in the order of their first mention.
+func Mentions(content string) []string {
+	content = codePattern.ReplaceAllString(content, " ")
+
+	var usernames []string
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			usernames = append(usernames, match[1])
+		}
+	}
+
+	return usernames
+}
+
+// notifyMentions notifies the users mentioned in a post once it is published and can be read by them.
+// A user is notified once per post, the mentions added when the post is edited later are notified too.
+func (b *notificationBiz) notifyMentions(ctx context.Context, e *event.Event) error {
+	var p event.Post
+	if err := e.Decode(&p); err != nil {
+		return err
+	}
+
+	post, err := b.ds.Posts().Get(ctx, p.Username, p.PostID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if post.Status != model.PostStatusPublished || post.Visibility == model.PostVisibilityPrivate {
+		return nil
+	}
+
+	var count int
+	for _, username := range Mentions(post.Title + "\n" + post.Content) {
+		if username == post.Username {
+			continue
+		}
+
+		// The words which look like mentions but are not usernames are ignored.
+		if _, err := b.ds.Users().Get(ctx, username); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+
+			return err
+		}
+
+		if err := b.notify(ctx, &model.NotificationM{
+			Username: username,
+			Type:     model.NotificationTypeMention,
+			Actor:    post.Username,
+			Resource: post.PostID,
+			PostID:   post.PostID,
+		}); err != nil {
+			return err
+		}
+
+		if count++; count == MaxMentions {
+			break
+		}
+	}
+
+	return nil
+}
+
+// notifyFollow notifies a user of a new follower.
+func (b *notificationBiz) notifyFollow(ctx context.Context, e *event.Event) error {
+	var f event.Follow
+	if err := e.Decode(&f); err != nil {
+		return err
+	}
+
+	return b.notify(ctx, &model.NotificationM{
+		Username: f.Followee,
+		Type:     model.NotificationTypeFollow,
+		Actor:    f.Username,
+		Resource: f.Username,
+	})
+}
+
+// notifyComment notifies the owner of a post of a new comment, and the author of the replied comment of
+// an approved reply. The replies waiting for moderation are only notified to the owner of the post.
+func (b *notificationBiz) notifyComment(ctx context.Context, e *event.Event) error {
+	var c event.Comment
+	if err := e.Decode(&c); err != nil {
+		return err
+	}
+
+	comment, err := b.ds.Comments().Get(ctx, c.PostID, c.CommentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	post, err := b.ds.Posts().GetByPostID(ctx, c.PostID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if post.Username != comment.Username {
+		if err := b.notify(ctx, &model.NotificationM{
+			Username:  post.Username,
+			Type:      model.NotificationTypeComment,
+			Actor:     comment.Username,
+			Resource:  comment.CommentID,
+			PostID:    post.PostID,
+			CommentID: comment.CommentID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if comment.ParentID == "" || comment.Status != model.CommentStatusApproved {
+		return nil
+	}
+
+	parent, err := b.ds.Comments().Get(ctx, c.PostID, comment.ParentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// The owner of the post has been notified of the comment.
+	if parent.Username == comment.Username || parent.Username == post.Username {
+		return nil
+	}
+
+	return b.notify(ctx, &model.NotificationM{
+		Username:  parent.Username,
+		Type:      model.NotificationTypeReply,
+		Actor:     comment.Username,
+		Resource:  comment.CommentID,
+		PostID:    post.PostID,
+		CommentID: comment.CommentID,
+	})
+}
+
+// notify saves a notification unless the recipient has turned off its type, NotificationCreated is published
+// for a new notification so that it can be pushed to the recipient.
+func (b *notificationBiz) notify(ctx context.Context, notification *model.NotificationM) error {
+	preference, err := b.preference(ctx, notification.Username)
+	if err != nil {
+		return err
+	}
+
+	if !preference.Allows(notification.Type) {
+		return nil
+	}
+
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		created, err := b.ds.Notifications().Create(ctx, notification)
+		if err != nil || !created {
+			return err
+		}
+
+		return event.Publish(ctx, b.ds, event.NotificationCreated, event.Notification{
+			Username:       notification.Username,
+			NotificationID: notification.NotificationID,
+		})
+	})
+}
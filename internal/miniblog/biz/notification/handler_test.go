@@ -0,0 +1,144 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/event"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+func TestMentions(t *testing.T) {
+	assert.Equal(t, []string{"belm", "colin"}, Mentions("@belm thanks, cc @colin and @belm."))
+	assert.Nil(t, Mentions("mail jane@example.com or see https://example.com/@belm"))
+	assert.Nil(t, Mentions("`@belm` and\n```\n@colin\n```"))
+	assert.Equal(t, []string{"colin"}, Mentions("(@colin)"))
+}
+
+func newEvent(typ string, payload interface{}) *event.Event {
+	data, _ := json.Marshal(payload)
+
+	return &event.Event{ID: "event-1", Type: typ, Payload: data}
+}
+
+// expectEvents expects count NotificationCreated events to be published.
+func expectEvents(ctrl *gomock.Controller, mockStore *store.MockIStore, count int) {
+	mockEventStore := store.NewMockEventStore(ctrl)
+	mockEventStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, e *model.EventM) error {
+			if e.Type != event.NotificationCreated {
+				return fmt.Errorf("unexpected event %s", e.Type)
+			}
+
+			return nil
+		},
+	).Times(count)
+
+	mockStore.EXPECT().Events().AnyTimes().Return(mockEventStore)
+	mockStore.EXPECT().TX(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+}
+
+func Test_notificationBiz_notifyMentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	published := &model.PostM{
+		Username: "belm", PostID: "post-1", Status: model.PostStatusPublished, Visibility: model.PostVisibilityPublic,
+		Title: "Hello @colin", Content: "by @belm, thanks @alice and @nobody",
+	}
+	private := &model.PostM{
+		Username: "belm", PostID: "post-2", Status: model.PostStatusPublished, Visibility: model.PostVisibilityPrivate,
+		Content: "@colin",
+	}
+
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().Get(gomock.Any(), "belm", "post-1").Return(published, nil).AnyTimes()
+	mockPostStore.EXPECT().Get(gomock.Any(), "belm", "post-2").Return(private, nil).AnyTimes()
+	mockPostStore.EXPECT().Get(gomock.Any(), "belm", "post-3").Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+
+	mockUserStore := store.NewMockUserStore(ctrl)
+	mockUserStore.EXPECT().Get(gomock.Any(), "colin").Return(&model.UserM{}, nil).AnyTimes()
+	mockUserStore.EXPECT().Get(gomock.Any(), "alice").Return(&model.UserM{}, nil).AnyTimes()
+	mockUserStore.EXPECT().Get(gomock.Any(), "nobody").Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+
+	// alice does not want to be notified of mentions.
+	mockNotificationStore := store.NewMockNotificationStore(ctrl)
+	mockNotificationStore.EXPECT().GetPreference(gomock.Any(), "colin").Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	mockNotificationStore.EXPECT().GetPreference(gomock.Any(), "alice").Return(&model.NotificationPreferenceM{Follow: true, Comment: true}, nil).AnyTimes()
+	mockNotificationStore.EXPECT().Create(gomock.Any(), &model.NotificationM{
+		Username: "colin", Type: model.NotificationTypeMention, Actor: "belm", Resource: "post-1", PostID: "post-1",
+	}).Return(true, nil).Times(1)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+	mockStore.EXPECT().Users().AnyTimes().Return(mockUserStore)
+	mockStore.EXPECT().Notifications().AnyTimes().Return(mockNotificationStore)
+	expectEvents(ctrl, mockStore, 1)
+
+	b := New(mockStore)
+	ctx := context.Background()
+
+	for _, postID := range []string{"post-1", "post-2", "post-3"} {
+		assert.Nil(t, b.notifyMentions(ctx, newEvent(event.PostUpdated, event.Post{Username: "belm", PostID: postID})))
+	}
+}
+
+func Test_notificationBiz_notifyComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	post := &model.PostM{Username: "belm", PostID: "post-1"}
+	parent := &model.CommentM{CommentID: "comment-1", PostID: "post-1", Username: "alice", Status: model.CommentStatusApproved}
+	reply := &model.CommentM{CommentID: "comment-2", PostID: "post-1", Username: "colin", ParentID: "comment-1", Status: model.CommentStatusApproved}
+	pending := &model.CommentM{CommentID: "comment-3", PostID: "post-1", Username: "colin", ParentID: "comment-1", Status: model.CommentStatusPending}
+	own := &model.CommentM{CommentID: "comment-4", PostID: "post-1", Username: "belm", Status: model.CommentStatusApproved}
+
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().GetByPostID(gomock.Any(), "post-1").Return(post, nil).AnyTimes()
+
+	mockCommentStore := store.NewMockCommentStore(ctrl)
+	for _, c := range []*model.CommentM{parent, reply, pending, own} {
+		mockCommentStore.EXPECT().Get(gomock.Any(), "post-1", c.CommentID).Return(c, nil).AnyTimes()
+	}
+
+	mockNotificationStore := store.NewMockNotificationStore(ctrl)
+	mockNotificationStore.EXPECT().GetPreference(gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	for _, n := range []*model.NotificationM{
+		{Username: "belm", Type: model.NotificationTypeComment, Actor: "colin", Resource: "comment-2", PostID: "post-1", CommentID: "comment-2"},
+		{Username: "alice", Type: model.NotificationTypeReply, Actor: "colin", Resource: "comment-2", PostID: "post-1", CommentID: "comment-2"},
+		{Username: "belm", Type: model.NotificationTypeComment, Actor: "colin", Resource: "comment-3", PostID: "post-1", CommentID: "comment-3"},
+	} {
+		mockNotificationStore.EXPECT().Create(gomock.Any(), n).Return(true, nil).Times(1)
+	}
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+	mockStore.EXPECT().Comments().AnyTimes().Return(mockCommentStore)
+	mockStore.EXPECT().Notifications().AnyTimes().Return(mockNotificationStore)
+	expectEvents(ctrl, mockStore, 3)
+
+	b := New(mockStore)
+	ctx := context.Background()
+
+	// The reply waiting for moderation is not notified to the author of the replied comment, and the
+	// owner of the post is not notified of its own comments.
+	for _, c := range []*model.CommentM{reply, pending, own} {
+		assert.Nil(t, b.notifyComment(ctx, newEvent(event.CommentCreated, event.Comment{Username: c.Username, PostID: "post-1", CommentID: c.CommentID})))
+	}
+}
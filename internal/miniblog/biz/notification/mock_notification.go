@@ -0,0 +1,130 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/marmotedu/miniblog/internal/miniblog/biz/notification (interfaces: NotificationBiz)
+
+// Package notification is a generated GoMock package.
+package notification
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// MockNotificationBiz is a mock of NotificationBiz interface.
+type MockNotificationBiz struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationBizMockRecorder
+}
+
+// MockNotificationBizMockRecorder is the mock recorder for MockNotificationBiz.
+type MockNotificationBizMockRecorder struct {
+	mock *MockNotificationBiz
+}
+
+// NewMockNotificationBiz creates a new mock instance.
+func NewMockNotificationBiz(ctrl *gomock.Controller) *MockNotificationBiz {
+	mock := &MockNotificationBiz{ctrl: ctrl}
+	mock.recorder = &MockNotificationBizMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationBiz) EXPECT() *MockNotificationBizMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockNotificationBiz) Get(arg0 context.Context, arg1, arg2 string) (*v1.NotificationInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.NotificationInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockNotificationBizMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockNotificationBiz)(nil).Get), arg0, arg1, arg2)
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotificationBiz) GetPreferences(arg0 context.Context, arg1 string) (*v1.GetNotificationPreferencesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", arg0, arg1)
+	ret0, _ := ret[0].(*v1.GetNotificationPreferencesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationBizMockRecorder) GetPreferences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotificationBiz)(nil).GetPreferences), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockNotificationBiz) List(arg0 context.Context, arg1 string, arg2 *v1.ListNotificationRequest) (*v1.ListNotificationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.ListNotificationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockNotificationBizMockRecorder) List(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNotificationBiz)(nil).List), arg0, arg1, arg2)
+}
+
+// Read mocks base method.
+func (m *MockNotificationBiz) Read(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockNotificationBizMockRecorder) Read(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockNotificationBiz)(nil).Read), arg0, arg1, arg2)
+}
+
+// ReadAll mocks base method.
+func (m *MockNotificationBiz) ReadAll(arg0 context.Context, arg1 string) (*v1.ReadAllNotificationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAll", arg0, arg1)
+	ret0, _ := ret[0].(*v1.ReadAllNotificationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAll indicates an expected call of ReadAll.
+func (mr *MockNotificationBizMockRecorder) ReadAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockNotificationBiz)(nil).ReadAll), arg0, arg1)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockNotificationBiz) UpdatePreferences(arg0 context.Context, arg1 string, arg2 *v1.UpdateNotificationPreferencesRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockNotificationBizMockRecorder) UpdatePreferences(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockNotificationBiz)(nil).UpdatePreferences), arg0, arg1, arg2)
+}
@@ -0,0 +1,168 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package notification
+
+//go:generate mockgen -destination mock_notification.go -package notification github.com/marmotedu/miniblog/internal/miniblog/biz/notification NotificationBiz
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// NotificationBiz defines functions used to handle notification request.
+type NotificationBiz interface {
+	Get(ctx context.Context, username, notificationID string) (*v1.NotificationInfo, error)
+	List(ctx context.Context, username string, r *v1.ListNotificationRequest) (*v1.ListNotificationResponse, error)
+	Read(ctx context.Context, username, notificationID string) error
+	ReadAll(ctx context.Context, username string) (*v1.ReadAllNotificationResponse, error)
+	GetPreferences(ctx context.Context, username string) (*v1.GetNotificationPreferencesResponse, error)
+	UpdatePreferences(ctx context.Context, username string, r *v1.UpdateNotificationPreferencesRequest) error
+}
+
+// The implementation of NotificationBiz interface.
+type notificationBiz struct {
+	ds store.IStore
+}
+
+// Make sure that notificationBiz implements the NotificationBiz interface.
+var _ NotificationBiz = (*notificationBiz)(nil)
+
+func New(ds store.IStore) *notificationBiz {
+	return &notificationBiz{ds: ds}
+}
+
+// Get is the implementation of the `Get` method in NotificationBiz interface.
+func (b *notificationBiz) Get(ctx context.Context, username, notificationID string) (*v1.NotificationInfo, error) {
+	notification, err := b.ds.Notifications().Get(ctx, username, notificationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrNotificationNotFound
+		}
+
+		return nil, err
+	}
+
+	return notificationInfo(notification), nil
+}
+
+// List is the implementation of the `List` method in NotificationBiz interface.
+func (b *notificationBiz) List(ctx context.Context, username string, r *v1.ListNotificationRequest) (*v1.ListNotificationResponse, error) {
+	count, list, err := b.ds.Notifications().List(ctx, username, r.Unread, r.Offset, r.Limit)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to list notifications from storage", "err", err)
+		return nil, err
+	}
+
+	unread := count
+	if !r.Unread {
+		if unread, err = b.ds.Notifications().CountUnread(ctx, username); err != nil {
+			return nil, err
+		}
+	}
+
+	notifications := make([]*v1.NotificationInfo, 0, len(list))
+	for _, item := range list {
+		notifications = append(notifications, notificationInfo(item))
+	}
+
+	return &v1.ListNotificationResponse{TotalCount: count, UnreadCount: unread, Notifications: notifications}, nil
+}
+
+// Read is the implementation of the `Read` method in NotificationBiz interface.
+// Reading a notification which has been read does nothing.
+func (b *notificationBiz) Read(ctx context.Context, username, notificationID string) error {
+	if _, err := b.ds.Notifications().Get(ctx, username, notificationID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrNotificationNotFound
+		}
+
+		return err
+	}
+
+	_, err := b.ds.Notifications().MarkRead(ctx, username, []string{notificationID}, time.Now())
+
+	return err
+}
+
+// ReadAll is the implementation of the `ReadAll` method in NotificationBiz interface.
+func (b *notificationBiz) ReadAll(ctx context.Context, username string) (*v1.ReadAllNotificationResponse, error) {
+	count, err := b.ds.Notifications().MarkRead(ctx, username, nil, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ReadAllNotificationResponse{Count: count}, nil
+}
+
+// GetPreferences is the implementation of the `GetPreferences` method in NotificationBiz interface.
+func (b *notificationBiz) GetPreferences(ctx context.Context, username string) (*v1.GetNotificationPreferencesResponse, error) {
+	preference, err := b.preference(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.GetNotificationPreferencesResponse{Mention: preference.Mention, Follow: preference.Follow, Comment: preference.Comment}, nil
+}
+
+// UpdatePreferences is the implementation of the `UpdatePreferences` method in NotificationBiz interface.
+func (b *notificationBiz) UpdatePreferences(ctx context.Context, username string, r *v1.UpdateNotificationPreferencesRequest) error {
+	preference, err := b.preference(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if r.Mention != nil {
+		preference.Mention = *r.Mention
+	}
+
+	if r.Follow != nil {
+		preference.Follow = *r.Follow
+	}
+
+	if r.Comment != nil {
+		preference.Comment = *r.Comment
+	}
+
+	return b.ds.Notifications().SavePreference(ctx, preference)
+}
+
+// preference returns the notification preference of username, users who have not set it receive all notifications.
+func (b *notificationBiz) preference(ctx context.Context, username string) (*model.NotificationPreferenceM, error) {
+	preference, err := b.ds.Notifications().GetPreference(ctx, username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &model.NotificationPreferenceM{Username: username, Mention: true, Follow: true, Comment: true}, nil
+	}
+
+	return preference, err
+}
+
+// notificationInfo converts a notification to its API representation.
+func notificationInfo(notification *model.NotificationM) *v1.NotificationInfo {
+	info := &v1.NotificationInfo{
+		NotificationID: notification.NotificationID,
+		Type:           notification.Type,
+		Actor:          notification.Actor,
+		PostID:         notification.PostID,
+		CommentID:      notification.CommentID,
+		Read:           notification.ReadAt != nil,
+		CreatedAt:      notification.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if notification.ReadAt != nil {
+		info.ReadAt = notification.ReadAt.Format("2006-01-02 15:04:05")
+	}
+
+	return info
+}
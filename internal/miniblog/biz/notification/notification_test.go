@@ -0,0 +1,112 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+func Test_notificationBiz_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	readAt := time.Date(2022, 11, 20, 10, 0, 0, 0, time.Local)
+	list := []*model.NotificationM{
+		{NotificationID: "notification-2", Type: model.NotificationTypeFollow, Actor: "colin", CreatedAt: readAt},
+		{NotificationID: "notification-1", Type: model.NotificationTypeMention, Actor: "colin", PostID: "post-1", ReadAt: &readAt, CreatedAt: readAt},
+	}
+
+	mockNotificationStore := store.NewMockNotificationStore(ctrl)
+	mockNotificationStore.EXPECT().List(gomock.Any(), "belm", false, 0, 10).Return(int64(2), list, nil).Times(1)
+	mockNotificationStore.EXPECT().List(gomock.Any(), "belm", true, 0, 10).Return(int64(1), list[:1], nil).Times(1)
+	mockNotificationStore.EXPECT().CountUnread(gomock.Any(), "belm").Return(int64(1), nil).Times(1)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Notifications().AnyTimes().Return(mockNotificationStore)
+
+	b := New(mockStore)
+	ctx := context.Background()
+
+	resp, err := b.List(ctx, "belm", &v1.ListNotificationRequest{Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), resp.TotalCount)
+	assert.Equal(t, int64(1), resp.UnreadCount)
+	assert.False(t, resp.Notifications[0].Read)
+	assert.Equal(t, &v1.NotificationInfo{
+		NotificationID: "notification-1",
+		Type:           model.NotificationTypeMention,
+		Actor:          "colin",
+		PostID:         "post-1",
+		Read:           true,
+		ReadAt:         "2022-11-20 10:00:00",
+		CreatedAt:      "2022-11-20 10:00:00",
+	}, resp.Notifications[1])
+
+	// The unread notifications are counted once.
+	resp, err = b.List(ctx, "belm", &v1.ListNotificationRequest{Limit: 10, Unread: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), resp.UnreadCount)
+}
+
+func Test_notificationBiz_Read(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNotificationStore := store.NewMockNotificationStore(ctrl)
+	mockNotificationStore.EXPECT().Get(gomock.Any(), "belm", "notification-1").Return(&model.NotificationM{}, nil).AnyTimes()
+	mockNotificationStore.EXPECT().Get(gomock.Any(), "belm", "notification-2").Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	mockNotificationStore.EXPECT().MarkRead(gomock.Any(), "belm", []string{"notification-1"}, gomock.Any()).Return(int64(1), nil).Times(1)
+	mockNotificationStore.EXPECT().MarkRead(gomock.Any(), "belm", nil, gomock.Any()).Return(int64(3), nil).Times(1)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Notifications().AnyTimes().Return(mockNotificationStore)
+
+	b := New(mockStore)
+	ctx := context.Background()
+
+	assert.Nil(t, b.Read(ctx, "belm", "notification-1"))
+	assert.Equal(t, errno.ErrNotificationNotFound, b.Read(ctx, "belm", "notification-2"))
+
+	resp, err := b.ReadAll(ctx, "belm")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), resp.Count)
+}
+
+func Test_notificationBiz_Preferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNotificationStore := store.NewMockNotificationStore(ctrl)
+	mockNotificationStore.EXPECT().GetPreference(gomock.Any(), "belm").Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	mockNotificationStore.EXPECT().SavePreference(gomock.Any(), &model.NotificationPreferenceM{
+		Username: "belm", Mention: true, Follow: false, Comment: true,
+	}).Return(nil).Times(1)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Notifications().AnyTimes().Return(mockNotificationStore)
+
+	b := New(mockStore)
+	ctx := context.Background()
+
+	// Users who have not set their preferences receive all notifications.
+	resp, err := b.GetPreferences(ctx, "belm")
+	assert.Nil(t, err)
+	assert.Equal(t, &v1.GetNotificationPreferencesResponse{Mention: true, Follow: true, Comment: true}, resp)
+
+	follow := false
+	assert.Nil(t, b.UpdatePreferences(ctx, "belm", &v1.UpdateNotificationPreferencesRequest{Follow: &follow}))
+}
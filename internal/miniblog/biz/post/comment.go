@@ -0,0 +1,35 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// attachCommentCounts fills in the number of approved comments of posts.
+func (b *postBiz) attachCommentCounts(ctx context.Context, posts ...*v1.PostInfo) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.PostID)
+	}
+
+	counts, err := b.ds.Comments().CountByPostIDs(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		post.CommentCount = counts[post.PostID]
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/event"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/webhook"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// publish saves the domain event of a post change in the outbox and queues its webhook deliveries, the
+// webhook payload contains the post with its tags. It must be called in the transaction which changes the post.
+func (b *postBiz) publish(ctx context.Context, typ string, post *model.PostM) error {
+	if err := event.Publish(ctx, b.ds, typ, event.Post{Username: post.Username, PostID: post.PostID}); err != nil {
+		return err
+	}
+
+	return webhook.PublishFunc(ctx, b.ds, post.Username, typ, func() (interface{}, error) {
+		info := postInfo(post)
+		if err := b.attachTags(ctx, info); err != nil {
+			return nil, err
+		}
+
+		return info, nil
+	})
+}
+
+// publishDeleted publishes the deletion of posts like publish, the webhook payload only contains the owner and
+// the ID of each post. It must be called in the transaction which deletes the posts.
+func (b *postBiz) publishDeleted(ctx context.Context, posts []*model.PostM) error {
+	for _, post := range posts {
+		data := event.Post{Username: post.Username, PostID: post.PostID}
+		if err := event.Publish(ctx, b.ds, event.PostDeleted, data); err != nil {
+			return err
+		}
+
+		if err := webhook.Publish(ctx, b.ds, post.Username, webhook.EventPostDeleted, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// noWebhooks returns a mocked webhook store in which no user has any webhook.
+func noWebhooks(ctrl *gomock.Controller) *store.MockWebhookStore {
+	mockWebhookStore := store.NewMockWebhookStore(ctrl)
+	mockWebhookStore.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	return mockWebhookStore
+}
+
+// noEvents returns a mocked event store which accepts any event.
+func noEvents(ctrl *gomock.Controller) *store.MockEventStore {
+	mockEventStore := store.NewMockEventStore(ctrl)
+	mockEventStore.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	return mockEventStore
+}
+
+// eventFixture returns a mocked store in which belm has a webhook subscribing to all events. The payloads
+// of the queued deliveries are appended to payloads, and the types and payloads of the domain events
+// saved in the outbox are appended to events.
+func eventFixture(ctrl *gomock.Controller, payloads *[]map[string]interface{}, events *[]string) *store.MockIStore {
+	mockWebhookStore := store.NewMockWebhookStore(ctrl)
+	mockWebhookStore.EXPECT().List(gomock.Any(), "belm").Return([]*model.WebhookM{
+		{WebhookID: "webhook-1", Username: "belm", Events: "*", Active: true},
+	}, nil).AnyTimes()
+
+	mockDeliveryStore := store.NewMockDeliveryStore(ctrl)
+	mockDeliveryStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, delivery *model.WebhookDeliveryM) error {
+			var payload map[string]interface{}
+			if err := json.Unmarshal([]byte(delivery.Payload), &payload); err != nil {
+				return err
+			}
+
+			*payloads = append(*payloads, payload)
+
+			return nil
+		},
+	).AnyTimes()
+
+	mockEventStore := store.NewMockEventStore(ctrl)
+	mockEventStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, event *model.EventM) error {
+			*events = append(*events, event.Type+" "+event.Payload)
+
+			return nil
+		},
+	).AnyTimes()
+
+	mockTagStore := store.NewMockTagStore(ctrl)
+	mockTagStore.EXPECT().ListByPostIDs(gomock.Any(), gomock.Any()).Return(map[string][]string{"post-1": {"go"}}, nil).AnyTimes()
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Webhooks().AnyTimes().Return(mockWebhookStore)
+	mockStore.EXPECT().Deliveries().AnyTimes().Return(mockDeliveryStore)
+	mockStore.EXPECT().Events().AnyTimes().Return(mockEventStore)
+	mockStore.EXPECT().Tags().AnyTimes().Return(mockTagStore)
+	mockStore.EXPECT().TX(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+
+	return mockStore
+}
+
+func Test_postBiz_PublishScheduled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		payloads []map[string]interface{}
+		events   []string
+	)
+	mockStore := eventFixture(ctrl, &payloads, &events)
+
+	publishAt := time.Now().Add(-time.Minute)
+	mockPostStore := store.NewMockPostStore(ctrl)
+	gomock.InOrder(
+		mockPostStore.EXPECT().ListDue(gomock.Any(), gomock.Any()).Return([]*model.PostM{
+			{ID: 1, PostID: "post-1", Username: "belm", Title: "hello", Status: model.PostStatusScheduled, PublishAt: &publishAt},
+			{ID: 2, PostID: "post-2", Username: "belm", Title: "world", Status: model.PostStatusScheduled, PublishAt: &publishAt},
+		}, nil),
+		mockPostStore.EXPECT().Publish(gomock.Any(), int64(1)).Return(true, nil),
+		// post-2 has been published by another instance, no event is emitted for it.
+		mockPostStore.EXPECT().Publish(gomock.Any(), int64(2)).Return(false, nil),
+		mockPostStore.EXPECT().ListDue(gomock.Any(), gomock.Any()).Return(nil, nil),
+	)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+
+	b := New(mockStore)
+
+	count, err := b.PublishScheduled(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// Nothing is published if no post is due.
+	count, err = b.PublishScheduled(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+
+	assert.Len(t, payloads, 1)
+	assert.Equal(t, "post.published", payloads[0]["event"])
+	data := payloads[0]["data"].(map[string]interface{})
+	assert.Equal(t, "post-1", data["postID"])
+	assert.Equal(t, "published", data["status"])
+	assert.Equal(t, []interface{}{"go"}, data["tags"])
+
+	assert.Equal(t, []string{`post.published {"username":"belm","postID":"post-1"}`}, events)
+}
+
+func Test_postBiz_DeleteCollection_event(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		payloads []map[string]interface{}
+		events   []string
+	)
+	mockStore := eventFixture(ctrl, &payloads, &events)
+
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().ListByPostIDs(gomock.Any(), "belm", []string{"post-1", "post-2"}).Return([]*model.PostM{
+		{PostID: "post-1", Username: "belm"},
+	}, nil).Times(1)
+	mockPostStore.EXPECT().Delete(gomock.Any(), "belm", []string{"post-1", "post-2"}).Return(nil).Times(1)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+
+	b := New(mockStore)
+	assert.Nil(t, b.DeleteCollection(context.Background(), "belm", []string{"post-1", "post-2"}))
+
+	// Only the posts which existed are reported as deleted.
+	assert.Len(t, payloads, 1)
+	assert.Equal(t, "post.deleted", payloads[0]["event"])
+	assert.Equal(t, map[string]interface{}{"username": "belm", "postID": "post-1"}, payloads[0]["data"])
+
+	assert.Equal(t, []string{`post.deleted {"username":"belm","postID":"post-1"}`}, events)
+}
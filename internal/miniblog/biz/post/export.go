@@ -0,0 +1,85 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"io"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	"github.com/marmotedu/miniblog/pkg/archive"
+)
+
+// exportBatchSize is the number of posts read from the storage at a time when exporting posts.
+const exportBatchSize = 100
+
+// Export is the implementation of the `Export` method in PostBiz interface.
+// It writes all the posts of username except the ones in the trash to w as an archive of format, oldest first.
+func (b *postBiz) Export(ctx context.Context, username, format string, w io.Writer) error {
+	aw, err := archive.NewWriter(w, format)
+	if err != nil {
+		return err
+	}
+
+	opts := &store.ListOptions{Limit: exportBatchSize, SkipCount: true, Ascending: true}
+	for {
+		_, list, err := b.ds.Posts().List(ctx, username, nil, opts)
+		if err != nil {
+			return err
+		}
+
+		more := len(list) > opts.PageSize()
+		if more {
+			list = list[:opts.PageSize()]
+		}
+
+		postIDs := make([]string, 0, len(list))
+		for _, post := range list {
+			postIDs = append(postIDs, post.PostID)
+		}
+
+		tags, err := b.ds.Tags().ListByPostIDs(ctx, postIDs)
+		if err != nil {
+			return err
+		}
+
+		for _, post := range list {
+			if err := aw.Write(archivePost(post, tags[post.PostID])); err != nil {
+				return err
+			}
+		}
+
+		if !more {
+			break
+		}
+
+		last := list[len(list)-1]
+		opts.Cursor = store.NewCursor(opts, last.CreatedAt, last.ID)
+	}
+
+	return aw.Close()
+}
+
+// archivePost converts post to the format of archives.
+func archivePost(post *model.PostM, tags []string) *archive.Post {
+	createdAt, updatedAt := post.CreatedAt, post.UpdatedAt
+
+	return &archive.Post{
+		PostID:        post.PostID,
+		Slug:          post.Slug,
+		Title:         post.Title,
+		ContentFormat: post.ContentFormat,
+		Status:        post.Status,
+		Visibility:    post.Visibility,
+		CommentPolicy: post.CommentPolicy,
+		Tags:          tags,
+		PublishAt:     post.PublishAt,
+		CreatedAt:     &createdAt,
+		UpdatedAt:     &updatedAt,
+		Content:       post.Content,
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	"github.com/marmotedu/miniblog/pkg/archive"
+)
+
+func Test_postBiz_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// More posts than a batch, so that the second batch is read with a cursor.
+	created := time.Date(2022, 11, 20, 8, 30, 0, 0, time.UTC)
+	var posts []*model.PostM
+	for i := 1; i <= exportBatchSize+1; i++ {
+		posts = append(posts, &model.PostM{
+			ID:        int64(i),
+			PostID:    fmt.Sprintf("post-%d", i),
+			Slug:      fmt.Sprintf("slug-%d", i),
+			Title:     fmt.Sprintf("Post %d", i),
+			Content:   "content",
+			Status:    model.PostStatusDraft,
+			CreatedAt: created.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().List(gomock.Any(), "belm", gomock.Nil(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, username string, filter *store.PostFilter, opts *store.ListOptions) (int64, []*model.PostM, error) {
+			assert.True(t, opts.Ascending)
+			if opts.Cursor == nil {
+				return 0, posts, nil
+			}
+
+			assert.Equal(t, int64(exportBatchSize), opts.Cursor.ID)
+
+			return 0, posts[exportBatchSize:], nil
+		}).Times(2)
+
+	mockTagStore := store.NewMockTagStore(ctrl)
+	mockTagStore.EXPECT().ListByPostIDs(gomock.Any(), gomock.Any()).Return(map[string][]string{"post-1": {"go"}}, nil).Times(2)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+	mockStore.EXPECT().Tags().AnyTimes().Return(mockTagStore)
+
+	var buf bytes.Buffer
+	assert.NoError(t, New(mockStore).Export(context.Background(), "belm", archive.FormatJSONL, &buf))
+
+	_, entries, err := archive.Read(buf.Bytes(), archive.FormatJSONL)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, exportBatchSize+1) {
+		assert.Equal(t, &archive.Post{
+			PostID:    "post-1",
+			Slug:      "slug-1",
+			Title:     "Post 1",
+			Status:    model.PostStatusDraft,
+			Tags:      []string{"go"},
+			CreatedAt: &posts[0].CreatedAt,
+			UpdatedAt: &posts[0].UpdatedAt,
+			Content:   "content",
+		}, entries[0].Post)
+		assert.Equal(t, "post-101", entries[exportBatchSize].Post.PostID)
+	}
+}
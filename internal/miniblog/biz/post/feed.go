@@ -0,0 +1,101 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	"github.com/marmotedu/miniblog/pkg/feed"
+)
+
+// feedSize is the number of the latest posts in a feed.
+const feedSize = 20
+
+// rootRelativeURL matches the root relative URLs in the links and images of the rendered content.
+var rootRelativeURL = regexp.MustCompile(`(\s(?:href|src)=")/([^/"])`)
+
+// Feed is the implementation of the `Feed` method in PostBiz interface.
+// It returns the latest published public posts of the user, baseURL is the scheme and host used to build absolute links.
+// The FeedURL of the returned feed is left to the caller.
+func (b *postBiz) Feed(ctx context.Context, username, baseURL string) (*feed.Feed, error) {
+	user, err := b.ds.Users().Get(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	filter := &store.PostFilter{Status: model.PostStatusPublished, Visibility: model.PostVisibilityPublic}
+	opts := &store.ListOptions{Limit: feedSize, SortBy: "publishAt", SkipCount: true}
+	_, list, err := b.ds.Posts().List(ctx, username, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > feedSize {
+		list = list[:feedSize]
+	}
+
+	author := user.Nickname
+	if author == "" {
+		author = user.Username
+	}
+
+	f := &feed.Feed{
+		Title:       author,
+		Description: "Latest posts of " + author,
+		Link:        baseURL + "/v1/users/" + username + "/posts",
+		Author:      author,
+		Updated:     user.CreatedAt,
+	}
+
+	postIDs := make([]string, 0, len(list))
+	for _, post := range list {
+		postIDs = append(postIDs, post.PostID)
+	}
+
+	tags, err := b.ds.Tags().ListByPostIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, post := range list {
+		item := &feed.Item{
+			ID:          baseURL + "/v1/public/posts/" + post.PostID,
+			Title:       post.Title,
+			Link:        baseURL + "/v1/public/posts/" + post.PostID,
+			ContentHTML: rootRelativeURL.ReplaceAllString(contentHTML(post), "${1}"+baseURL+"/${2}"),
+			Author:      author,
+			Tags:        tags[post.PostID],
+			Updated:     post.UpdatedAt,
+		}
+
+		if post.Slug != "" {
+			item.Link = baseURL + "/v1/public/" + username + "/" + post.Slug
+		}
+
+		if post.PublishAt != nil {
+			item.Published = *post.PublishAt
+		}
+
+		if post.UpdatedAt.After(f.Updated) {
+			f.Updated = post.UpdatedAt
+		}
+
+		f.Items = append(f.Items, item)
+	}
+
+	return f, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+func Test_postBiz_Feed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	created := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	published := time.Date(2022, 11, 20, 8, 30, 0, 0, time.UTC)
+
+	mockUserStore := store.NewMockUserStore(ctrl)
+	mockUserStore.EXPECT().Get(gomock.Any(), "belm").Return(&model.UserM{Username: "belm", Nickname: "Belm", CreatedAt: created}, nil).AnyTimes()
+	mockUserStore.EXPECT().Get(gomock.Any(), "colin").Return(&model.UserM{Username: "colin", CreatedAt: created}, nil).AnyTimes()
+	mockUserStore.EXPECT().Get(gomock.Any(), "nobody").Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().List(gomock.Any(), "belm", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, username string, filter *store.PostFilter, opts *store.ListOptions) (int64, []*model.PostM, error) {
+			assert.Equal(t, &store.PostFilter{Status: model.PostStatusPublished, Visibility: model.PostVisibilityPublic}, filter)
+			assert.Equal(t, "publishAt", opts.SortBy)
+			assert.False(t, opts.Ascending)
+
+			return 0, []*model.PostM{
+				{
+					PostID: "post-2", Slug: "hello", Title: "Hello", PublishAt: &published, UpdatedAt: published.Add(time.Hour),
+					Content: "![cat](/v1/public/media/media-1) [home](//example.com)", ContentFormat: model.PostFormatMarkdown,
+				},
+				{PostID: "post-1", Title: "Legacy", PublishAt: &published, UpdatedAt: published, ContentHTML: "<p>old</p>\n"},
+			}, nil
+		}).Times(1)
+	mockPostStore.EXPECT().List(gomock.Any(), "colin", gomock.Any(), gomock.Any()).Return(int64(0), nil, nil).Times(1)
+
+	mockTagStore := store.NewMockTagStore(ctrl)
+	mockTagStore.EXPECT().ListByPostIDs(gomock.Any(), gomock.Any()).Return(map[string][]string{"post-2": {"go"}}, nil).AnyTimes()
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Users().AnyTimes().Return(mockUserStore)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+	mockStore.EXPECT().Tags().AnyTimes().Return(mockTagStore)
+
+	b := New(mockStore)
+
+	f, err := b.Feed(context.Background(), "belm", "https://blog.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "Belm", f.Title)
+	assert.Equal(t, "https://blog.example.com/v1/users/belm/posts", f.Link)
+	assert.Equal(t, published.Add(time.Hour), f.Updated)
+	if assert.Len(t, f.Items, 2) {
+		assert.Equal(t, "https://blog.example.com/v1/public/posts/post-2", f.Items[0].ID)
+		assert.Equal(t, "https://blog.example.com/v1/public/belm/hello", f.Items[0].Link)
+		assert.Equal(t, published, f.Items[0].Published)
+		assert.Equal(t, []string{"go"}, f.Items[0].Tags)
+		assert.Equal(t, `<p><img src="https://blog.example.com/v1/public/media/media-1" alt="cat"> <a href="//example.com">home</a></p>`+"\n", f.Items[0].ContentHTML)
+
+		// Posts without slug are linked by their postID.
+		assert.Equal(t, "https://blog.example.com/v1/public/posts/post-1", f.Items[1].Link)
+		assert.Equal(t, "<p>old</p>\n", f.Items[1].ContentHTML)
+	}
+
+	// The feed of a user without posts is updated when the user is created.
+	f, err = b.Feed(context.Background(), "colin", "http://localhost:8080")
+	assert.NoError(t, err)
+	assert.Equal(t, "colin", f.Author)
+	assert.Equal(t, created, f.Updated)
+	assert.Empty(t, f.Items)
+
+	_, err = b.Feed(context.Background(), "nobody", "http://localhost:8080")
+	assert.ErrorIs(t, err, errno.ErrUserNotFound)
+}
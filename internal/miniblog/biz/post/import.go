@@ -0,0 +1,394 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/archive"
+	"github.com/marmotedu/miniblog/pkg/render"
+)
+
+// importProgressInterval is the number of items imported between two saves of the progress of an import.
+const importProgressInterval = 20
+
+// importLease is how long a running import is reserved for the process running it. The process renews the
+// lease every importHeartbeat, an import whose lease has expired was interrupted and is marked as failed.
+const (
+	importLease     = time.Minute
+	importHeartbeat = importLease / 4
+)
+
+// The results of importing an archive item, see v1.ImportItem.
+const (
+	importCreated = "created"
+	importSkipped = "skipped"
+	importFailed  = "failed"
+)
+
+// postIDPattern matches the IDs generated for posts, only such IDs are kept when posts are imported.
+var postIDPattern = regexp.MustCompile(`^post-[0-9a-z]{1,32}$`)
+
+// runAsync runs fn in the background. It is replaced in tests to run imports synchronously.
+var runAsync = func(fn func()) { go fn() }
+
+// Import is the implementation of the `Import` method in PostBiz interface.
+// The archive is parsed before returning, the posts are imported in the background.
+func (b *postBiz) Import(ctx context.Context, username, format string, data []byte) (*v1.ImportPostResponse, error) {
+	format, entries, err := archive.Read(data, format)
+	if err != nil {
+		return nil, errno.ErrImportInvalid.SetMessage("The archive to import is invalid: %s", err)
+	}
+
+	return b.ImportEntries(ctx, username, format, entries)
+}
+
+// ImportEntries is the implementation of the `ImportEntries` method in PostBiz interface.
+// It imports the entries already read from an archive of format in the background, the author of the entries is ignored.
+func (b *postBiz) ImportEntries(ctx context.Context, username, format string, entries []*archive.Entry) (*v1.ImportPostResponse, error) {
+	imp := &model.PostImportM{
+		Username:       username,
+		Format:         format,
+		Status:         model.ImportStatusRunning,
+		Total:          len(entries),
+		LeaseExpiresAt: time.Now().Add(importLease),
+	}
+	if err := b.ds.Imports().Create(ctx, imp); err != nil {
+		return nil, err
+	}
+
+	// The import outlives the request, only the values used for logging are kept.
+	bg := context.WithValue(context.Background(), known.XRequestIDKey, ctx.Value(known.XRequestIDKey))
+	bg = context.WithValue(bg, known.XUsernameKey, username)
+	runAsync(func() { b.runImport(bg, imp, entries) })
+
+	return &v1.ImportPostResponse{ImportID: imp.ImportID}, nil
+}
+
+// GetImport is the implementation of the `GetImport` method in PostBiz interface.
+func (b *postBiz) GetImport(ctx context.Context, username, importID string) (*v1.GetImportResponse, error) {
+	imp, err := b.ds.Imports().Get(ctx, username, importID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrImportNotFound
+		}
+
+		return nil, err
+	}
+
+	resp := &v1.GetImportResponse{
+		ImportID:  imp.ImportID,
+		Format:    imp.Format,
+		Status:    imp.Status,
+		Total:     imp.Total,
+		Processed: imp.Processed,
+		Created:   imp.Created,
+		Skipped:   imp.Skipped,
+		Failed:    imp.Failed,
+		Error:     imp.Error,
+		CreatedAt: imp.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: imp.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if imp.Items != "" {
+		if err := json.Unmarshal([]byte(imp.Items), &resp.Items); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// FailExpiredImports is the implementation of the `FailExpiredImports` method in PostBiz interface.
+// It marks the running imports whose lease has expired as failed, and returns the number of failed imports.
+func (b *postBiz) FailExpiredImports(ctx context.Context) (int64, error) {
+	return b.ds.Imports().FailExpired(ctx, time.Now(), "The import was interrupted because the server running it stopped.")
+}
+
+// DryRunImport is the implementation of the `DryRunImport` method in PostBiz interface.
+// It checks the entries like an import does and returns the results the import would have, nothing is saved.
+// The ID of a post which would be created is empty unless the ID in the archive would be kept.
+func (b *postBiz) DryRunImport(ctx context.Context, username string, entries []*archive.Entry) []*v1.ImportItem {
+	items := make([]*v1.ImportItem, 0, len(entries))
+
+	// The posts which would be created, so that the later entries with the same ID or slug are skipped like an import does.
+	planned := map[string]bool{}
+	for _, entry := range entries {
+		if p := entry.Post; p != nil && (p.PostID != "" && planned["id:"+p.PostID] || p.Slug != "" && planned["slug:"+p.Slug]) {
+			items = append(items, &v1.ImportItem{Name: entry.Name, Result: importSkipped, Message: "The post already exists."})
+			continue
+		}
+
+		item := b.importEntry(ctx, username, entry, true)
+		if item.Result == importCreated {
+			planned["id:"+entry.Post.PostID], planned["slug:"+entry.Post.Slug] = true, true
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// runImport imports entries one by one and records the progress in imp.
+// A failed item does not stop the import, it is reported in the items of the import.
+func (b *postBiz) runImport(ctx context.Context, imp *model.PostImportM, entries []*archive.Entry) {
+	items := make([]*v1.ImportItem, 0, len(entries))
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go b.renewImport(ctx, imp.ID, stop)
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.C(ctx).Errorw("Import panicked", "importID", imp.ImportID, "panic", r)
+
+			imp.Status, imp.Error = model.ImportStatusFailed, "Internal server error."
+			b.saveImport(ctx, imp, items)
+		}
+	}()
+
+	for _, entry := range entries {
+		item := b.importEntry(ctx, imp.Username, entry, false)
+		items = append(items, item)
+
+		imp.Processed++
+		switch item.Result {
+		case importCreated:
+			imp.Created++
+		case importSkipped:
+			imp.Skipped++
+		default:
+			imp.Failed++
+		}
+
+		if imp.Processed%importProgressInterval == 0 && imp.Processed < imp.Total {
+			b.saveImport(ctx, imp, nil)
+		}
+	}
+
+	imp.Status = model.ImportStatusSucceeded
+	b.saveImport(ctx, imp, items)
+
+	log.C(ctx).Infow("Import finished", "importID", imp.ImportID, "created", imp.Created, "skipped", imp.Skipped, "failed", imp.Failed)
+}
+
+// renewImport renews the lease of the import id every importHeartbeat until stop is closed.
+func (b *postBiz) renewImport(ctx context.Context, id int64, stop <-chan struct{}) {
+	ticker := time.NewTicker(importHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := b.ds.Imports().Renew(ctx, id, time.Now().Add(importLease)); err != nil {
+				log.C(ctx).Errorw("Failed to renew the lease of import", "id", id, "err", err)
+			}
+		}
+	}
+}
+
+// saveImport saves the progress of imp, the results of the items are saved too if items is not nil.
+// The lease is renewed too, so that saving does not overwrite it with an earlier time.
+func (b *postBiz) saveImport(ctx context.Context, imp *model.PostImportM, items []*v1.ImportItem) {
+	if items != nil {
+		data, _ := json.Marshal(items)
+		imp.Items = string(data)
+	}
+
+	imp.LeaseExpiresAt = time.Now().Add(importLease)
+
+	if err := b.ds.Imports().Update(ctx, imp); err != nil {
+		log.C(ctx).Errorw("Failed to save the progress of import", "importID", imp.ImportID, "err", err)
+	}
+}
+
+// importEntry imports a single archive entry and returns its result, the post is only checked if dryRun is true.
+func (b *postBiz) importEntry(ctx context.Context, username string, entry *archive.Entry, dryRun bool) *v1.ImportItem {
+	item := &v1.ImportItem{Name: entry.Name}
+	if entry.Err != nil {
+		item.Result, item.Message = importFailed, entry.Err.Error()
+
+		return item
+	}
+
+	postID, exists, err := b.importPost(ctx, username, entry.Post, dryRun)
+	switch {
+	case err != nil:
+		log.C(ctx).Warnw("Failed to import post", "item", entry.Name, "err", err)
+
+		_, _, message := errno.Decode(err)
+		item.Result, item.Message = importFailed, message
+	case exists:
+		item.Result, item.PostID, item.Message = importSkipped, postID, "The post already exists."
+	default:
+		item.Result, item.PostID = importCreated, postID
+	}
+
+	return item
+}
+
+// importPost creates a post of username from p. A post is not imported again if username already has a post with
+// the same ID or slug, in which case the ID of the existing post is returned and exists is true.
+// The ID of p is kept for the new post unless it is used by a post of another user. Nothing is created if dryRun is true.
+func (b *postBiz) importPost(ctx context.Context, username string, p *archive.Post, dryRun bool) (postID string, exists bool, err error) {
+	if p.PostID != "" {
+		post, err := b.ds.Posts().Get(ctx, username, p.PostID)
+		if err == nil {
+			return post.PostID, true, nil
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", false, err
+		}
+	}
+
+	if p.Slug != "" {
+		postID, err := b.ds.Slugs().Resolve(ctx, username, p.Slug)
+		if err == nil {
+			return postID, true, nil
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", false, err
+		}
+	}
+
+	postM, tags, err := importedPost(p, time.Now())
+	if err != nil {
+		return "", false, err
+	}
+	postM.Username = username
+
+	if p.Slug != "" {
+		err = b.checkSlug(ctx, username, "", p.Slug)
+	} else {
+		postM.Slug, err = b.uniqueSlug(ctx, username, "", postM.Title)
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	if postIDPattern.MatchString(p.PostID) {
+		_, err := b.ds.Posts().GetByPostID(ctx, p.PostID)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			postM.PostID = p.PostID
+		case err != nil:
+			return "", false, err
+		}
+	}
+
+	if dryRun {
+		return postM.PostID, false, nil
+	}
+
+	if err := b.create(ctx, username, postM, tags); err != nil {
+		return "", false, err
+	}
+
+	return postM.PostID, false, nil
+}
+
+// importedPost validates p and converts it to a new post, the missing fields are set to the defaults of creating a post.
+// Unlike creating a post, the publish time and the creation time of p are kept, and a post scheduled before now is published.
+func importedPost(p *archive.Post, now time.Time) (*model.PostM, []string, error) {
+	r := &v1.CreatePostRequest{
+		Title:         p.Title,
+		Slug:          p.Slug,
+		Content:       p.Content,
+		ContentFormat: p.ContentFormat,
+		Visibility:    p.Visibility,
+		CommentPolicy: p.CommentPolicy,
+	}
+	if _, err := govalidator.ValidateStruct(r); err != nil {
+		return nil, nil, errno.ErrInvalidParameter.SetMessage(err.Error())
+	}
+
+	tags, err := normalizeTags(p.Tags)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	postM := &model.PostM{
+		Slug:          p.Slug,
+		Title:         p.Title,
+		Content:       p.Content,
+		ContentFormat: p.ContentFormat,
+		Status:        p.Status,
+		Visibility:    p.Visibility,
+		CommentPolicy: p.CommentPolicy,
+		PublishAt:     p.PublishAt,
+	}
+
+	if postM.ContentFormat == "" {
+		postM.ContentFormat = model.PostFormatPlain
+	}
+
+	if postM.Visibility == "" {
+		postM.Visibility = model.PostVisibilityPublic
+	}
+
+	if postM.CommentPolicy == "" {
+		postM.CommentPolicy = model.PostCommentOpen
+	}
+
+	postM.ContentHTML = render.Render(postM.ContentFormat, postM.Content)
+
+	if postM.Status == "" {
+		postM.Status = model.PostStatusDraft
+		if postM.PublishAt != nil {
+			postM.Status = model.PostStatusPublished
+		}
+	}
+
+	switch postM.Status {
+	case model.PostStatusDraft:
+		postM.PublishAt = nil
+	case model.PostStatusScheduled:
+		if postM.PublishAt == nil {
+			return nil, nil, errno.ErrPublishAtInvalid
+		}
+
+		if !postM.PublishAt.After(now) {
+			postM.Status = model.PostStatusPublished
+		}
+	case model.PostStatusPublished, model.PostStatusArchived:
+		if postM.PublishAt == nil {
+			postM.PublishAt = p.CreatedAt
+		}
+
+		if postM.PublishAt == nil {
+			postM.PublishAt = &now
+		}
+	default:
+		return nil, nil, errno.ErrInvalidParameter.SetMessage("unknown post status %q", postM.Status)
+	}
+
+	if p.CreatedAt != nil {
+		postM.CreatedAt = *p.CreatedAt
+	}
+
+	if p.UpdatedAt != nil {
+		postM.UpdatedAt = *p.UpdatedAt
+	}
+
+	return postM, tags, nil
+}
@@ -0,0 +1,274 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/archive"
+)
+
+func Test_postBiz_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Run the imports synchronously so that the results can be checked after Import returns.
+	runAsync = func(fn func()) { fn() }
+	defer func() { runAsync = func(fn func()) { go fn() } }()
+
+	created := map[string]*model.PostM{}
+
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().Get(gomock.Any(), "belm", "post-1").Return(&model.PostM{PostID: "post-1"}, nil).AnyTimes()
+	mockPostStore.EXPECT().Get(gomock.Any(), "belm", gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	mockPostStore.EXPECT().GetByPostID(gomock.Any(), "post-colin").Return(&model.PostM{PostID: "post-colin"}, nil).AnyTimes()
+	mockPostStore.EXPECT().GetByPostID(gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	mockPostStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, post *model.PostM) error {
+			if post.PostID == "" {
+				post.PostID = "post-new"
+			}
+			created[post.PostID] = post
+
+			return nil
+		},
+	).AnyTimes()
+
+	mockRevisionStore := store.NewMockRevisionStore(ctrl)
+	mockRevisionStore.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	mockTimelineStore := store.NewMockTimelineStore(ctrl)
+	mockTimelineStore.EXPECT().FanOut(gomock.Any(), gomock.Any()).Return(int64(0), nil).AnyTimes()
+
+	mockTagStore := store.NewMockTagStore(ctrl)
+	mockTagStore.EXPECT().FirstOrCreate(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockTagStore.EXPECT().SetPostTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	var saved model.PostImportM
+	mockImportStore := store.NewMockImportStore(ctrl)
+	mockImportStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, imp *model.PostImportM) error {
+			assert.WithinDuration(t, time.Now().Add(importLease), imp.LeaseExpiresAt, time.Second)
+			imp.ImportID = "import-1"
+
+			return nil
+		},
+	).Times(1)
+	mockImportStore.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, imp *model.PostImportM) error {
+			saved = *imp
+
+			return nil
+		},
+	).Times(1)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+	mockStore.EXPECT().Revisions().AnyTimes().Return(mockRevisionStore)
+	mockStore.EXPECT().Timelines().AnyTimes().Return(mockTimelineStore)
+	mockStore.EXPECT().Tags().AnyTimes().Return(mockTagStore)
+	mockStore.EXPECT().Imports().AnyTimes().Return(mockImportStore)
+	mockStore.EXPECT().Slugs().AnyTimes().Return(slugFixture(ctrl, map[string]string{"taken": "post-2"}, map[string]string{}))
+	mockStore.EXPECT().Webhooks().AnyTimes().Return(noWebhooks(ctrl))
+	mockStore.EXPECT().Events().AnyTimes().Return(noEvents(ctrl))
+	mockStore.EXPECT().TX(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+
+	data := `{"postID":"post-1","title":"Existing","content":"a"}
+{"slug":"taken","title":"Same slug","content":"b"}
+{"postID":"post-abc","slug":"kept","title":"Kept","content":"*c*","contentFormat":"markdown","status":"published","tags":["Go"],"publishAt":"2022-11-20T08:30:00Z","createdAt":"2022-11-19T08:30:00Z"}
+{"postID":"post-colin","title":"Used by another user","content":"d"}
+not json
+{"title":"","content":"e"}
+{"title":"Unknown status","content":"f","status":"deleted"}
+`
+	b := New(mockStore)
+	resp, err := b.Import(context.Background(), "belm", "", []byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, &v1.ImportPostResponse{ImportID: "import-1"}, resp)
+
+	assert.Equal(t, model.ImportStatusSucceeded, saved.Status)
+	assert.Equal(t, archive.FormatJSONL, saved.Format)
+	assert.Equal(t, []int{7, 7, 2, 2, 3}, []int{saved.Total, saved.Processed, saved.Created, saved.Skipped, saved.Failed})
+
+	var items []*v1.ImportItem
+	assert.NoError(t, json.Unmarshal([]byte(saved.Items), &items))
+	if assert.Len(t, items, 7) {
+		assert.Equal(t, &v1.ImportItem{Name: "line 1", Result: importSkipped, PostID: "post-1", Message: "The post already exists."}, items[0])
+		assert.Equal(t, &v1.ImportItem{Name: "line 2", Result: importSkipped, PostID: "post-2", Message: "The post already exists."}, items[1])
+		assert.Equal(t, &v1.ImportItem{Name: "line 3", Result: importCreated, PostID: "post-abc"}, items[2])
+		assert.Equal(t, &v1.ImportItem{Name: "line 4", Result: importCreated, PostID: "post-new"}, items[3])
+		assert.Equal(t, importFailed, items[4].Result)
+		assert.Equal(t, "line 6", items[5].Name)
+		assert.Equal(t, importFailed, items[5].Result)
+		assert.Equal(t, importFailed, items[6].Result)
+	}
+
+	// The ID, slug and times of the imported post are kept.
+	if post := created["post-abc"]; assert.NotNil(t, post) {
+		assert.Equal(t, "kept", post.Slug)
+		assert.Equal(t, "<p><em>c</em></p>\n", post.ContentHTML)
+		assert.Equal(t, model.PostStatusPublished, post.Status)
+		assert.Equal(t, time.Date(2022, 11, 20, 8, 30, 0, 0, time.UTC), *post.PublishAt)
+		assert.Equal(t, time.Date(2022, 11, 19, 8, 30, 0, 0, time.UTC), post.CreatedAt)
+		assert.Equal(t, model.PostVisibilityPublic, post.Visibility)
+	}
+
+	// A new ID and a slug generated from the title are used if the ID is used by another user.
+	if post := created["post-new"]; assert.NotNil(t, post) {
+		assert.Equal(t, "used-by-another-user", post.Slug)
+		assert.Equal(t, model.PostStatusDraft, post.Status)
+		assert.Nil(t, post.PublishAt)
+	}
+
+	_, err = b.Import(context.Background(), "belm", "", []byte("PK\x03\x04broken"))
+	assert.ErrorIs(t, err, errno.ErrImportInvalid)
+}
+
+func Test_postBiz_FailExpiredImports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockImportStore := store.NewMockImportStore(ctrl)
+	mockImportStore.EXPECT().FailExpired(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, now time.Time, reason string) (int64, error) {
+			assert.WithinDuration(t, time.Now(), now, time.Second)
+			assert.NotEmpty(t, reason)
+
+			return 2, nil
+		},
+	).Times(1)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Imports().AnyTimes().Return(mockImportStore)
+
+	count, err := New(mockStore).FailExpiredImports(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func Test_importedPost(t *testing.T) {
+	now := time.Date(2022, 11, 20, 0, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		post          *archive.Post
+		wantStatus    string
+		wantPublishAt *time.Time
+		wantErr       bool
+	}{
+		{name: "draft by default", post: &archive.Post{Title: "t", Content: "c"}, wantStatus: model.PostStatusDraft},
+		{name: "published if publish time is set", post: &archive.Post{Title: "t", Content: "c", PublishAt: &past}, wantStatus: model.PostStatusPublished, wantPublishAt: &past},
+		{name: "published at creation time", post: &archive.Post{Title: "t", Content: "c", Status: "archived", CreatedAt: &past}, wantStatus: model.PostStatusArchived, wantPublishAt: &past},
+		{name: "scheduled in the future", post: &archive.Post{Title: "t", Content: "c", Status: "scheduled", PublishAt: &future}, wantStatus: model.PostStatusScheduled, wantPublishAt: &future},
+		{name: "scheduled in the past", post: &archive.Post{Title: "t", Content: "c", Status: "scheduled", PublishAt: &past}, wantStatus: model.PostStatusPublished, wantPublishAt: &past},
+		{name: "scheduled without time", post: &archive.Post{Title: "t", Content: "c", Status: "scheduled"}, wantErr: true},
+		{name: "invalid visibility", post: &archive.Post{Title: "t", Content: "c", Visibility: "friends"}, wantErr: true},
+		{name: "too many tags", post: &archive.Post{Title: "t", Content: "c", Tags: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := importedPost(tt.post, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.Equal(t, tt.wantPublishAt, got.PublishAt)
+		})
+	}
+}
+
+func Test_postBiz_DryRunImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().Get(gomock.Any(), "belm", gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	mockPostStore.EXPECT().GetByPostID(gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+
+	// Nothing is saved in a dry run.
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+	mockStore.EXPECT().Slugs().AnyTimes().Return(slugFixture(ctrl, map[string]string{"taken": "post-2"}, map[string]string{}))
+
+	_, entries, err := archive.Read([]byte(fakeWXR), archive.FormatWXR)
+	assert.NoError(t, err)
+
+	items := New(mockStore).DryRunImport(context.Background(), "belm", entries)
+	assert.Equal(t, []*v1.ImportItem{
+		{Name: "post 12", Result: importCreated},
+		{Name: "post 13", Result: importCreated},
+		{Name: "post 14", Result: importSkipped, PostID: "post-2", Message: "The post already exists."},
+		{Name: "post 15", Result: importSkipped, Message: "The post already exists."},
+		{Name: "post 16", Result: importFailed, Message: "content: non zero value required"},
+	}, items)
+}
+
+// fakeWXR is a WordPress export with a new post, a draft, a post whose slug is used, a duplicated post and an invalid post.
+const fakeWXR = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/" xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<item>
+		<title>Hello</title>
+		<dc:creator>jane</dc:creator>
+		<content:encoded>Hello world</content:encoded>
+		<wp:post_id>12</wp:post_id>
+		<wp:post_date_gmt>2022-11-20 08:30:00</wp:post_date_gmt>
+		<wp:post_name>hello</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_type>post</wp:post_type>
+	</item>
+	<item>
+		<title>Draft</title>
+		<content:encoded>Draft</content:encoded>
+		<wp:post_id>13</wp:post_id>
+		<wp:status>draft</wp:status>
+		<wp:post_type>post</wp:post_type>
+	</item>
+	<item>
+		<title>Taken</title>
+		<content:encoded>Taken</content:encoded>
+		<wp:post_id>14</wp:post_id>
+		<wp:post_name>taken</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_type>post</wp:post_type>
+	</item>
+	<item>
+		<title>Hello again</title>
+		<content:encoded>Hello again</content:encoded>
+		<wp:post_id>15</wp:post_id>
+		<wp:post_name>hello</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_type>post</wp:post_type>
+	</item>
+	<item>
+		<title>Empty</title>
+		<wp:post_id>16</wp:post_id>
+		<wp:status>publish</wp:status>
+		<wp:post_type>post</wp:post_type>
+	</item>
+</channel>
+</rss>
+`
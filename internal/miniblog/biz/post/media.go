@@ -0,0 +1,35 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// mediaURL matches the public URLs of the uploaded media and their thumbnails in the content of a post.
+var mediaURL = regexp.MustCompile(`/v1/public/media/(media-[0-9a-z]+)`)
+
+// MediaIDs returns the IDs of the media referenced in content without duplicates, in order of appearance.
+func MediaIDs(content string) []string {
+	var ids []string
+	seen := map[string]bool{}
+	for _, m := range mediaURL.FindAllStringSubmatch(content, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			ids = append(ids, m[1])
+		}
+	}
+
+	return ids
+}
+
+// setMedia records the media referenced by the content of the post, so that they are not garbage collected.
+func (b *postBiz) setMedia(ctx context.Context, post *model.PostM) error {
+	return b.ds.Media().SetPostMedia(ctx, post.PostID, MediaIDs(post.Content))
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+func TestMediaIDs(t *testing.T) {
+	content := "![cat](/v1/public/media/media-abc123/thumbnail)\n" +
+		"[full size](https://blog.example.com/v1/public/media/media-abc123) " +
+		`<img src="/v1/public/media/media-def456">` +
+		" /v1/public/media/post-1 /media/media-ghi789"
+
+	assert.Equal(t, []string{"media-abc123", "media-def456"}, MediaIDs(content))
+	assert.Nil(t, MediaIDs("no media"))
+}
+
+func Test_postBiz_Update_media(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	post := &model.PostM{PostID: "post-1", Username: "belm", Slug: "hello", Title: "hello", Content: "old"}
+	var revisions []*model.PostRevisionM
+	mockStore := revisionFixture(ctrl, post, &revisions)
+
+	mockMediaStore := store.NewMockMediaStore(ctrl)
+	mockMediaStore.EXPECT().SetPostMedia(gomock.Any(), "post-1", []string{"media-abc123"}).Return(nil).Times(1)
+	mockStore.EXPECT().Media().Return(mockMediaStore).Times(1)
+
+	b := New(mockStore)
+
+	content := "![cat](/v1/public/media/media-abc123)"
+	assert.Nil(t, b.Update(context.Background(), "belm", "post-1", &v1.UpdatePostRequest{Content: &content}))
+
+	// The references are kept if the content is not changed.
+	title := "world"
+	assert.Nil(t, b.Update(context.Background(), "belm", "post-1", &v1.UpdatePostRequest{Title: &title, Content: &content}))
+}
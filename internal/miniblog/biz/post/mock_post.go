@@ -11,11 +11,15 @@ package post
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 
 	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	archive "github.com/marmotedu/miniblog/pkg/archive"
+	feed "github.com/marmotedu/miniblog/pkg/feed"
 )
 
 // MockPostBiz is a mock of PostBiz interface.
@@ -84,34 +88,345 @@ func (mr *MockPostBizMockRecorder) DeleteCollection(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockPostBiz)(nil).DeleteCollection), arg0, arg1, arg2)
 }
 
+// DiffRevisions mocks base method.
+func (m *MockPostBiz) DiffRevisions(arg0 context.Context, arg1, arg2 string, arg3 *v1.DiffRevisionRequest) (*v1.DiffRevisionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.DiffRevisionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockPostBizMockRecorder) DiffRevisions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockPostBiz)(nil).DiffRevisions), arg0, arg1, arg2, arg3)
+}
+
+// DryRunImport mocks base method.
+func (m *MockPostBiz) DryRunImport(arg0 context.Context, arg1 string, arg2 []*archive.Entry) []*v1.ImportItem {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRunImport", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*v1.ImportItem)
+	return ret0
+}
+
+// DryRunImport indicates an expected call of DryRunImport.
+func (mr *MockPostBizMockRecorder) DryRunImport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRunImport", reflect.TypeOf((*MockPostBiz)(nil).DryRunImport), arg0, arg1, arg2)
+}
+
+// Export mocks base method.
+func (m *MockPostBiz) Export(arg0 context.Context, arg1, arg2 string, arg3 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockPostBizMockRecorder) Export(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockPostBiz)(nil).Export), arg0, arg1, arg2, arg3)
+}
+
+// FailExpiredImports mocks base method.
+func (m *MockPostBiz) FailExpiredImports(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailExpiredImports", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailExpiredImports indicates an expected call of FailExpiredImports.
+func (mr *MockPostBizMockRecorder) FailExpiredImports(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailExpiredImports", reflect.TypeOf((*MockPostBiz)(nil).FailExpiredImports), arg0)
+}
+
+// Feed mocks base method.
+func (m *MockPostBiz) Feed(arg0 context.Context, arg1, arg2 string) (*feed.Feed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Feed", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*feed.Feed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Feed indicates an expected call of Feed.
+func (mr *MockPostBizMockRecorder) Feed(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Feed", reflect.TypeOf((*MockPostBiz)(nil).Feed), arg0, arg1, arg2)
+}
+
 // Get mocks base method.
-func (m *MockPostBiz) Get(arg0 context.Context, arg1, arg2 string) (*v1.GetPostResponse, error) {
+func (m *MockPostBiz) Get(arg0 context.Context, arg1, arg2 string, arg3 *v1.GetPostRequest) (*v1.GetPostResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(*v1.GetPostResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockPostBizMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockPostBizMockRecorder) Get(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPostBiz)(nil).Get), arg0, arg1, arg2, arg3)
+}
+
+// GetImport mocks base method.
+func (m *MockPostBiz) GetImport(arg0 context.Context, arg1, arg2 string) (*v1.GetImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.GetImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImport indicates an expected call of GetImport.
+func (mr *MockPostBizMockRecorder) GetImport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImport", reflect.TypeOf((*MockPostBiz)(nil).GetImport), arg0, arg1, arg2)
+}
+
+// GetPublished mocks base method.
+func (m *MockPostBiz) GetPublished(arg0 context.Context, arg1 string, arg2 *v1.GetPostRequest) (*v1.GetPostResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublished", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.GetPostResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublished indicates an expected call of GetPublished.
+func (mr *MockPostBizMockRecorder) GetPublished(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublished", reflect.TypeOf((*MockPostBiz)(nil).GetPublished), arg0, arg1, arg2)
+}
+
+// GetPublishedBySlug mocks base method.
+func (m *MockPostBiz) GetPublishedBySlug(arg0 context.Context, arg1, arg2 string, arg3 *v1.GetPostRequest) (*v1.GetPostResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublishedBySlug", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.GetPostResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublishedBySlug indicates an expected call of GetPublishedBySlug.
+func (mr *MockPostBizMockRecorder) GetPublishedBySlug(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPostBiz)(nil).Get), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedBySlug", reflect.TypeOf((*MockPostBiz)(nil).GetPublishedBySlug), arg0, arg1, arg2, arg3)
+}
+
+// Import mocks base method.
+func (m *MockPostBiz) Import(arg0 context.Context, arg1, arg2 string, arg3 []byte) (*v1.ImportPostResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.ImportPostResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockPostBizMockRecorder) Import(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockPostBiz)(nil).Import), arg0, arg1, arg2, arg3)
+}
+
+// ImportEntries mocks base method.
+func (m *MockPostBiz) ImportEntries(arg0 context.Context, arg1, arg2 string, arg3 []*archive.Entry) (*v1.ImportPostResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportEntries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.ImportPostResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportEntries indicates an expected call of ImportEntries.
+func (mr *MockPostBizMockRecorder) ImportEntries(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportEntries", reflect.TypeOf((*MockPostBiz)(nil).ImportEntries), arg0, arg1, arg2, arg3)
 }
 
 // List mocks base method.
-func (m *MockPostBiz) List(arg0 context.Context, arg1 string, arg2, arg3 int) (*v1.ListPostResponse, error) {
+func (m *MockPostBiz) List(arg0 context.Context, arg1 string, arg2 *v1.ListPostRequest) (*v1.ListPostResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*v1.ListPostResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockPostBizMockRecorder) List(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockPostBizMockRecorder) List(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPostBiz)(nil).List), arg0, arg1, arg2)
+}
+
+// ListPublished mocks base method.
+func (m *MockPostBiz) ListPublished(arg0 context.Context, arg1 string, arg2 *v1.ListPostRequest) (*v1.ListPostResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPublished", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.ListPostResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPublished indicates an expected call of ListPublished.
+func (mr *MockPostBizMockRecorder) ListPublished(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublished", reflect.TypeOf((*MockPostBiz)(nil).ListPublished), arg0, arg1, arg2)
+}
+
+// ListReacted mocks base method.
+func (m *MockPostBiz) ListReacted(arg0 context.Context, arg1 string, arg2 *v1.ListReactedPostRequest) (*v1.ListPostResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReacted", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.ListPostResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReacted indicates an expected call of ListReacted.
+func (mr *MockPostBizMockRecorder) ListReacted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReacted", reflect.TypeOf((*MockPostBiz)(nil).ListReacted), arg0, arg1, arg2)
+}
+
+// ListRevisions mocks base method.
+func (m *MockPostBiz) ListRevisions(arg0 context.Context, arg1, arg2 string, arg3 *v1.ListRevisionRequest) (*v1.ListRevisionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.ListRevisionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockPostBizMockRecorder) ListRevisions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockPostBiz)(nil).ListRevisions), arg0, arg1, arg2, arg3)
+}
+
+// ListTrash mocks base method.
+func (m *MockPostBiz) ListTrash(arg0 context.Context, arg1 string, arg2, arg3 int) (*v1.ListTrashResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.ListTrashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockPostBizMockRecorder) ListTrash(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockPostBiz)(nil).ListTrash), arg0, arg1, arg2, arg3)
+}
+
+// PublishScheduled mocks base method.
+func (m *MockPostBiz) PublishScheduled(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduled", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishScheduled indicates an expected call of PublishScheduled.
+func (mr *MockPostBizMockRecorder) PublishScheduled(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduled", reflect.TypeOf((*MockPostBiz)(nil).PublishScheduled), arg0)
+}
+
+// PurgeTrash mocks base method.
+func (m *MockPostBiz) PurgeTrash(arg0 context.Context, arg1 time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrash", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrash indicates an expected call of PurgeTrash.
+func (mr *MockPostBizMockRecorder) PurgeTrash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrash", reflect.TypeOf((*MockPostBiz)(nil).PurgeTrash), arg0, arg1)
+}
+
+// Reindex mocks base method.
+func (m *MockPostBiz) Reindex(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reindex", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reindex indicates an expected call of Reindex.
+func (mr *MockPostBizMockRecorder) Reindex(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reindex", reflect.TypeOf((*MockPostBiz)(nil).Reindex), arg0)
+}
+
+// Restore mocks base method.
+func (m *MockPostBiz) Restore(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockPostBizMockRecorder) Restore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockPostBiz)(nil).Restore), arg0, arg1, arg2)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockPostBiz) RestoreRevision(arg0 context.Context, arg1, arg2 string, arg3 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockPostBizMockRecorder) RestoreRevision(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockPostBiz)(nil).RestoreRevision), arg0, arg1, arg2, arg3)
+}
+
+// Search mocks base method.
+func (m *MockPostBiz) Search(arg0 context.Context, arg1 string, arg2 *v1.SearchPostRequest) (*v1.SearchPostResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.SearchPostResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockPostBizMockRecorder) Search(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockPostBiz)(nil).Search), arg0, arg1, arg2)
+}
+
+// Timeline mocks base method.
+func (m *MockPostBiz) Timeline(arg0 context.Context, arg1 string, arg2 *v1.ListTimelineRequest) (*v1.ListTimelineResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Timeline", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.ListTimelineResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Timeline indicates an expected call of Timeline.
+func (mr *MockPostBizMockRecorder) Timeline(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPostBiz)(nil).List), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Timeline", reflect.TypeOf((*MockPostBiz)(nil).Timeline), arg0, arg1, arg2)
 }
 
 // Update mocks base method.
@@ -10,15 +10,29 @@ package post
 import (
 	"context"
 	"errors"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/jinzhu/copier"
 	"gorm.io/gorm"
 
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/event"
 	"github.com/marmotedu/miniblog/internal/miniblog/store"
 	"github.com/marmotedu/miniblog/internal/pkg/errno"
 	"github.com/marmotedu/miniblog/internal/pkg/log"
 	"github.com/marmotedu/miniblog/internal/pkg/model"
 	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/archive"
+	"github.com/marmotedu/miniblog/pkg/feed"
+	"github.com/marmotedu/miniblog/pkg/render"
+)
+
+// The content views of a post, see v1.GetPostRequest.
+const (
+	contentViewRaw      = "raw"
+	contentViewRendered = "rendered"
+	contentViewBoth     = "both"
 )
 
 // PostBiz defines functions used to handle post request.
@@ -27,8 +41,29 @@ type PostBiz interface {
 	Update(ctx context.Context, username, postID string, r *v1.UpdatePostRequest) error
 	Delete(ctx context.Context, username, postID string) error
 	DeleteCollection(ctx context.Context, username string, postIDs []string) error
-	Get(ctx context.Context, username, postID string) (*v1.GetPostResponse, error)
-	List(ctx context.Context, username string, offset, limit int) (*v1.ListPostResponse, error)
+	Get(ctx context.Context, username, postID string, r *v1.GetPostRequest) (*v1.GetPostResponse, error)
+	List(ctx context.Context, username string, r *v1.ListPostRequest) (*v1.ListPostResponse, error)
+	ListTrash(ctx context.Context, username string, offset, limit int) (*v1.ListTrashResponse, error)
+	Restore(ctx context.Context, username, postID string) error
+	PurgeTrash(ctx context.Context, retention time.Duration) (int64, error)
+	PublishScheduled(ctx context.Context) (int64, error)
+	ListPublished(ctx context.Context, username string, r *v1.ListPostRequest) (*v1.ListPostResponse, error)
+	ListReacted(ctx context.Context, username string, r *v1.ListReactedPostRequest) (*v1.ListPostResponse, error)
+	GetPublished(ctx context.Context, postID string, r *v1.GetPostRequest) (*v1.GetPostResponse, error)
+	GetPublishedBySlug(ctx context.Context, username, slug string, r *v1.GetPostRequest) (*v1.GetPostResponse, error)
+	Feed(ctx context.Context, username, baseURL string) (*feed.Feed, error)
+	Search(ctx context.Context, username string, r *v1.SearchPostRequest) (*v1.SearchPostResponse, error)
+	Reindex(ctx context.Context) (int64, error)
+	Timeline(ctx context.Context, username string, r *v1.ListTimelineRequest) (*v1.ListTimelineResponse, error)
+	ListRevisions(ctx context.Context, username, postID string, r *v1.ListRevisionRequest) (*v1.ListRevisionResponse, error)
+	DiffRevisions(ctx context.Context, username, postID string, r *v1.DiffRevisionRequest) (*v1.DiffRevisionResponse, error)
+	RestoreRevision(ctx context.Context, username, postID string, revision int64) error
+	Export(ctx context.Context, username, format string, w io.Writer) error
+	Import(ctx context.Context, username, format string, data []byte) (*v1.ImportPostResponse, error)
+	ImportEntries(ctx context.Context, username, format string, entries []*archive.Entry) (*v1.ImportPostResponse, error)
+	DryRunImport(ctx context.Context, username string, entries []*archive.Entry) []*v1.ImportItem
+	GetImport(ctx context.Context, username, importID string) (*v1.GetImportResponse, error)
+	FailExpiredImports(ctx context.Context) (int64, error)
 }
 
 // The implementation of PostBiz interface.
@@ -50,33 +85,123 @@ func (b *postBiz) Create(ctx context.Context, username string, r *v1.CreatePostR
 	_ = copier.Copy(&postM, r)
 	postM.Username = username
 
-	if err := b.ds.Posts().Create(ctx, &postM); err != nil {
+	// The status and publish time are set by setPublication below.
+	postM.Status, postM.PublishAt = "", nil
+
+	status := r.Status
+	if status == "" {
+		status = model.PostStatusDraft
+	}
+
+	if postM.Visibility == "" {
+		postM.Visibility = model.PostVisibilityPublic
+	}
+
+	if postM.CommentPolicy == "" {
+		postM.CommentPolicy = model.PostCommentOpen
+	}
+
+	if postM.ContentFormat == "" {
+		postM.ContentFormat = model.PostFormatPlain
+	}
+
+	postM.ContentHTML = render.Render(postM.ContentFormat, postM.Content)
+
+	if err := setPublication(&postM, status, r.PublishAt, time.Now()); err != nil {
+		return nil, err
+	}
+
+	tags, err := normalizeTags(r.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.checkCategory(ctx, postM.CategoryID); err != nil {
+		return nil, err
+	}
+
+	if r.Slug != "" {
+		err = b.checkSlug(ctx, username, "", r.Slug)
+	} else {
+		postM.Slug, err = b.uniqueSlug(ctx, username, "", postM.Title)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.create(ctx, username, &postM, tags); err != nil {
 		return nil, err
 	}
 
 	return &v1.CreatePostResponse{PostID: postM.PostID}, nil
 }
 
+// create saves the new post with its first revision, media references and tags, adds it to the timelines
+// of the followers and publishes its events.
+func (b *postBiz) create(ctx context.Context, username string, postM *model.PostM, tags []string) error {
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.Posts().Create(ctx, postM); err != nil {
+			return err
+		}
+
+		if err := b.addRevision(ctx, username, postM); err != nil {
+			return err
+		}
+
+		if err := b.fanOut(ctx, postM); err != nil {
+			return err
+		}
+
+		if len(MediaIDs(postM.Content)) > 0 {
+			if err := b.setMedia(ctx, postM); err != nil {
+				return err
+			}
+		}
+
+		if err := b.setTags(ctx, postM.PostID, tags); err != nil {
+			return err
+		}
+
+		if err := b.publish(ctx, event.PostCreated, postM); err != nil {
+			return err
+		}
+
+		if postM.Status != model.PostStatusPublished {
+			return nil
+		}
+
+		return b.publish(ctx, event.PostPublished, postM)
+	})
+}
+
 // Delete is the implementation of the `Delete` method in PostBiz interface.
 func (b *postBiz) Delete(ctx context.Context, username, postID string) error {
-	if err := b.ds.Posts().Delete(ctx, username, []string{postID}); err != nil {
-		return err
-	}
-
-	return nil
+	return b.DeleteCollection(ctx, username, []string{postID})
 }
 
 // DeleteCollection is the implementation of the `DeleteCollection` method in PostBiz interface.
+// The posts which do not exist are ignored.
 func (b *postBiz) DeleteCollection(ctx context.Context, username string, postIDs []string) error {
-	if err := b.ds.Posts().Delete(ctx, username, postIDs); err != nil {
-		return err
-	}
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		posts, err := b.ds.Posts().ListByPostIDs(ctx, username, postIDs)
+		if err != nil || len(posts) == 0 {
+			return err
+		}
 
-	return nil
+		if err := b.ds.Posts().Delete(ctx, username, postIDs); err != nil {
+			return err
+		}
+
+		return b.publishDeleted(ctx, posts)
+	})
 }
 
 // Get is the implementation of the `Get` method in PostBiz interface.
-func (b *postBiz) Get(ctx context.Context, username, postID string) (*v1.GetPostResponse, error) {
+func (b *postBiz) Get(ctx context.Context, username, postID string, r *v1.GetPostRequest) (*v1.GetPostResponse, error) {
+	if err := checkContentView(r.ContentView); err != nil {
+		return nil, err
+	}
+
 	post, err := b.ds.Posts().Get(ctx, username, postID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -86,12 +211,25 @@ func (b *postBiz) Get(ctx context.Context, username, postID string) (*v1.GetPost
 		return nil, err
 	}
 
+	return b.getResponse(ctx, post, r.ContentView)
+}
+
+// getResponse builds the response of getting a post with the given content view.
+func (b *postBiz) getResponse(ctx context.Context, post *model.PostM, view string) (*v1.GetPostResponse, error) {
 	var resp v1.GetPostResponse
 	_ = copier.Copy(&resp, post)
 
+	resp.ContentHTML = ""
+	applyContentView((*v1.PostInfo)(&resp), post, view)
+
+	resp.PublishAt = formatPublishAt(post)
 	resp.CreatedAt = post.CreatedAt.Format("2006-01-02 15:04:05")
 	resp.UpdatedAt = post.UpdatedAt.Format("2006-01-02 15:04:05")
 
+	if err := b.attach(ctx, nil, (*v1.PostInfo)(&resp)); err != nil {
+		return nil, err
+	}
+
 	return &resp, nil
 }
 
@@ -102,41 +240,510 @@ func (b *postBiz) Update(ctx context.Context, username, postID string, r *v1.Upd
 		return err
 	}
 
+	// A new revision is saved only if the title or content is changed.
+	revised := (r.Title != nil && *r.Title != postM.Title) ||
+		(r.Content != nil && *r.Content != postM.Content) ||
+		(r.ContentFormat != nil && *r.ContentFormat != postM.ContentFormat)
+	var original model.PostM
+	if revised {
+		original = *postM
+	}
+
+	// The slug of a post is regenerated from its title until the post is scheduled or published,
+	// and the old slug is redirected to the new one if the post has been published.
+	retitled := r.Title != nil && *r.Title != postM.Title
+	oldSlug, published := postM.Slug, postM.Status == model.PostStatusPublished || postM.Status == model.PostStatusArchived
+
 	if r.Title != nil {
 		postM.Title = *r.Title
 	}
 
+	switch {
+	case r.Slug != nil && *r.Slug != postM.Slug:
+		if err := b.checkSlug(ctx, postM.Username, postM.PostID, *r.Slug); err != nil {
+			return err
+		}
+
+		postM.Slug = *r.Slug
+	case postM.Slug == "" || (retitled && postM.PublishAt == nil):
+		if postM.Slug, err = b.uniqueSlug(ctx, postM.Username, postM.PostID, postM.Title); err != nil {
+			return err
+		}
+	}
+
 	if r.Content != nil {
 		postM.Content = *r.Content
 	}
 
-	if err := b.ds.Posts().Update(ctx, postM); err != nil {
-		return err
+	if r.ContentFormat != nil {
+		postM.ContentFormat = *r.ContentFormat
 	}
 
-	return nil
+	if r.Content != nil || r.ContentFormat != nil {
+		postM.ContentHTML = render.Render(postM.ContentFormat, postM.Content)
+	}
+
+	if r.Visibility != nil {
+		postM.Visibility = *r.Visibility
+	}
+
+	if r.CommentPolicy != nil {
+		postM.CommentPolicy = *r.CommentPolicy
+	}
+
+	if r.CategoryID != nil {
+		if err := b.checkCategory(ctx, *r.CategoryID); err != nil {
+			return err
+		}
+
+		postM.CategoryID = *r.CategoryID
+	}
+
+	// The post is written to the timelines again only if its publish time is changed.
+	oldPublishAt, wasPublished := postM.PublishAt, postM.Status == model.PostStatusPublished
+	if r.Status != nil || r.PublishAt != nil {
+		status, publishAt := postM.Status, ""
+		if r.Status != nil {
+			status = *r.Status
+		}
+
+		if r.PublishAt != nil {
+			publishAt = *r.PublishAt
+		}
+
+		if err := setPublication(postM, status, publishAt, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	var tags []string
+	if r.Tags != nil {
+		if tags, err = normalizeTags(*r.Tags); err != nil {
+			return err
+		}
+	}
+
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.Posts().Update(ctx, postM); err != nil {
+			return err
+		}
+
+		if revised {
+			if err := b.revise(ctx, username, &original, postM); err != nil {
+				return err
+			}
+		}
+
+		if revised && original.Content != postM.Content {
+			if err := b.setMedia(ctx, postM); err != nil {
+				return err
+			}
+		}
+
+		if postM.Slug != oldSlug {
+			if oldSlug != "" && published {
+				if err := b.ds.Slugs().AddRedirect(ctx, postM.Username, oldSlug, postM.PostID); err != nil {
+					return err
+				}
+			}
+
+			if err := b.ds.Slugs().DeleteRedirect(ctx, postM.Username, postM.Slug); err != nil {
+				return err
+			}
+		}
+
+		if oldPublishAt == nil || postM.PublishAt == nil || !oldPublishAt.Equal(*postM.PublishAt) {
+			if err := b.fanOut(ctx, postM); err != nil {
+				return err
+			}
+		}
+
+		if r.Tags != nil {
+			if err := b.setTags(ctx, postM.PostID, tags); err != nil {
+				return err
+			}
+		}
+
+		if err := b.publish(ctx, event.PostUpdated, postM); err != nil {
+			return err
+		}
+
+		if wasPublished || postM.Status != model.PostStatusPublished {
+			return nil
+		}
+
+		return b.publish(ctx, event.PostPublished, postM)
+	})
 }
 
 // List is the implementation of the `List` method in PostBiz interface.
-func (b *postBiz) List(ctx context.Context, username string, offset, limit int) (*v1.ListPostResponse, error) {
-	count, list, err := b.ds.Posts().List(ctx, username, offset, limit)
+func (b *postBiz) List(ctx context.Context, username string, r *v1.ListPostRequest) (*v1.ListPostResponse, error) {
+	filter, opts, fields, err := b.listOptions(ctx, r)
 	if err != nil {
+		return nil, err
+	}
+
+	count, list, err := b.ds.Posts().List(ctx, username, filter, opts)
+
+	return b.listResponse(ctx, count, list, opts, fields, r.ContentView, err)
+}
+
+// listOptions builds the storage filter and list options from a post list request.
+// It also returns the fields of v1.PostInfo to be returned, which are empty if all fields are to be returned.
+func (b *postBiz) listOptions(ctx context.Context, r *v1.ListPostRequest) (*store.PostFilter, *store.ListOptions, []string, error) {
+	opts, err := store.NewListOptions(r.Offset, r.Limit, r.PageToken, r.SkipTotalCount)
+	if err != nil {
+		return nil, nil, nil, errno.ErrPageTokenInvalid
+	}
+
+	if opts.SortBy, opts.Ascending, err = parseSort(r.SortBy, r.Order); err != nil {
+		return nil, nil, nil, err
+	}
+
+	fields, err := parseFields(r.Fields)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := checkContentView(r.ContentView); err != nil {
+		return nil, nil, nil, err
+	}
+
+	opts.Fields = fieldColumns(fields)
+
+	filter := &store.PostFilter{
+		Title:         r.Title,
+		Content:       r.Content,
+		Status:        r.Status,
+		Visibility:    r.Visibility,
+		CreatedAfter:  r.CreatedAfter,
+		CreatedBefore: r.CreatedBefore,
+		UpdatedAfter:  r.UpdatedAfter,
+		UpdatedBefore: r.UpdatedBefore,
+		Tag:           strings.ToLower(strings.TrimSpace(r.Tag)),
+	}
+
+	if r.CategoryID != 0 {
+		if filter.CategoryIDs, err = b.categoryIDs(ctx, r.CategoryID); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return filter, opts, fields, nil
+}
+
+// listResponse builds the post list response from the result of listing posts from the storage.
+// The content view is used only if fields is empty, otherwise the rendered content is returned if it is one of fields.
+func (b *postBiz) listResponse(ctx context.Context, count int64, list []*model.PostM, opts *store.ListOptions, fields []string, view string, err error) (*v1.ListPostResponse, error) {
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidCursor) {
+			return nil, errno.ErrPageTokenInvalid
+		}
+
 		log.C(ctx).Errorw("Failed to list posts from storage", "err", err)
 		return nil, err
 	}
 
+	// The storage returns one more post than the page size if there is a next page.
+	var nextPageToken string
+	if len(list) > opts.PageSize() {
+		list = list[:opts.PageSize()]
+		last := list[len(list)-1]
+		nextPageToken = store.NewCursor(opts, sortValue(last, opts.SortBy), last.ID).Encode()
+	}
+
+	if len(fields) > 0 {
+		view = contentViewRaw
+		if hasField(fields, "contentHTML") {
+			view = contentViewBoth
+		}
+	}
+
+	posts := make([]*v1.PostInfo, 0, len(list))
+	for _, post := range list {
+		info := postInfo(post)
+		applyContentView(info, post, view)
+		posts = append(posts, maskPostInfo(info, fields))
+	}
+
+	if err := b.attach(ctx, fields, posts...); err != nil {
+		log.C(ctx).Errorw("Failed to list post details from storage", "err", err)
+		return nil, err
+	}
+
+	return &v1.ListPostResponse{TotalCount: count, Posts: posts, NextPageToken: nextPageToken}, nil
+}
+
+// postInfo converts a post to v1.PostInfo with the raw content. The fields stored in other tables are filled in by attach.
+func postInfo(post *model.PostM) *v1.PostInfo {
+	return &v1.PostInfo{
+		Username:      post.Username,
+		PostID:        post.PostID,
+		Slug:          post.Slug,
+		Title:         post.Title,
+		Content:       post.Content,
+		ContentFormat: post.ContentFormat,
+		Status:        post.Status,
+		Visibility:    post.Visibility,
+		PublishAt:     formatPublishAt(post),
+		CategoryID:    post.CategoryID,
+		CommentPolicy: post.CommentPolicy,
+		CreatedAt:     post.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:     post.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// applyContentView sets the content fields of post converted from postM according to the content view.
+// The raw content is kept by default.
+func applyContentView(post *v1.PostInfo, postM *model.PostM, view string) {
+	switch view {
+	case contentViewRendered:
+		post.Content, post.ContentHTML = "", contentHTML(postM)
+	case contentViewBoth:
+		post.ContentHTML = contentHTML(postM)
+	}
+}
+
+// contentHTML returns the rendered content of post. Posts created before the rendered content is cached are rendered on the fly.
+func contentHTML(post *model.PostM) string {
+	if post.ContentHTML == "" && post.Content != "" {
+		return render.Render(post.ContentFormat, post.Content)
+	}
+
+	return post.ContentHTML
+}
+
+// checkContentView validates the content view of a post request.
+func checkContentView(view string) error {
+	switch view {
+	case "", contentViewRaw, contentViewRendered, contentViewBoth:
+		return nil
+	default:
+		return errno.ErrInvalidParameter.SetMessage("contentView must be one of raw, rendered and both")
+	}
+}
+
+// attach fills in the given fields of posts which are stored in other tables. All of them are filled in if fields is empty.
+func (b *postBiz) attach(ctx context.Context, fields []string, posts ...*v1.PostInfo) error {
+	if hasField(fields, "tags") {
+		if err := b.attachTags(ctx, posts...); err != nil {
+			return err
+		}
+	}
+
+	if hasField(fields, "commentCount") {
+		if err := b.attachCommentCounts(ctx, posts...); err != nil {
+			return err
+		}
+	}
+
+	if hasField(fields, "reactions") {
+		if err := b.attachReactions(ctx, posts...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListTrash is the implementation of the `ListTrash` method in PostBiz interface.
+func (b *postBiz) ListTrash(ctx context.Context, username string, offset, limit int) (*v1.ListTrashResponse, error) {
+	count, list, err := b.ds.Posts().ListDeleted(ctx, username, offset, limit)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to list deleted posts from storage", "err", err)
+		return nil, err
+	}
+
 	posts := make([]*v1.PostInfo, 0, len(list))
 	for _, item := range list {
 		post := item
 		posts = append(posts, &v1.PostInfo{
-			Username:  post.Username,
-			PostID:    post.PostID,
-			Title:     post.Title,
-			Content:   post.Content,
-			CreatedAt: post.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt: post.UpdatedAt.Format("2006-01-02 15:04:05"),
+			Username:      post.Username,
+			PostID:        post.PostID,
+			Slug:          post.Slug,
+			Title:         post.Title,
+			Content:       post.Content,
+			ContentFormat: post.ContentFormat,
+			Status:        post.Status,
+			Visibility:    post.Visibility,
+			PublishAt:     formatPublishAt(post),
+			CategoryID:    post.CategoryID,
+			CommentPolicy: post.CommentPolicy,
+			CreatedAt:     post.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt:     post.UpdatedAt.Format("2006-01-02 15:04:05"),
+			DeletedAt:     post.DeletedAt.Time.Format("2006-01-02 15:04:05"),
 		})
 	}
 
-	return &v1.ListPostResponse{TotalCount: count, Posts: posts}, nil
+	return &v1.ListTrashResponse{TotalCount: count, Posts: posts}, nil
+}
+
+// Restore is the implementation of the `Restore` method in PostBiz interface.
+func (b *postBiz) Restore(ctx context.Context, username, postID string) error {
+	err := b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.Posts().Restore(ctx, username, postID); err != nil {
+			return err
+		}
+
+		post, err := b.ds.Posts().Get(ctx, username, postID)
+		if err != nil {
+			return err
+		}
+
+		return b.publish(ctx, event.PostRestored, post)
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errno.ErrPostNotFound
+	}
+
+	return err
+}
+
+// PurgeTrash is the implementation of the `PurgeTrash` method in PostBiz interface.
+// It permanently deletes the posts which have been in the trash longer than retention.
+func (b *postBiz) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	return b.ds.Posts().Purge(ctx, time.Now().Add(-retention))
+}
+
+// parseSort validates the sort field and direction of a post list request.
+// Posts are sorted by createdAt in descending order by default.
+func parseSort(sortBy, order string) (string, bool, error) {
+	switch sortBy {
+	case "", "createdAt", "updatedAt", "title":
+	default:
+		return "", false, errno.ErrInvalidParameter.SetMessage("sortBy must be one of createdAt, updatedAt and title")
+	}
+
+	switch order {
+	case "", "desc":
+		return sortBy, false, nil
+	case "asc":
+		return sortBy, true, nil
+	default:
+		return "", false, errno.ErrInvalidParameter.SetMessage("order must be asc or desc")
+	}
+}
+
+// parseFields parses the comma-separated field mask of a post list request.
+func parseFields(fields string) ([]string, error) {
+	var columns []string
+	seen := map[string]bool{}
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || seen[field] {
+			continue
+		}
+
+		switch field {
+		case "username", "postID", "slug", "title", "content", "contentFormat", "contentHTML", "status", "visibility", "publishAt", "tags", "categoryID", "commentPolicy", "commentCount", "reactions", "createdAt", "updatedAt":
+		default:
+			return nil, errno.ErrInvalidParameter.SetMessage("unknown field %q", field)
+		}
+
+		seen[field] = true
+		columns = append(columns, field)
+	}
+
+	return columns, nil
+}
+
+// fieldColumns returns the database columns needed to build the given fields of v1.PostInfo.
+// The field names of v1.PostInfo are the same as the column names of model.PostM except tags,
+// commentCount and reactions, which are looked up from other tables by postID. contentHTML also
+// needs the raw content in case the post has to be rendered on the fly.
+func fieldColumns(fields []string) []string {
+	var columns []string
+	for _, field := range fields {
+		needed := []string{field}
+		switch field {
+		case "tags", "commentCount", "reactions":
+			needed = []string{"postID"}
+		case "contentHTML":
+			needed = []string{"contentHTML", "content", "contentFormat"}
+		}
+
+		for _, column := range needed {
+			if len(columns) == 0 || !hasField(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+	}
+
+	return columns
+}
+
+// hasField reports whether field is in fields. All fields are included if fields is empty.
+func hasField(fields []string, field string) bool {
+	if len(fields) == 0 {
+		return true
+	}
+
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortValue returns the value of the sort field of post, which is used to build the page token.
+func sortValue(post *model.PostM, sortBy string) interface{} {
+	switch sortBy {
+	case "updatedAt":
+		return post.UpdatedAt
+	case "title":
+		return post.Title
+	default:
+		return post.CreatedAt
+	}
+}
+
+// maskPostInfo keeps only the given fields of post. All fields are kept if fields is empty.
+func maskPostInfo(post *v1.PostInfo, fields []string) *v1.PostInfo {
+	if len(fields) == 0 {
+		return post
+	}
+
+	masked := &v1.PostInfo{}
+	for _, field := range fields {
+		switch field {
+		case "username":
+			masked.Username = post.Username
+		case "postID":
+			masked.PostID = post.PostID
+		case "slug":
+			masked.Slug = post.Slug
+		case "title":
+			masked.Title = post.Title
+		case "content":
+			masked.Content = post.Content
+		case "contentFormat":
+			masked.ContentFormat = post.ContentFormat
+		case "contentHTML":
+			masked.ContentHTML = post.ContentHTML
+		case "status":
+			masked.Status = post.Status
+		case "visibility":
+			masked.Visibility = post.Visibility
+		case "publishAt":
+			masked.PublishAt = post.PublishAt
+		case "tags":
+			masked.Tags = post.Tags
+		case "categoryID":
+			masked.CategoryID = post.CategoryID
+		case "commentPolicy":
+			masked.CommentPolicy = post.CommentPolicy
+		case "commentCount":
+			masked.CommentCount = post.CommentCount
+		case "reactions":
+			masked.Reactions = post.Reactions
+		case "createdAt":
+			masked.CreatedAt = post.CreatedAt
+		case "updatedAt":
+			masked.UpdatedAt = post.UpdatedAt
+		}
+	}
+
+	return masked
 }
@@ -0,0 +1,70 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// ListPublished is the implementation of the `ListPublished` method in PostBiz interface.
+// It lists the published public posts of username, or of all users if username is empty.
+// The status and visibility filters of r are ignored.
+func (b *postBiz) ListPublished(ctx context.Context, username string, r *v1.ListPostRequest) (*v1.ListPostResponse, error) {
+	filter, opts, fields, err := b.listOptions(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Status, filter.Visibility = model.PostStatusPublished, model.PostVisibilityPublic
+
+	if username == "" {
+		count, list, err := b.ds.Posts().ListAll(ctx, filter, opts)
+
+		return b.listResponse(ctx, count, list, opts, fields, r.ContentView, err)
+	}
+
+	if _, err := b.ds.Users().Get(ctx, username); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	count, list, err := b.ds.Posts().List(ctx, username, filter, opts)
+
+	return b.listResponse(ctx, count, list, opts, fields, r.ContentView, err)
+}
+
+// GetPublished is the implementation of the `GetPublished` method in PostBiz interface.
+// Both public and unlisted posts can be read by their postID, the other posts are reported as not found.
+func (b *postBiz) GetPublished(ctx context.Context, postID string, r *v1.GetPostRequest) (*v1.GetPostResponse, error) {
+	if err := checkContentView(r.ContentView); err != nil {
+		return nil, err
+	}
+
+	post, err := b.ds.Posts().GetByPostID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrPostNotFound
+		}
+
+		return nil, err
+	}
+
+	if post.Status != model.PostStatusPublished || post.Visibility == model.PostVisibilityPrivate {
+		return nil, errno.ErrPostNotFound
+	}
+
+	return b.getResponse(ctx, post, r.ContentView)
+}
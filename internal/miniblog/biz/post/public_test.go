@@ -0,0 +1,99 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+func Test_postBiz_GetPublished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	posts := map[string]*model.PostM{
+		"post-public": {
+			PostID: "post-public", Status: model.PostStatusPublished, Visibility: model.PostVisibilityPublic,
+			Content: "*hi*", ContentFormat: model.PostFormatMarkdown,
+		},
+		"post-unlisted": {
+			PostID: "post-unlisted", Status: model.PostStatusPublished, Visibility: model.PostVisibilityUnlisted,
+			Content: "hi", ContentFormat: model.PostFormatPlain, ContentHTML: "<p>cached</p>\n",
+		},
+		"post-private": {PostID: "post-private", Status: model.PostStatusPublished, Visibility: model.PostVisibilityPrivate},
+		"post-draft":   {PostID: "post-draft", Status: model.PostStatusDraft, Visibility: model.PostVisibilityPublic},
+	}
+
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().GetByPostID(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, postID string) (*model.PostM, error) {
+			if post, ok := posts[postID]; ok {
+				return post, nil
+			}
+
+			return nil, gorm.ErrRecordNotFound
+		},
+	).AnyTimes()
+
+	mockTagStore := store.NewMockTagStore(ctrl)
+	mockTagStore.EXPECT().ListByPostIDs(gomock.Any(), gomock.Any()).Return(map[string][]string{"post-public": {"go"}}, nil).AnyTimes()
+
+	mockCommentStore := store.NewMockCommentStore(ctrl)
+	mockCommentStore.EXPECT().CountByPostIDs(gomock.Any(), gomock.Any()).Return(map[string]int64{}, nil).AnyTimes()
+
+	mockReactionStore := store.NewMockReactionStore(ctrl)
+	mockReactionStore.EXPECT().Counts(gomock.Any(), gomock.Any()).Return(map[string]map[string]int64{}, nil).AnyTimes()
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+	mockStore.EXPECT().Tags().AnyTimes().Return(mockTagStore)
+	mockStore.EXPECT().Comments().AnyTimes().Return(mockCommentStore)
+	mockStore.EXPECT().Reactions().AnyTimes().Return(mockReactionStore)
+
+	tests := []struct {
+		name        string
+		postID      string
+		view        string
+		wantContent string
+		wantHTML    string
+		wantErr     error
+	}{
+		{name: "public", postID: "post-public", wantContent: "*hi*"},
+		{name: "rendered", postID: "post-public", view: "rendered", wantHTML: "<p><em>hi</em></p>\n"},
+		{name: "both", postID: "post-unlisted", view: "both", wantContent: "hi", wantHTML: "<p>cached</p>\n"},
+		{name: "invalid view", postID: "post-public", view: "html", wantErr: errno.ErrInvalidParameter},
+		{name: "unlisted", postID: "post-unlisted", wantContent: "hi"},
+		{name: "private", postID: "post-private", wantErr: errno.ErrPostNotFound},
+		{name: "draft", postID: "post-draft", wantErr: errno.ErrPostNotFound},
+		{name: "not found", postID: "post-none", wantErr: errno.ErrPostNotFound},
+	}
+
+	b := New(mockStore)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := b.GetPublished(context.Background(), tt.postID, &v1.GetPostRequest{ContentView: tt.view})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.postID, got.PostID)
+			assert.Equal(t, tt.wantContent, got.Content)
+			assert.Equal(t, tt.wantHTML, got.ContentHTML)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"time"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/event"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// PublishScheduled is the implementation of the `PublishScheduled` method in PostBiz interface.
+// It publishes the scheduled posts whose publish time has come, and returns the number of published posts.
+//
+// The due posts are locked until the transaction ends, so that the instances running the job at the same
+// time publish different posts, and the events are only emitted for the posts published by this call.
+func (b *postBiz) PublishScheduled(ctx context.Context) (int64, error) {
+	now := time.Now()
+
+	var count int64
+	err := b.ds.TX(ctx, func(ctx context.Context) error {
+		posts, err := b.ds.Posts().ListDue(ctx, now)
+		if err != nil {
+			return err
+		}
+
+		for _, post := range posts {
+			published, err := b.ds.Posts().Publish(ctx, post.ID)
+			if err != nil {
+				return err
+			}
+
+			if !published {
+				continue
+			}
+
+			post.Status = model.PostStatusPublished
+			if err := b.publish(ctx, event.PostPublished, post); err != nil {
+				return err
+			}
+
+			count++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// setPublication moves post to status, publishAt is the scheduled publish time in the format
+// `2006-01-02 15:04:05` and can only be set for scheduled posts.
+//
+// The publish time of post is updated according to the new status:
+//   - draft: the publish time is cleared.
+//   - scheduled: the publish time is set to publishAt, which must be after now. publishAt can be
+//     omitted if the post is already scheduled, in which case the post keeps its publish time.
+//   - published: the publish time is set to now unless the post is already published or was archived
+//     after being published, so that republishing an archived post keeps its original publish time.
+//   - archived: the publish time is kept.
+func setPublication(post *model.PostM, status, publishAt string, now time.Time) error {
+	var scheduled *time.Time
+	if publishAt != "" {
+		if status != model.PostStatusScheduled {
+			return errno.ErrPublishAtInvalid
+		}
+
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", publishAt, time.Local)
+		if err != nil {
+			return errno.ErrPublishAtInvalid
+		}
+
+		scheduled = &t
+	}
+
+	switch status {
+	case model.PostStatusDraft:
+		post.PublishAt = nil
+	case model.PostStatusScheduled:
+		if scheduled == nil && post.Status == model.PostStatusScheduled {
+			scheduled = post.PublishAt
+		}
+
+		if scheduled == nil || !scheduled.After(now) {
+			return errno.ErrPublishAtInvalid
+		}
+
+		post.PublishAt = scheduled
+	case model.PostStatusPublished:
+		if post.PublishAt == nil || (post.Status != model.PostStatusPublished && post.Status != model.PostStatusArchived) {
+			post.PublishAt = &now
+		}
+	case model.PostStatusArchived:
+	default:
+		return errno.ErrInvalidParameter.SetMessage("unknown post status %q", status)
+	}
+
+	post.Status = status
+
+	return nil
+}
+
+// formatPublishAt formats the publish time of post, it returns an empty string if the post has no publish time.
+func formatPublishAt(post *model.PostM) string {
+	if post.PublishAt == nil {
+		return ""
+	}
+
+	return post.PublishAt.Format("2006-01-02 15:04:05")
+}
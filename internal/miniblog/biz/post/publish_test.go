@@ -0,0 +1,114 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+func Test_setPublication(t *testing.T) {
+	now := time.Date(2022, 11, 20, 10, 0, 0, 0, time.Local)
+	past := now.Add(-24 * time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		post          *model.PostM
+		status        string
+		publishAt     string
+		wantStatus    string
+		wantPublishAt *time.Time
+		wantErr       error
+	}{
+		{name: "draft", post: &model.PostM{}, status: model.PostStatusDraft, wantStatus: model.PostStatusDraft},
+		{
+			name:          "publish draft",
+			post:          &model.PostM{Status: model.PostStatusDraft},
+			status:        model.PostStatusPublished,
+			wantStatus:    model.PostStatusPublished,
+			wantPublishAt: &now,
+		},
+		{
+			name:          "schedule",
+			post:          &model.PostM{Status: model.PostStatusDraft},
+			status:        model.PostStatusScheduled,
+			publishAt:     "2022-11-20 11:00:00",
+			wantStatus:    model.PostStatusScheduled,
+			wantPublishAt: &future,
+		},
+		{
+			name:          "keep schedule",
+			post:          &model.PostM{Status: model.PostStatusScheduled, PublishAt: &future},
+			status:        model.PostStatusScheduled,
+			wantStatus:    model.PostStatusScheduled,
+			wantPublishAt: &future,
+		},
+		{
+			name:          "republish archived",
+			post:          &model.PostM{Status: model.PostStatusArchived, PublishAt: &past},
+			status:        model.PostStatusPublished,
+			wantStatus:    model.PostStatusPublished,
+			wantPublishAt: &past,
+		},
+		{
+			name:          "publish scheduled",
+			post:          &model.PostM{Status: model.PostStatusScheduled, PublishAt: &future},
+			status:        model.PostStatusPublished,
+			wantStatus:    model.PostStatusPublished,
+			wantPublishAt: &now,
+		},
+		{
+			name:       "unpublish",
+			post:       &model.PostM{Status: model.PostStatusPublished, PublishAt: &past},
+			status:     model.PostStatusDraft,
+			wantStatus: model.PostStatusDraft,
+		},
+		{
+			name:      "schedule in the past",
+			post:      &model.PostM{},
+			status:    model.PostStatusScheduled,
+			publishAt: "2022-11-19 10:00:00",
+			wantErr:   errno.ErrPublishAtInvalid,
+		},
+		{name: "schedule without time", post: &model.PostM{}, status: model.PostStatusScheduled, wantErr: errno.ErrPublishAtInvalid},
+		{
+			name:      "publishAt of draft",
+			post:      &model.PostM{},
+			status:    model.PostStatusDraft,
+			publishAt: "2022-11-20 11:00:00",
+			wantErr:   errno.ErrPublishAtInvalid,
+		},
+		{
+			name:      "invalid publishAt",
+			post:      &model.PostM{},
+			status:    model.PostStatusScheduled,
+			publishAt: "tomorrow",
+			wantErr:   errno.ErrPublishAtInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := setPublication(tt.post, tt.status, tt.publishAt, now)
+			assert.Equal(t, tt.wantErr, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.wantStatus, tt.post.Status)
+			if tt.wantPublishAt == nil {
+				assert.Nil(t, tt.post.PublishAt)
+			} else {
+				assert.True(t, tt.wantPublishAt.Equal(*tt.post.PublishAt))
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"errors"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// defaultReactionType is the reaction type listed by ListReacted by default.
+const defaultReactionType = "like"
+
+// ListReacted is the implementation of the `ListReacted` method in PostBiz interface.
+// It lists the posts which the user reacted to, from the most recently reacted one. The posts which
+// have been deleted or can no longer be read by the user are skipped, so a page may contain fewer posts than the limit.
+func (b *postBiz) ListReacted(ctx context.Context, username string, r *v1.ListReactedPostRequest) (*v1.ListPostResponse, error) {
+	typ := r.Type
+	if typ == "" {
+		typ = defaultReactionType
+	}
+
+	opts, err := store.NewListOptions(r.Offset, r.Limit, r.PageToken, r.SkipTotalCount)
+	if err != nil {
+		return nil, errno.ErrPageTokenInvalid
+	}
+
+	count, list, err := b.ds.Reactions().List(ctx, username, model.ReactionTargetPost, typ, opts)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidCursor) {
+			return nil, errno.ErrPageTokenInvalid
+		}
+
+		log.C(ctx).Errorw("Failed to list reactions from storage", "err", err)
+		return nil, err
+	}
+
+	// The storage returns one more reaction than the page size if there is a next page.
+	var nextPageToken string
+	if len(list) > opts.PageSize() {
+		list = list[:opts.PageSize()]
+		last := list[len(list)-1]
+		nextPageToken = store.NewCursor(opts, last.CreatedAt, last.ID).Encode()
+	}
+
+	postIDs := make([]string, 0, len(list))
+	for _, reaction := range list {
+		postIDs = append(postIDs, reaction.TargetID)
+	}
+
+	found, err := b.ds.Posts().GetByPostIDs(ctx, postIDs)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to list posts from storage", "err", err)
+		return nil, err
+	}
+
+	m := make(map[string]*model.PostM, len(found))
+	for _, post := range found {
+		m[post.PostID] = post
+	}
+
+	posts := make([]*v1.PostInfo, 0, len(list))
+	for _, reaction := range list {
+		post, ok := m[reaction.TargetID]
+		if !ok || (post.Username != username && (post.Status != model.PostStatusPublished || post.Visibility == model.PostVisibilityPrivate)) {
+			continue
+		}
+
+		posts = append(posts, postInfo(post))
+	}
+
+	if err := b.attach(ctx, nil, posts...); err != nil {
+		log.C(ctx).Errorw("Failed to list post details from storage", "err", err)
+		return nil, err
+	}
+
+	return &v1.ListPostResponse{TotalCount: count, Posts: posts, NextPageToken: nextPageToken}, nil
+}
+
+// attachReactions fills in the reaction counts of posts.
+func (b *postBiz) attachReactions(ctx context.Context, posts ...*v1.PostInfo) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.PostID)
+	}
+
+	counts, err := b.ds.Reactions().Counts(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		post.Reactions = counts[post.PostID]
+	}
+
+	return nil
+}
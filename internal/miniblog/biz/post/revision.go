@@ -0,0 +1,146 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/util/diff"
+)
+
+// ListRevisions is the implementation of the `ListRevisions` method in PostBiz interface.
+func (b *postBiz) ListRevisions(ctx context.Context, username, postID string, r *v1.ListRevisionRequest) (*v1.ListRevisionResponse, error) {
+	if err := b.checkPost(ctx, username, postID); err != nil {
+		return nil, err
+	}
+
+	count, list, err := b.ds.Revisions().List(ctx, postID, r.Offset, r.Limit)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to list post revisions from storage", "err", err)
+		return nil, err
+	}
+
+	revisions := make([]*v1.RevisionInfo, 0, len(list))
+	for _, rev := range list {
+		revisions = append(revisions, &v1.RevisionInfo{
+			Revision:      rev.Revision,
+			Username:      rev.Username,
+			Title:         rev.Title,
+			Content:       rev.Content,
+			ContentFormat: rev.ContentFormat,
+			CreatedAt:     rev.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return &v1.ListRevisionResponse{TotalCount: count, Revisions: revisions}, nil
+}
+
+// DiffRevisions is the implementation of the `DiffRevisions` method in PostBiz interface.
+func (b *postBiz) DiffRevisions(ctx context.Context, username, postID string, r *v1.DiffRevisionRequest) (*v1.DiffRevisionResponse, error) {
+	if err := b.checkPost(ctx, username, postID); err != nil {
+		return nil, err
+	}
+
+	from, err := b.getRevision(ctx, postID, r.From)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := b.getRevision(ctx, postID, r.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.DiffRevisionResponse{
+		From:      from.Revision,
+		To:        to.Revision,
+		FromTitle: from.Title,
+		ToTitle:   to.Title,
+		Diff:      diff.Unified(fmt.Sprintf("revision %d", from.Revision), fmt.Sprintf("revision %d", to.Revision), from.Content, to.Content),
+	}, nil
+}
+
+// RestoreRevision is the implementation of the `RestoreRevision` method in PostBiz interface.
+// The title and content of the revision are written back to the post as a new revision, so the restore can be undone.
+func (b *postBiz) RestoreRevision(ctx context.Context, username, postID string, revision int64) error {
+	if err := b.checkPost(ctx, username, postID); err != nil {
+		return err
+	}
+
+	rev, err := b.getRevision(ctx, postID, revision)
+	if err != nil {
+		return err
+	}
+
+	return b.Update(ctx, username, postID, &v1.UpdatePostRequest{
+		Title:         &rev.Title,
+		Content:       &rev.Content,
+		ContentFormat: &rev.ContentFormat,
+	})
+}
+
+// checkPost checks that the post exists and belongs to username.
+func (b *postBiz) checkPost(ctx context.Context, username, postID string) error {
+	if _, err := b.ds.Posts().Get(ctx, username, postID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrPostNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// getRevision gets a revision of the post, errno.ErrRevisionNotFound is returned if it does not exist.
+func (b *postBiz) getRevision(ctx context.Context, postID string, revision int64) (*model.PostRevisionM, error) {
+	rev, err := b.ds.Revisions().Get(ctx, postID, revision)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrRevisionNotFound
+		}
+
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+// revise saves the updated post as a new revision. Posts created before revisions are recorded have no revision,
+// their original version is saved first so that it can still be restored.
+func (b *postBiz) revise(ctx context.Context, username string, original, updated *model.PostM) error {
+	count, _, err := b.ds.Revisions().List(ctx, updated.PostID, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if err := b.addRevision(ctx, original.Username, original); err != nil {
+			return err
+		}
+	}
+
+	return b.addRevision(ctx, username, updated)
+}
+
+// addRevision saves the current title and content of the post as a new revision created by username.
+func (b *postBiz) addRevision(ctx context.Context, username string, post *model.PostM) error {
+	return b.ds.Revisions().Create(ctx, &model.PostRevisionM{
+		PostID:        post.PostID,
+		Username:      username,
+		Title:         post.Title,
+		Content:       post.Content,
+		ContentFormat: post.ContentFormat,
+	})
+}
@@ -0,0 +1,166 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// revisionFixture returns a mocked store holding post and its revisions. Updates are written back to post and new revisions are appended to revisions.
+func revisionFixture(ctrl *gomock.Controller, post *model.PostM, revisions *[]*model.PostRevisionM) *store.MockIStore {
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, username, postID string) (*model.PostM, error) {
+			if username != post.Username || postID != post.PostID {
+				return nil, gorm.ErrRecordNotFound
+			}
+
+			copied := *post
+
+			return &copied, nil
+		},
+	).AnyTimes()
+	mockPostStore.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, updated *model.PostM) error {
+			*post = *updated
+
+			return nil
+		},
+	).AnyTimes()
+
+	mockRevisionStore := store.NewMockRevisionStore(ctrl)
+	mockRevisionStore.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, postID string, revision int64) (*model.PostRevisionM, error) {
+			for _, rev := range *revisions {
+				if rev.PostID == postID && rev.Revision == revision {
+					return rev, nil
+				}
+			}
+
+			return nil, gorm.ErrRecordNotFound
+		},
+	).AnyTimes()
+	mockRevisionStore.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, postID string, offset, limit int) (int64, []*model.PostRevisionM, error) {
+			return int64(len(*revisions)), *revisions, nil
+		},
+	).AnyTimes()
+	mockRevisionStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, rev *model.PostRevisionM) error {
+			rev.Revision = int64(len(*revisions)) + 1
+			*revisions = append(*revisions, rev)
+
+			return nil
+		},
+	).AnyTimes()
+
+	mockSlugStore := store.NewMockSlugStore(ctrl)
+	mockSlugStore.EXPECT().Available(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockSlugStore.EXPECT().DeleteRedirect(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+	mockStore.EXPECT().Revisions().AnyTimes().Return(mockRevisionStore)
+	mockStore.EXPECT().Slugs().AnyTimes().Return(mockSlugStore)
+	mockStore.EXPECT().Webhooks().AnyTimes().Return(noWebhooks(ctrl))
+	mockStore.EXPECT().Events().AnyTimes().Return(noEvents(ctrl))
+	mockStore.EXPECT().TX(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+
+	return mockStore
+}
+
+func Test_postBiz_DiffRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	post := &model.PostM{Username: "belm", PostID: "post-1"}
+	revisions := []*model.PostRevisionM{
+		{PostID: "post-1", Revision: 1, Title: "v1", Content: "a\nb\n"},
+		{PostID: "post-1", Revision: 2, Title: "v2", Content: "a\nc\n"},
+	}
+	b := New(revisionFixture(ctrl, post, &revisions))
+
+	got, err := b.DiffRevisions(context.Background(), "belm", "post-1", &v1.DiffRevisionRequest{From: 1, To: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, &v1.DiffRevisionResponse{
+		From:      1,
+		To:        2,
+		FromTitle: "v1",
+		ToTitle:   "v2",
+		Diff:      "--- revision 1\n+++ revision 2\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n",
+	}, got)
+
+	_, err = b.DiffRevisions(context.Background(), "belm", "post-1", &v1.DiffRevisionRequest{From: 1, To: 3})
+	assert.Equal(t, errno.ErrRevisionNotFound, err)
+
+	_, err = b.DiffRevisions(context.Background(), "colin", "post-1", &v1.DiffRevisionRequest{From: 1, To: 2})
+	assert.Equal(t, errno.ErrPostNotFound, err)
+}
+
+func Test_postBiz_RestoreRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	post := &model.PostM{Username: "belm", PostID: "post-1", Title: "v2", Content: "*new*", ContentFormat: model.PostFormatMarkdown}
+	revisions := []*model.PostRevisionM{
+		{PostID: "post-1", Revision: 1, Username: "belm", Title: "v1", Content: "old", ContentFormat: model.PostFormatPlain},
+		{PostID: "post-1", Revision: 2, Username: "belm", Title: "v2", Content: "*new*", ContentFormat: model.PostFormatMarkdown},
+	}
+	mockStore := revisionFixture(ctrl, post, &revisions)
+
+	// The restored content references no media.
+	mockMediaStore := store.NewMockMediaStore(ctrl)
+	mockMediaStore.EXPECT().SetPostMedia(gomock.Any(), "post-1", gomock.Nil()).Return(nil).Times(1)
+	mockStore.EXPECT().Media().Return(mockMediaStore).Times(1)
+
+	b := New(mockStore)
+
+	assert.NoError(t, b.RestoreRevision(context.Background(), "belm", "post-1", 1))
+	assert.Equal(t, "v1", post.Title)
+	assert.Equal(t, "old", post.Content)
+	assert.Equal(t, "<p>old</p>\n", post.ContentHTML)
+	if assert.Len(t, revisions, 3) {
+		assert.Equal(t, "old", revisions[2].Content)
+		assert.Equal(t, int64(3), revisions[2].Revision)
+	}
+
+	// Restoring the current version does not save a new revision.
+	assert.NoError(t, b.RestoreRevision(context.Background(), "belm", "post-1", 3))
+	assert.Len(t, revisions, 3)
+
+	assert.Equal(t, errno.ErrRevisionNotFound, b.RestoreRevision(context.Background(), "belm", "post-1", 9))
+}
+
+func Test_postBiz_Update_firstRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Posts created before revisions are recorded have no revision.
+	post := &model.PostM{Username: "belm", PostID: "post-1", Title: "old", Content: "old", ContentFormat: model.PostFormatPlain}
+	var revisions []*model.PostRevisionM
+	b := New(revisionFixture(ctrl, post, &revisions))
+
+	title := "new"
+	assert.NoError(t, b.Update(context.Background(), "belm", "post-1", &v1.UpdatePostRequest{Title: &title}))
+	if assert.Len(t, revisions, 2) {
+		assert.Equal(t, "old", revisions[0].Title)
+		assert.Equal(t, "new", revisions[1].Title)
+	}
+}
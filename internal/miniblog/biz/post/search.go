@@ -0,0 +1,199 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+const (
+	// snippetLength is the maximum number of runes of a search result snippet.
+	snippetLength = 160
+
+	// snippetLeading is the number of runes kept before the first match in a snippet.
+	snippetLeading = 40
+
+	// reindexBatchSize is the number of posts read from the storage at a time when rebuilding the search index.
+	reindexBatchSize = 500
+)
+
+// Search is the implementation of the `Search` method in PostBiz interface.
+func (b *postBiz) Search(ctx context.Context, username string, r *v1.SearchPostRequest) (*v1.SearchPostResponse, error) {
+	count, hits, err := b.ds.Search().Search(ctx, username, r.Query, r.Offset, r.Limit)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to search posts", "err", err)
+		return nil, err
+	}
+
+	posts := make([]*v1.SearchPostInfo, 0, len(hits))
+	if len(hits) == 0 {
+		return &v1.SearchPostResponse{TotalCount: count, Posts: posts}, nil
+	}
+
+	postIDs := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		postIDs = append(postIDs, hit.PostID)
+	}
+
+	list, err := b.ds.Posts().ListByPostIDs(ctx, username, postIDs)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to list posts from storage", "err", err)
+		return nil, err
+	}
+
+	m := make(map[string]*model.PostM, len(list))
+	for _, post := range list {
+		m[post.PostID] = post
+	}
+
+	terms := queryTerms(r.Query)
+	for _, hit := range hits {
+		// The index may lag behind the storage, skip the posts which no longer exist.
+		post, ok := m[hit.PostID]
+		if !ok {
+			continue
+		}
+
+		posts = append(posts, &v1.SearchPostInfo{
+			PostID:    post.PostID,
+			Title:     highlight([]rune(post.Title), terms),
+			Snippet:   snippet(post.Content, terms),
+			Score:     hit.Score,
+			CreatedAt: post.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt: post.UpdatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return &v1.SearchPostResponse{TotalCount: count, Posts: posts}, nil
+}
+
+// Reindex is the implementation of the `Reindex` method in PostBiz interface.
+// It rebuilds the search index from all the posts not in the trash, and returns the number of indexed posts.
+func (b *postBiz) Reindex(ctx context.Context) (int64, error) {
+	if err := b.ds.Search().Reset(ctx); err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err := b.ds.Posts().ForEach(ctx, reindexBatchSize, func(posts []*model.PostM) error {
+		for _, post := range posts {
+			if err := b.ds.Search().Index(ctx, post); err != nil {
+				return err
+			}
+		}
+
+		count += int64(len(posts))
+		log.C(ctx).Infow("Indexed posts", "count", count)
+
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	// The index may only be persisted periodically, write the rebuilt index out before returning
+	return count, b.ds.Search().Flush(ctx)
+}
+
+// queryTerms splits a search query into lower case terms used to highlight the results.
+func queryTerms(query string) [][]rune {
+	var terms [][]rune
+	for _, field := range strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		terms = append(terms, []rune(field))
+	}
+
+	return terms
+}
+
+// snippet returns a fragment of content around the first occurrence of terms with the terms highlighted.
+// The beginning of content is returned if none of the terms occurs in content.
+func snippet(content string, terms [][]rune) string {
+	text := []rune(content)
+	lower := toLower(text)
+
+	start := 0
+	for i := range lower {
+		if matchAt(lower, i, terms) > 0 {
+			start = i - snippetLeading
+			break
+		}
+	}
+
+	if start < 0 {
+		start = 0
+	}
+
+	end := start + snippetLength
+	if end > len(text) {
+		end = len(text)
+	}
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString("…")
+	}
+	sb.WriteString(highlightRange(text, lower, start, end, terms))
+	if end < len(text) {
+		sb.WriteString("…")
+	}
+
+	return sb.String()
+}
+
+// highlight escapes text and wraps every occurrence of terms in text with `<mark></mark>`.
+func highlight(text []rune, terms [][]rune) string {
+	return highlightRange(text, toLower(text), 0, len(text), terms)
+}
+
+func highlightRange(text, lower []rune, start, end int, terms [][]rune) string {
+	var sb strings.Builder
+	for i := start; i < end; {
+		n := matchAt(lower[:end], i, terms)
+		if n == 0 {
+			sb.WriteString(html.EscapeString(string(text[i])))
+			i++
+
+			continue
+		}
+
+		sb.WriteString("<mark>")
+		sb.WriteString(html.EscapeString(string(text[i : i+n])))
+		sb.WriteString("</mark>")
+		i += n
+	}
+
+	return sb.String()
+}
+
+// matchAt returns the length of the longest term which occurs in text at position i, or 0 if there is none.
+func matchAt(text []rune, i int, terms [][]rune) int {
+	longest := 0
+	for _, term := range terms {
+		if len(term) > longest && i+len(term) <= len(text) && string(text[i:i+len(term)]) == string(term) {
+			longest = len(term)
+		}
+	}
+
+	return longest
+}
+
+func toLower(text []rune) []rune {
+	lower := make([]rune, len(text))
+	for i, r := range text {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	return lower
+}
@@ -0,0 +1,35 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_highlight(t *testing.T) {
+	terms := queryTerms("Go, modules")
+
+	assert.Equal(t, "<mark>Go</mark> <mark>Modules</mark> &amp; <mark>go</mark>pher", highlight([]rune("Go Modules & gopher"), terms))
+	assert.Equal(t, "no match", highlight([]rune("no match"), terms))
+	assert.Equal(t, "学习 <mark>Go</mark> 语言", highlight([]rune("学习 Go 语言"), queryTerms("go")))
+}
+
+func Test_snippet(t *testing.T) {
+	terms := queryTerms("needle")
+
+	assert.Equal(t, "short <mark>needle</mark>", snippet("short needle", terms))
+
+	content := strings.Repeat("a", 100) + " needle " + strings.Repeat("b", 300)
+	got := snippet(content, terms)
+	assert.True(t, strings.HasPrefix(got, "…"+strings.Repeat("a", snippetLeading-1)+" <mark>needle</mark>"))
+	assert.True(t, strings.HasSuffix(got, "b…"))
+
+	// The beginning of the content is returned if there is no match.
+	assert.Equal(t, strings.Repeat("b", snippetLength)+"…", snippet(strings.Repeat("b", 300), terms))
+}
@@ -0,0 +1,99 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/util/id"
+	"github.com/marmotedu/miniblog/pkg/util/slug"
+)
+
+const (
+	// defaultSlug is used if no slug can be generated from the title, e.g. the title consists of punctuations only.
+	defaultSlug = "post"
+
+	// maxSlugSuffix is the largest numeric suffix tried for a generated slug, a random suffix is used after that.
+	maxSlugSuffix = 100
+)
+
+// GetPublishedBySlug is the implementation of the `GetPublishedBySlug` method in PostBiz interface.
+// Old slugs of the post are resolved too, the caller can compare the slug of the returned post to redirect to the current one.
+func (b *postBiz) GetPublishedBySlug(ctx context.Context, username, slug string, r *v1.GetPostRequest) (*v1.GetPostResponse, error) {
+	postID, err := b.ds.Slugs().Resolve(ctx, username, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrPostNotFound
+		}
+
+		return nil, err
+	}
+
+	return b.GetPublished(ctx, postID, r)
+}
+
+// uniqueSlug generates a slug from title which is not used by the other posts of username.
+// A numeric suffix is added if the slug is already used. postID is empty for new posts.
+func (b *postBiz) uniqueSlug(ctx context.Context, username, postID, title string) (string, error) {
+	base := slug.Make(title)
+	if base == "" {
+		base = defaultSlug
+	}
+
+	candidate := base
+	for i := 2; ; i++ {
+		ok, err := b.ds.Slugs().Available(ctx, username, candidate, postID)
+		if err != nil {
+			return "", err
+		}
+
+		if ok {
+			return candidate, nil
+		}
+
+		suffix := fmt.Sprintf("-%d", i)
+		if i > maxSlugSuffix {
+			suffix = "-" + id.GenShortID()
+		}
+
+		candidate = withSuffix(base, suffix)
+	}
+}
+
+// checkSlug checks that the custom slug is valid and not used by the other posts of username.
+func (b *postBiz) checkSlug(ctx context.Context, username, postID, s string) error {
+	if !slug.Valid(s) {
+		return errno.ErrPostSlugInvalid
+	}
+
+	ok, err := b.ds.Slugs().Available(ctx, username, s, postID)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errno.ErrPostSlugAlreadyExist
+	}
+
+	return nil
+}
+
+// withSuffix appends suffix to the slug, the slug is shortened if needed to keep the result within slug.MaxLength.
+func withSuffix(s, suffix string) string {
+	runes := []rune(s)
+	if n := slug.MaxLength - len(suffix); len(runes) > n {
+		s = strings.TrimRight(string(runes[:n]), "-")
+	}
+
+	return s + suffix
+}
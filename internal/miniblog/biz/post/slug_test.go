@@ -0,0 +1,223 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/util/slug"
+)
+
+// slugFixture returns a mocked slug store backed by taken, which maps the slugs of belm to their postIDs.
+// Redirects added by the post biz are written to redirects.
+func slugFixture(ctrl *gomock.Controller, taken map[string]string, redirects map[string]string) *store.MockSlugStore {
+	mockSlugStore := store.NewMockSlugStore(ctrl)
+	mockSlugStore.EXPECT().Available(gomock.Any(), "belm", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, username, s, postID string) (bool, error) {
+			owner, ok := taken[s]
+			if !ok {
+				owner, ok = redirects[s]
+			}
+
+			return !ok || owner == postID, nil
+		},
+	).AnyTimes()
+	mockSlugStore.EXPECT().AddRedirect(gomock.Any(), "belm", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, username, s, postID string) error {
+			redirects[s] = postID
+
+			return nil
+		},
+	).AnyTimes()
+	mockSlugStore.EXPECT().DeleteRedirect(gomock.Any(), "belm", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, username, s string) error {
+			delete(redirects, s)
+
+			return nil
+		},
+	).AnyTimes()
+	mockSlugStore.EXPECT().Resolve(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, username, s string) (string, error) {
+			if postID, ok := taken[s]; ok && username == "belm" {
+				return postID, nil
+			}
+
+			if postID, ok := redirects[s]; ok && username == "belm" {
+				return postID, nil
+			}
+
+			return "", gorm.ErrRecordNotFound
+		},
+	).AnyTimes()
+
+	return mockSlugStore
+}
+
+func Test_postBiz_uniqueSlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taken := map[string]string{"hello-world": "post-1", "hello-world-2": "post-2"}
+	redirects := map[string]string{"hello-world-3": "post-3"}
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Slugs().AnyTimes().Return(slugFixture(ctrl, taken, redirects))
+
+	b := New(mockStore)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		postID string
+		title  string
+		want   string
+	}{
+		{name: "new", title: "Another Post", want: "another-post"},
+		{name: "collision", title: "Hello, World!", want: "hello-world-4"},
+		{name: "own slug", postID: "post-1", title: "Hello World", want: "hello-world"},
+		{name: "own redirect", postID: "post-3", title: "Hello World", want: "hello-world-3"},
+		{name: "no letters", title: "???", want: "post"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := b.uniqueSlug(ctx, "belm", tt.postID, tt.title)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// The suffix is kept within the max length of slugs.
+	long := strings.Repeat("a", slug.MaxLength)
+	taken[long] = "post-4"
+	got, err := b.uniqueSlug(ctx, "belm", "", long)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("a", slug.MaxLength-2)+"-2", got)
+
+	assert.Equal(t, errno.ErrPostSlugInvalid, b.checkSlug(ctx, "belm", "", "Hello World"))
+	assert.Equal(t, errno.ErrPostSlugAlreadyExist, b.checkSlug(ctx, "belm", "", "hello-world"))
+	assert.Nil(t, b.checkSlug(ctx, "belm", "post-1", "hello-world"))
+}
+
+func Test_postBiz_Update_slug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publishAt := time.Now().Add(-time.Hour)
+	posts := map[string]*model.PostM{
+		"post-1": {Username: "belm", PostID: "post-1", Slug: "draft", Title: "Draft", Status: model.PostStatusDraft},
+		"post-2": {Username: "belm", PostID: "post-2", Slug: "published", Title: "Published", Status: model.PostStatusPublished, PublishAt: &publishAt},
+	}
+	taken := map[string]string{"draft": "post-1", "published": "post-2"}
+	redirects := map[string]string{}
+
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().Get(gomock.Any(), "belm", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, username, postID string) (*model.PostM, error) {
+			copied := *posts[postID]
+
+			return &copied, nil
+		},
+	).AnyTimes()
+	mockPostStore.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, post *model.PostM) error {
+			delete(taken, posts[post.PostID].Slug)
+			taken[post.Slug] = post.PostID
+			posts[post.PostID] = post
+
+			return nil
+		},
+	).AnyTimes()
+
+	mockRevisionStore := store.NewMockRevisionStore(ctrl)
+	mockRevisionStore.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil, nil).AnyTimes()
+	mockRevisionStore.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+	mockStore.EXPECT().Revisions().AnyTimes().Return(mockRevisionStore)
+	mockStore.EXPECT().Slugs().AnyTimes().Return(slugFixture(ctrl, taken, redirects))
+	mockStore.EXPECT().Webhooks().AnyTimes().Return(noWebhooks(ctrl))
+	mockStore.EXPECT().Events().AnyTimes().Return(noEvents(ctrl))
+	mockStore.EXPECT().TX(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+
+	b := New(mockStore)
+	ctx := context.Background()
+	title := func(s string) *string { return &s }
+
+	// The slug of a draft follows its title and no redirect is kept.
+	assert.Nil(t, b.Update(ctx, "belm", "post-1", &v1.UpdatePostRequest{Title: title("New Draft")}))
+	assert.Equal(t, "new-draft", posts["post-1"].Slug)
+	assert.Empty(t, redirects)
+
+	// The slug of a published post is stable, renaming it keeps a redirect from the old slug.
+	assert.Nil(t, b.Update(ctx, "belm", "post-2", &v1.UpdatePostRequest{Title: title("Renamed")}))
+	assert.Equal(t, "published", posts["post-2"].Slug)
+
+	assert.Equal(t, errno.ErrPostSlugAlreadyExist, b.Update(ctx, "belm", "post-2", &v1.UpdatePostRequest{Slug: title("new-draft")}))
+	assert.Nil(t, b.Update(ctx, "belm", "post-2", &v1.UpdatePostRequest{Slug: title("renamed")}))
+	assert.Equal(t, "renamed", posts["post-2"].Slug)
+	assert.Equal(t, map[string]string{"published": "post-2"}, redirects)
+
+	// Renaming back to the old slug removes the redirect.
+	assert.Nil(t, b.Update(ctx, "belm", "post-2", &v1.UpdatePostRequest{Slug: title("published")}))
+	assert.Equal(t, map[string]string{"renamed": "post-2"}, redirects)
+}
+
+func Test_postBiz_GetPublishedBySlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	post := &model.PostM{Username: "belm", PostID: "post-1", Slug: "current", Status: model.PostStatusPublished, Visibility: model.PostVisibilityPublic}
+
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().GetByPostID(gomock.Any(), "post-1").Return(post, nil).AnyTimes()
+
+	mockTagStore := store.NewMockTagStore(ctrl)
+	mockTagStore.EXPECT().ListByPostIDs(gomock.Any(), gomock.Any()).Return(map[string][]string{}, nil).AnyTimes()
+
+	mockCommentStore := store.NewMockCommentStore(ctrl)
+	mockCommentStore.EXPECT().CountByPostIDs(gomock.Any(), gomock.Any()).Return(map[string]int64{}, nil).AnyTimes()
+
+	mockReactionStore := store.NewMockReactionStore(ctrl)
+	mockReactionStore.EXPECT().Counts(gomock.Any(), gomock.Any()).Return(map[string]map[string]int64{}, nil).AnyTimes()
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+	mockStore.EXPECT().Tags().AnyTimes().Return(mockTagStore)
+	mockStore.EXPECT().Comments().AnyTimes().Return(mockCommentStore)
+	mockStore.EXPECT().Reactions().AnyTimes().Return(mockReactionStore)
+	mockStore.EXPECT().Slugs().AnyTimes().Return(slugFixture(ctrl, map[string]string{"current": "post-1"}, map[string]string{"old": "post-1"}))
+
+	b := New(mockStore)
+	ctx := context.Background()
+
+	for _, s := range []string{"current", "old"} {
+		got, err := b.GetPublishedBySlug(ctx, "belm", s, &v1.GetPostRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, "current", got.Slug)
+	}
+
+	_, err := b.GetPublishedBySlug(ctx, "belm", "none", &v1.GetPostRequest{})
+	assert.Equal(t, errno.ErrPostNotFound, err)
+
+	_, err = b.GetPublishedBySlug(ctx, "colin", "current", &v1.GetPostRequest{})
+	assert.Equal(t, errno.ErrPostNotFound, err)
+}
@@ -0,0 +1,140 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+const (
+	// MaxTagLength is the maximum number of runes of a tag.
+	MaxTagLength = 32
+
+	// maxPostTags is the maximum number of tags of a post.
+	maxPostTags = 10
+)
+
+// NormalizeTag lowercases and trims tag, and reports whether the result is a valid tag.
+func NormalizeTag(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if n := utf8.RuneCountInString(tag); n == 0 || n > MaxTagLength {
+		return "", false
+	}
+
+	return tag, true
+}
+
+// normalizeTags normalizes the tags of a post and removes the duplicated ones.
+func normalizeTags(tags []string) ([]string, error) {
+	ret := make([]string, 0, len(tags))
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag, ok := NormalizeTag(tag)
+		if !ok {
+			return nil, errno.ErrTagInvalid
+		}
+
+		if !seen[tag] {
+			seen[tag] = true
+			ret = append(ret, tag)
+		}
+	}
+
+	if len(ret) > maxPostTags {
+		return nil, errno.ErrTagInvalid
+	}
+
+	return ret, nil
+}
+
+// setTags replaces the tags of the post with the given normalized tags, the missing tags are created.
+func (b *postBiz) setTags(ctx context.Context, postID string, names []string) error {
+	tags, err := b.ds.Tags().FirstOrCreate(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	tagIDs := make([]int64, 0, len(tags))
+	for _, tag := range tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	return b.ds.Tags().SetPostTags(ctx, postID, tagIDs)
+}
+
+// attachTags fills in the tags of posts.
+func (b *postBiz) attachTags(ctx context.Context, posts ...*v1.PostInfo) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.PostID)
+	}
+
+	tags, err := b.ds.Tags().ListByPostIDs(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		post.Tags = tags[post.PostID]
+	}
+
+	return nil
+}
+
+// checkCategory makes sure that the category exists. Zero means no category.
+func (b *postBiz) checkCategory(ctx context.Context, categoryID int64) error {
+	if categoryID == 0 {
+		return nil
+	}
+
+	if _, err := b.ds.Categories().Get(ctx, categoryID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrCategoryNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// categoryIDs returns the ID of the category and the IDs of all its descendants.
+func (b *postBiz) categoryIDs(ctx context.Context, categoryID int64) ([]int64, error) {
+	categories, err := b.ds.Categories().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	children := map[int64][]int64{}
+	found := false
+	for _, category := range categories {
+		children[category.ParentID] = append(children[category.ParentID], category.ID)
+		found = found || category.ID == categoryID
+	}
+
+	if !found {
+		return nil, errno.ErrCategoryNotFound
+	}
+
+	ids := []int64{categoryID}
+	for i := 0; i < len(ids); i++ {
+		ids = append(ids, children[ids[i]]...)
+	}
+
+	return ids, nil
+}
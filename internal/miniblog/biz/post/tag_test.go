@@ -0,0 +1,39 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+)
+
+func Test_normalizeTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr error
+	}{
+		{name: "empty", tags: nil, want: []string{}},
+		{name: "normalized", tags: []string{" Go ", "go", "MySQL"}, want: []string{"go", "mysql"}},
+		{name: "blank", tags: []string{"go", "  "}, wantErr: errno.ErrTagInvalid},
+		{name: "too long", tags: []string{strings.Repeat("a", MaxTagLength+1)}, wantErr: errno.ErrTagInvalid},
+		{name: "too many", tags: strings.Split("a,b,c,d,e,f,g,h,i,j,k", ","), wantErr: errno.ErrTagInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeTags(tt.tags)
+			assert.Equal(t, tt.wantErr, err)
+			if err == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"errors"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// Timeline is the implementation of the `Timeline` method in PostBiz interface.
+// It lists the published public posts of the users followed by username, from the most recently published one.
+//
+// The timeline is built on write: when a post gets a publish time it is fanned out to the timelines of all
+// followers of its author (see fanOut), so reading a page only scans one index however many users are followed.
+func (b *postBiz) Timeline(ctx context.Context, username string, r *v1.ListTimelineRequest) (*v1.ListTimelineResponse, error) {
+	opts, err := store.NewListOptions(0, r.Limit, r.PageToken, true)
+	if err != nil {
+		return nil, errno.ErrPageTokenInvalid
+	}
+
+	opts.SortBy = "publishAt"
+
+	list, err := b.ds.Timelines().List(ctx, username, opts)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidCursor) {
+			return nil, errno.ErrPageTokenInvalid
+		}
+
+		log.C(ctx).Errorw("Failed to list timeline from storage", "err", err)
+		return nil, err
+	}
+
+	// The storage returns one more entry than the page size if there is a next page.
+	var nextPageToken string
+	if len(list) > opts.PageSize() {
+		list = list[:opts.PageSize()]
+		last := list[len(list)-1]
+		nextPageToken = store.NewCursor(opts, last.PublishAt, last.ID).Encode()
+	}
+
+	postIDs := make([]string, 0, len(list))
+	for _, entry := range list {
+		postIDs = append(postIDs, entry.PostID)
+	}
+
+	found, err := b.ds.Posts().GetByPostIDs(ctx, postIDs)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to list posts from storage", "err", err)
+		return nil, err
+	}
+
+	m := make(map[string]*model.PostM, len(found))
+	for _, post := range found {
+		m[post.PostID] = post
+	}
+
+	posts := make([]*v1.PostInfo, 0, len(list))
+	for _, entry := range list {
+		if post, ok := m[entry.PostID]; ok {
+			posts = append(posts, postInfo(post))
+		}
+	}
+
+	if err := b.attach(ctx, nil, posts...); err != nil {
+		log.C(ctx).Errorw("Failed to list post details from storage", "err", err)
+		return nil, err
+	}
+
+	return &v1.ListTimelineResponse{Posts: posts, NextPageToken: nextPageToken}, nil
+}
+
+// fanOut writes the post to the timelines of the followers of its author if the post has a publish time.
+// Posts are written as soon as they are scheduled, the timeline shows them only after they are published.
+func (b *postBiz) fanOut(ctx context.Context, post *model.PostM) error {
+	if post.PublishAt == nil {
+		return nil
+	}
+
+	_, err := b.ds.Timelines().FanOut(ctx, post)
+
+	return err
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+func Test_postBiz_Timeline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	entries := []*model.TimelineM{
+		{ID: 3, Username: "belm", PostID: "post-c", Author: "colin", PublishAt: now},
+		{ID: 2, Username: "belm", PostID: "post-b", Author: "colin", PublishAt: now.Add(-time.Hour)},
+		{ID: 1, Username: "belm", PostID: "post-a", Author: "colin", PublishAt: now.Add(-2 * time.Hour)},
+	}
+
+	mockTimelineStore := store.NewMockTimelineStore(ctrl)
+	mockTimelineStore.EXPECT().List(gomock.Any(), "belm", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, username string, opts *store.ListOptions) ([]*model.TimelineM, error) {
+			if opts.Cursor != nil && opts.Cursor.Sort != "publishAt desc" {
+				return nil, store.ErrInvalidCursor
+			}
+
+			return entries[:opts.PageSize()+1], nil
+		},
+	).AnyTimes()
+
+	// post-b 在读取时间线后被永久删除
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().GetByPostIDs(gomock.Any(), []string{"post-c", "post-b"}).Return([]*model.PostM{
+		{PostID: "post-c", Username: "colin", Status: model.PostStatusPublished, Visibility: model.PostVisibilityPublic},
+	}, nil).AnyTimes()
+
+	mockTagStore := store.NewMockTagStore(ctrl)
+	mockTagStore.EXPECT().ListByPostIDs(gomock.Any(), gomock.Any()).Return(map[string][]string{}, nil).AnyTimes()
+
+	mockCommentStore := store.NewMockCommentStore(ctrl)
+	mockCommentStore.EXPECT().CountByPostIDs(gomock.Any(), gomock.Any()).Return(map[string]int64{}, nil).AnyTimes()
+
+	mockReactionStore := store.NewMockReactionStore(ctrl)
+	mockReactionStore.EXPECT().Counts(gomock.Any(), gomock.Any()).Return(map[string]map[string]int64{}, nil).AnyTimes()
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Timelines().AnyTimes().Return(mockTimelineStore)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+	mockStore.EXPECT().Tags().AnyTimes().Return(mockTagStore)
+	mockStore.EXPECT().Comments().AnyTimes().Return(mockCommentStore)
+	mockStore.EXPECT().Reactions().AnyTimes().Return(mockReactionStore)
+
+	b := New(mockStore)
+	got, err := b.Timeline(context.Background(), "belm", &v1.ListTimelineRequest{Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(got.Posts))
+	assert.Equal(t, "post-c", got.Posts[0].PostID)
+
+	cursor, err := store.DecodeCursor(got.NextPageToken)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), cursor.ID)
+
+	_, err = b.Timeline(context.Background(), "belm", &v1.ListTimelineRequest{PageToken: "invalid token"})
+	assert.Equal(t, errno.ErrPageTokenInvalid, err)
+}
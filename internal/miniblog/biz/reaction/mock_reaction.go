@@ -0,0 +1,96 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/marmotedu/miniblog/internal/miniblog/biz/reaction (interfaces: ReactionBiz)
+
+// Package reaction is a generated GoMock package.
+package reaction
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReactionBiz is a mock of ReactionBiz interface.
+type MockReactionBiz struct {
+	ctrl     *gomock.Controller
+	recorder *MockReactionBizMockRecorder
+}
+
+// MockReactionBizMockRecorder is the mock recorder for MockReactionBiz.
+type MockReactionBizMockRecorder struct {
+	mock *MockReactionBiz
+}
+
+// NewMockReactionBiz creates a new mock instance.
+func NewMockReactionBiz(ctrl *gomock.Controller) *MockReactionBiz {
+	mock := &MockReactionBiz{ctrl: ctrl}
+	mock.recorder = &MockReactionBizMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReactionBiz) EXPECT() *MockReactionBizMockRecorder {
+	return m.recorder
+}
+
+// ReactComment mocks base method.
+func (m *MockReactionBiz) ReactComment(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactComment", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReactComment indicates an expected call of ReactComment.
+func (mr *MockReactionBizMockRecorder) ReactComment(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactComment", reflect.TypeOf((*MockReactionBiz)(nil).ReactComment), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ReactPost mocks base method.
+func (m *MockReactionBiz) ReactPost(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactPost", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReactPost indicates an expected call of ReactPost.
+func (mr *MockReactionBizMockRecorder) ReactPost(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactPost", reflect.TypeOf((*MockReactionBiz)(nil).ReactPost), arg0, arg1, arg2, arg3)
+}
+
+// UnreactComment mocks base method.
+func (m *MockReactionBiz) UnreactComment(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnreactComment", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnreactComment indicates an expected call of UnreactComment.
+func (mr *MockReactionBizMockRecorder) UnreactComment(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnreactComment", reflect.TypeOf((*MockReactionBiz)(nil).UnreactComment), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UnreactPost mocks base method.
+func (m *MockReactionBiz) UnreactPost(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnreactPost", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnreactPost indicates an expected call of UnreactPost.
+func (mr *MockReactionBizMockRecorder) UnreactPost(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnreactPost", reflect.TypeOf((*MockReactionBiz)(nil).UnreactPost), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,160 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package reaction
+
+//go:generate mockgen -destination mock_reaction.go -package reaction github.com/marmotedu/miniblog/internal/miniblog/biz/reaction ReactionBiz
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/comment"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// ReactionBiz defines functions used to handle reaction request.
+// Adding and removing reactions are idempotent.
+type ReactionBiz interface {
+	ReactPost(ctx context.Context, username, postID, typ string) error
+	UnreactPost(ctx context.Context, username, postID, typ string) error
+	ReactComment(ctx context.Context, username, postID, commentID, typ string) error
+	UnreactComment(ctx context.Context, username, postID, commentID, typ string) error
+}
+
+// The implementation of ReactionBiz interface.
+type reactionBiz struct {
+	ds store.IStore
+}
+
+// Make sure that reactionBiz implements the ReactionBiz interface.
+var _ ReactionBiz = (*reactionBiz)(nil)
+
+func New(ds store.IStore) *reactionBiz {
+	return &reactionBiz{ds: ds}
+}
+
+// ReactPost is the implementation of the `ReactPost` method in ReactionBiz interface.
+func (b *reactionBiz) ReactPost(ctx context.Context, username, postID, typ string) error {
+	if _, err := b.getPost(ctx, username, postID); err != nil {
+		return err
+	}
+
+	return b.add(ctx, username, model.ReactionTargetPost, postID, typ)
+}
+
+// UnreactPost is the implementation of the `UnreactPost` method in ReactionBiz interface.
+func (b *reactionBiz) UnreactPost(ctx context.Context, username, postID, typ string) error {
+	if _, err := b.getPost(ctx, username, postID); err != nil {
+		return err
+	}
+
+	return b.remove(ctx, username, postID, typ)
+}
+
+// ReactComment is the implementation of the `ReactComment` method in ReactionBiz interface.
+func (b *reactionBiz) ReactComment(ctx context.Context, username, postID, commentID, typ string) error {
+	if err := b.checkComment(ctx, username, postID, commentID); err != nil {
+		return err
+	}
+
+	return b.add(ctx, username, model.ReactionTargetComment, commentID, typ)
+}
+
+// UnreactComment is the implementation of the `UnreactComment` method in ReactionBiz interface.
+func (b *reactionBiz) UnreactComment(ctx context.Context, username, postID, commentID, typ string) error {
+	if err := b.checkComment(ctx, username, postID, commentID); err != nil {
+		return err
+	}
+
+	return b.remove(ctx, username, commentID, typ)
+}
+
+// add adds a reaction and increases the reaction count in a transaction.
+func (b *reactionBiz) add(ctx context.Context, username, targetType, targetID, typ string) error {
+	if !validType(typ) {
+		return errno.ErrReactionTypeInvalid
+	}
+
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		_, err := b.ds.Reactions().Add(ctx, &model.ReactionM{
+			Username:   username,
+			TargetType: targetType,
+			TargetID:   targetID,
+			Type:       typ,
+		})
+
+		return err
+	})
+}
+
+// remove removes a reaction and decreases the reaction count in a transaction.
+func (b *reactionBiz) remove(ctx context.Context, username, targetID, typ string) error {
+	if !validType(typ) {
+		return errno.ErrReactionTypeInvalid
+	}
+
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		_, err := b.ds.Reactions().Remove(ctx, username, targetID, typ)
+
+		return err
+	})
+}
+
+// getPost returns the post which can be read by the user.
+func (b *reactionBiz) getPost(ctx context.Context, username, postID string) (*model.PostM, error) {
+	post, err := b.ds.Posts().GetByPostID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrPostNotFound
+		}
+
+		return nil, err
+	}
+
+	if post.Username != username && (post.Status != model.PostStatusPublished || post.Visibility == model.PostVisibilityPrivate) {
+		return nil, errno.ErrPostNotFound
+	}
+
+	return post, nil
+}
+
+// checkComment makes sure that the comment can be seen by the user.
+func (b *reactionBiz) checkComment(ctx context.Context, username, postID, commentID string) error {
+	post, err := b.getPost(ctx, username, postID)
+	if err != nil {
+		return err
+	}
+
+	c, err := b.ds.Comments().Get(ctx, postID, commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrCommentNotFound
+		}
+
+		return err
+	}
+
+	if !comment.Visible(c, post, username) {
+		return errno.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// validType reports whether typ is a supported reaction type.
+func validType(typ string) bool {
+	for _, t := range model.ReactionTypes {
+		if t == typ {
+			return true
+		}
+	}
+
+	return false
+}
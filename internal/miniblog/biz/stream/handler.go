@@ -0,0 +1,180 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package stream
+
+//go:generate mockgen -destination mock_stream.go -package stream github.com/marmotedu/miniblog/internal/miniblog/biz/stream StreamBiz
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/event"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/notification"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// followingPageSize is the number of followed users loaded at a time when a stream is opened.
+const followingPageSize = 1000
+
+// Subscribe registers the handlers which send the domain events to the streams of the default hub. The events
+// are only sent to the streams connected to the server which dispatches them, and an event handled more than
+// once is sent more than once, so the clients should identify the data by its IDs.
+func Subscribe(ds store.IStore) {
+	h := &handler{ds: ds}
+
+	event.Subscribe("StreamPost", h.post, event.PostCreated, event.PostPublished)
+	event.Subscribe("StreamComment", h.comment, event.CommentCreated)
+	event.Subscribe("StreamNotification", h.notification, event.NotificationCreated)
+	event.Subscribe("StreamFollow", h.follow, event.UserFollowed, event.UserUnfollowed)
+}
+
+// StreamBiz defines functions used to handle the event stream request.
+type StreamBiz interface {
+	Open(ctx context.Context, username, lastEventID string) (*Subscription, []*Message, error)
+}
+
+// The implementation of StreamBiz interface.
+type streamBiz struct {
+	ds store.IStore
+}
+
+// Make sure that streamBiz implements the StreamBiz interface.
+var _ StreamBiz = (*streamBiz)(nil)
+
+func New(ds store.IStore) *streamBiz {
+	return &streamBiz{ds: ds}
+}
+
+// Open is the implementation of the `Open` method in StreamBiz interface, it opens a stream of username on
+// the default hub, see Hub.Subscribe.
+func (b *streamBiz) Open(ctx context.Context, username, lastEventID string) (*Subscription, []*Message, error) {
+	var followees []string
+	for opts := (&store.ListOptions{Limit: followingPageSize, SkipCount: true}); ; opts.Offset += followingPageSize {
+		_, following, err := b.ds.Follows().ListFollowing(ctx, username, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for i := 0; i < len(following) && i < followingPageSize; i++ {
+			followees = append(followees, following[i].Followee)
+		}
+
+		if len(following) <= followingPageSize {
+			break
+		}
+	}
+
+	return Default.Subscribe(username, followees, lastEventID)
+}
+
+type handler struct {
+	ds store.IStore
+}
+
+// post sends a newly published post to its author, and to the followers of the author if it is public.
+func (h *handler) post(ctx context.Context, e *event.Event) error {
+	var p event.Post
+	if err := e.Decode(&p); err != nil {
+		return err
+	}
+
+	post, err := h.ds.Posts().Get(ctx, p.Username, p.PostID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if post.Status != model.PostStatusPublished {
+		return nil
+	}
+
+	data := &v1.StreamPostEvent{PostID: post.PostID, Username: post.Username, Title: post.Title}
+	if post.PublishAt != nil {
+		data.PublishedAt = post.PublishAt.Format("2006-01-02 15:04:05")
+	}
+
+	if post.Visibility != model.PostVisibilityPublic {
+		return Default.Send(post.Username, EventPost, data)
+	}
+
+	return Default.Broadcast(post.Username, EventPost, data)
+}
+
+// comment sends a new comment to the owner of the post, unless the owner wrote it.
+func (h *handler) comment(ctx context.Context, e *event.Event) error {
+	var c event.Comment
+	if err := e.Decode(&c); err != nil {
+		return err
+	}
+
+	comment, err := h.ds.Comments().Get(ctx, c.PostID, c.CommentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	post, err := h.ds.Posts().GetByPostID(ctx, c.PostID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if post.Username == comment.Username {
+		return nil
+	}
+
+	return Default.Send(post.Username, EventComment, &v1.StreamCommentEvent{
+		PostID:    comment.PostID,
+		CommentID: comment.CommentID,
+		Username:  comment.Username,
+		Status:    comment.Status,
+	})
+}
+
+// notification sends a new notification to its recipient.
+func (h *handler) notification(ctx context.Context, e *event.Event) error {
+	var n event.Notification
+	if err := e.Decode(&n); err != nil {
+		return err
+	}
+
+	info, err := notification.New(h.ds).Get(ctx, n.Username, n.NotificationID)
+	if errors.Is(err, errno.ErrNotificationNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return Default.Send(n.Username, EventNotification, info)
+}
+
+// follow makes the open streams of a follower start or stop receiving the posts of the followee.
+func (h *handler) follow(ctx context.Context, e *event.Event) error {
+	var f event.Follow
+	if err := e.Decode(&f); err != nil {
+		return err
+	}
+
+	if e.Type == event.UserUnfollowed {
+		Default.Unfollow(f.Username, f.Followee)
+	} else {
+		Default.Follow(f.Username, f.Followee)
+	}
+
+	return nil
+}
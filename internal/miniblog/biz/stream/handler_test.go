@@ -0,0 +1,82 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/event"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+func Test_streamBiz_Open(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	Default = NewHub(10, 1)
+	defer Init(0, 0, 0)
+
+	page := make([]*model.FollowM, followingPageSize+1)
+	for i := range page {
+		page[i] = &model.FollowM{Username: "belm", Followee: "colin"}
+	}
+
+	mockFollowStore := store.NewMockFollowStore(ctrl)
+	gomock.InOrder(
+		mockFollowStore.EXPECT().ListFollowing(gomock.Any(), "belm", &store.ListOptions{Limit: followingPageSize, SkipCount: true}).Return(int64(0), page, nil),
+		mockFollowStore.EXPECT().ListFollowing(gomock.Any(), "belm", &store.ListOptions{Offset: followingPageSize, Limit: followingPageSize, SkipCount: true}).
+			Return(int64(0), []*model.FollowM{{Username: "belm", Followee: "alice"}}, nil),
+	)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Follows().AnyTimes().Return(mockFollowStore)
+
+	s, backlog, err := New(mockStore).Open(context.Background(), "belm", "")
+	assert.Nil(t, err)
+	assert.Empty(t, backlog)
+	assert.Equal(t, map[string]bool{"colin": true, "alice": true}, s.following)
+}
+
+func Test_handler_post(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	Default = NewHub(10, 1)
+	defer Init(0, 0, 0)
+
+	posts := []*model.PostM{
+		{Username: "colin", PostID: "post-1", Title: "public", Status: model.PostStatusPublished, Visibility: model.PostVisibilityPublic},
+		{Username: "colin", PostID: "post-2", Title: "unlisted", Status: model.PostStatusPublished, Visibility: model.PostVisibilityUnlisted},
+		{Username: "colin", PostID: "post-3", Title: "draft", Status: model.PostStatusDraft, Visibility: model.PostVisibilityPublic},
+	}
+
+	mockPostStore := store.NewMockPostStore(ctrl)
+	for _, post := range posts {
+		mockPostStore.EXPECT().Get(gomock.Any(), "colin", post.PostID).Return(post, nil).AnyTimes()
+	}
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+
+	belm, _, _ := Default.Subscribe("belm", []string{"colin"}, "")
+	colin, _, _ := Default.Subscribe("colin", nil, "")
+
+	h := &handler{ds: mockStore}
+	for _, post := range posts {
+		data, _ := json.Marshal(event.Post{Username: "colin", PostID: post.PostID})
+		assert.Nil(t, h.post(context.Background(), &event.Event{Type: event.PostCreated, Payload: data}))
+	}
+
+	// The followers only receive the public posts, the author receives all the published posts.
+	assert.Equal(t, []string{`post:{"postID":"post-1","username":"colin","title":"public","publishedAt":""}`}, received(belm))
+	assert.Len(t, received(colin), 2)
+}
@@ -0,0 +1,298 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package stream
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+)
+
+const (
+	// DefaultBufferSize is the default number of recent messages kept for the streams to resume from.
+	DefaultBufferSize = 1000
+
+	// DefaultMaxStreams is the default maximum number of concurrent streams of a user.
+	DefaultMaxStreams = 3
+
+	// DefaultHeartbeat is the default interval of the heartbeats sent on idle streams.
+	DefaultHeartbeat = 30 * time.Second
+
+	// subscriptionBuffer is the number of messages queued for a stream. A stream which falls further behind
+	// is closed, the client reconnects and resumes from the buffer of the hub.
+	subscriptionBuffer = 64
+)
+
+// The events sent on the streams.
+const (
+	EventPost         = "post"
+	EventComment      = "comment"
+	EventNotification = "notification"
+
+	// EventReset tells the client that the stream could not be resumed from the given ID and that the
+	// messages since then may have been missed, the client should reload the data it shows.
+	EventReset = "reset"
+)
+
+// Message is a message sent on the streams.
+type Message struct {
+	ID    string
+	Event string
+	Data  []byte
+
+	seq uint64
+	// recipient is the user who receives the message, author is the user whose followers receive the message.
+	recipient, author string
+}
+
+// Hub sends the messages to the streams of the users connected to this server, and keeps the recent
+// messages in a ring buffer so that a reconnecting stream can resume from the last message it received.
+type Hub struct {
+	mu            sync.Mutex
+	epoch         string
+	next          uint64
+	buffer        []*Message
+	maxStreams    int
+	subscriptions map[string]map[*Subscription]struct{}
+	closed        bool
+}
+
+// Default is the hub used by the server.
+var Default = NewHub(DefaultBufferSize, DefaultMaxStreams)
+
+// heartbeat is the interval of the heartbeats, which keep the idle streams from being closed by the proxies.
+var heartbeat = DefaultHeartbeat
+
+// Init replaces the default hub and sets the interval of the heartbeats, a value which is not positive
+// keeps the default.
+func Init(bufferSize, maxStreams int, interval time.Duration) {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	if maxStreams <= 0 {
+		maxStreams = DefaultMaxStreams
+	}
+
+	if interval <= 0 {
+		interval = DefaultHeartbeat
+	}
+
+	Default = NewHub(bufferSize, maxStreams)
+	heartbeat = interval
+}
+
+// Heartbeat returns the interval of the heartbeats sent on idle streams.
+func Heartbeat() time.Duration {
+	return heartbeat
+}
+
+// NewHub creates a hub which keeps bufferSize messages and accepts maxStreams streams per user.
+func NewHub(bufferSize, maxStreams int) *Hub {
+	return &Hub{
+		// The message IDs are prefixed with the creation time of the hub, so that the IDs given by a
+		// previous run of the server are not mistaken for the ones of this run.
+		epoch:         strconv.FormatInt(time.Now().UnixNano(), 36),
+		next:          1,
+		buffer:        make([]*Message, bufferSize),
+		maxStreams:    maxStreams,
+		subscriptions: map[string]map[*Subscription]struct{}{},
+	}
+}
+
+// Subscription is a stream of a user, the messages are received from C, which is closed when the stream
+// falls behind or the hub is closed.
+type Subscription struct {
+	C <-chan *Message
+
+	c         chan *Message
+	hub       *Hub
+	username  string
+	following map[string]bool
+}
+
+// Subscribe opens a stream of username, who follows the given users. If lastEventID is not empty, the messages
+// sent since then are returned to be sent first, or a reset message if some of them are no longer buffered.
+func (h *Hub) Subscribe(username string, following []string, lastEventID string) (*Subscription, []*Message, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, nil, errno.InternalServerError
+	}
+
+	if len(h.subscriptions[username]) >= h.maxStreams {
+		return nil, nil, errno.ErrStreamLimitExceeded
+	}
+
+	c := make(chan *Message, subscriptionBuffer)
+	s := &Subscription{C: c, c: c, hub: h, username: username, following: map[string]bool{}}
+	for _, followee := range following {
+		s.following[followee] = true
+	}
+
+	var backlog []*Message
+	if lastEventID != "" {
+		backlog = h.since(s, lastEventID)
+	}
+
+	if h.subscriptions[username] == nil {
+		h.subscriptions[username] = map[*Subscription]struct{}{}
+	}
+	h.subscriptions[username][s] = struct{}{}
+
+	return s, backlog, nil
+}
+
+// since returns the buffered messages for s which follow the message lastEventID.
+func (h *Hub) since(s *Subscription, lastEventID string) []*Message {
+	oldest := uint64(1)
+	if h.next > uint64(len(h.buffer)) {
+		oldest = h.next - uint64(len(h.buffer))
+	}
+
+	epoch, id, _ := strings.Cut(lastEventID, "-")
+	seq, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || epoch != h.epoch || seq >= h.next || seq+1 < oldest {
+		return []*Message{{ID: h.id(h.next - 1), Event: EventReset, Data: []byte("{}")}}
+	}
+
+	var messages []*Message
+	for seq++; seq < h.next; seq++ {
+		if m := h.buffer[seq%uint64(len(h.buffer))]; s.accepts(m) {
+			messages = append(messages, m)
+		}
+	}
+
+	return messages
+}
+
+// Send sends a message to the streams of username.
+func (h *Hub) Send(username, event string, data interface{}) error {
+	return h.publish(&Message{Event: event, recipient: username}, data)
+}
+
+// Broadcast sends a message to the streams of author and of the users who follow author.
+func (h *Hub) Broadcast(author, event string, data interface{}) error {
+	return h.publish(&Message{Event: event, author: author}, data)
+}
+
+func (h *Hub) publish(m *Message, data interface{}) error {
+	var err error
+	if m.Data, err = json.Marshal(data); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil
+	}
+
+	m.seq, m.ID = h.next, h.id(h.next)
+	h.buffer[m.seq%uint64(len(h.buffer))] = m
+	h.next++
+
+	if m.recipient != "" {
+		for s := range h.subscriptions[m.recipient] {
+			h.deliver(s, m)
+		}
+
+		return nil
+	}
+
+	for _, subscriptions := range h.subscriptions {
+		for s := range subscriptions {
+			if s.accepts(m) {
+				h.deliver(s, m)
+			}
+		}
+	}
+
+	return nil
+}
+
+// deliver queues m for s without blocking, s is closed if it is too far behind.
+func (h *Hub) deliver(s *Subscription, m *Message) {
+	select {
+	case s.c <- m:
+	default:
+		h.remove(s)
+	}
+}
+
+// Follow adds followee to the users followed by the streams of username.
+func (h *Hub) Follow(username, followee string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for s := range h.subscriptions[username] {
+		s.following[followee] = true
+	}
+}
+
+// Unfollow removes followee from the users followed by the streams of username.
+func (h *Hub) Unfollow(username, followee string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for s := range h.subscriptions[username] {
+		delete(s.following, followee)
+	}
+}
+
+// Close closes all the streams, it is called when the server shuts down.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for _, subscriptions := range h.subscriptions {
+		for s := range subscriptions {
+			h.remove(s)
+		}
+	}
+}
+
+func (h *Hub) id(seq uint64) string {
+	return h.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// remove unregisters s and closes its channel, h.mu must be held.
+func (h *Hub) remove(s *Subscription) {
+	if _, ok := h.subscriptions[s.username][s]; !ok {
+		return
+	}
+
+	delete(h.subscriptions[s.username], s)
+	if len(h.subscriptions[s.username]) == 0 {
+		delete(h.subscriptions, s.username)
+	}
+
+	close(s.c)
+}
+
+// Close closes the stream.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s)
+}
+
+// accepts reports whether m is sent to the stream.
+func (s *Subscription) accepts(m *Message) bool {
+	if m.recipient != "" {
+		return m.recipient == s.username
+	}
+
+	return m.author == s.username || s.following[m.author]
+}
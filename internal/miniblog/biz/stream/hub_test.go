@@ -0,0 +1,127 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+)
+
+// received returns the events of the messages queued for s.
+func received(s *Subscription) []string {
+	var events []string
+	for {
+		select {
+		case m, ok := <-s.C:
+			if !ok {
+				return append(events, "closed")
+			}
+
+			events = append(events, m.Event+":"+string(m.Data))
+		default:
+			return events
+		}
+	}
+}
+
+func TestHub_Send(t *testing.T) {
+	h := NewHub(10, 2)
+
+	belm, _, err := h.Subscribe("belm", []string{"colin"}, "")
+	assert.Nil(t, err)
+	colin, _, err := h.Subscribe("colin", nil, "")
+	assert.Nil(t, err)
+
+	assert.Nil(t, h.Send("belm", EventNotification, 1))
+	assert.Nil(t, h.Broadcast("colin", EventPost, 2))
+	assert.Nil(t, h.Broadcast("alice", EventPost, 3))
+
+	// A follow takes effect on the open streams.
+	h.Follow("belm", "alice")
+	assert.Nil(t, h.Broadcast("alice", EventPost, 4))
+
+	// So does an unfollow.
+	h.Unfollow("belm", "colin")
+	assert.Nil(t, h.Broadcast("colin", EventPost, 5))
+
+	assert.Equal(t, []string{"notification:1", "post:2", "post:4"}, received(belm))
+	assert.Equal(t, []string{"post:2", "post:5"}, received(colin))
+
+	belm.Close()
+	assert.Equal(t, []string{"closed"}, received(belm))
+
+	h.Close()
+	assert.Equal(t, []string{"closed"}, received(colin))
+}
+
+func TestHub_Subscribe_limit(t *testing.T) {
+	h := NewHub(10, 2)
+
+	s, _, _ := h.Subscribe("belm", nil, "")
+	_, _, _ = h.Subscribe("belm", nil, "")
+	_, _, err := h.Subscribe("belm", nil, "")
+	assert.Equal(t, errno.ErrStreamLimitExceeded, err)
+
+	// Other users are not limited by the streams of belm, and a closed stream frees its place.
+	_, _, err = h.Subscribe("colin", nil, "")
+	assert.Nil(t, err)
+
+	s.Close()
+	_, _, err = h.Subscribe("belm", nil, "")
+	assert.Nil(t, err)
+}
+
+func TestHub_Subscribe_resume(t *testing.T) {
+	h := NewHub(3, 10)
+
+	var ids []string
+	for i := 1; i <= 5; i++ {
+		recipient := "belm"
+		if i == 4 {
+			recipient = "colin"
+		}
+
+		assert.Nil(t, h.Send(recipient, EventNotification, i))
+		ids = append(ids, h.buffer[uint64(i)%3].ID)
+	}
+
+	// The messages since the last one received by the client are sent again.
+	_, backlog, err := h.Subscribe("belm", nil, ids[2])
+	assert.Nil(t, err)
+	assert.Len(t, backlog, 1)
+	assert.Equal(t, ids[4], backlog[0].ID)
+
+	_, backlog, _ = h.Subscribe("belm", nil, ids[4])
+	assert.Empty(t, backlog)
+
+	// The stream cannot be resumed from a message which is no longer buffered, or from an unknown ID.
+	for _, id := range []string{ids[0], "1-1", "invalid", ids[4] + "0"} {
+		_, backlog, _ = h.Subscribe("belm", nil, id)
+		assert.Len(t, backlog, 1, id)
+		assert.Equal(t, EventReset, backlog[0].Event, id)
+		assert.Equal(t, ids[4], backlog[0].ID, id)
+	}
+}
+
+func TestHub_slowStream(t *testing.T) {
+	h := NewHub(100, 1)
+
+	s, _, _ := h.Subscribe("belm", nil, "")
+	for i := 0; i <= subscriptionBuffer; i++ {
+		assert.Nil(t, h.Send("belm", EventNotification, i))
+	}
+
+	// The stream is closed instead of blocking the hub, the client resumes from the buffer.
+	events := received(s)
+	assert.Len(t, events, subscriptionBuffer+1)
+	assert.Equal(t, "closed", events[subscriptionBuffer])
+
+	_, _, err := h.Subscribe("belm", nil, "")
+	assert.Nil(t, err)
+}
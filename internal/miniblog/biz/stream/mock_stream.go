@@ -0,0 +1,56 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/marmotedu/miniblog/internal/miniblog/biz/stream (interfaces: StreamBiz)
+
+// Package stream is a generated GoMock package.
+package stream
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStreamBiz is a mock of StreamBiz interface.
+type MockStreamBiz struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamBizMockRecorder
+}
+
+// MockStreamBizMockRecorder is the mock recorder for MockStreamBiz.
+type MockStreamBizMockRecorder struct {
+	mock *MockStreamBiz
+}
+
+// NewMockStreamBiz creates a new mock instance.
+func NewMockStreamBiz(ctrl *gomock.Controller) *MockStreamBiz {
+	mock := &MockStreamBiz{ctrl: ctrl}
+	mock.recorder = &MockStreamBizMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreamBiz) EXPECT() *MockStreamBizMockRecorder {
+	return m.recorder
+}
+
+// Open mocks base method.
+func (m *MockStreamBiz) Open(arg0 context.Context, arg1, arg2 string) (*Subscription, []*Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*Subscription)
+	ret1, _ := ret[1].([]*Message)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockStreamBizMockRecorder) Open(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockStreamBiz)(nil).Open), arg0, arg1, arg2)
+}
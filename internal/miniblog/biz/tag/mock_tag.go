@@ -0,0 +1,85 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/marmotedu/miniblog/internal/miniblog/biz/tag (interfaces: TagBiz)
+
+// Package tag is a generated GoMock package.
+package tag
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// MockTagBiz is a mock of TagBiz interface.
+type MockTagBiz struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagBizMockRecorder
+}
+
+// MockTagBizMockRecorder is the mock recorder for MockTagBiz.
+type MockTagBizMockRecorder struct {
+	mock *MockTagBiz
+}
+
+// NewMockTagBiz creates a new mock instance.
+func NewMockTagBiz(ctrl *gomock.Controller) *MockTagBiz {
+	mock := &MockTagBiz{ctrl: ctrl}
+	mock.recorder = &MockTagBizMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagBiz) EXPECT() *MockTagBizMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockTagBiz) List(arg0 context.Context) (*v1.ListTagResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].(*v1.ListTagResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTagBizMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTagBiz)(nil).List), arg0)
+}
+
+// Merge mocks base method.
+func (m *MockTagBiz) Merge(arg0 context.Context, arg1 string, arg2 *v1.MergeTagRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockTagBizMockRecorder) Merge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockTagBiz)(nil).Merge), arg0, arg1, arg2)
+}
+
+// Rename mocks base method.
+func (m *MockTagBiz) Rename(arg0 context.Context, arg1 string, arg2 *v1.RenameTagRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockTagBizMockRecorder) Rename(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockTagBiz)(nil).Rename), arg0, arg1, arg2)
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package tag
+
+//go:generate mockgen -destination mock_tag.go -package tag github.com/marmotedu/miniblog/internal/miniblog/biz/tag TagBiz
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/post"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// TagBiz defines functions used to handle tag request.
+type TagBiz interface {
+	List(ctx context.Context) (*v1.ListTagResponse, error)
+	Rename(ctx context.Context, name string, r *v1.RenameTagRequest) error
+	Merge(ctx context.Context, name string, r *v1.MergeTagRequest) error
+}
+
+// The implementation of TagBiz interface.
+type tagBiz struct {
+	ds store.IStore
+}
+
+// Make sure that tagBiz implements the TagBiz interface.
+var _ TagBiz = (*tagBiz)(nil)
+
+func New(ds store.IStore) *tagBiz {
+	return &tagBiz{ds: ds}
+}
+
+// List is the implementation of the `List` method in TagBiz interface.
+func (b *tagBiz) List(ctx context.Context) (*v1.ListTagResponse, error) {
+	list, err := b.ds.Tags().List(ctx)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to list tags from storage", "err", err)
+		return nil, err
+	}
+
+	tags := make([]*v1.TagInfo, 0, len(list))
+	for _, item := range list {
+		tags = append(tags, &v1.TagInfo{Name: item.Name, PostCount: item.PostCount})
+	}
+
+	return &v1.ListTagResponse{Tags: tags}, nil
+}
+
+// Rename is the implementation of the `Rename` method in TagBiz interface.
+// Renaming a tag to an existing tag is refused, the tags should be merged instead.
+func (b *tagBiz) Rename(ctx context.Context, name string, r *v1.RenameTagRequest) error {
+	to, ok := post.NormalizeTag(r.Name)
+	if !ok {
+		return errno.ErrTagInvalid
+	}
+
+	tag, err := b.get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if to == tag.Name {
+		return nil
+	}
+
+	if _, err := b.get(ctx, to); err == nil {
+		return errno.ErrTagAlreadyExist
+	} else if !errors.Is(err, errno.ErrTagNotFound) {
+		return err
+	}
+
+	return b.ds.Tags().Rename(ctx, tag.ID, to)
+}
+
+// Merge is the implementation of the `Merge` method in TagBiz interface.
+func (b *tagBiz) Merge(ctx context.Context, name string, r *v1.MergeTagRequest) error {
+	from, err := b.get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	into, err := b.get(ctx, r.Into)
+	if err != nil {
+		return err
+	}
+
+	if from.ID == into.ID {
+		return errno.ErrInvalidParameter.SetMessage("can not merge a tag into itself")
+	}
+
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		return b.ds.Tags().Merge(ctx, from.ID, into.ID)
+	})
+}
+
+// get returns the tag with the given name, the name is normalized before looking up.
+func (b *tagBiz) get(ctx context.Context, name string) (*model.TagM, error) {
+	name, ok := post.NormalizeTag(name)
+	if !ok {
+		return nil, errno.ErrTagNotFound
+	}
+
+	tag, err := b.ds.Tags().Get(ctx, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrTagNotFound
+		}
+
+		return nil, err
+	}
+
+	return tag, nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package user
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/event"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/webhook"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// Follow 是 UserBiz 接口中 `Follow` 方法的实现. 重复关注不会报错.
+// 首次关注时将 followee 最近的博客写入 username 的时间线，发布 user.followed 事件，并向 followee 的 webhook 发送该事件.
+func (b *userBiz) Follow(ctx context.Context, username, followee string) error {
+	if username == followee {
+		return errno.ErrFollowSelf
+	}
+
+	if err := b.checkUser(ctx, followee); err != nil {
+		return err
+	}
+
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		created, err := b.ds.Follows().Create(ctx, &model.FollowM{Username: username, Followee: followee})
+		if err != nil || !created {
+			return err
+		}
+
+		if _, err := b.ds.Timelines().Backfill(ctx, username, followee); err != nil {
+			return err
+		}
+
+		if err := event.Publish(ctx, b.ds, event.UserFollowed, event.Follow{Username: username, Followee: followee}); err != nil {
+			return err
+		}
+
+		return webhook.Publish(ctx, b.ds, followee, webhook.EventUserFollowed, map[string]string{"username": followee, "follower": username})
+	})
+}
+
+// Unfollow 是 UserBiz 接口中 `Unfollow` 方法的实现. 未关注时不会报错.
+// 取消关注时从 username 的时间线中删除 followee 的博客，发布 user.unfollowed 事件，并向 followee 的 webhook 发送该事件.
+func (b *userBiz) Unfollow(ctx context.Context, username, followee string) error {
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		deleted, err := b.ds.Follows().Delete(ctx, username, followee)
+		if err != nil || !deleted {
+			return err
+		}
+
+		if _, err := b.ds.Timelines().Remove(ctx, username, followee); err != nil {
+			return err
+		}
+
+		if err := event.Publish(ctx, b.ds, event.UserUnfollowed, event.Follow{Username: username, Followee: followee}); err != nil {
+			return err
+		}
+
+		return webhook.Publish(ctx, b.ds, followee, webhook.EventUserUnfollowed, map[string]string{"username": followee, "follower": username})
+	})
+}
+
+// ListFollowers 是 UserBiz 接口中 `ListFollowers` 方法的实现.
+func (b *userBiz) ListFollowers(ctx context.Context, username string, r *v1.ListFollowRequest) (*v1.ListFollowResponse, error) {
+	return b.listFollows(ctx, username, r, b.ds.Follows().ListFollowers, func(f *model.FollowM) string { return f.Username })
+}
+
+// ListFollowing 是 UserBiz 接口中 `ListFollowing` 方法的实现.
+func (b *userBiz) ListFollowing(ctx context.Context, username string, r *v1.ListFollowRequest) (*v1.ListFollowResponse, error) {
+	return b.listFollows(ctx, username, r, b.ds.Follows().ListFollowing, func(f *model.FollowM) string { return f.Followee })
+}
+
+// listFollows 使用 list 分页查询 username 的关注记录，other 返回关注记录中另一方的用户名.
+func (b *userBiz) listFollows(
+	ctx context.Context,
+	username string,
+	r *v1.ListFollowRequest,
+	list func(context.Context, string, *store.ListOptions) (int64, []*model.FollowM, error),
+	other func(*model.FollowM) string,
+) (*v1.ListFollowResponse, error) {
+	if err := b.checkUser(ctx, username); err != nil {
+		return nil, err
+	}
+
+	opts, err := store.NewListOptions(r.Offset, r.Limit, r.PageToken, r.SkipTotalCount)
+	if err != nil {
+		return nil, errno.ErrPageTokenInvalid
+	}
+
+	count, follows, err := list(ctx, username, opts)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidCursor) {
+			return nil, errno.ErrPageTokenInvalid
+		}
+
+		log.C(ctx).Errorw("Failed to list follows from storage", "err", err)
+		return nil, err
+	}
+
+	// 存储层会多返回一条记录，用来判断是否还有下一页
+	var nextPageToken string
+	if len(follows) > opts.PageSize() {
+		follows = follows[:opts.PageSize()]
+		last := follows[len(follows)-1]
+		nextPageToken = store.NewCursor(opts, last.CreatedAt, last.ID).Encode()
+	}
+
+	users := make([]*v1.FollowInfo, 0, len(follows))
+	for _, f := range follows {
+		users = append(users, &v1.FollowInfo{Username: other(f), FollowedAt: f.CreatedAt.Format("2006-01-02 15:04:05")})
+	}
+
+	return &v1.ListFollowResponse{TotalCount: count, Users: users, NextPageToken: nextPageToken}, nil
+}
+
+// checkUser 检查用户是否存在，不存在时返回 errno.ErrUserNotFound.
+func (b *userBiz) checkUser(ctx context.Context, username string) error {
+	if _, err := b.ds.Users().Get(ctx, username); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrUserNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
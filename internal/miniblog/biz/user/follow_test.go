@@ -0,0 +1,99 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+func Test_userBiz_Follow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := store.NewMockUserStore(ctrl)
+	mockUserStore.EXPECT().Get(gomock.Any(), "colin").Return(fakeUser(2), nil).AnyTimes()
+	mockUserStore.EXPECT().Get(gomock.Any(), "nobody").Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+
+	// 第一次关注时添加记录，重复关注时不添加记录
+	mockFollowStore := store.NewMockFollowStore(ctrl)
+	gomock.InOrder(
+		mockFollowStore.EXPECT().Create(gomock.Any(), &model.FollowM{Username: "belm", Followee: "colin"}).Return(true, nil),
+		mockFollowStore.EXPECT().Create(gomock.Any(), gomock.Any()).Return(false, nil),
+	)
+	gomock.InOrder(
+		mockFollowStore.EXPECT().Delete(gomock.Any(), "belm", "colin").Return(true, nil),
+		mockFollowStore.EXPECT().Delete(gomock.Any(), "belm", "colin").Return(false, nil),
+	)
+
+	// 只有关注关系发生变化时才修改时间线
+	mockTimelineStore := store.NewMockTimelineStore(ctrl)
+	mockTimelineStore.EXPECT().Backfill(gomock.Any(), "belm", "colin").Return(int64(3), nil).Times(1)
+	mockTimelineStore.EXPECT().Remove(gomock.Any(), "belm", "colin").Return(int64(3), nil).Times(1)
+
+	// 关注和取消关注各向 colin 订阅了该事件的 webhook 投递一次
+	mockWebhookStore := store.NewMockWebhookStore(ctrl)
+	mockWebhookStore.EXPECT().List(gomock.Any(), "colin").Return([]*model.WebhookM{
+		{WebhookID: "webhook-1", Username: "colin", Events: "user.followed,user.unfollowed", Active: true},
+		{WebhookID: "webhook-2", Username: "colin", Events: "post.created", Active: true},
+		{WebhookID: "webhook-3", Username: "colin", Events: "*", Active: false},
+	}, nil).Times(2)
+
+	var events []string
+	mockDeliveryStore := store.NewMockDeliveryStore(ctrl)
+	mockDeliveryStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, delivery *model.WebhookDeliveryM) error {
+			assert.Equal(t, "webhook-1", delivery.WebhookID)
+			assert.Contains(t, delivery.Payload, `"follower":"belm"`)
+			events = append(events, delivery.Event)
+			return nil
+		},
+	).Times(2)
+
+	// 只有首次关注时发布 user.followed 事件，只有取消已有的关注时发布 user.unfollowed 事件
+	var published []string
+	mockEventStore := store.NewMockEventStore(ctrl)
+	mockEventStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, e *model.EventM) error {
+			assert.Equal(t, `{"username":"belm","followee":"colin"}`, e.Payload)
+			published = append(published, e.Type)
+			return nil
+		},
+	).Times(2)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Users().AnyTimes().Return(mockUserStore)
+	mockStore.EXPECT().Events().AnyTimes().Return(mockEventStore)
+	mockStore.EXPECT().Follows().AnyTimes().Return(mockFollowStore)
+	mockStore.EXPECT().Timelines().AnyTimes().Return(mockTimelineStore)
+	mockStore.EXPECT().Webhooks().AnyTimes().Return(mockWebhookStore)
+	mockStore.EXPECT().Deliveries().AnyTimes().Return(mockDeliveryStore)
+	mockStore.EXPECT().TX(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+
+	b := New(mockStore)
+	ctx := context.Background()
+
+	assert.Equal(t, errno.ErrFollowSelf, b.Follow(ctx, "belm", "belm"))
+	assert.Equal(t, errno.ErrUserNotFound, b.Follow(ctx, "belm", "nobody"))
+	assert.Nil(t, b.Follow(ctx, "belm", "colin"))
+	assert.Nil(t, b.Follow(ctx, "belm", "colin"))
+	assert.Nil(t, b.Unfollow(ctx, "belm", "colin"))
+	assert.Nil(t, b.Unfollow(ctx, "belm", "colin"))
+	assert.Equal(t, []string{"user.followed", "user.unfollowed"}, events)
+	assert.Equal(t, []string{"user.followed", "user.unfollowed"}, published)
+}
@@ -0,0 +1,367 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package user
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	// 内嵌时区数据库，没有安装时区数据的容器中也能校验和使用时区
+	_ "time/tzdata"
+
+	"golang.org/x/text/language"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/thumbnail"
+	"github.com/marmotedu/miniblog/pkg/util/id"
+)
+
+const (
+	// MaxLinks 是个人资料中链接的最大数量.
+	MaxLinks = 5
+
+	// MaxAvatarSize 是上传的头像文件的最大字节数.
+	MaxAvatarSize = 5 << 20
+
+	// AvatarSize 是头像的边长（像素），上传的图片会被截取为正方形并缩小到该尺寸.
+	AvatarSize = 256
+
+	// AvatarURLPrefix 是头像公开地址的前缀.
+	AvatarURLPrefix = "/v1/public/avatars/"
+
+	// maxLinkLength 是个人资料中每个链接的最大长度.
+	maxLinkLength = 255
+)
+
+// avatarTypes 是头像文件扩展名对应的 Content-Type，头像统一编码为 JPEG 或 PNG 格式.
+var avatarTypes = map[string]string{".jpg": "image/jpeg", ".png": "image/png"}
+
+// avatarPattern 匹配头像文件名，防止通过文件名访问其他对象.
+var avatarPattern = regexp.MustCompile(`^avatar-[A-Za-z0-9]+\.(jpg|png)$`)
+
+// Avatar 是头像文件的内容，调用者需要关闭.
+type Avatar struct {
+	io.ReadCloser
+	ContentType string
+}
+
+// GetProfile 是 UserBiz 接口中 `GetProfile` 方法的实现，返回不需要认证就可以查看的公开资料.
+// 联系方式和时区只有在用户选择公开时才返回.
+func (b *userBiz) GetProfile(ctx context.Context, username string) (*v1.GetProfileResponse, error) {
+	user, err := b.ds.Users().Get(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	resp := &v1.GetProfileResponse{
+		Username:  user.Username,
+		Nickname:  user.Nickname,
+		Bio:       user.Bio,
+		AvatarURL: avatarURL(user.Avatar),
+		Links:     splitLinks(user.Links),
+		Locale:    user.Locale,
+		CreatedAt: user.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if user.ShowEmail {
+		resp.Email = user.Email
+	}
+
+	if user.ShowPhone {
+		resp.Phone = user.Phone
+	}
+
+	if user.ShowTimezone {
+		resp.Timezone = user.Timezone
+	}
+
+	if resp.FollowerCount, resp.FollowingCount, err = b.ds.Follows().Count(ctx, username); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// UploadAvatar 是 UserBiz 接口中 `UploadAvatar` 方法的实现.
+// 图片被截取为居中的正方形并缩小到 AvatarSize 后重新编码，每次上传使用新的文件名，旧的头像在更新用户记录后删除.
+func (b *userBiz) UploadAvatar(ctx context.Context, username string, r io.Reader) (*v1.UploadAvatarResponse, error) {
+	user, err := b.ds.Users().Get(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxAvatarSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > MaxAvatarSize {
+		return nil, errno.ErrAvatarTooLarge
+	}
+
+	switch http.DetectContentType(data) {
+	case "image/png", "image/jpeg", "image/gif":
+	default:
+		return nil, errno.ErrAvatarInvalid
+	}
+
+	avatar, contentType, err := thumbnail.Square(data, AvatarSize)
+	if err != nil {
+		return nil, errno.ErrAvatarInvalid
+	}
+
+	ext := ".png"
+	if contentType == "image/jpeg" {
+		ext = ".jpg"
+	}
+
+	old := user.Avatar
+	user.Avatar = "avatar-" + id.GenShortID() + ext
+	if err := b.ds.Blobs().Put(ctx, avatarKey(user.Avatar), bytes.NewReader(avatar), int64(len(avatar)), contentType); err != nil {
+		return nil, err
+	}
+
+	if err := b.ds.Users().Update(ctx, user); err != nil {
+		b.deleteAvatar(ctx, user.Avatar)
+		return nil, err
+	}
+
+	b.deleteAvatar(ctx, old)
+
+	return &v1.UploadAvatarResponse{AvatarURL: avatarURL(user.Avatar)}, nil
+}
+
+// DeleteAvatar 是 UserBiz 接口中 `DeleteAvatar` 方法的实现，用户没有头像时不做任何修改.
+func (b *userBiz) DeleteAvatar(ctx context.Context, username string) error {
+	user, err := b.ds.Users().Get(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrUserNotFound
+		}
+
+		return err
+	}
+
+	if user.Avatar == "" {
+		return nil
+	}
+
+	old := user.Avatar
+	user.Avatar = ""
+	if err := b.ds.Users().Update(ctx, user); err != nil {
+		return err
+	}
+
+	b.deleteAvatar(ctx, old)
+
+	return nil
+}
+
+// OpenAvatar 是 UserBiz 接口中 `OpenAvatar` 方法的实现，返回头像文件的内容.
+func (b *userBiz) OpenAvatar(ctx context.Context, avatar string) (*Avatar, error) {
+	if !avatarPattern.MatchString(avatar) {
+		return nil, errno.ErrAvatarNotFound
+	}
+
+	rc, err := b.ds.Blobs().Get(ctx, avatarKey(avatar))
+	if err != nil {
+		if errors.Is(err, store.ErrBlobNotFound) {
+			return nil, errno.ErrAvatarNotFound
+		}
+
+		return nil, err
+	}
+
+	return &Avatar{ReadCloser: rc, ContentType: avatarTypes[avatar[strings.LastIndex(avatar, "."):]]}, nil
+}
+
+// deleteAvatar 删除不再使用的头像文件，删除失败只记录日志，不影响请求的结果.
+func (b *userBiz) deleteAvatar(ctx context.Context, avatar string) {
+	if avatar == "" {
+		return
+	}
+
+	if err := b.ds.Blobs().Delete(ctx, avatarKey(avatar)); err != nil {
+		log.C(ctx).Errorw("Failed to delete avatar", "avatar", avatar, "err", err)
+	}
+}
+
+// updateProfile 校验并修改 r 中指定的个人资料和隐私设置.
+func updateProfile(user *model.UserM, r *v1.UpdateUserRequest) error {
+	if r.Bio != nil {
+		user.Bio = strings.TrimSpace(*r.Bio)
+	}
+
+	if r.Links != nil {
+		links, err := normalizeLinks(*r.Links)
+		if err != nil {
+			return err
+		}
+
+		user.Links = links
+	}
+
+	if r.Timezone != nil {
+		timezone, err := normalizeTimezone(*r.Timezone)
+		if err != nil {
+			return err
+		}
+
+		user.Timezone = timezone
+	}
+
+	if r.Locale != nil {
+		locale, err := normalizeLocale(*r.Locale)
+		if err != nil {
+			return err
+		}
+
+		user.Locale = locale
+	}
+
+	if r.ShowEmail != nil {
+		user.ShowEmail = *r.ShowEmail
+	}
+
+	if r.ShowPhone != nil {
+		user.ShowPhone = *r.ShowPhone
+	}
+
+	if r.ShowTimezone != nil {
+		user.ShowTimezone = *r.ShowTimezone
+	}
+
+	return nil
+}
+
+// normalizeLinks 校验链接并返回换行分隔的链接列表，空链接会被忽略.
+func normalizeLinks(links []string) (string, error) {
+	var ret []string
+	for _, link := range links {
+		link = strings.TrimSpace(link)
+		if link == "" {
+			continue
+		}
+
+		u, err := url.Parse(link)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(link) > maxLinkLength {
+			return "", errno.ErrProfileLinkInvalid
+		}
+
+		ret = append(ret, link)
+	}
+
+	if len(ret) > MaxLinks {
+		return "", errno.ErrProfileLinkInvalid
+	}
+
+	return strings.Join(ret, "\n"), nil
+}
+
+// normalizeTimezone 校验 IANA 时区名，空字符串表示清除时区.
+func normalizeTimezone(timezone string) (string, error) {
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" {
+		return "", nil
+	}
+
+	// Local 是服务器所在的时区，对用户没有意义
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "Local" {
+		return "", errno.ErrTimezoneInvalid
+	}
+
+	return loc.String(), nil
+}
+
+// normalizeLocale 校验 BCP 47 语言标签并返回规范形式，例如 zh-cn 规范为 zh-CN，空字符串表示清除语言.
+func normalizeLocale(locale string) (string, error) {
+	locale = strings.TrimSpace(locale)
+	if locale == "" {
+		return "", nil
+	}
+
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", errno.ErrLocaleInvalid
+	}
+
+	return tag.String(), nil
+}
+
+// userInfo 将用户记录转换为返回给用户本人和管理员的详细信息，不包含统计数据.
+func userInfo(user *model.UserM) *v1.UserInfo {
+	info := &v1.UserInfo{
+		Username:     user.Username,
+		Nickname:     user.Nickname,
+		Email:        user.Email,
+		Phone:        user.Phone,
+		Bio:          user.Bio,
+		AvatarURL:    avatarURL(user.Avatar),
+		Links:        splitLinks(user.Links),
+		Timezone:     user.Timezone,
+		Locale:       user.Locale,
+		ShowEmail:    user.ShowEmail,
+		ShowPhone:    user.ShowPhone,
+		ShowTimezone: user.ShowTimezone,
+		State:        user.EffectiveState(time.Now()),
+		CreatedAt:    user.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:    user.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	// 账号恢复正常后，之前的停用原因和截止时间不再有意义
+	if info.State != model.UserStateActive {
+		info.StateReason = user.StateReason
+		if user.StateExpiresAt != nil {
+			info.StateExpiresAt = user.StateExpiresAt.Format("2006-01-02 15:04:05")
+		}
+	}
+
+	return info
+}
+
+// splitLinks 将换行分隔的链接拆分为列表，没有链接时返回空列表.
+func splitLinks(links string) []string {
+	if links == "" {
+		return []string{}
+	}
+
+	return strings.Split(links, "\n")
+}
+
+// avatarURL 返回头像的公开地址，没有头像时返回空字符串.
+func avatarURL(avatar string) string {
+	if avatar == "" {
+		return ""
+	}
+
+	return AvatarURLPrefix + avatar
+}
+
+// avatarKey 返回头像文件在对象存储中的 key.
+func avatarKey(avatar string) string {
+	return "avatars/" + avatar
+}
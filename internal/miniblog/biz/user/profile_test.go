@@ -0,0 +1,172 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package user
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+func Test_updateProfile(t *testing.T) {
+	user := fakeUser(1)
+	err := updateProfile(user, &v1.UpdateUserRequest{
+		Bio:          pointer.ToString(" Hello "),
+		Links:        &[]string{"https://example.com", " ", "http://example.com/blog"},
+		Timezone:     pointer.ToString("Asia/Shanghai"),
+		Locale:       pointer.ToString("zh-cn"),
+		ShowEmail:    pointer.ToBool(true),
+		ShowTimezone: pointer.ToBool(true),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Hello", user.Bio)
+	assert.Equal(t, "https://example.com\nhttp://example.com/blog", user.Links)
+	assert.Equal(t, "Asia/Shanghai", user.Timezone)
+	assert.Equal(t, "zh-CN", user.Locale)
+	assert.True(t, user.ShowEmail)
+	assert.False(t, user.ShowPhone)
+
+	// The fields which are not given are kept, empty values clear them.
+	assert.Nil(t, updateProfile(user, &v1.UpdateUserRequest{Timezone: pointer.ToString(""), Links: &[]string{}}))
+	assert.Equal(t, "", user.Timezone)
+	assert.Equal(t, "", user.Links)
+	assert.Equal(t, "zh-CN", user.Locale)
+
+	tests := []struct {
+		r    *v1.UpdateUserRequest
+		want error
+	}{
+		{&v1.UpdateUserRequest{Links: &[]string{"javascript:alert(1)"}}, errno.ErrProfileLinkInvalid},
+		{&v1.UpdateUserRequest{Links: &[]string{"https://"}}, errno.ErrProfileLinkInvalid},
+		{&v1.UpdateUserRequest{Links: &[]string{"https://example.com/" + strings.Repeat("x", maxLinkLength)}}, errno.ErrProfileLinkInvalid},
+		{&v1.UpdateUserRequest{Links: &[]string{"https://a.com", "https://b.com", "https://c.com", "https://d.com", "https://e.com", "https://f.com"}}, errno.ErrProfileLinkInvalid},
+		{&v1.UpdateUserRequest{Timezone: pointer.ToString("Mars/Olympus")}, errno.ErrTimezoneInvalid},
+		{&v1.UpdateUserRequest{Timezone: pointer.ToString("Local")}, errno.ErrTimezoneInvalid},
+		{&v1.UpdateUserRequest{Locale: pointer.ToString("not a locale")}, errno.ErrLocaleInvalid},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, updateProfile(fakeUser(1), tt.r))
+	}
+}
+
+func Test_userBiz_GetProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := fakeUser(1)
+	user.Bio, user.Avatar, user.Links, user.Timezone = "Hello", "avatar-abc.jpg", "https://example.com", "Asia/Shanghai"
+	user.ShowPhone = true
+
+	mockUserStore := store.NewMockUserStore(ctrl)
+	mockUserStore.EXPECT().Get(gomock.Any(), "belm1").Return(user, nil).AnyTimes()
+	mockFollowStore := store.NewMockFollowStore(ctrl)
+	mockFollowStore.EXPECT().Count(gomock.Any(), "belm1").Return(int64(2), int64(3), nil).AnyTimes()
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Users().AnyTimes().Return(mockUserStore)
+	mockStore.EXPECT().Follows().AnyTimes().Return(mockFollowStore)
+
+	resp, err := New(mockStore).GetProfile(context.Background(), "belm1")
+	assert.Nil(t, err)
+	assert.Equal(t, &v1.GetProfileResponse{
+		Username:       "belm1",
+		Nickname:       "belm1",
+		Bio:            "Hello",
+		AvatarURL:      "/v1/public/avatars/avatar-abc.jpg",
+		Links:          []string{"https://example.com"},
+		Phone:          "18188888xxx",
+		FollowerCount:  2,
+		FollowingCount: 3,
+		CreatedAt:      user.CreatedAt.Format("2006-01-02 15:04:05"),
+	}, resp)
+}
+
+func Test_userBiz_UploadAvatar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := fakeUser(1)
+	user.Avatar = "avatar-old.png"
+
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 600, 400)))
+
+	mockUserStore := store.NewMockUserStore(ctrl)
+	mockUserStore.EXPECT().Get(gomock.Any(), "belm1").Return(user, nil).AnyTimes()
+	mockUserStore.EXPECT().Update(gomock.Any(), user).Return(nil).Times(1)
+
+	// The new avatar is stored before the user is updated, the old one is deleted afterwards.
+	var stored string
+	mockBlobStore := store.NewMockBlobStore(ctrl)
+	gomock.InOrder(
+		mockBlobStore.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "image/png").DoAndReturn(
+			func(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+				stored = key
+
+				return nil
+			}),
+		mockBlobStore.EXPECT().Delete(gomock.Any(), "avatars/avatar-old.png").Return(nil),
+	)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Users().AnyTimes().Return(mockUserStore)
+	mockStore.EXPECT().Blobs().AnyTimes().Return(mockBlobStore)
+
+	b := New(mockStore)
+	ctx := context.Background()
+
+	resp, err := b.UploadAvatar(ctx, "belm1", bytes.NewReader(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, "avatars/"+user.Avatar, stored)
+	assert.Equal(t, AvatarURLPrefix+user.Avatar, resp.AvatarURL)
+	assert.True(t, avatarPattern.MatchString(user.Avatar))
+
+	_, err = b.UploadAvatar(ctx, "belm1", strings.NewReader("<svg></svg>"))
+	assert.Equal(t, errno.ErrAvatarInvalid, err)
+
+	_, err = b.UploadAvatar(ctx, "belm1", bytes.NewReader(make([]byte, MaxAvatarSize+1)))
+	assert.Equal(t, errno.ErrAvatarTooLarge, err)
+
+	_, err = b.OpenAvatar(ctx, "../media/media-1")
+	assert.Equal(t, errno.ErrAvatarNotFound, err)
+}
+
+func Test_userBiz_DeleteAvatar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	withAvatar, withoutAvatar := fakeUser(1), fakeUser(2)
+	withAvatar.Avatar = "avatar-abc.jpg"
+
+	mockUserStore := store.NewMockUserStore(ctrl)
+	mockUserStore.EXPECT().Get(gomock.Any(), "belm1").Return(withAvatar, nil).AnyTimes()
+	mockUserStore.EXPECT().Get(gomock.Any(), "belm2").Return(withoutAvatar, nil).AnyTimes()
+	mockUserStore.EXPECT().Update(gomock.Any(), withAvatar).Return(nil).Times(1)
+
+	mockBlobStore := store.NewMockBlobStore(ctrl)
+	mockBlobStore.EXPECT().Delete(gomock.Any(), "avatars/avatar-abc.jpg").Return(nil).Times(1)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Users().AnyTimes().Return(mockUserStore)
+	mockStore.EXPECT().Blobs().AnyTimes().Return(mockBlobStore)
+
+	b := New(mockStore)
+	assert.Nil(t, b.DeleteAvatar(context.Background(), "belm1"))
+	assert.Equal(t, "", withAvatar.Avatar)
+	assert.Nil(t, b.DeleteAvatar(context.Background(), "belm2"))
+}
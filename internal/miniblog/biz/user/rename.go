@@ -0,0 +1,186 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/event"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/webhook"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/token"
+)
+
+// DefaultRenameCooldown 是旧用户名默认的保留时长.
+const DefaultRenameCooldown = 30 * 24 * time.Hour
+
+// renameCooldown 是旧用户名的保留时长，也是用户两次修改用户名之间的最短间隔，可以通过 Init 修改.
+var renameCooldown = DefaultRenameCooldown
+
+// Init 设置旧用户名的保留时长，不是正数时使用默认值.
+func Init(cooldown time.Duration) {
+	if cooldown > 0 {
+		renameCooldown = cooldown
+	}
+}
+
+// Rename 是 UserBiz 接口中 `Rename` 方法的实现.
+// 在同一个事务中将用户的博客、评论、关注关系、授权策略等所有数据转移到新用户名下，旧用户名在冷却期内为用户保留，
+// 访问旧用户名的公开接口会被重定向到新用户名. 用户在冷却期内只能修改一次自己的用户名，管理员修改时不受限制.
+func (b *userBiz) Rename(ctx context.Context, username string, r *v1.RenameUserRequest) (*v1.RenameUserResponse, error) {
+	from, to := username, r.Username
+	if from == to {
+		return nil, errno.ErrUserAlreadyExist
+	}
+
+	user, err := b.getUser(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	self := operator(ctx) == from
+	if self {
+		aliases, err := b.ds.Aliases().List(ctx, from)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, alias := range aliases {
+			if alias.CreatedAt.Add(renameCooldown).After(now) {
+				return nil, errno.ErrRenameTooFrequent
+			}
+		}
+	}
+
+	if err := b.available(ctx, to, from, now); err != nil {
+		return nil, err
+	}
+
+	err = b.ds.TX(ctx, func(ctx context.Context) error {
+		// 用户记录最先更新，新用户名已经被并发的请求使用时，唯一索引冲突会使整个事务回滚
+		user.Username = to
+		if err := b.ds.Users().Update(ctx, user); err != nil {
+			return err
+		}
+
+		for _, update := range []func(ctx context.Context, from, to string) (int64, error){
+			b.ds.Posts().UpdateUsername,
+			b.ds.Revisions().UpdateUsername,
+			b.ds.Comments().UpdateUsername,
+			b.ds.Reactions().UpdateUsername,
+			b.ds.Media().UpdateUsername,
+			b.ds.Follows().UpdateUsername,
+			b.ds.Timelines().UpdateUsername,
+			b.ds.Imports().UpdateUsername,
+			b.ds.Webhooks().UpdateUsername,
+			b.ds.Deliveries().UpdateUsername,
+			b.ds.Notifications().UpdateUsername,
+			b.ds.Aliases().UpdateUsername,
+		} {
+			if _, err := update(ctx, from, to); err != nil {
+				return err
+			}
+		}
+
+		if err := b.ds.Policies().UpdateSubject(ctx, from, to); err != nil {
+			return err
+		}
+
+		if err := b.ds.Policies().UpdateObject(ctx, "/v1/users/"+from, "/v1/users/"+to); err != nil {
+			return err
+		}
+
+		alias := &model.UserAliasM{Alias: from, Username: to, ExpiresAt: now.Add(renameCooldown), CreatedAt: now}
+		if err := b.ds.Aliases().Create(ctx, alias); err != nil {
+			return err
+		}
+
+		// 博客搜索索引由 user.renamed 事件的处理函数同步
+		if err := event.Publish(ctx, b.ds, event.UserRenamed, event.Rename{Username: from, NewUsername: to}); err != nil {
+			return err
+		}
+
+		data := map[string]string{"username": to, "oldUsername": from}
+		if err := webhook.Publish(ctx, b.ds, to, webhook.EventUserRenamed, data); err != nil {
+			return err
+		}
+
+		detail, _ := json.Marshal(map[string]string{"from": from, "to": to})
+
+		return b.ds.AuditLogs().Create(ctx, &model.AuditLogM{
+			Operator: operator(ctx),
+			Action:   "RenameUser",
+			Resource: "users/" + to,
+			Detail:   string(detail),
+		})
+	})
+	if err != nil {
+		if match, _ := regexp.MatchString("Duplicate entry '.*' for key 'username'", err.Error()); match {
+			return nil, errno.ErrUserAlreadyExist
+		}
+
+		return nil, err
+	}
+
+	resp := &v1.RenameUserResponse{Username: to}
+	if self {
+		// 旧用户名签发的 token 已经失效，为用户签发新的 token 以免需要重新登录
+		if resp.Token, err = token.Sign(to); err != nil {
+			return nil, errno.ErrSignToken
+		}
+	}
+
+	return resp, nil
+}
+
+// ResolveAlias 是 UserBiz 接口中 `ResolveAlias` 方法的实现.
+// 返回当前使用旧用户名 alias 的用户，alias 不是保留期内的旧用户名时返回空字符串.
+func (b *userBiz) ResolveAlias(ctx context.Context, alias string) (string, error) {
+	username, err := b.ds.Aliases().Resolve(ctx, alias, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return username, nil
+}
+
+// available 检查 username 是否可以被用户 owner 使用，owner 为空表示创建新用户.
+// 用户名不能已经被其他用户使用，也不能是其他用户在保留期内的旧用户名.
+func (b *userBiz) available(ctx context.Context, username, owner string, now time.Time) error {
+	if _, err := b.ds.Users().Get(ctx, username); err == nil {
+		return errno.ErrUserAlreadyExist
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	reserver, err := b.ds.Aliases().Resolve(ctx, username, now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if reserver != owner {
+		return errno.ErrUsernameReserved
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/token"
+)
+
+// newRenameStore 返回一个 mock IStore，用户 belm1 存在，用户 colin 已经被使用，用户名 alice 是 belm2 的旧用户名，
+// 用户名 bob 是 belm1 的旧用户名. aliases 是 belm1 的旧用户名记录.
+func newRenameStore(ctrl *gomock.Controller, aliases []*model.UserAliasM) (*store.MockIStore, *store.MockAliasStore) {
+	mockUserStore := store.NewMockUserStore(ctrl)
+	mockUserStore.EXPECT().Get(gomock.Any(), "belm1").Return(fakeUser(1), nil).AnyTimes()
+	mockUserStore.EXPECT().Get(gomock.Any(), "colin").Return(fakeUser(2), nil).AnyTimes()
+	mockUserStore.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+
+	mockAliasStore := store.NewMockAliasStore(ctrl)
+	mockAliasStore.EXPECT().List(gomock.Any(), "belm1").Return(aliases, nil).AnyTimes()
+	mockAliasStore.EXPECT().Resolve(gomock.Any(), "alice", gomock.Any()).Return("belm2", nil).AnyTimes()
+	mockAliasStore.EXPECT().Resolve(gomock.Any(), "bob", gomock.Any()).Return("belm1", nil).AnyTimes()
+	mockAliasStore.EXPECT().Resolve(gomock.Any(), gomock.Any(), gomock.Any()).Return("", gorm.ErrRecordNotFound).AnyTimes()
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Users().AnyTimes().Return(mockUserStore)
+	mockStore.EXPECT().Aliases().AnyTimes().Return(mockAliasStore)
+	mockStore.EXPECT().TX(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+
+	return mockStore, mockAliasStore
+}
+
+func Test_userBiz_Rename_unavailable(t *testing.T) {
+	ctx := context.WithValue(context.Background(), known.XUsernameKey, "belm1")
+	recent := []*model.UserAliasM{{Alias: "bob", Username: "belm1", CreatedAt: time.Now().Add(-time.Hour)}}
+
+	tests := []struct {
+		name     string
+		aliases  []*model.UserAliasM
+		username string
+		want     error
+	}{
+		{name: "same", username: "belm1", want: errno.ErrUserAlreadyExist},
+		{name: "taken", username: "colin", want: errno.ErrUserAlreadyExist},
+		{name: "reserved", username: "alice", want: errno.ErrUsernameReserved},
+		{name: "too frequent", aliases: recent, username: "belm3", want: errno.ErrRenameTooFrequent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore, _ := newRenameStore(ctrl, tt.aliases)
+			got, err := New(mockStore).Rename(ctx, "belm1", &v1.RenameUserRequest{Username: tt.username})
+			assert.Nil(t, got)
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
+
+func Test_userBiz_Rename(t *testing.T) {
+	old := []*model.UserAliasM{{Alias: "bob", Username: "belm1", CreatedAt: time.Now().Add(-2 * DefaultRenameCooldown)}}
+
+	tests := []struct {
+		name      string
+		operator  string
+		aliases   []*model.UserAliasM
+		username  string
+		wantToken bool
+	}{
+		{name: "self", operator: "belm1", aliases: old, username: "belm3", wantToken: true},
+		{name: "reclaim", operator: "belm1", aliases: old, username: "bob", wantToken: true},
+		{name: "root", operator: "root", username: "belm3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore, mockAliasStore := newRenameStore(ctrl, tt.aliases)
+			from, to := "belm1", tt.username
+
+			mockStore.Users().(*store.MockUserStore).EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, user *model.UserM) error {
+					assert.Equal(t, to, user.Username)
+					return nil
+				},
+			).Times(1)
+
+			mockPostStore := store.NewMockPostStore(ctrl)
+			mockPostStore.EXPECT().UpdateUsername(gomock.Any(), from, to).Return(int64(1), nil).Times(1)
+			mockRevisionStore := store.NewMockRevisionStore(ctrl)
+			mockRevisionStore.EXPECT().UpdateUsername(gomock.Any(), from, to).Return(int64(1), nil).Times(1)
+			mockCommentStore := store.NewMockCommentStore(ctrl)
+			mockCommentStore.EXPECT().UpdateUsername(gomock.Any(), from, to).Return(int64(1), nil).Times(1)
+			mockReactionStore := store.NewMockReactionStore(ctrl)
+			mockReactionStore.EXPECT().UpdateUsername(gomock.Any(), from, to).Return(int64(1), nil).Times(1)
+			mockMediaStore := store.NewMockMediaStore(ctrl)
+			mockMediaStore.EXPECT().UpdateUsername(gomock.Any(), from, to).Return(int64(1), nil).Times(1)
+			mockFollowStore := store.NewMockFollowStore(ctrl)
+			mockFollowStore.EXPECT().UpdateUsername(gomock.Any(), from, to).Return(int64(1), nil).Times(1)
+			mockTimelineStore := store.NewMockTimelineStore(ctrl)
+			mockTimelineStore.EXPECT().UpdateUsername(gomock.Any(), from, to).Return(int64(1), nil).Times(1)
+			mockImportStore := store.NewMockImportStore(ctrl)
+			mockImportStore.EXPECT().UpdateUsername(gomock.Any(), from, to).Return(int64(1), nil).Times(1)
+			mockWebhookStore := store.NewMockWebhookStore(ctrl)
+			mockWebhookStore.EXPECT().UpdateUsername(gomock.Any(), from, to).Return(int64(1), nil).Times(1)
+			mockWebhookStore.EXPECT().List(gomock.Any(), to).Return(nil, nil).Times(1)
+			mockDeliveryStore := store.NewMockDeliveryStore(ctrl)
+			mockDeliveryStore.EXPECT().UpdateUsername(gomock.Any(), from, to).Return(int64(1), nil).Times(1)
+			mockNotificationStore := store.NewMockNotificationStore(ctrl)
+			mockNotificationStore.EXPECT().UpdateUsername(gomock.Any(), from, to).Return(int64(1), nil).Times(1)
+
+			mockPolicyStore := store.NewMockPolicyStore(ctrl)
+			mockPolicyStore.EXPECT().UpdateSubject(gomock.Any(), from, to).Return(nil).Times(1)
+			mockPolicyStore.EXPECT().UpdateObject(gomock.Any(), "/v1/users/"+from, "/v1/users/"+to).Return(nil).Times(1)
+
+			mockAliasStore.EXPECT().UpdateUsername(gomock.Any(), from, to).Return(int64(1), nil).Times(1)
+			mockAliasStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, alias *model.UserAliasM) error {
+					assert.Equal(t, from, alias.Alias)
+					assert.Equal(t, to, alias.Username)
+					assert.Equal(t, DefaultRenameCooldown, alias.ExpiresAt.Sub(alias.CreatedAt))
+					return nil
+				},
+			).Times(1)
+
+			// 博客搜索索引由 user.renamed 事件的处理函数同步
+			mockEventStore := store.NewMockEventStore(ctrl)
+			mockEventStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, e *model.EventM) error {
+					var r struct{ Username, NewUsername string }
+					assert.Equal(t, "user.renamed", e.Type)
+					assert.Nil(t, json.Unmarshal([]byte(e.Payload), &r))
+					assert.Equal(t, from, r.Username)
+					assert.Equal(t, to, r.NewUsername)
+					return nil
+				},
+			).Times(1)
+
+			mockAuditLogStore := store.NewMockAuditLogStore(ctrl)
+			mockAuditLogStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, log *model.AuditLogM) error {
+					assert.Equal(t, tt.operator, log.Operator)
+					assert.Equal(t, "RenameUser", log.Action)
+					return nil
+				},
+			).Times(1)
+
+			mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+			mockStore.EXPECT().Revisions().AnyTimes().Return(mockRevisionStore)
+			mockStore.EXPECT().Comments().AnyTimes().Return(mockCommentStore)
+			mockStore.EXPECT().Reactions().AnyTimes().Return(mockReactionStore)
+			mockStore.EXPECT().Media().AnyTimes().Return(mockMediaStore)
+			mockStore.EXPECT().Follows().AnyTimes().Return(mockFollowStore)
+			mockStore.EXPECT().Timelines().AnyTimes().Return(mockTimelineStore)
+			mockStore.EXPECT().Imports().AnyTimes().Return(mockImportStore)
+			mockStore.EXPECT().Webhooks().AnyTimes().Return(mockWebhookStore)
+			mockStore.EXPECT().Deliveries().AnyTimes().Return(mockDeliveryStore)
+			mockStore.EXPECT().Notifications().AnyTimes().Return(mockNotificationStore)
+			mockStore.EXPECT().Policies().AnyTimes().Return(mockPolicyStore)
+			mockStore.EXPECT().Events().AnyTimes().Return(mockEventStore)
+			mockStore.EXPECT().AuditLogs().AnyTimes().Return(mockAuditLogStore)
+
+			ctx := context.WithValue(context.Background(), known.XUsernameKey, tt.operator)
+			got, err := New(mockStore).Rename(ctx, from, &v1.RenameUserRequest{Username: to})
+			assert.Nil(t, err)
+			assert.Equal(t, to, got.Username)
+
+			if !tt.wantToken {
+				assert.Empty(t, got.Token)
+				return
+			}
+
+			username, err := token.Parse(got.Token, "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5")
+			assert.Nil(t, err)
+			assert.Equal(t, to, username)
+		})
+	}
+}
+
+func Test_userBiz_ResolveAlias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore, _ := newRenameStore(ctrl, nil)
+	b := New(mockStore)
+
+	got, err := b.ResolveAlias(context.Background(), "bob")
+	assert.Nil(t, err)
+	assert.Equal(t, "belm1", got)
+
+	got, err = b.ResolveAlias(context.Background(), "belm1")
+	assert.Nil(t, err)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,223 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/webhook"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/auth"
+)
+
+const (
+	// tempPasswordLength 是管理员重置密码时生成的临时密码的长度，需要满足修改密码接口对旧密码的长度要求.
+	tempPasswordLength = 12
+
+	// tempPasswordAlphabet 是临时密码使用的字符，去掉了容易混淆的 0、O、1、l 和 I.
+	tempPasswordAlphabet = "23456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+)
+
+// CheckAccount 是 UserBiz 接口中 `CheckAccount` 方法的实现.
+// 用户不存在、token 在用户创建或 TokensRevokedAt 之前签发，或者账号已被停用、需要重置密码时返回错误.
+// 用户名被修改或用户被删除后，用户名可能被其他用户使用，在新用户创建之前签发的 token 不能用于新用户.
+func (b *userBiz) CheckAccount(ctx context.Context, username string, issuedAt time.Time) error {
+	user, err := b.ds.Users().Get(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrTokenInvalid
+		}
+
+		return err
+	}
+
+	// token 的签发时间只精确到秒
+	if issuedAt.Before(user.CreatedAt.Truncate(time.Second)) {
+		return errno.ErrTokenInvalid
+	}
+
+	if user.TokensRevokedAt != nil && issuedAt.Before(user.TokensRevokedAt.Truncate(time.Second)) {
+		return errno.ErrTokenInvalid
+	}
+
+	return checkState(user, time.Now())
+}
+
+// Suspend 是 UserBiz 接口中 `Suspend` 方法的实现.
+// 停用期间用户不能登录，已经签发的 token 也不能访问需要认证的接口，停用到期后自动恢复.
+func (b *userBiz) Suspend(ctx context.Context, username string, r *v1.SuspendUserRequest) error {
+	if username == operator(ctx) {
+		return errno.ErrSuspendSelf
+	}
+
+	now := time.Now()
+
+	var until *time.Time
+	if r.Until != "" {
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", r.Until, time.Local)
+		if err != nil || !t.After(now) {
+			return errno.ErrSuspendUntilInvalid
+		}
+
+		until = &t
+	}
+
+	user, err := b.getUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	user.State, user.StateReason, user.StateExpiresAt = model.UserStateSuspended, r.Reason, until
+
+	detail := map[string]interface{}{"reason": r.Reason, "until": r.Until}
+
+	return b.updateState(ctx, user, "SuspendUser", detail)
+}
+
+// Unsuspend 是 UserBiz 接口中 `Unsuspend` 方法的实现.
+// 停用和需要重置密码的账号都会恢复正常，需要重置密码的账号恢复后可以继续使用临时密码登录.
+func (b *userBiz) Unsuspend(ctx context.Context, username string) error {
+	user, err := b.getUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	detail := map[string]interface{}{"from": user.State}
+	user.State, user.StateReason, user.StateExpiresAt = model.UserStateActive, "", nil
+
+	return b.updateState(ctx, user, "UnsuspendUser", detail)
+}
+
+// ResetPassword 是 UserBiz 接口中 `ResetPassword` 方法的实现.
+// 将用户的密码重置为随机生成的临时密码，并吊销用户已经签发的所有 token. 用户需要使用临时密码修改密码后才能登录.
+func (b *userBiz) ResetPassword(ctx context.Context, username string) (*v1.ResetPasswordResponse, error) {
+	if username == operator(ctx) {
+		return nil, errno.ErrSuspendSelf
+	}
+
+	user, err := b.getUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	// 重置密码会覆盖账号状态，需要先恢复被停用的账号，避免意外解除停用
+	now := time.Now()
+	if user.EffectiveState(now) == model.UserStateSuspended {
+		return nil, errno.ErrUserSuspended
+	}
+
+	password, err := tempPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Password, err = auth.Encrypt(password); err != nil {
+		return nil, err
+	}
+
+	user.State, user.StateReason, user.StateExpiresAt, user.TokensRevokedAt = model.UserStatePending, "", nil, &now
+
+	err = b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.Users().Update(ctx, user); err != nil {
+			return err
+		}
+
+		data := map[string]string{"username": username}
+		if err := webhook.Publish(ctx, b.ds, username, webhook.EventUserPasswordChanged, data); err != nil {
+			return err
+		}
+
+		return b.ds.AuditLogs().Create(ctx, &model.AuditLogM{
+			Operator: operator(ctx),
+			Action:   "ResetPassword",
+			Resource: "users/" + username,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ResetPasswordResponse{Password: password}, nil
+}
+
+// getUser 返回指定用户的数据库记录，用户不存在时返回 errno.ErrUserNotFound.
+func (b *userBiz) getUser(ctx context.Context, username string) (*model.UserM, error) {
+	user, err := b.ds.Users().Get(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// updateState 保存用户账号状态的变更，并在同一个事务中记录审计日志.
+func (b *userBiz) updateState(ctx context.Context, user *model.UserM, action string, detail map[string]interface{}) error {
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.Users().Update(ctx, user); err != nil {
+			return err
+		}
+
+		data, _ := json.Marshal(detail)
+
+		return b.ds.AuditLogs().Create(ctx, &model.AuditLogM{
+			Operator: operator(ctx),
+			Action:   action,
+			Resource: "users/" + user.Username,
+			Detail:   string(data),
+		})
+	})
+}
+
+// checkState 检查用户账号在 now 时刻是否可以使用.
+func checkState(user *model.UserM, now time.Time) error {
+	switch user.EffectiveState(now) {
+	case model.UserStateSuspended:
+		return errno.ErrUserSuspended
+	case model.UserStatePending:
+		return errno.ErrPasswordResetRequired
+	}
+
+	return nil
+}
+
+// validState 判断 state 是否为有效的用户状态.
+func validState(state string) bool {
+	switch state {
+	case model.UserStateActive, model.UserStateSuspended, model.UserStatePending:
+		return true
+	}
+
+	return false
+}
+
+// tempPassword 生成一个随机的临时密码.
+func tempPassword() (string, error) {
+	size := big.NewInt(int64(len(tempPasswordAlphabet)))
+	password := make([]byte, tempPasswordLength)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+
+		password[i] = tempPasswordAlphabet[n.Int64()]
+	}
+
+	return string(password), nil
+}
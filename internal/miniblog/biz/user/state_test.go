@@ -0,0 +1,296 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/auth"
+)
+
+// newStateStore 返回一个 mock IStore，Users().Get 返回 user，事务直接执行.
+func newStateStore(ctrl *gomock.Controller, user *model.UserM) (*store.MockIStore, *store.MockUserStore, *store.MockAuditLogStore) {
+	mockUserStore := store.NewMockUserStore(ctrl)
+	mockUserStore.EXPECT().Get(gomock.Any(), "belm1").Return(user, nil).AnyTimes()
+	mockUserStore.EXPECT().Get(gomock.Any(), "nobody").Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+
+	mockAuditLogStore := store.NewMockAuditLogStore(ctrl)
+
+	mockWebhookStore := store.NewMockWebhookStore(ctrl)
+	mockWebhookStore.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Users().AnyTimes().Return(mockUserStore)
+	mockStore.EXPECT().AuditLogs().AnyTimes().Return(mockAuditLogStore)
+	mockStore.EXPECT().Webhooks().AnyTimes().Return(mockWebhookStore)
+	mockStore.EXPECT().TX(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+
+	return mockStore, mockUserStore, mockAuditLogStore
+}
+
+func TestUserM_EffectiveState(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		user *model.UserM
+		want string
+	}{
+		{name: "empty", user: &model.UserM{}, want: model.UserStateActive},
+		{name: "active", user: &model.UserM{State: model.UserStateActive}, want: model.UserStateActive},
+		{name: "suspended", user: &model.UserM{State: model.UserStateSuspended}, want: model.UserStateSuspended},
+		{
+			name: "suspended until future",
+			user: &model.UserM{State: model.UserStateSuspended, StateExpiresAt: pointer.ToTime(now.Add(time.Hour))},
+			want: model.UserStateSuspended,
+		},
+		{
+			name: "suspension expired",
+			user: &model.UserM{State: model.UserStateSuspended, StateExpiresAt: pointer.ToTime(now)},
+			want: model.UserStateActive,
+		},
+		{name: "pending", user: &model.UserM{State: model.UserStatePending}, want: model.UserStatePending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.user.EffectiveState(now))
+		})
+	}
+}
+
+func Test_userBiz_CheckAccount(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		user     *model.UserM
+		username string
+		issuedAt time.Time
+		want     error
+	}{
+		{name: "active", user: fakeUser(1), username: "belm1", issuedAt: now},
+		{name: "not found", user: fakeUser(1), username: "nobody", issuedAt: now, want: errno.ErrTokenInvalid},
+		{
+			name:     "suspended",
+			user:     &model.UserM{Username: "belm1", State: model.UserStateSuspended},
+			username: "belm1",
+			issuedAt: now,
+			want:     errno.ErrUserSuspended,
+		},
+		{
+			name:     "suspension expired",
+			user:     &model.UserM{Username: "belm1", State: model.UserStateSuspended, StateExpiresAt: pointer.ToTime(now.Add(-time.Second))},
+			username: "belm1",
+			issuedAt: now,
+		},
+		{
+			name:     "pending",
+			user:     &model.UserM{Username: "belm1", State: model.UserStatePending},
+			username: "belm1",
+			issuedAt: now,
+			want:     errno.ErrPasswordResetRequired,
+		},
+		{
+			name:     "revoked",
+			user:     &model.UserM{Username: "belm1", State: model.UserStateActive, TokensRevokedAt: pointer.ToTime(now)},
+			username: "belm1",
+			issuedAt: now.Add(-time.Hour),
+			want:     errno.ErrTokenInvalid,
+		},
+		{
+			name:     "issued after revocation",
+			user:     &model.UserM{Username: "belm1", State: model.UserStateActive, TokensRevokedAt: pointer.ToTime(now)},
+			username: "belm1",
+			issuedAt: time.Unix(now.Unix(), 0),
+		},
+		{
+			name:     "issued before creation",
+			user:     &model.UserM{Username: "belm1", State: model.UserStateActive, CreatedAt: now},
+			username: "belm1",
+			issuedAt: now.Add(-time.Hour),
+			want:     errno.ErrTokenInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore, _, _ := newStateStore(ctrl, tt.user)
+			err := New(mockStore).CheckAccount(context.Background(), tt.username, tt.issuedAt)
+			if tt.want == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, tt.want, err)
+			}
+		})
+	}
+}
+
+func Test_userBiz_Login_suspended(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	password, _ := auth.Encrypt("miniblog1234")
+	user := &model.UserM{Username: "belm1", Password: password, State: model.UserStateSuspended}
+	mockStore, _, _ := newStateStore(ctrl, user)
+
+	got, err := New(mockStore).Login(context.Background(), &v1.LoginRequest{Username: "belm1", Password: "miniblog1234"})
+	assert.Nil(t, got)
+	assert.Equal(t, errno.ErrUserSuspended, err)
+
+	user.State = model.UserStatePending
+	got, err = New(mockStore).Login(context.Background(), &v1.LoginRequest{Username: "belm1", Password: "miniblog1234"})
+	assert.Nil(t, got)
+	assert.Equal(t, errno.ErrPasswordResetRequired, err)
+}
+
+func Test_userBiz_Suspend(t *testing.T) {
+	ctx := context.WithValue(context.Background(), known.XUsernameKey, "root")
+	until := time.Now().Add(time.Hour).Format("2006-01-02 15:04:05")
+
+	t.Run("self", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore, _, _ := newStateStore(ctrl, fakeUser(1))
+		err := New(mockStore).Suspend(ctx, "root", &v1.SuspendUserRequest{Reason: "spam"})
+		assert.Equal(t, errno.ErrSuspendSelf, err)
+	})
+
+	t.Run("invalid until", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore, _, _ := newStateStore(ctrl, fakeUser(1))
+		for _, until := range []string{"tomorrow", "2000-01-01 00:00:00"} {
+			err := New(mockStore).Suspend(ctx, "belm1", &v1.SuspendUserRequest{Reason: "spam", Until: until})
+			assert.Equal(t, errno.ErrSuspendUntilInvalid, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore, _, _ := newStateStore(ctrl, fakeUser(1))
+		err := New(mockStore).Suspend(ctx, "nobody", &v1.SuspendUserRequest{Reason: "spam"})
+		assert.Equal(t, errno.ErrUserNotFound, err)
+	})
+
+	t.Run("default", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		user := fakeUser(1)
+		mockStore, mockUserStore, mockAuditLogStore := newStateStore(ctrl, user)
+		mockUserStore.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, u *model.UserM) error {
+			assert.Equal(t, model.UserStateSuspended, u.State)
+			assert.Equal(t, "spam", u.StateReason)
+			assert.Equal(t, until, u.StateExpiresAt.Format("2006-01-02 15:04:05"))
+			return nil
+		}).Times(1)
+		mockAuditLogStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, log *model.AuditLogM) error {
+			assert.Equal(t, "root", log.Operator)
+			assert.Equal(t, "SuspendUser", log.Action)
+			assert.Equal(t, "users/belm1", log.Resource)
+			return nil
+		}).Times(1)
+
+		err := New(mockStore).Suspend(ctx, "belm1", &v1.SuspendUserRequest{Reason: "spam", Until: until})
+		assert.Nil(t, err)
+	})
+}
+
+func Test_userBiz_Unsuspend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &model.UserM{Username: "belm1", State: model.UserStateSuspended, StateReason: "spam", StateExpiresAt: pointer.ToTime(time.Now().Add(time.Hour))}
+	mockStore, mockUserStore, mockAuditLogStore := newStateStore(ctrl, user)
+	mockUserStore.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, u *model.UserM) error {
+		assert.Equal(t, model.UserStateActive, u.State)
+		assert.Empty(t, u.StateReason)
+		assert.Nil(t, u.StateExpiresAt)
+		return nil
+	}).Times(1)
+	mockAuditLogStore.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	ctx := context.WithValue(context.Background(), known.XUsernameKey, "root")
+	assert.Nil(t, New(mockStore).Unsuspend(ctx, "belm1"))
+}
+
+func Test_userBiz_ResetPassword(t *testing.T) {
+	ctx := context.WithValue(context.Background(), known.XUsernameKey, "root")
+
+	t.Run("suspended", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore, _, _ := newStateStore(ctrl, &model.UserM{Username: "belm1", State: model.UserStateSuspended})
+		got, err := New(mockStore).ResetPassword(ctx, "belm1")
+		assert.Nil(t, got)
+		assert.Equal(t, errno.ErrUserSuspended, err)
+	})
+
+	t.Run("default", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		user := fakeUser(1)
+		mockStore, mockUserStore, mockAuditLogStore := newStateStore(ctrl, user)
+		mockUserStore.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockAuditLogStore.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		got, err := New(mockStore).ResetPassword(ctx, "belm1")
+		assert.Nil(t, err)
+		assert.Len(t, got.Password, tempPasswordLength)
+		assert.Nil(t, auth.Compare(user.Password, got.Password))
+		assert.Equal(t, model.UserStatePending, user.State)
+		assert.NotNil(t, user.TokensRevokedAt)
+	})
+}
+
+func Test_userBiz_ChangePassword_pending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	password, _ := auth.Encrypt("temp1234")
+	user := &model.UserM{Username: "belm1", Password: password, State: model.UserStatePending}
+	mockStore, mockUserStore, _ := newStateStore(ctrl, user)
+	mockUserStore.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, u *model.UserM) error {
+		assert.Equal(t, model.UserStateActive, u.State)
+		return nil
+	}).Times(1)
+
+	err := New(mockStore).ChangePassword(context.Background(), "belm1", &v1.ChangePasswordRequest{OldPassword: "temp1234", NewPassword: "miniblog1234"})
+	assert.Nil(t, err)
+}
+
+func Test_userBiz_List_invalidState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	got, err := New(store.NewMockIStore(ctrl)).List(context.Background(), &v1.ListUserRequest{State: "deleted"})
+	assert.Nil(t, got)
+	assert.Equal(t, errno.ErrUserStateInvalid, err)
+}
@@ -7,32 +7,63 @@ package user
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"regexp"
 	"sync"
+	"time"
 
 	"github.com/jinzhu/copier"
 	"golang.org/x/sync/errgroup"
 	"gorm.io/gorm"
 
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/event"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/webhook"
 	"github.com/marmotedu/miniblog/internal/miniblog/store"
 	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
 	"github.com/marmotedu/miniblog/internal/pkg/log"
 	"github.com/marmotedu/miniblog/internal/pkg/model"
 	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
 	"github.com/marmotedu/miniblog/pkg/auth"
 	"github.com/marmotedu/miniblog/pkg/token"
+	"github.com/marmotedu/miniblog/pkg/util/id"
 )
 
+// anonymousUserPrefix 是匿名化用户数据时使用的用户名前缀.
+// 注册用户名只能包含字母和数字，因此带有 `-` 的匿名用户名不会与任何用户冲突.
+const anonymousUserPrefix = "deleted-"
+
+// anonymousUserNickname 是匿名化用户数据时创建的匿名用户的昵称.
+const anonymousUserNickname = "Deleted user"
+
+// defaultMethods 是用户访问自己的资源时允许使用的请求方法.
+const defaultMethods = "(GET)|(POST)|(PUT)|(DELETE)"
+
 // UserBiz 定义了 user 模块在 biz 层所实现的方法.
 type UserBiz interface {
 	ChangePassword(ctx context.Context, username string, r *v1.ChangePasswordRequest) error
 	Login(ctx context.Context, r *v1.LoginRequest) (*v1.LoginResponse, error)
 	Create(ctx context.Context, r *v1.CreateUserRequest) error
 	Get(ctx context.Context, username string) (*v1.GetUserResponse, error)
-	List(ctx context.Context, offset, limit int) (*v1.ListUserResponse, error)
+	GetProfile(ctx context.Context, username string) (*v1.GetProfileResponse, error)
+	UploadAvatar(ctx context.Context, username string, r io.Reader) (*v1.UploadAvatarResponse, error)
+	DeleteAvatar(ctx context.Context, username string) error
+	OpenAvatar(ctx context.Context, avatar string) (*Avatar, error)
+	List(ctx context.Context, r *v1.ListUserRequest) (*v1.ListUserResponse, error)
 	Update(ctx context.Context, username string, r *v1.UpdateUserRequest) error
-	Delete(ctx context.Context, username string) error
+	Delete(ctx context.Context, username string, r *v1.DeleteUserRequest) error
+	Follow(ctx context.Context, username, followee string) error
+	Unfollow(ctx context.Context, username, followee string) error
+	ListFollowers(ctx context.Context, username string, r *v1.ListFollowRequest) (*v1.ListFollowResponse, error)
+	ListFollowing(ctx context.Context, username string, r *v1.ListFollowRequest) (*v1.ListFollowResponse, error)
+	CheckAccount(ctx context.Context, username string, issuedAt time.Time) error
+	Suspend(ctx context.Context, username string, r *v1.SuspendUserRequest) error
+	Unsuspend(ctx context.Context, username string) error
+	ResetPassword(ctx context.Context, username string) (*v1.ResetPasswordResponse, error)
+	Rename(ctx context.Context, username string, r *v1.RenameUserRequest) (*v1.RenameUserResponse, error)
+	ResolveAlias(ctx context.Context, alias string) (string, error)
 }
 
 // UserBiz 接口的实现.
@@ -60,11 +91,19 @@ func (b *userBiz) ChangePassword(ctx context.Context, username string, r *v1.Cha
 	}
 
 	userM.Password, _ = auth.Encrypt(r.NewPassword)
-	if err := b.ds.Users().Update(ctx, userM); err != nil {
-		return err
+
+	// 管理员重置密码后，用户使用临时密码修改密码即可恢复账号
+	if userM.State == model.UserStatePending {
+		userM.State = model.UserStateActive
 	}
 
-	return nil
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.Users().Update(ctx, userM); err != nil {
+			return err
+		}
+
+		return webhook.Publish(ctx, b.ds, username, webhook.EventUserPasswordChanged, map[string]string{"username": username})
+	})
 }
 
 // Login 是 UserBiz 接口中 `Login` 方法的实现.
@@ -80,6 +119,11 @@ func (b *userBiz) Login(ctx context.Context, r *v1.LoginRequest) (*v1.LoginRespo
 		return nil, errno.ErrPasswordIncorrect
 	}
 
+	// 密码正确后再检查账号状态，避免泄露账号状态
+	if err := checkState(user, time.Now()); err != nil {
+		return nil, err
+	}
+
 	// 如果匹配成功，说明登录成功，签发 token 并返回
 	t, err := token.Sign(r.Username)
 	if err != nil {
@@ -90,10 +134,26 @@ func (b *userBiz) Login(ctx context.Context, r *v1.LoginRequest) (*v1.LoginRespo
 }
 
 // Create 是 UserBiz 接口中 `Create` 方法的实现.
+// 用户的授权策略和用户记录在同一个事务中添加. 其他用户在保留期内的旧用户名不能被使用.
 func (b *userBiz) Create(ctx context.Context, r *v1.CreateUserRequest) error {
+	if err := b.available(ctx, r.Username, "", time.Now()); err != nil {
+		return err
+	}
+
 	var userM model.UserM
 	_ = copier.Copy(&userM, r)
-	if err := b.ds.Users().Create(ctx, &userM); err != nil {
+	err := b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.Users().Create(ctx, &userM); err != nil {
+			return err
+		}
+
+		if err := b.ds.Policies().Create(ctx, userM.Username, "/v1/users/"+userM.Username, defaultMethods); err != nil {
+			return err
+		}
+
+		return event.Publish(ctx, b.ds, event.UserCreated, event.User{Username: userM.Username})
+	})
+	if err != nil {
 		if match, _ := regexp.MatchString("Duplicate entry '.*' for key 'username'", err.Error()); match {
 			return errno.ErrUserAlreadyExist
 		}
@@ -111,21 +171,55 @@ func (b *userBiz) Get(ctx context.Context, username string) (*v1.GetUserResponse
 		}
 		return nil, err
 	}
-	var resp v1.GetUserResponse
-	_ = copier.Copy(&resp, user)
-	resp.CreatedAt = user.CreatedAt.Format("2006-01-02 15:04:05")
-	resp.UpdatedAt = user.UpdatedAt.Format("2006-01-02 15:04:05")
+	resp := v1.GetUserResponse(*userInfo(user))
+	if resp.FollowerCount, resp.FollowingCount, err = b.ds.Follows().Count(ctx, username); err != nil {
+		return nil, err
+	}
+
 	return &resp, nil
 }
 
 // List 是 UserBiz 接口中 `List` 方法的实现.
-func (b *userBiz) List(ctx context.Context, offset, limit int) (*v1.ListUserResponse, error) {
-	count, list, err := b.ds.Users().List(ctx, offset, limit)
+func (b *userBiz) List(ctx context.Context, r *v1.ListUserRequest) (*v1.ListUserResponse, error) {
+	if r.State != "" && !validState(r.State) {
+		return nil, errno.ErrUserStateInvalid
+	}
+
+	opts, err := store.NewListOptions(r.Offset, r.Limit, r.PageToken, r.SkipTotalCount)
 	if err != nil {
+		return nil, errno.ErrPageTokenInvalid
+	}
+
+	count, list, err := b.ds.Users().List(ctx, &store.UserFilter{State: r.State, Now: time.Now()}, opts)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidCursor) {
+			return nil, errno.ErrPageTokenInvalid
+		}
+
 		log.C(ctx).Errorw("Failed to list users from storage", "err", err)
 		return nil, err
 	}
 
+	// 存储层会多返回一条记录，用来判断是否还有下一页
+	var nextPageToken string
+	if len(list) > opts.PageSize() {
+		list = list[:opts.PageSize()]
+		last := list[len(list)-1]
+		nextPageToken = store.NewCursor(opts, last.CreatedAt, last.ID).Encode()
+	}
+
+	// 一次查询出本页所有用户的关注数，避免每个用户查询一次
+	usernames := make([]string, 0, len(list))
+	for _, item := range list {
+		usernames = append(usernames, item.Username)
+	}
+
+	followers, following, err := b.ds.Follows().CountAll(ctx, usernames)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to count follows", "err", err)
+		return nil, err
+	}
+
 	var m sync.Map
 	eg, ctx := errgroup.WithContext(ctx)
 	// 使用 goroutine 提高接口性能
@@ -136,21 +230,15 @@ func (b *userBiz) List(ctx context.Context, offset, limit int) (*v1.ListUserResp
 			case <-ctx.Done():
 				return nil
 			default:
-				count, _, err := b.ds.Posts().List(ctx, user.Username, 0, 0)
+				count, _, err := b.ds.Posts().List(ctx, user.Username, nil, &store.ListOptions{})
 				if err != nil {
 					log.C(ctx).Errorw("Failed to list posts", "err", err)
 					return err
 				}
 
-				m.Store(user.ID, &v1.UserInfo{
-					Username:  user.Username,
-					Nickname:  user.Nickname,
-					Email:     user.Email,
-					Phone:     user.Email,
-					PostCount: count,
-					CreatedAt: user.CreatedAt.Format("2006-01-02 15:04:05"),
-					UpdatedAt: user.UpdatedAt.Format("2006-01-02 15:04:05"),
-				})
+				info := userInfo(user)
+				info.PostCount, info.FollowerCount, info.FollowingCount = count, followers[user.Username], following[user.Username]
+				m.Store(user.ID, info)
 
 				return nil
 			}
@@ -170,22 +258,27 @@ func (b *userBiz) List(ctx context.Context, offset, limit int) (*v1.ListUserResp
 
 	log.C(ctx).Debugw("Get users from backend storage", "count", len(users))
 
-	return &v1.ListUserResponse{TotalCount: count, Users: users}, nil
+	return &v1.ListUserResponse{TotalCount: count, Users: users, NextPageToken: nextPageToken}, nil
 }
 
 // ListWithBadPerformance 是一个性能较差的实现方式（已废弃）.
 func (b *userBiz) ListWithBadPerformance(ctx context.Context, offset, limit int) (*v1.ListUserResponse, error) {
-	count, list, err := b.ds.Users().List(ctx, offset, limit)
+	opts := &store.ListOptions{Offset: offset, Limit: limit}
+	count, list, err := b.ds.Users().List(ctx, nil, opts)
 	if err != nil {
 		log.C(ctx).Errorw("Failed to list users from storage", "err", err)
 		return nil, err
 	}
 
+	if len(list) > opts.PageSize() {
+		list = list[:opts.PageSize()]
+	}
+
 	users := make([]*v1.UserInfo, 0, len(list))
 	for _, item := range list {
 		user := item
 
-		count, _, err := b.ds.Posts().List(ctx, user.Username, 0, 0)
+		count, _, err := b.ds.Posts().List(ctx, user.Username, nil, &store.ListOptions{})
 		if err != nil {
 			log.C(ctx).Errorw("Failed to list posts", "err", err)
 			return nil, err
@@ -226,18 +319,197 @@ func (b *userBiz) Update(ctx context.Context, username string, user *v1.UpdateUs
 		userM.Phone = *user.Phone
 	}
 
-	if err := b.ds.Users().Update(ctx, userM); err != nil {
+	if err := updateProfile(userM, user); err != nil {
 		return err
 	}
 
-	return nil
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.Users().Update(ctx, userM); err != nil {
+			return err
+		}
+
+		if err := event.Publish(ctx, b.ds, event.UserUpdated, event.User{Username: username}); err != nil {
+			return err
+		}
+
+		// 事件中只包含用户的资料，不包含需要额外查询的统计数据
+		data := map[string]string{"username": username, "nickname": userM.Nickname, "email": userM.Email, "phone": userM.Phone}
+
+		return webhook.Publish(ctx, b.ds, username, webhook.EventUserUpdated, data)
+	})
 }
 
 // Delete 是 UserBiz 接口中 `Delete` 方法的实现.
-func (b *userBiz) Delete(ctx context.Context, username string) error {
-	if err := b.ds.Users().Delete(ctx, username); err != nil {
+// 根据 r.Mode 删除或转移用户的博客，并删除用户的授权策略和用户记录，所有变更和审计日志在同一个事务中完成.
+func (b *userBiz) Delete(ctx context.Context, username string, r *v1.DeleteUserRequest) error {
+	user, err := b.ds.Users().Get(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrUserNotFound
+		}
+
 		return err
 	}
 
-	return nil
+	mode := r.Mode
+	if mode == "" {
+		mode = v1.DeleteModeCascade
+	}
+
+	// heir 为接收用户数据的用户名，为空表示删除用户数据
+	var heir string
+	switch mode {
+	case v1.DeleteModeReassign:
+		if r.ReassignTo == "" || r.ReassignTo == username {
+			return errno.ErrReassignTargetInvalid
+		}
+
+		if _, err := b.ds.Users().Get(ctx, r.ReassignTo); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.ErrReassignTargetInvalid
+			}
+
+			return err
+		}
+
+		heir = r.ReassignTo
+	case v1.DeleteModeAnonymize:
+		heir = anonymousUserPrefix + id.GenShortID()
+	}
+
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		// 匿名用户和普通用户一样有用户记录，转移给匿名用户的博客可以继续通过公开接口访问
+		if mode == v1.DeleteModeAnonymize {
+			if err := b.createAnonymous(ctx, heir); err != nil {
+				return err
+			}
+		}
+
+		var (
+			posts int64
+			err   error
+		)
+		if heir == "" {
+			posts, err = b.ds.Posts().DeleteByUsername(ctx, username)
+		} else {
+			posts, err = b.ds.Posts().UpdateUsername(ctx, username, heir)
+		}
+		if err != nil {
+			return err
+		}
+
+		// 用户在其他用户博客下发表的评论和添加的 reaction 与博客按相同的方式处理
+		if heir == "" {
+			_, err = b.ds.Comments().DeleteByUsername(ctx, username)
+		} else {
+			_, err = b.ds.Comments().UpdateUsername(ctx, username, heir)
+		}
+		if err != nil {
+			return err
+		}
+
+		if heir == "" {
+			_, err = b.ds.Reactions().DeleteByUsername(ctx, username)
+		} else {
+			_, err = b.ds.Reactions().UpdateUsername(ctx, username, heir)
+		}
+		if err != nil {
+			return err
+		}
+
+		// 删除用户数据时，用户的博客已经被删除，上传的媒体文件不再被引用，会由后台任务清理
+		if heir != "" {
+			if _, err := b.ds.Media().UpdateUsername(ctx, username, heir); err != nil {
+				return err
+			}
+		}
+
+		// 关注关系和用户自己的时间线不转移给 heir，其他用户时间线中已有的博客随博客一起转移
+		if _, err := b.ds.Follows().DeleteByUsername(ctx, username); err != nil {
+			return err
+		}
+
+		if heir != "" {
+			if _, err := b.ds.Timelines().UpdateAuthor(ctx, username, heir); err != nil {
+				return err
+			}
+		}
+
+		if _, err := b.ds.Timelines().DeleteByUsername(ctx, username); err != nil {
+			return err
+		}
+
+		// 导入任务记录只对发起导入的用户有意义，不转移给 heir
+		if _, err := b.ds.Imports().DeleteByUsername(ctx, username); err != nil {
+			return err
+		}
+
+		// webhook 和投递记录不转移给 heir，还没有投递的事件也不再投递
+		if _, err := b.ds.Webhooks().DeleteByUsername(ctx, username); err != nil {
+			return err
+		}
+
+		if _, err := b.ds.Deliveries().DeleteByUsername(ctx, username); err != nil {
+			return err
+		}
+
+		// 通知只对接收通知的用户有意义，由用户引起的通知也一并删除
+		if _, err := b.ds.Notifications().DeleteByUsername(ctx, username); err != nil {
+			return err
+		}
+
+		// 用户删除后不再为用户保留旧用户名
+		if _, err := b.ds.Aliases().DeleteByUsername(ctx, username); err != nil {
+			return err
+		}
+
+		if err := b.ds.Policies().DeleteBySubject(ctx, username); err != nil {
+			return err
+		}
+
+		if err := b.ds.Users().Delete(ctx, username); err != nil {
+			return err
+		}
+
+		// 头像不转移给 heir，用户记录删除后不再引用头像文件
+		store.AfterCommit(ctx, func() { b.deleteAvatar(ctx, user.Avatar) })
+
+		// 博客搜索索引由 user.deleted 事件的处理函数同步
+		if err := event.Publish(ctx, b.ds, event.UserDeleted, event.User{Username: username, Heir: heir}); err != nil {
+			return err
+		}
+
+		detail, _ := json.Marshal(map[string]interface{}{"mode": mode, "heir": heir, "posts": posts})
+
+		return b.ds.AuditLogs().Create(ctx, &model.AuditLogM{
+			Operator: operator(ctx),
+			Action:   "DeleteUser",
+			Resource: "users/" + username,
+			Detail:   string(detail),
+		})
+	})
+}
+
+// createAnonymous 创建用户名为 username 的匿名用户，用来接收被删除用户的数据.
+// 匿名用户的密码是不公开的随机密码，并且用户处于停用状态，任何人都不能登录匿名用户.
+func (b *userBiz) createAnonymous(ctx context.Context, username string) error {
+	password, err := tempPassword()
+	if err != nil {
+		return err
+	}
+
+	return b.ds.Users().Create(ctx, &model.UserM{
+		Username:    username,
+		Password:    password,
+		Nickname:    anonymousUserNickname,
+		State:       model.UserStateSuspended,
+		StateReason: "The account holds the content of a deleted user.",
+	})
+}
+
+// operator 返回发起请求的用户名，用于记录审计日志.
+func operator(ctx context.Context) string {
+	username, _ := ctx.Value(known.XUsernameKey).(string)
+
+	return username
 }
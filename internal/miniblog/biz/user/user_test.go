@@ -7,6 +7,7 @@ package user
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/jinzhu/copier"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 
 	"github.com/marmotedu/miniblog/internal/miniblog/store"
 	"github.com/marmotedu/miniblog/internal/pkg/errno"
@@ -30,6 +32,7 @@ func fakeUser(id int64) *model.UserM {
 		Nickname:  fmt.Sprintf("belm%d", id),
 		Email:     "jxs121@gmail.com",
 		Phone:     "18188888xxx",
+		State:     model.UserStateActive,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -69,13 +72,17 @@ func Test_userBiz_List(t *testing.T) {
 	wantUsers := make([]*v1.UserInfo, 0, len(fakeUsers))
 	for _, u := range fakeUsers {
 		wantUsers = append(wantUsers, &v1.UserInfo{
-			Username:  u.Username,
-			Nickname:  u.Nickname,
-			Email:     u.Email,
-			Phone:     u.Email,
-			PostCount: 10,
-			CreatedAt: u.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt: u.UpdatedAt.Format("2006-01-02 15:04:05"),
+			Username:       u.Username,
+			Nickname:       u.Nickname,
+			Email:          u.Email,
+			Phone:          u.Phone,
+			Links:          []string{},
+			State:          u.State,
+			PostCount:      10,
+			FollowerCount:  2,
+			FollowingCount: 3,
+			CreatedAt:      u.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt:      u.UpdatedAt.Format("2006-01-02 15:04:05"),
 		})
 	}
 
@@ -85,9 +92,13 @@ func Test_userBiz_List(t *testing.T) {
 	mockPostStore := store.NewMockPostStore(ctrl)
 	mockPostStore.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(10), nil, nil).AnyTimes()
 
+	mockFollowStore := store.NewMockFollowStore(ctrl)
+	mockFollowStore.EXPECT().CountAll(gomock.Any(), gomock.Any()).DoAndReturn(fakeFollowCounts).Times(1)
+
 	mockStore := store.NewMockIStore(ctrl)
 	mockStore.EXPECT().Users().Return(mockUserStore).Times(1)
 	mockStore.EXPECT().Posts().Return(mockPostStore).AnyTimes()
+	mockStore.EXPECT().Follows().Return(mockFollowStore).AnyTimes()
 
 	tests := []struct {
 		name    string
@@ -100,13 +111,57 @@ func Test_userBiz_List(t *testing.T) {
 	ub := New(mockStore)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := ub.List(context.Background(), 0, 10)
+			got, err := ub.List(context.Background(), &v1.ListUserRequest{Offset: 0, Limit: 10})
 			assert.Equal(t, tt.wantErr, (err != nil))
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
+// fakeFollowCounts 返回每个用户有 2 个关注者、关注了 3 个用户.
+func fakeFollowCounts(ctx context.Context, usernames []string) (map[string]int64, map[string]int64, error) {
+	followers, following := map[string]int64{}, map[string]int64{}
+	for _, username := range usernames {
+		followers[username], following[username] = 2, 3
+	}
+
+	return followers, following, nil
+}
+
+func Test_userBiz_List_nextPageToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 存储层多返回一条记录，表示还有下一页
+	fakeUsers := []*model.UserM{fakeUser(3), fakeUser(2), fakeUser(1)}
+
+	mockUserStore := store.NewMockUserStore(ctrl)
+	mockUserStore.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), fakeUsers, nil).Times(1)
+
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(10), nil, nil).AnyTimes()
+
+	mockFollowStore := store.NewMockFollowStore(ctrl)
+	mockFollowStore.EXPECT().CountAll(gomock.Any(), gomock.Any()).DoAndReturn(fakeFollowCounts).Times(1)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Users().Return(mockUserStore).Times(1)
+	mockStore.EXPECT().Posts().Return(mockPostStore).AnyTimes()
+	mockStore.EXPECT().Follows().Return(mockFollowStore).AnyTimes()
+
+	ub := New(mockStore)
+	got, err := ub.List(context.Background(), &v1.ListUserRequest{Limit: 2, SkipTotalCount: true})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(got.Users))
+
+	cursor, err := store.DecodeCursor(got.NextPageToken)
+	assert.Nil(t, err)
+	assert.Equal(t, fakeUsers[1].ID, cursor.ID)
+
+	_, err = ub.List(context.Background(), &v1.ListUserRequest{PageToken: "invalid token"})
+	assert.Equal(t, errno.ErrPageTokenInvalid, err)
+}
+
 func TestNew(t *testing.T) {
 	type args struct {
 		ds store.IStore
@@ -130,10 +185,35 @@ func Test_userBiz_Create(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserStore := store.NewMockUserStore(ctrl)
+	mockUserStore.EXPECT().Get(gomock.Any(), "belm").Return(nil, gorm.ErrRecordNotFound).Times(1)
 	mockUserStore.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
+	mockAliasStore := store.NewMockAliasStore(ctrl)
+	mockAliasStore.EXPECT().Resolve(gomock.Any(), "belm", gomock.Any()).Return("", gorm.ErrRecordNotFound).Times(1)
+
+	mockEventStore := store.NewMockEventStore(ctrl)
+	mockEventStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, e *model.EventM) error {
+			assert.Equal(t, "user.created", e.Type)
+			assert.Equal(t, `{"username":"belm"}`, e.Payload)
+
+			return nil
+		},
+	).Times(1)
+
+	mockPolicyStore := store.NewMockPolicyStore(ctrl)
+	mockPolicyStore.EXPECT().Create(gomock.Any(), "belm", "/v1/users/belm", defaultMethods).Return(nil).Times(1)
+
 	mockStore := store.NewMockIStore(ctrl)
 	mockStore.EXPECT().Users().AnyTimes().Return(mockUserStore)
+	mockStore.EXPECT().Aliases().AnyTimes().Return(mockAliasStore)
+	mockStore.EXPECT().Policies().AnyTimes().Return(mockPolicyStore)
+	mockStore.EXPECT().Events().AnyTimes().Return(mockEventStore)
+	mockStore.EXPECT().TX(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
 
 	type fields struct {
 		ds store.IStore
@@ -147,7 +227,7 @@ func Test_userBiz_Create(t *testing.T) {
 		fields fields
 		args   args
 	}{
-		{name: "default", fields: fields{mockStore}, args: args{context.Background(), nil}},
+		{name: "default", fields: fields{mockStore}, args: args{context.Background(), &v1.CreateUserRequest{Username: "belm"}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -167,11 +247,17 @@ func Test_userBiz_Get(t *testing.T) {
 	mockUserStore := store.NewMockUserStore(ctrl)
 	mockUserStore.EXPECT().Get(gomock.Any(), gomock.Any()).Return(fakeUser, nil).AnyTimes()
 
+	mockFollowStore := store.NewMockFollowStore(ctrl)
+	mockFollowStore.EXPECT().Count(gomock.Any(), "belm").Return(int64(2), int64(3), nil).AnyTimes()
+
 	mockStore := store.NewMockIStore(ctrl)
 	mockStore.EXPECT().Users().AnyTimes().Return(mockUserStore)
+	mockStore.EXPECT().Follows().AnyTimes().Return(mockFollowStore)
 
 	var want v1.GetUserResponse
 	_ = copier.Copy(&want, fakeUser)
+	want.Links = []string{}
+	want.FollowerCount, want.FollowingCount = 2, 3
 	want.CreatedAt = fakeUser.CreatedAt.Format("2006-01-02 15:04:05")
 	want.UpdatedAt = fakeUser.UpdatedAt.Format("2006-01-02 15:04:05")
 
@@ -219,8 +305,21 @@ func Test_userBiz_Update(t *testing.T) {
 	mockUserStore.EXPECT().Get(gomock.Any(), gomock.Any()).Return(fakeUser, nil).AnyTimes()
 	mockUserStore.EXPECT().Update(gomock.Any(), &wantedUser).Return(nil).AnyTimes()
 
+	mockWebhookStore := store.NewMockWebhookStore(ctrl)
+	mockWebhookStore.EXPECT().List(gomock.Any(), "belm").Return(nil, nil).AnyTimes()
+
+	mockEventStore := store.NewMockEventStore(ctrl)
+	mockEventStore.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
 	mockStore := store.NewMockIStore(ctrl)
 	mockStore.EXPECT().Users().AnyTimes().Return(mockUserStore)
+	mockStore.EXPECT().Webhooks().AnyTimes().Return(mockWebhookStore)
+	mockStore.EXPECT().Events().AnyTimes().Return(mockEventStore)
+	mockStore.EXPECT().TX(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
 
 	type fields struct {
 		ds store.IStore
@@ -253,10 +352,103 @@ func Test_userBiz_Delete(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserStore := store.NewMockUserStore(ctrl)
-	mockUserStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockUserStore.EXPECT().Get(gomock.Any(), "belm").Return(fakeUser(1), nil).AnyTimes()
+	mockUserStore.EXPECT().Get(gomock.Any(), "colin").Return(fakeUser(2), nil).AnyTimes()
+	mockUserStore.EXPECT().Get(gomock.Any(), "nobody").Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	mockUserStore.EXPECT().Delete(gomock.Any(), "belm").Return(nil).Times(3)
+	// Anonymize creates the anonymous user who receives the content, which nobody can log in as.
+	mockUserStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, user *model.UserM) error {
+			assert.Contains(t, user.Username, "deleted-")
+			assert.NotEmpty(t, user.Password)
+			assert.Equal(t, model.UserStateSuspended, user.State)
+
+			return nil
+		},
+	).Times(1)
+
+	mockPostStore := store.NewMockPostStore(ctrl)
+	mockPostStore.EXPECT().DeleteByUsername(gomock.Any(), "belm").Return(int64(3), nil).Times(1)
+	mockPostStore.EXPECT().UpdateUsername(gomock.Any(), "belm", "colin").Return(int64(3), nil).Times(1)
+	mockPostStore.EXPECT().UpdateUsername(gomock.Any(), "belm", gomock.Not("colin")).Return(int64(3), nil).Times(1)
+
+	mockCommentStore := store.NewMockCommentStore(ctrl)
+	mockCommentStore.EXPECT().DeleteByUsername(gomock.Any(), "belm").Return(int64(1), nil).Times(1)
+	mockCommentStore.EXPECT().UpdateUsername(gomock.Any(), "belm", "colin").Return(int64(1), nil).Times(1)
+	mockCommentStore.EXPECT().UpdateUsername(gomock.Any(), "belm", gomock.Not("colin")).Return(int64(1), nil).Times(1)
+
+	mockReactionStore := store.NewMockReactionStore(ctrl)
+	mockReactionStore.EXPECT().DeleteByUsername(gomock.Any(), "belm").Return(int64(1), nil).Times(1)
+	mockReactionStore.EXPECT().UpdateUsername(gomock.Any(), "belm", gomock.Any()).Return(int64(1), nil).Times(2)
+
+	mockMediaStore := store.NewMockMediaStore(ctrl)
+	mockMediaStore.EXPECT().UpdateUsername(gomock.Any(), "belm", gomock.Any()).Return(int64(1), nil).Times(2)
+
+	mockFollowStore := store.NewMockFollowStore(ctrl)
+	mockFollowStore.EXPECT().DeleteByUsername(gomock.Any(), "belm").Return(int64(2), nil).Times(3)
+
+	mockTimelineStore := store.NewMockTimelineStore(ctrl)
+	mockTimelineStore.EXPECT().DeleteByUsername(gomock.Any(), "belm").Return(int64(5), nil).Times(3)
+	mockTimelineStore.EXPECT().UpdateAuthor(gomock.Any(), "belm", "colin").Return(int64(4), nil).Times(1)
+	mockTimelineStore.EXPECT().UpdateAuthor(gomock.Any(), "belm", gomock.Not("colin")).Return(int64(4), nil).Times(1)
+
+	mockImportStore := store.NewMockImportStore(ctrl)
+	mockImportStore.EXPECT().DeleteByUsername(gomock.Any(), "belm").Return(int64(1), nil).Times(3)
+
+	mockWebhookStore := store.NewMockWebhookStore(ctrl)
+	mockWebhookStore.EXPECT().DeleteByUsername(gomock.Any(), "belm").Return(int64(1), nil).Times(3)
+
+	mockDeliveryStore := store.NewMockDeliveryStore(ctrl)
+	mockDeliveryStore.EXPECT().DeleteByUsername(gomock.Any(), "belm").Return(int64(10), nil).Times(3)
+
+	mockNotificationStore := store.NewMockNotificationStore(ctrl)
+	mockNotificationStore.EXPECT().DeleteByUsername(gomock.Any(), "belm").Return(int64(2), nil).Times(3)
+
+	mockAliasStore := store.NewMockAliasStore(ctrl)
+	mockAliasStore.EXPECT().DeleteByUsername(gomock.Any(), "belm").Return(int64(0), nil).Times(3)
+
+	mockPolicyStore := store.NewMockPolicyStore(ctrl)
+	mockPolicyStore.EXPECT().DeleteBySubject(gomock.Any(), "belm").Return(nil).Times(3)
+
+	mockAuditLogStore := store.NewMockAuditLogStore(ctrl)
+	mockAuditLogStore.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+
+	// The search index is synced by the handler of the user.deleted event, which tells the heir.
+	var heirs []string
+	mockEventStore := store.NewMockEventStore(ctrl)
+	mockEventStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, e *model.EventM) error {
+			var u struct{ Username, Heir string }
+			assert.Equal(t, "user.deleted", e.Type)
+			assert.Nil(t, json.Unmarshal([]byte(e.Payload), &u))
+			assert.Equal(t, "belm", u.Username)
+			heirs = append(heirs, u.Heir)
+
+			return nil
+		},
+	).Times(3)
 
 	mockStore := store.NewMockIStore(ctrl)
 	mockStore.EXPECT().Users().AnyTimes().Return(mockUserStore)
+	mockStore.EXPECT().Posts().AnyTimes().Return(mockPostStore)
+	mockStore.EXPECT().Comments().AnyTimes().Return(mockCommentStore)
+	mockStore.EXPECT().Reactions().AnyTimes().Return(mockReactionStore)
+	mockStore.EXPECT().Media().AnyTimes().Return(mockMediaStore)
+	mockStore.EXPECT().Follows().AnyTimes().Return(mockFollowStore)
+	mockStore.EXPECT().Timelines().AnyTimes().Return(mockTimelineStore)
+	mockStore.EXPECT().Imports().AnyTimes().Return(mockImportStore)
+	mockStore.EXPECT().Webhooks().AnyTimes().Return(mockWebhookStore)
+	mockStore.EXPECT().Deliveries().AnyTimes().Return(mockDeliveryStore)
+	mockStore.EXPECT().Notifications().AnyTimes().Return(mockNotificationStore)
+	mockStore.EXPECT().Aliases().AnyTimes().Return(mockAliasStore)
+	mockStore.EXPECT().Policies().AnyTimes().Return(mockPolicyStore)
+	mockStore.EXPECT().AuditLogs().AnyTimes().Return(mockAuditLogStore)
+	mockStore.EXPECT().Events().AnyTimes().Return(mockEventStore)
+	mockStore.EXPECT().TX(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
 
 	type fields struct {
 		ds store.IStore
@@ -264,22 +456,50 @@ func Test_userBiz_Delete(t *testing.T) {
 	type args struct {
 		ctx      context.Context
 		username string
+		r        *v1.DeleteUserRequest
 	}
 	tests := []struct {
 		name   string
 		fields fields
 		args   args
+		want   error
 	}{
-		{name: "default", fields: fields{mockStore}, args: args{context.Background(), "belm"}},
+		{name: "default", fields: fields{mockStore}, args: args{context.Background(), "belm", &v1.DeleteUserRequest{}}},
+		{
+			name:   "reassign",
+			fields: fields{mockStore},
+			args:   args{context.Background(), "belm", &v1.DeleteUserRequest{Mode: v1.DeleteModeReassign, ReassignTo: "colin"}},
+		},
+		{
+			name:   "anonymize",
+			fields: fields{mockStore},
+			args:   args{context.Background(), "belm", &v1.DeleteUserRequest{Mode: v1.DeleteModeAnonymize}},
+		},
+		{
+			name:   "reassign to nonexistent user",
+			fields: fields{mockStore},
+			args:   args{context.Background(), "belm", &v1.DeleteUserRequest{Mode: v1.DeleteModeReassign, ReassignTo: "nobody"}},
+			want:   errno.ErrReassignTargetInvalid,
+		},
+		{
+			name:   "reassign to self",
+			fields: fields{mockStore},
+			args:   args{context.Background(), "belm", &v1.DeleteUserRequest{Mode: v1.DeleteModeReassign, ReassignTo: "belm"}},
+			want:   errno.ErrReassignTargetInvalid,
+		},
+		{name: "user not found", fields: fields{mockStore}, args: args{context.Background(), "nobody", &v1.DeleteUserRequest{}}, want: errno.ErrUserNotFound},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			b := &userBiz{
 				ds: tt.fields.ds,
 			}
-			assert.Nil(t, b.Delete(tt.args.ctx, tt.args.username))
+			assert.Equal(t, tt.want, b.Delete(tt.args.ctx, tt.args.username, tt.args.r))
 		})
 	}
+	assert.Len(t, heirs, 3)
+	assert.Equal(t, []string{"", "colin"}, heirs[:2])
+	assert.Contains(t, heirs[2], "deleted-")
 }
 
 func Test_userBiz_ChangePassword(t *testing.T) {
@@ -310,7 +530,7 @@ func Test_userBiz_ChangePassword(t *testing.T) {
 		{
 			name:   "default",
 			fields: fields{mockStore},
-			args:   args{context.Background(), "belm", &v1.ChangePasswordRequest{"miniblog1234", "miniblog12345"}},
+			args:   args{context.Background(), "belm", &v1.ChangePasswordRequest{OldPassword: "miniblog1234", NewPassword: "miniblog12345"}},
 		},
 	}
 	for _, tt := range tests {
@@ -385,7 +605,7 @@ func BenchmarkListUser(b *testing.B) {
 
 	ub := New(mockStore)
 	for i := 0; i < b.N; i++ {
-		_, _ = ub.List(context.TODO(), 0, 0)
+		_, _ = ub.List(context.TODO(), &v1.ListUserRequest{})
 	}
 }
 
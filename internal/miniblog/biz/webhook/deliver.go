@@ -0,0 +1,273 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/util/id"
+	"github.com/marmotedu/miniblog/pkg/util/retry"
+	"github.com/marmotedu/miniblog/pkg/webhook"
+)
+
+const (
+	// DefaultTimeout is the default timeout of a delivery request.
+	DefaultTimeout = 10 * time.Second
+
+	// MaxAttempts is the number of attempts of a delivery before it fails.
+	MaxAttempts = 10
+
+	// FailureThreshold is the number of consecutive failed attempts to a webhook, without any successful one
+	// in between, after which the webhook is disabled.
+	FailureThreshold = 20
+
+	// initialBackoff is the delay before the first retry of a delivery, it is doubled for each further retry.
+	initialBackoff = time.Minute
+
+	// maxBackoff is the maximum delay between two attempts of a delivery.
+	maxBackoff = 6 * time.Hour
+
+	// claimMargin is added to the timeout of the delivery requests to get the lease of a claimed delivery, it
+	// leaves the worker enough time to record the result after the request.
+	claimMargin = time.Minute
+
+	// deliverBatchSize is the number of due deliveries fetched at a time by Deliver.
+	deliverBatchSize = 100
+
+	// deliverConcurrency is the number of deliveries sent at the same time by Deliver.
+	deliverConcurrency = 8
+
+	// maxResponseLength is the maximum number of bytes of the response body kept in the delivery log.
+	maxResponseLength = 1024
+
+	// userAgent is the User-Agent header of the delivery requests.
+	userAgent = "miniblog-webhook/1.0"
+)
+
+// config holds the delivery options, which can be changed with Init. claimLease is how long a claimed delivery
+// is reserved for the worker which claimed it, the delivery is attempted again after the lease if the worker
+// exits before recording the result. It is longer than the request timeout, so that a delivery in flight is
+// never claimed and sent again by another worker.
+var config = struct {
+	allowPrivate bool
+	client       *http.Client
+	claimLease   time.Duration
+}{false, webhook.NewClient(DefaultTimeout, false), DefaultTimeout + claimMargin}
+
+// Init sets the timeout of the delivery requests, a value which is not positive keeps the default, and whether
+// webhooks can be delivered to private network addresses. Private addresses should only be allowed when all
+// the users are trusted, otherwise webhooks can be used to reach the internal services of the server.
+func Init(timeout time.Duration, allowPrivate bool) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	config.allowPrivate = allowPrivate
+	config.client = webhook.NewClient(timeout, allowPrivate)
+	config.claimLease = timeout + claimMargin
+}
+
+// Publish queues a delivery of event to each active webhook of username which subscribes to it, data is the
+// data of the event in the payload. It should be called in the transaction which makes the change of the event,
+// so that the deliveries are queued if and only if the change is committed.
+func Publish(ctx context.Context, ds store.IStore, username, event string, data interface{}) error {
+	return PublishFunc(ctx, ds, username, event, func() (interface{}, error) { return data, nil })
+}
+
+// PublishFunc is like Publish, but the data of the event is only loaded by data if any webhook subscribes to it.
+func PublishFunc(ctx context.Context, ds store.IStore, username, event string, data func() (interface{}, error)) error {
+	webhooks, err := ds.Webhooks().List(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	var loaded interface{}
+	now := time.Now()
+	for _, webhookM := range webhooks {
+		if !webhookM.Active || !subscribes(webhookM, event) {
+			continue
+		}
+
+		if loaded == nil {
+			if loaded, err = data(); err != nil {
+				return err
+			}
+		}
+
+		delivery := newDelivery(webhookM, event)
+		payload, err := json.Marshal(&v1.WebhookPayload{
+			ID:        delivery.DeliveryID,
+			Event:     event,
+			Username:  username,
+			CreatedAt: now.Format(time.RFC3339),
+			Data:      loaded,
+		})
+		if err != nil {
+			return err
+		}
+
+		delivery.Payload = string(payload)
+		if err := ds.Deliveries().Create(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newDelivery creates a pending delivery of event to webhook which is due now, the payload is set by the caller.
+func newDelivery(webhook *model.WebhookM, event string) *model.WebhookDeliveryM {
+	return &model.WebhookDeliveryM{
+		DeliveryID:    "delivery-" + id.GenShortID(),
+		WebhookID:     webhook.WebhookID,
+		Username:      webhook.Username,
+		Event:         event,
+		Status:        model.DeliveryStatusPending,
+		NextAttemptAt: time.Now(),
+	}
+}
+
+// Deliver is the implementation of the `Deliver` method in WebhookBiz interface.
+// It sends the due deliveries and returns the number of attempts. Each delivery is claimed before it is sent,
+// so that several instances of miniblog can deliver at the same time without sending a delivery twice.
+func (b *webhookBiz) Deliver(ctx context.Context) (int64, error) {
+	var count int64
+	for {
+		list, err := b.ds.Deliveries().ListDue(ctx, time.Now(), deliverBatchSize)
+		if err != nil {
+			return count, err
+		}
+
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, deliverConcurrency)
+		for _, delivery := range list {
+			claimed, err := b.ds.Deliveries().Claim(ctx, delivery, time.Now().Add(config.claimLease))
+			if err != nil {
+				return count, err
+			}
+
+			if !claimed {
+				continue
+			}
+
+			count++
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(delivery *model.WebhookDeliveryM) {
+				defer func() { <-sem; wg.Done() }()
+
+				if err := b.attempt(ctx, delivery); err != nil {
+					log.C(ctx).Errorw("Failed to record webhook delivery", "deliveryID", delivery.DeliveryID, "err", err)
+				}
+			}(delivery)
+		}
+		wg.Wait()
+
+		if len(list) < deliverBatchSize || ctx.Err() != nil {
+			return count, nil
+		}
+	}
+}
+
+// attempt sends a claimed delivery and records the result. A failed delivery is retried with exponential
+// back-off until MaxAttempts, and the webhook is disabled after FailureThreshold consecutive failed attempts.
+func (b *webhookBiz) attempt(ctx context.Context, delivery *model.WebhookDeliveryM) error {
+	webhookM, err := b.ds.Webhooks().GetByWebhookID(ctx, delivery.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if !webhookM.Active {
+		delivery.Status, delivery.Error = model.DeliveryStatusFailed, "The webhook was disabled."
+		return b.ds.Deliveries().Update(ctx, delivery)
+	}
+
+	delivery.StatusCode, delivery.Response, err = send(ctx, webhookM, delivery)
+	if ctx.Err() != nil {
+		// miniblog is exiting, the delivery is attempted again after the claim lease.
+		return nil
+	}
+
+	succeeded := err == nil
+	switch {
+	case succeeded:
+		delivery.Status, delivery.Error, delivery.DeliveredAt = model.DeliveryStatusSucceeded, "", &now
+	case delivery.Attempts >= MaxAttempts:
+		delivery.Status, delivery.Error = model.DeliveryStatusFailed, retry.Truncate(err.Error(), maxResponseLength)
+	default:
+		delivery.Error = retry.Truncate(err.Error(), maxResponseLength)
+		delivery.NextAttemptAt = now.Add(retry.Backoff(delivery.Attempts, initialBackoff, maxBackoff))
+	}
+
+	if err := b.ds.Deliveries().Update(ctx, delivery); err != nil {
+		return err
+	}
+
+	if err := b.ds.Webhooks().RecordDelivery(ctx, webhookM.WebhookID, succeeded, now); err != nil {
+		return err
+	}
+
+	if succeeded {
+		return nil
+	}
+
+	reason := fmt.Sprintf("The webhook was disabled after %d consecutive failed attempts, the last one failed with: %s",
+		FailureThreshold, delivery.Error)
+	disabled, err := b.ds.Webhooks().DisableFailing(ctx, webhookM.WebhookID, FailureThreshold, retry.Truncate(reason, maxResponseLength))
+	if err != nil || !disabled {
+		return err
+	}
+
+	log.C(ctx).Warnw("Disabled failing webhook", "webhookID", webhookM.WebhookID, "username", webhookM.Username)
+	_, err = b.ds.Deliveries().FailPending(ctx, webhookM.WebhookID, "The webhook was disabled.")
+
+	return err
+}
+
+// send posts the payload of delivery to the webhook, it returns the status code and the truncated response body.
+// An error is returned if the request fails or the status code is not 2xx.
+func send(ctx context.Context, webhookM *model.WebhookM, delivery *model.WebhookDeliveryM) (int, string, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookM.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(webhook.HeaderEvent, delivery.Event)
+	req.Header.Set(webhook.HeaderDelivery, delivery.DeliveryID)
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(webhookM.Secret, body))
+
+	resp, err := config.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseLength))
+	// Drain a little more of the body so that the connection can be reused.
+	_, _ = io.CopyN(io.Discard, resp.Body, 64<<10)
+
+	response := retry.Truncate(string(data), maxResponseLength)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, response, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, response, nil
+}
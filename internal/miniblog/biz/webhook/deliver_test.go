@@ -0,0 +1,145 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	"github.com/marmotedu/miniblog/pkg/util/retry"
+	"github.com/marmotedu/miniblog/pkg/webhook"
+)
+
+func Test_backoff(t *testing.T) {
+	assert.Equal(t, time.Minute, retry.Backoff(1, initialBackoff, maxBackoff))
+	assert.Equal(t, 2*time.Minute, retry.Backoff(2, initialBackoff, maxBackoff))
+	assert.Equal(t, 256*time.Minute, retry.Backoff(9, initialBackoff, maxBackoff))
+	assert.Equal(t, maxBackoff, retry.Backoff(10, initialBackoff, maxBackoff))
+	assert.Equal(t, maxBackoff, retry.Backoff(100, initialBackoff, maxBackoff))
+}
+
+func TestInit(t *testing.T) {
+	defer Init(0, false)
+
+	Init(2*time.Minute, false)
+	assert.Equal(t, 2*time.Minute+claimMargin, config.claimLease)
+
+	Init(0, false)
+	assert.Equal(t, DefaultTimeout+claimMargin, config.claimLease)
+}
+
+func Test_webhookBiz_Deliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	Init(time.Second, true)
+	defer Init(0, false)
+
+	var requests []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.True(t, webhook.Verify("secret", body, r.Header.Get(webhook.HeaderSignature)))
+		requests = append(requests, r)
+
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = io.WriteString(w, strings.Repeat("x", 2*maxResponseLength))
+
+			return
+		}
+
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	ok := &model.WebhookM{WebhookID: "webhook-1", Username: "belm", URL: srv.URL + "/ok", Secret: "secret", Active: true}
+	failing := &model.WebhookM{WebhookID: "webhook-2", Username: "belm", URL: srv.URL + "/fail", Secret: "secret", Active: true}
+	disabled := &model.WebhookM{WebhookID: "webhook-3", Username: "belm", URL: srv.URL + "/ok", Secret: "secret"}
+
+	deliveries := []*model.WebhookDeliveryM{
+		{ID: 1, DeliveryID: "delivery-1", WebhookID: "webhook-1", Event: "post.created", Payload: `{"id":"delivery-1"}`, Status: model.DeliveryStatusPending},
+		{ID: 2, DeliveryID: "delivery-2", WebhookID: "webhook-2", Event: "post.created", Payload: `{"id":"delivery-2"}`, Status: model.DeliveryStatusPending},
+		{ID: 3, DeliveryID: "delivery-3", WebhookID: "webhook-2", Event: "post.created", Payload: `{"id":"delivery-3"}`, Status: model.DeliveryStatusPending, Attempts: MaxAttempts - 1},
+		{ID: 4, DeliveryID: "delivery-4", WebhookID: "webhook-3", Event: "post.created", Payload: `{"id":"delivery-4"}`, Status: model.DeliveryStatusPending},
+		{ID: 5, DeliveryID: "delivery-5", WebhookID: "webhook-1", Event: "post.created", Payload: `{"id":"delivery-5"}`, Status: model.DeliveryStatusPending},
+	}
+
+	mockWebhookStore := store.NewMockWebhookStore(ctrl)
+	for _, w := range []*model.WebhookM{ok, failing, disabled} {
+		mockWebhookStore.EXPECT().GetByWebhookID(gomock.Any(), w.WebhookID).Return(w, nil).AnyTimes()
+	}
+	mockWebhookStore.EXPECT().RecordDelivery(gomock.Any(), "webhook-1", true, gomock.Any()).Return(nil).Times(1)
+	mockWebhookStore.EXPECT().RecordDelivery(gomock.Any(), "webhook-2", false, gomock.Any()).Return(nil).Times(2)
+	gomock.InOrder(
+		mockWebhookStore.EXPECT().DisableFailing(gomock.Any(), "webhook-2", FailureThreshold, gomock.Any()).Return(false, nil),
+		mockWebhookStore.EXPECT().DisableFailing(gomock.Any(), "webhook-2", FailureThreshold, gomock.Any()).Return(true, nil),
+	)
+
+	mockDeliveryStore := store.NewMockDeliveryStore(ctrl)
+	mockDeliveryStore.EXPECT().ListDue(gomock.Any(), gomock.Any(), deliverBatchSize).Return(deliveries, nil).Times(1)
+	mockDeliveryStore.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, delivery *model.WebhookDeliveryM, until time.Time) (bool, error) {
+			// delivery-5 is claimed by another instance.
+			if delivery.DeliveryID == "delivery-5" {
+				return false, nil
+			}
+
+			// The lease outlasts the configured request timeout.
+			assert.WithinDuration(t, time.Now().Add(time.Second+claimMargin), until, time.Second)
+
+			delivery.Attempts++
+			delivery.NextAttemptAt = until
+
+			return true, nil
+		},
+	).Times(5)
+	mockDeliveryStore.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(4)
+	mockDeliveryStore.EXPECT().FailPending(gomock.Any(), "webhook-2", gomock.Any()).Return(int64(0), nil).Times(1)
+
+	mockStore := store.NewMockIStore(ctrl)
+	mockStore.EXPECT().Webhooks().AnyTimes().Return(mockWebhookStore)
+	mockStore.EXPECT().Deliveries().AnyTimes().Return(mockDeliveryStore)
+
+	b := New(mockStore)
+
+	start := time.Now()
+	count, err := b.Deliver(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), count)
+	assert.Len(t, requests, 3)
+
+	// The successful delivery is recorded with the response.
+	assert.Equal(t, model.DeliveryStatusSucceeded, deliveries[0].Status)
+	assert.Equal(t, http.StatusOK, deliveries[0].StatusCode)
+	assert.Equal(t, "ok", deliveries[0].Response)
+	assert.NotNil(t, deliveries[0].DeliveredAt)
+
+	// The failed delivery is retried with back-off, the truncated response is kept.
+	assert.Equal(t, model.DeliveryStatusPending, deliveries[1].Status)
+	assert.Equal(t, http.StatusInternalServerError, deliveries[1].StatusCode)
+	assert.Len(t, deliveries[1].Response, maxResponseLength)
+	assert.Equal(t, "unexpected status code 500", deliveries[1].Error)
+	assert.True(t, deliveries[1].NextAttemptAt.After(start.Add(initialBackoff-time.Second)))
+
+	// The delivery fails after the last attempt.
+	assert.Equal(t, model.DeliveryStatusFailed, deliveries[2].Status)
+
+	// The deliveries of a disabled webhook fail without being sent.
+	assert.Equal(t, model.DeliveryStatusFailed, deliveries[3].Status)
+	assert.Equal(t, 0, deliveries[3].StatusCode)
+
+	assert.Equal(t, 0, deliveries[4].Attempts)
+}
@@ -0,0 +1,176 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/marmotedu/miniblog/internal/miniblog/biz/webhook (interfaces: WebhookBiz)
+
+// Package webhook is a generated GoMock package.
+package webhook
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// MockWebhookBiz is a mock of WebhookBiz interface.
+type MockWebhookBiz struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookBizMockRecorder
+}
+
+// MockWebhookBizMockRecorder is the mock recorder for MockWebhookBiz.
+type MockWebhookBizMockRecorder struct {
+	mock *MockWebhookBiz
+}
+
+// NewMockWebhookBiz creates a new mock instance.
+func NewMockWebhookBiz(ctrl *gomock.Controller) *MockWebhookBiz {
+	mock := &MockWebhookBiz{ctrl: ctrl}
+	mock.recorder = &MockWebhookBizMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookBiz) EXPECT() *MockWebhookBizMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookBiz) Create(arg0 context.Context, arg1 string, arg2 *v1.CreateWebhookRequest) (*v1.CreateWebhookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.CreateWebhookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookBizMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookBiz)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookBiz) Delete(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookBizMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookBiz)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Deliver mocks base method.
+func (m *MockWebhookBiz) Deliver(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deliver indicates an expected call of Deliver.
+func (mr *MockWebhookBizMockRecorder) Deliver(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockWebhookBiz)(nil).Deliver), arg0)
+}
+
+// Get mocks base method.
+func (m *MockWebhookBiz) Get(arg0 context.Context, arg1, arg2 string) (*v1.GetWebhookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.GetWebhookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWebhookBizMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWebhookBiz)(nil).Get), arg0, arg1, arg2)
+}
+
+// List mocks base method.
+func (m *MockWebhookBiz) List(arg0 context.Context, arg1 string) (*v1.ListWebhookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(*v1.ListWebhookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhookBizMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhookBiz)(nil).List), arg0, arg1)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWebhookBiz) ListDeliveries(arg0 context.Context, arg1, arg2 string, arg3 *v1.ListWebhookDeliveryRequest) (*v1.ListWebhookDeliveryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.ListWebhookDeliveryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWebhookBizMockRecorder) ListDeliveries(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookBiz)(nil).ListDeliveries), arg0, arg1, arg2, arg3)
+}
+
+// PurgeDeliveries mocks base method.
+func (m *MockWebhookBiz) PurgeDeliveries(arg0 context.Context, arg1 time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeliveries", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeliveries indicates an expected call of PurgeDeliveries.
+func (mr *MockWebhookBizMockRecorder) PurgeDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeliveries", reflect.TypeOf((*MockWebhookBiz)(nil).PurgeDeliveries), arg0, arg1)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhookBiz) Redeliver(arg0 context.Context, arg1, arg2, arg3 string) (*v1.RedeliverWebhookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.RedeliverWebhookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookBizMockRecorder) Redeliver(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhookBiz)(nil).Redeliver), arg0, arg1, arg2, arg3)
+}
+
+// Update mocks base method.
+func (m *MockWebhookBiz) Update(arg0 context.Context, arg1, arg2 string, arg3 *v1.UpdateWebhookRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookBizMockRecorder) Update(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookBiz)(nil).Update), arg0, arg1, arg2, arg3)
+}
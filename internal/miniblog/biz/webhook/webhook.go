@@ -0,0 +1,423 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package webhook
+
+//go:generate mockgen -destination mock_webhook.go -package webhook github.com/marmotedu/miniblog/internal/miniblog/biz/webhook WebhookBiz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/webhook"
+)
+
+// The event types which can be subscribed by webhooks.
+const (
+	EventPostCreated   = "post.created"
+	EventPostUpdated   = "post.updated"
+	EventPostDeleted   = "post.deleted"
+	EventPostRestored  = "post.restored"
+	EventPostPublished = "post.published"
+
+	EventUserUpdated         = "user.updated"
+	EventUserPasswordChanged = "user.password_changed"
+	EventUserRenamed         = "user.renamed"
+	EventUserFollowed        = "user.followed"
+	EventUserUnfollowed      = "user.unfollowed"
+
+	// EventAll subscribes to all the events, including the ones added in the future.
+	EventAll = "*"
+)
+
+// Events are all the event types, in the order they are documented.
+var Events = []string{
+	EventPostCreated, EventPostUpdated, EventPostDeleted, EventPostRestored, EventPostPublished,
+	EventUserUpdated, EventUserPasswordChanged, EventUserRenamed, EventUserFollowed, EventUserUnfollowed,
+}
+
+// MaxWebhooks is the maximum number of webhooks of a user.
+const MaxWebhooks = 20
+
+// WebhookBiz defines functions used to handle webhook request.
+type WebhookBiz interface {
+	Create(ctx context.Context, username string, r *v1.CreateWebhookRequest) (*v1.CreateWebhookResponse, error)
+	Get(ctx context.Context, username, webhookID string) (*v1.GetWebhookResponse, error)
+	List(ctx context.Context, username string) (*v1.ListWebhookResponse, error)
+	Update(ctx context.Context, username, webhookID string, r *v1.UpdateWebhookRequest) error
+	Delete(ctx context.Context, username, webhookID string) error
+	ListDeliveries(ctx context.Context, username, webhookID string, r *v1.ListWebhookDeliveryRequest) (*v1.ListWebhookDeliveryResponse, error)
+	Redeliver(ctx context.Context, username, webhookID, deliveryID string) (*v1.RedeliverWebhookResponse, error)
+	Deliver(ctx context.Context) (int64, error)
+	PurgeDeliveries(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// The implementation of WebhookBiz interface.
+type webhookBiz struct {
+	ds store.IStore
+}
+
+// Make sure that webhookBiz implements the WebhookBiz interface.
+var _ WebhookBiz = (*webhookBiz)(nil)
+
+func New(ds store.IStore) *webhookBiz {
+	return &webhookBiz{ds: ds}
+}
+
+// Create is the implementation of the `Create` method in WebhookBiz interface.
+// A random secret is generated if none is given, the secret is only returned here.
+func (b *webhookBiz) Create(ctx context.Context, username string, r *v1.CreateWebhookRequest) (*v1.CreateWebhookResponse, error) {
+	if err := checkURL(r.URL); err != nil {
+		return nil, err
+	}
+
+	events, err := normalizeEvents(r.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := b.ds.Webhooks().List(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(webhooks) >= MaxWebhooks {
+		return nil, errno.ErrWebhookLimitExceeded
+	}
+
+	secret := r.Secret
+	if secret == "" {
+		if secret, err = webhook.GenerateSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	webhookM := &model.WebhookM{
+		Username: username,
+		URL:      r.URL,
+		Secret:   secret,
+		Events:   strings.Join(events, ","),
+		Active:   true,
+	}
+	if err := b.ds.Webhooks().Create(ctx, webhookM); err != nil {
+		return nil, err
+	}
+
+	return &v1.CreateWebhookResponse{WebhookID: webhookM.WebhookID, Secret: secret}, nil
+}
+
+// Get is the implementation of the `Get` method in WebhookBiz interface.
+func (b *webhookBiz) Get(ctx context.Context, username, webhookID string) (*v1.GetWebhookResponse, error) {
+	webhookM, err := b.get(ctx, username, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*v1.GetWebhookResponse)(webhookInfo(webhookM)), nil
+}
+
+// List is the implementation of the `List` method in WebhookBiz interface.
+func (b *webhookBiz) List(ctx context.Context, username string) (*v1.ListWebhookResponse, error) {
+	list, err := b.ds.Webhooks().List(ctx, username)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to list webhooks from storage", "err", err)
+		return nil, err
+	}
+
+	webhooks := make([]*v1.WebhookInfo, 0, len(list))
+	for _, item := range list {
+		webhooks = append(webhooks, webhookInfo(item))
+	}
+
+	return &v1.ListWebhookResponse{TotalCount: int64(len(list)), Webhooks: webhooks}, nil
+}
+
+// Update is the implementation of the `Update` method in WebhookBiz interface.
+// Enabling a disabled webhook resets its consecutive failures, so that it is not disabled again by the next failure.
+func (b *webhookBiz) Update(ctx context.Context, username, webhookID string, r *v1.UpdateWebhookRequest) error {
+	webhookM, err := b.get(ctx, username, webhookID)
+	if err != nil {
+		return err
+	}
+
+	if r.URL != nil {
+		if err := checkURL(*r.URL); err != nil {
+			return err
+		}
+
+		webhookM.URL = *r.URL
+	}
+
+	if r.Secret != nil {
+		webhookM.Secret = *r.Secret
+	}
+
+	if r.Events != nil {
+		events, err := normalizeEvents(r.Events)
+		if err != nil {
+			return err
+		}
+
+		webhookM.Events = strings.Join(events, ",")
+	}
+
+	disabled := false
+	if r.Active != nil && *r.Active != webhookM.Active {
+		webhookM.Active, webhookM.DisabledReason, webhookM.ConsecutiveFailures = *r.Active, "", 0
+		disabled = !webhookM.Active
+	}
+
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.Webhooks().Update(ctx, webhookM); err != nil {
+			return err
+		}
+
+		if !disabled {
+			return nil
+		}
+
+		_, err := b.ds.Deliveries().FailPending(ctx, webhookID, "The webhook was disabled.")
+
+		return err
+	})
+}
+
+// Delete is the implementation of the `Delete` method in WebhookBiz interface. The deliveries of the webhook are deleted as well.
+func (b *webhookBiz) Delete(ctx context.Context, username, webhookID string) error {
+	return b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.Webhooks().Delete(ctx, username, webhookID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.ErrWebhookNotFound
+			}
+
+			return err
+		}
+
+		_, err := b.ds.Deliveries().DeleteByWebhookID(ctx, webhookID)
+
+		return err
+	})
+}
+
+// ListDeliveries is the implementation of the `ListDeliveries` method in WebhookBiz interface.
+func (b *webhookBiz) ListDeliveries(
+	ctx context.Context,
+	username, webhookID string,
+	r *v1.ListWebhookDeliveryRequest,
+) (*v1.ListWebhookDeliveryResponse, error) {
+	if _, err := b.get(ctx, username, webhookID); err != nil {
+		return nil, err
+	}
+
+	count, list, err := b.ds.Deliveries().List(ctx, webhookID, r.Offset, r.Limit)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to list webhook deliveries from storage", "err", err)
+		return nil, err
+	}
+
+	deliveries := make([]*v1.WebhookDeliveryInfo, 0, len(list))
+	for _, item := range list {
+		deliveries = append(deliveries, deliveryInfo(item))
+	}
+
+	return &v1.ListWebhookDeliveryResponse{TotalCount: count, Deliveries: deliveries}, nil
+}
+
+// Redeliver is the implementation of the `Redeliver` method in WebhookBiz interface.
+// A new delivery with the payload of the original one is queued, only the delivery ID in the payload is changed.
+func (b *webhookBiz) Redeliver(ctx context.Context, username, webhookID, deliveryID string) (*v1.RedeliverWebhookResponse, error) {
+	webhookM, err := b.get(ctx, username, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !webhookM.Active {
+		return nil, errno.ErrWebhookDisabled
+	}
+
+	original, err := b.ds.Deliveries().Get(ctx, webhookID, deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrDeliveryNotFound
+		}
+
+		return nil, err
+	}
+
+	// Keep the data as it is, it was encoded when the event occurred.
+	var payload struct {
+		v1.WebhookPayload
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(original.Payload), &payload); err != nil {
+		return nil, err
+	}
+
+	delivery := newDelivery(webhookM, original.Event)
+	payload.ID = delivery.DeliveryID
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Payload = string(data)
+	if err := b.ds.Deliveries().Create(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return &v1.RedeliverWebhookResponse{DeliveryID: delivery.DeliveryID}, nil
+}
+
+// PurgeDeliveries is the implementation of the `PurgeDeliveries` method in WebhookBiz interface.
+// It deletes the finished deliveries created more than retention ago, the pending ones are kept until they finish.
+func (b *webhookBiz) PurgeDeliveries(ctx context.Context, retention time.Duration) (int64, error) {
+	return b.ds.Deliveries().DeleteBefore(ctx, time.Now().Add(-retention))
+}
+
+// get gets the webhook of username, errno.ErrWebhookNotFound is returned if it does not exist.
+func (b *webhookBiz) get(ctx context.Context, username, webhookID string) (*model.WebhookM, error) {
+	webhookM, err := b.ds.Webhooks().Get(ctx, username, webhookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrWebhookNotFound
+		}
+
+		return nil, err
+	}
+
+	return webhookM, nil
+}
+
+// checkURL checks that the webhook URL is an http or https URL. URLs whose host is a private IP address or
+// localhost are rejected early unless private addresses are allowed, the addresses which hosts resolve to
+// are checked again when connecting.
+func checkURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.User != nil {
+		return errno.ErrWebhookURLInvalid
+	}
+
+	if config.allowPrivate {
+		return nil
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errno.ErrWebhookURLInvalid
+	}
+
+	if ip := net.ParseIP(host); ip != nil && webhook.IsPrivateIP(ip) {
+		return errno.ErrWebhookURLInvalid
+	}
+
+	return nil
+}
+
+// normalizeEvents checks the subscribed events and removes the duplicates. Subscribing to all events
+// replaces the other events.
+func normalizeEvents(events []string) ([]string, error) {
+	if len(events) == 0 {
+		return nil, errno.ErrWebhookEventInvalid.SetMessage("At least one event type must be subscribed.")
+	}
+
+	seen := make(map[string]bool, len(events))
+	ret := make([]string, 0, len(events))
+	for _, event := range events {
+		event = strings.TrimSpace(event)
+		if event == EventAll {
+			return []string{EventAll}, nil
+		}
+
+		if !isEvent(event) {
+			return nil, errno.ErrWebhookEventInvalid.SetMessage("Unknown webhook event type %q.", event)
+		}
+
+		if !seen[event] {
+			seen[event] = true
+			ret = append(ret, event)
+		}
+	}
+
+	return ret, nil
+}
+
+// isEvent reports whether event is a known event type.
+func isEvent(event string) bool {
+	for _, e := range Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// subscribes reports whether webhook subscribes to event.
+func subscribes(webhook *model.WebhookM, event string) bool {
+	for _, e := range strings.Split(webhook.Events, ",") {
+		if e == event || e == EventAll {
+			return true
+		}
+	}
+
+	return false
+}
+
+// webhookInfo converts a webhook record to the response, the secret is not returned.
+func webhookInfo(webhook *model.WebhookM) *v1.WebhookInfo {
+	info := &v1.WebhookInfo{
+		WebhookID:           webhook.WebhookID,
+		URL:                 webhook.URL,
+		Events:              strings.Split(webhook.Events, ","),
+		Active:              webhook.Active,
+		DisabledReason:      webhook.DisabledReason,
+		ConsecutiveFailures: webhook.ConsecutiveFailures,
+		CreatedAt:           webhook.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:           webhook.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if webhook.LastDeliveryAt != nil {
+		info.LastDeliveryAt = webhook.LastDeliveryAt.Format("2006-01-02 15:04:05")
+	}
+
+	return info
+}
+
+// deliveryInfo converts a delivery record to the response.
+func deliveryInfo(delivery *model.WebhookDeliveryM) *v1.WebhookDeliveryInfo {
+	info := &v1.WebhookDeliveryInfo{
+		DeliveryID: delivery.DeliveryID,
+		Event:      delivery.Event,
+		Status:     delivery.Status,
+		Attempts:   delivery.Attempts,
+		StatusCode: delivery.StatusCode,
+		Response:   delivery.Response,
+		Error:      delivery.Error,
+		Payload:    delivery.Payload,
+		CreatedAt:  delivery.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if delivery.Status == model.DeliveryStatusPending {
+		info.NextAttemptAt = delivery.NextAttemptAt.Format("2006-01-02 15:04:05")
+	}
+
+	if delivery.DeliveredAt != nil {
+		info.DeliveredAt = delivery.DeliveredAt.Format("2006-01-02 15:04:05")
+	}
+
+	return info
+}
@@ -0,0 +1,21 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package category
+
+import (
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+)
+
+// CategoryController 是 category 模块在 Controller 层的实现，用来处理分类模块的请求.
+type CategoryController struct {
+	b biz.IBiz
+}
+
+// New 创建一个 category controller.
+func New(ds store.IStore) *CategoryController {
+	return &CategoryController{b: biz.NewBiz(ds)}
+}
@@ -0,0 +1,27 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package category
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// List 以树形结构返回所有分类.
+func (ctrl *CategoryController) List(c *gin.Context) {
+	log.C(c).Infow("List category function called")
+
+	resp, err := ctrl.b.Categories().List(c)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
@@ -0,0 +1,27 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package comment
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// Delete 删除评论，评论作者和博客作者都可以删除.
+func (ctrl *CommentController) Delete(c *gin.Context) {
+	log.C(c).Infow("Delete comment function called")
+
+	if err := ctrl.b.Comments().Delete(c, c.GetString(known.XUsernameKey), c.Param("postID"), c.Param("commentID")); err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
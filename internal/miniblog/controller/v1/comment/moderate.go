@@ -0,0 +1,40 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package comment
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// Approve 公开评论，只有博客作者才能审核.
+func (ctrl *CommentController) Approve(c *gin.Context) {
+	log.C(c).Infow("Approve comment function called")
+
+	if err := ctrl.b.Comments().Approve(c, c.GetString(known.XUsernameKey), c.Param("postID"), c.Param("commentID")); err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
+
+// Hide 隐藏评论，只有博客作者才能隐藏.
+func (ctrl *CommentController) Hide(c *gin.Context) {
+	log.C(c).Infow("Hide comment function called")
+
+	if err := ctrl.b.Comments().Hide(c, c.GetString(known.XUsernameKey), c.Param("postID"), c.Param("commentID")); err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package comment
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/likexian/gokit/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/comment"
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+)
+
+func TestCommentController_Moderate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCommentBiz := comment.NewMockCommentBiz(ctrl)
+	mockBiz := biz.NewMockIBiz(ctrl)
+	mockCommentBiz.EXPECT().Approve(gomock.Any(), gomock.Any(), "post-22vtll", "comment-1").Return(nil).Times(1)
+	mockCommentBiz.EXPECT().Hide(gomock.Any(), gomock.Any(), "post-22vtll", "comment-1").Return(nil).Times(1)
+	mockCommentBiz.EXPECT().Hide(gomock.Any(), gomock.Any(), "post-22vtll", "comment-2").Return(errno.ErrUnauthorized).Times(1)
+	mockBiz.EXPECT().Comments().AnyTimes().Return(mockCommentBiz)
+
+	cc := &CommentController{b: mockBiz}
+	g := gin.New()
+	g.POST("/v1/posts/:postID/comments/:commentID", core.CustomVerbs("commentID", map[string]gin.HandlerFunc{
+		"approve": cc.Approve,
+		"hide":    cc.Hide,
+	}))
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "approve", path: "/v1/posts/post-22vtll/comments/comment-1:approve", want: http.StatusOK},
+		{name: "hide", path: "/v1/posts/post-22vtll/comments/comment-1:hide", want: http.StatusOK},
+		{name: "not post owner", path: "/v1/posts/post-22vtll/comments/comment-2:hide", want: http.StatusUnauthorized},
+		{name: "unknown verb", path: "/v1/posts/post-22vtll/comments/comment-1:pin", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, httptest.NewRequest("POST", tt.path, nil))
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
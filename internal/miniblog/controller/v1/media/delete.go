@@ -0,0 +1,27 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package media
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// Delete 删除当前用户上传的媒体文件，引用了该文件的博客中将无法再显示该文件.
+func (ctrl *MediaController) Delete(c *gin.Context) {
+	log.C(c).Infow("Delete media function called")
+
+	if err := ctrl.b.Media().Delete(c, c.GetString(known.XUsernameKey), c.Param("mediaID")); err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
@@ -0,0 +1,21 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package media
+
+import (
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+)
+
+// MediaController 是 media 模块在 Controller 层的实现，用来处理媒体文件的上传和访问请求.
+type MediaController struct {
+	b biz.IBiz
+}
+
+// New 创建一个 media controller.
+func New(ds store.IStore) *MediaController {
+	return &MediaController{b: biz.NewBiz(ds)}
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package media
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// Get 返回媒体文件的内容，不需要认证.
+func (ctrl *MediaController) Get(c *gin.Context) {
+	log.C(c).Infow("Get media function called")
+
+	ctrl.serve(c, false)
+}
+
+// GetThumbnail 返回媒体文件缩略图的内容，没有缩略图时返回原文件，不需要认证.
+func (ctrl *MediaController) GetThumbnail(c *gin.Context) {
+	log.C(c).Infow("Get media thumbnail function called")
+
+	ctrl.serve(c, true)
+}
+
+// serve 将媒体文件或缩略图的内容写入响应.
+func (ctrl *MediaController) serve(c *gin.Context, thumbnail bool) {
+	obj, err := ctrl.b.Media().Open(c, c.Param("mediaID"), thumbnail)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+	defer obj.Close()
+
+	// 禁止浏览器根据内容猜测类型，避免上传的文件被当作 HTML 执行
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": obj.Filename}))
+	c.DataFromReader(http.StatusOK, obj.Size, obj.ContentType, obj, nil)
+}
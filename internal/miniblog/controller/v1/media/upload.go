@@ -0,0 +1,68 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package media
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/media"
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// multipartOverhead 是请求体中除文件内容之外的 multipart 头部等内容允许的最大长度.
+const multipartOverhead = 64 << 10
+
+// Upload 上传一个媒体文件，文件内容通过 multipart/form-data 请求的 file 字段上传.
+func (ctrl *MediaController) Upload(c *gin.Context) {
+	log.C(c).Infow("Upload media function called")
+
+	// 在解析请求之前限制请求体的大小，避免超大的请求占用磁盘和内存
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, media.MaxSize()+multipartOverhead)
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			core.WriteResponse(c, errno.ErrMediaTooLarge, nil)
+		case errors.Is(err, http.ErrMissingFile):
+			core.WriteResponse(c, errno.ErrMediaFileRequired, nil)
+		default:
+			core.WriteResponse(c, errno.ErrBind, nil)
+		}
+
+		return
+	}
+
+	if fh.Size > media.MaxSize() {
+		core.WriteResponse(c, errno.ErrMediaTooLarge, nil)
+
+		return
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+	defer f.Close()
+
+	resp, err := ctrl.b.Media().Upload(c, c.GetString(known.XUsernameKey), fh.Filename, f)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
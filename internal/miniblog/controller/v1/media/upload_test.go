@@ -0,0 +1,74 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package media
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/likexian/gokit/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/media"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+func multipartBody(field, filename, content string) (io.Reader, string) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	fw, _ := w.CreateFormFile(field, filename)
+	io.WriteString(fw, content)
+	w.Close()
+
+	return &buf, w.FormDataContentType()
+}
+
+func TestMediaController_Upload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	media.Init(1024, 0)
+
+	mockMediaBiz := media.NewMockMediaBiz(ctrl)
+	mockBiz := biz.NewMockIBiz(ctrl)
+	mockMediaBiz.EXPECT().Upload(gomock.Any(), gomock.Any(), "cat.png", gomock.Any()).Return(&v1.MediaInfo{MediaID: "media-1"}, nil).Times(1)
+	mockBiz.EXPECT().Media().AnyTimes().Return(mockMediaBiz)
+
+	mc := &MediaController{b: mockBiz}
+	g := gin.New()
+	g.POST("/v1/media", mc.Upload)
+
+	tests := []struct {
+		name     string
+		field    string
+		filename string
+		content  string
+		want     int
+	}{
+		{name: "default", field: "file", filename: "cat.png", content: "data", want: http.StatusOK},
+		{name: "missing file", field: "image", filename: "cat.png", content: "data", want: http.StatusBadRequest},
+		{name: "too large", field: "file", filename: "big.png", content: strings.Repeat("x", 2048), want: http.StatusRequestEntityTooLarge},
+		{name: "body too large", field: "file", filename: "huge.png", content: strings.Repeat("x", 128<<10), want: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, contentType := multipartBody(tt.field, tt.filename, tt.content)
+			req := httptest.NewRequest(http.MethodPost, "/v1/media", body)
+			req.Header.Set("Content-Type", contentType)
+
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
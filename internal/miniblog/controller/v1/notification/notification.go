@@ -0,0 +1,21 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package notification
+
+import (
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+)
+
+// NotificationController 是 notification 模块在 Controller 层的实现，用来处理用户通知和通知偏好的请求.
+type NotificationController struct {
+	b biz.IBiz
+}
+
+// New 创建一个 notification controller.
+func New(ds store.IStore) *NotificationController {
+	return &NotificationController{b: biz.NewBiz(ds)}
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package notification
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// GetPreferences 返回当前用户接收哪些类型的通知，未设置过的用户接收所有类型的通知.
+func (ctrl *NotificationController) GetPreferences(c *gin.Context) {
+	log.C(c).Infow("Get notification preferences function called")
+
+	resp, err := ctrl.b.Notifications().GetPreferences(c, c.GetString(known.XUsernameKey))
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
+
+// UpdatePreferences 修改当前用户接收哪些类型的通知，关闭的类型不再产生新的通知.
+func (ctrl *NotificationController) UpdatePreferences(c *gin.Context) {
+	log.C(c).Infow("Update notification preferences function called")
+
+	var r v1.UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&r); err != nil {
+		core.WriteResponse(c, errno.ErrBind, nil)
+
+		return
+	}
+
+	if err := ctrl.b.Notifications().UpdatePreferences(c, c.GetString(known.XUsernameKey), &r); err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
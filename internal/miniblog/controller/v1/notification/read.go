@@ -0,0 +1,41 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package notification
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// Read 将一条通知标记为已读.
+func (ctrl *NotificationController) Read(c *gin.Context) {
+	log.C(c).Infow("Read notification function called")
+
+	if err := ctrl.b.Notifications().Read(c, c.GetString(known.XUsernameKey), c.Param("notificationID")); err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
+
+// ReadAll 将当前用户所有未读的通知标记为已读.
+func (ctrl *NotificationController) ReadAll(c *gin.Context) {
+	log.C(c).Infow("Read all notifications function called")
+
+	resp, err := ctrl.b.Notifications().ReadAll(c, c.GetString(known.XUsernameKey))
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/archive"
+)
+
+// Export 将当前用户的所有博客（不包括回收站中的博客）导出为归档文件下载.
+func (ctrl *PostController) Export(c *gin.Context) {
+	log.C(c).Infow("Export post function called")
+
+	var r v1.ExportPostRequest
+	if err := c.ShouldBindQuery(&r); err != nil {
+		core.WriteResponse(c, errno.ErrBind, nil)
+
+		return
+	}
+
+	if _, err := govalidator.ValidateStruct(r); err != nil {
+		core.WriteResponse(c, errno.ErrInvalidParameter.SetMessage(err.Error()), nil)
+
+		return
+	}
+
+	if r.Format == "" {
+		r.Format = archive.FormatMarkdown
+	}
+
+	username := c.GetString(known.XUsernameKey)
+	filename := fmt.Sprintf("%s-posts-%s%s", username, time.Now().Format("20060102"), archive.Extension(r.Format))
+	c.Header("Content-Type", archive.ContentType(r.Format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	// 归档文件直接写入响应，只有在写入任何内容之前发生的错误才能返回给客户端
+	if err := ctrl.b.Posts().Export(c, username, r.Format, c.Writer); err != nil {
+		log.C(c).Errorw("Failed to export posts", "err", err)
+
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			core.WriteResponse(c, err, nil)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/likexian/gokit/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/post"
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+)
+
+func TestPostController_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPostBiz := post.NewMockPostBiz(ctrl)
+	mockBiz := biz.NewMockIBiz(ctrl)
+	mockPostBiz.EXPECT().Export(gomock.Any(), gomock.Any(), "jsonl", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, username, format string, w io.Writer) error {
+			_, err := io.WriteString(w, `{"title":"a","content":"b"}`+"\n")
+
+			return err
+		}).Times(1)
+	mockPostBiz.EXPECT().Export(gomock.Any(), gomock.Any(), "markdown", gomock.Any()).Return(errors.New("database is down")).Times(1)
+	mockBiz.EXPECT().Posts().AnyTimes().Return(mockPostBiz)
+
+	pc := &PostController{b: mockBiz}
+	g := gin.New()
+	g.GET("/v1/posts:verb", core.CustomVerbs("verb", map[string]gin.HandlerFunc{"export": pc.Export}))
+
+	tests := []struct {
+		name     string
+		path     string
+		want     int
+		wantType string
+	}{
+		{name: "jsonl", path: "/v1/posts:export?format=jsonl", want: http.StatusOK, wantType: "application/x-ndjson"},
+		{name: "error before writing", path: "/v1/posts:export", want: http.StatusInternalServerError, wantType: "application/json; charset=utf-8"},
+		{name: "unknown format", path: "/v1/posts:export?format=wxr", want: http.StatusBadRequest, wantType: "application/json; charset=utf-8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, tt.wantType, w.Header().Get("Content-Type"))
+
+			if tt.want == http.StatusOK {
+				assert.True(t, strings.HasPrefix(w.Header().Get("Content-Disposition"), "attachment; filename="))
+			} else {
+				assert.Equal(t, "", w.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/pkg/feed"
+)
+
+// RSS 返回用户最新发布的公开博客的 RSS 2.0 订阅源，不需要认证.
+func (ctrl *PostController) RSS(c *gin.Context) {
+	log.C(c).Infow("Get RSS feed function called")
+
+	ctrl.feed(c, feed.FormatRSS)
+}
+
+// Atom 返回用户最新发布的公开博客的 Atom 1.0 订阅源，不需要认证.
+func (ctrl *PostController) Atom(c *gin.Context) {
+	log.C(c).Infow("Get Atom feed function called")
+
+	ctrl.feed(c, feed.FormatAtom)
+}
+
+// JSONFeed 返回用户最新发布的公开博客的 JSON Feed 1.1 订阅源，不需要认证.
+func (ctrl *PostController) JSONFeed(c *gin.Context) {
+	log.C(c).Infow("Get JSON feed function called")
+
+	ctrl.feed(c, feed.FormatJSON)
+}
+
+// feed 生成指定格式的订阅源并写入响应. 响应的 Last-Modified 为订阅源的更新时间，路由上的 Cache 中间件
+// 根据内容生成 ETag，订阅源没有变化时，带有 If-None-Match 或 If-Modified-Since 请求头的条件请求会得到 304 响应.
+func (ctrl *PostController) feed(c *gin.Context, format string) {
+	base := baseURL(c.Request)
+
+	f, err := ctrl.b.Posts().Feed(c, c.Param("name"), base)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	f.FeedURL = base + c.Request.URL.Path
+
+	data, contentType, err := feed.Render(f, format)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	c.Header("Last-Modified", f.Updated.UTC().Format(http.TimeFormat))
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// baseURL 返回客户端访问服务时使用的协议和地址，例如 `https://blog.example.com`. 服务部署在 HTTPS 反向代理之后时，
+// 代理需要设置 X-Forwarded-Proto 请求头.
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	} else if proto := r.Header.Get("X-Forwarded-Proto"); proto == "https" || proto == "http" {
+		scheme = proto
+	}
+
+	return scheme + "://" + strings.TrimSuffix(r.Host, "/")
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/likexian/gokit/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/post"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	mw "github.com/marmotedu/miniblog/internal/pkg/middleware"
+	"github.com/marmotedu/miniblog/pkg/feed"
+)
+
+func TestPostController_Feed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	updated := time.Date(2022, 11, 20, 8, 30, 0, 0, time.UTC)
+
+	mockPostBiz := post.NewMockPostBiz(ctrl)
+	mockBiz := biz.NewMockIBiz(ctrl)
+	mockPostBiz.EXPECT().Feed(gomock.Any(), "belm", "https://blog.example.com").DoAndReturn(
+		func(ctx interface{}, username, baseURL string) (*feed.Feed, error) {
+			return &feed.Feed{Title: "belm", Link: baseURL + "/v1/users/belm/posts", Updated: updated}, nil
+		}).AnyTimes()
+	mockPostBiz.EXPECT().Feed(gomock.Any(), "nobody", gomock.Any()).Return(nil, errno.ErrUserNotFound).AnyTimes()
+	mockBiz.EXPECT().Posts().AnyTimes().Return(mockPostBiz)
+
+	pc := &PostController{b: mockBiz}
+	g := gin.New()
+	cache := mw.Cache(time.Minute)
+	g.GET("/v1/users/:name/feed.rss", cache, pc.RSS)
+	g.GET("/v1/users/:name/feed.atom", cache, pc.Atom)
+	g.GET("/v1/users/:name/feed.json", cache, pc.JSONFeed)
+
+	tests := []struct {
+		name            string
+		path            string
+		ifModifiedSince string
+		want            int
+		wantType        string
+	}{
+		{name: "rss", path: "/v1/users/belm/feed.rss", want: http.StatusOK, wantType: "application/rss+xml; charset=utf-8"},
+		{name: "atom", path: "/v1/users/belm/feed.atom", want: http.StatusOK, wantType: "application/atom+xml; charset=utf-8"},
+		{name: "json", path: "/v1/users/belm/feed.json", want: http.StatusOK, wantType: "application/feed+json; charset=utf-8"},
+		{name: "not modified", path: "/v1/users/belm/feed.rss", ifModifiedSince: "Sun, 20 Nov 2022 08:30:00 GMT", want: http.StatusNotModified},
+		{name: "modified", path: "/v1/users/belm/feed.rss", ifModifiedSince: "Sat, 19 Nov 2022 00:00:00 GMT", want: http.StatusOK},
+		{name: "user not found", path: "/v1/users/nobody/feed.atom", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Host = "blog.example.com"
+			req.Header.Set("X-Forwarded-Proto", "https")
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code)
+
+			if tt.want == http.StatusOK && tt.wantType != "" {
+				assert.Equal(t, tt.wantType, w.Header().Get("Content-Type"))
+				assert.Equal(t, "Sun, 20 Nov 2022 08:30:00 GMT", w.Header().Get("Last-Modified"))
+				assert.True(t, strings.Contains(w.Body.String(), "https://blog.example.com"+tt.path))
+			}
+		})
+	}
+}
@@ -9,15 +9,24 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
 	"github.com/marmotedu/miniblog/internal/pkg/known"
 	"github.com/marmotedu/miniblog/internal/pkg/log"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
 )
 
-// Get 获取指定的博客.
+// Get 获取指定的博客，contentView 参数指定返回原始内容还是渲染后的 HTML.
 func (ctrl *PostController) Get(c *gin.Context) {
 	log.C(c).Infow("Get post function called")
 
-	post, err := ctrl.b.Posts().Get(c, c.GetString(known.XUsernameKey), c.Param("postID"))
+	var r v1.GetPostRequest
+	if err := c.ShouldBindQuery(&r); err != nil {
+		core.WriteResponse(c, errno.ErrBind, nil)
+
+		return
+	}
+
+	post, err := ctrl.b.Posts().Get(c, c.GetString(known.XUsernameKey), c.Param("postID"), &r)
 	if err != nil {
 		core.WriteResponse(c, err, nil)
 
@@ -0,0 +1,109 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+const (
+	// maxImportSize 是导入的归档文件的最大字节数.
+	maxImportSize = 32 << 20
+
+	// multipartOverhead 是请求体中除文件内容之外的 multipart 头部等内容允许的最大长度.
+	multipartOverhead = 64 << 10
+)
+
+// Import 从上传的归档文件中导入博客. 归档文件解析成功后立即返回导入任务的 ID，博客在后台导入.
+func (ctrl *PostController) Import(c *gin.Context) {
+	log.C(c).Infow("Import post function called")
+
+	// 在解析请求之前限制请求体的大小，避免超大的请求占用磁盘和内存
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize+multipartOverhead)
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			core.WriteResponse(c, errno.ErrImportTooLarge, nil)
+		case errors.Is(err, http.ErrMissingFile):
+			core.WriteResponse(c, errno.ErrImportFileRequired, nil)
+		default:
+			core.WriteResponse(c, errno.ErrBind, nil)
+		}
+
+		return
+	}
+
+	if fh.Size > maxImportSize {
+		core.WriteResponse(c, errno.ErrImportTooLarge, nil)
+
+		return
+	}
+
+	var r v1.ImportPostRequest
+	if err := c.ShouldBind(&r); err != nil {
+		core.WriteResponse(c, errno.ErrBind, nil)
+
+		return
+	}
+
+	if _, err := govalidator.ValidateStruct(r); err != nil {
+		core.WriteResponse(c, errno.ErrInvalidParameter.SetMessage(err.Error()), nil)
+
+		return
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	resp, err := ctrl.b.Posts().Import(c, c.GetString(known.XUsernameKey), r.Format, data)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
+
+// GetImport 获取当前用户的博客导入任务的进度和结果.
+func (ctrl *PostController) GetImport(c *gin.Context) {
+	log.C(c).Infow("Get import function called")
+
+	resp, err := ctrl.b.Posts().GetImport(c, c.GetString(known.XUsernameKey), c.Param("importID"))
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
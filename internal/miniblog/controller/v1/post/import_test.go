@@ -0,0 +1,80 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/likexian/gokit/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/post"
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+func TestPostController_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPostBiz := post.NewMockPostBiz(ctrl)
+	mockBiz := biz.NewMockIBiz(ctrl)
+	mockPostBiz.EXPECT().Import(gomock.Any(), gomock.Any(), "jsonl", []byte("{}\n")).Return(&v1.ImportPostResponse{ImportID: "import-1"}, nil).Times(1)
+	mockPostBiz.EXPECT().Import(gomock.Any(), gomock.Any(), "", []byte("PK")).Return(nil, errno.ErrImportInvalid).Times(1)
+	mockPostBiz.EXPECT().GetImport(gomock.Any(), gomock.Any(), "import-1").Return(&v1.GetImportResponse{ImportID: "import-1"}, nil).Times(1)
+	mockPostBiz.EXPECT().GetImport(gomock.Any(), gomock.Any(), "import-2").Return(nil, errno.ErrImportNotFound).Times(1)
+	mockBiz.EXPECT().Posts().AnyTimes().Return(mockPostBiz)
+
+	pc := &PostController{b: mockBiz}
+	g := gin.New()
+	g.POST("/v1/posts:verb", core.CustomVerbs("verb", map[string]gin.HandlerFunc{"import": pc.Import}))
+	g.GET("/v1/imports/:importID", pc.GetImport)
+
+	tests := []struct {
+		name    string
+		field   string
+		format  string
+		content string
+		want    int
+	}{
+		{name: "default", field: "file", format: "jsonl", content: "{}\n", want: http.StatusOK},
+		{name: "invalid archive", field: "file", content: "PK", want: http.StatusBadRequest},
+		{name: "unknown format", field: "file", format: "csv", content: "{}\n", want: http.StatusBadRequest},
+		{name: "missing file", field: "archive", content: "{}\n", want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mw := multipart.NewWriter(&buf)
+			if tt.format != "" {
+				mw.WriteField("format", tt.format)
+			}
+			fw, _ := mw.CreateFormFile(tt.field, "posts.jsonl")
+			fw.Write([]byte(tt.content))
+			mw.Close()
+
+			req := httptest.NewRequest("POST", "/v1/posts:import", &buf)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+
+	for path, want := range map[string]int{"/v1/imports/import-1": http.StatusOK, "/v1/imports/import-2": http.StatusNotFound} {
+		w := httptest.NewRecorder()
+		g.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, want, w.Code)
+	}
+}
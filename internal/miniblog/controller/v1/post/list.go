@@ -6,13 +6,18 @@
 package post
 
 import (
+	"context"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/marmotedu/miniblog/internal/pkg/core"
 	"github.com/marmotedu/miniblog/internal/pkg/errno"
 	"github.com/marmotedu/miniblog/internal/pkg/known"
 	"github.com/marmotedu/miniblog/internal/pkg/log"
 	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	pb "github.com/marmotedu/miniblog/pkg/proto/miniblog/v1"
 )
 
 // List 返回博客列表.
@@ -26,7 +31,7 @@ func (ctrl *PostController) List(c *gin.Context) {
 		return
 	}
 
-	resp, err := ctrl.b.Posts().List(c, c.GetString(known.XUsernameKey), r.Offset, r.Limit)
+	resp, err := ctrl.b.Posts().List(c, c.GetString(known.XUsernameKey), &r)
 	if err != nil {
 		core.WriteResponse(c, err, nil)
 
@@ -35,3 +40,76 @@ func (ctrl *PostController) List(c *gin.Context) {
 
 	core.WriteResponse(c, nil, resp)
 }
+
+// ListPost 返回指定用户已发布的公开博客列表，username 为空时返回所有用户的.
+// gRPC 接口没有认证，因此和 `GET /v1/users/{name}/posts` 接口一样不返回草稿、定时发布和非公开的博客.
+func (ctrl *PostController) ListPost(ctx context.Context, r *pb.ListPostRequest) (*pb.ListPostResponse, error) {
+	log.C(ctx).Infow("ListPost function called")
+
+	resp, err := ctrl.b.Posts().ListPublished(ctx, r.Username, &v1.ListPostRequest{
+		Offset:         int(r.Offset),
+		Limit:          int(r.Limit),
+		PageToken:      r.PageToken,
+		SkipTotalCount: r.SkipTotalCount,
+		Title:          r.Title,
+		Content:        r.Content,
+		CreatedAfter:   fromTimestamp(r.CreatedAfter),
+		CreatedBefore:  fromTimestamp(r.CreatedBefore),
+		UpdatedAfter:   fromTimestamp(r.UpdatedAfter),
+		UpdatedBefore:  fromTimestamp(r.UpdatedBefore),
+		SortBy:         r.SortBy,
+		Order:          r.Order,
+		Fields:         r.Fields,
+		Tag:            r.Tag,
+		CategoryID:     r.CategoryID,
+		ContentView:    r.ContentView,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make([]*pb.PostInfo, 0, len(resp.Posts))
+	for _, p := range resp.Posts {
+		posts = append(posts, &pb.PostInfo{
+			Username:      p.Username,
+			PostID:        p.PostID,
+			Slug:          p.Slug,
+			Title:         p.Title,
+			Content:       p.Content,
+			CreatedAt:     toTimestamp(p.CreatedAt),
+			UpdatedAt:     toTimestamp(p.UpdatedAt),
+			Status:        p.Status,
+			Visibility:    p.Visibility,
+			PublishAt:     toTimestamp(p.PublishAt),
+			Tags:          p.Tags,
+			CategoryID:    p.CategoryID,
+			CommentPolicy: p.CommentPolicy,
+			CommentCount:  p.CommentCount,
+			Reactions:     p.Reactions,
+			ContentFormat: p.ContentFormat,
+			ContentHTML:   p.ContentHTML,
+		})
+	}
+
+	return &pb.ListPostResponse{TotalCount: resp.TotalCount, Posts: posts, NextPageToken: resp.NextPageToken}, nil
+}
+
+// fromTimestamp 将 protobuf 时间转换为 time.Time，未设置时返回零值.
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
+}
+
+// toTimestamp 将 PostInfo 中的时间转换为 protobuf 时间，时间为空（被字段选项过滤或尚未发布）时返回 nil.
+func toTimestamp(value string) *timestamppb.Timestamp {
+	if value == "" {
+		return nil
+	}
+
+	t, _ := time.ParseInLocation("2006-01-02 15:04:05", value, time.Local)
+
+	return timestamppb.New(t)
+}
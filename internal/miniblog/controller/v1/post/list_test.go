@@ -6,11 +6,20 @@
 package post
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/likexian/gokit/assert"
 
 	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/post"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	pb "github.com/marmotedu/miniblog/pkg/proto/miniblog/v1"
 )
 
 func TestPostController_List(t *testing.T) {
@@ -36,3 +45,68 @@ func TestPostController_List(t *testing.T) {
 		})
 	}
 }
+
+func TestPostController_List_query(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	want := &v1.ListPostRequest{
+		Limit:        5,
+		Title:        "go",
+		CreatedAfter: time.Date(2022, 11, 1, 0, 0, 0, 0, time.Local),
+		SortBy:       "title",
+		Order:        "asc",
+		Fields:       "postID,title",
+	}
+
+	mockPostBiz := post.NewMockPostBiz(ctrl)
+	mockBiz := biz.NewMockIBiz(ctrl)
+	mockPostBiz.EXPECT().List(gomock.Any(), gomock.Any(), want).Return(&v1.ListPostResponse{}, nil).Times(1)
+	mockBiz.EXPECT().Posts().AnyTimes().Return(mockPostBiz)
+
+	pc := &PostController{b: mockBiz}
+	g := gin.New()
+	g.GET("/v1/posts", pc.List)
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "default", path: "/v1/posts?limit=5&title=go&createdAfter=2022-11-01+00:00:00&sortBy=title&order=asc&fields=postID,title", want: http.StatusOK},
+		{name: "invalid time", path: "/v1/posts?createdAfter=yesterday", want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestPostController_ListPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 状态和可见性过滤条件被忽略，只能获取已发布的公开博客
+	want := &v1.ListPostRequest{Limit: 5, Title: "go"}
+	resp := &v1.ListPostResponse{TotalCount: 1, Posts: []*v1.PostInfo{{Username: "belm", PostID: "post-22222"}}}
+
+	mockPostBiz := post.NewMockPostBiz(ctrl)
+	mockBiz := biz.NewMockIBiz(ctrl)
+	mockPostBiz.EXPECT().ListPublished(gomock.Any(), "belm", want).Return(resp, nil).Times(1)
+	mockBiz.EXPECT().Posts().AnyTimes().Return(mockPostBiz)
+
+	pc := &PostController{b: mockBiz}
+	got, err := pc.ListPost(context.Background(), &pb.ListPostRequest{
+		Username:   "belm",
+		Limit:      5,
+		Title:      "go",
+		Status:     "draft",
+		Visibility: "private",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), got.TotalCount)
+	assert.Equal(t, "post-22222", got.Posts[0].PostID)
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// ListPublished 返回已发布的公开博客列表，不需要认证.
+// 路径中包含 name 参数时只返回该用户的博客，否则返回所有用户最新发布的博客.
+func (ctrl *PostController) ListPublished(c *gin.Context) {
+	log.C(c).Infow("List published post function called")
+
+	var r v1.ListPostRequest
+	if err := c.ShouldBindQuery(&r); err != nil {
+		core.WriteResponse(c, errno.ErrBind, nil)
+
+		return
+	}
+
+	resp, err := ctrl.b.Posts().ListPublished(c, c.Param("name"), &r)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
+
+// GetPublished 获取已发布博客的详情，不需要认证. 公开和不公开列出（unlisted）的博客都可以通过 postID 获取.
+func (ctrl *PostController) GetPublished(c *gin.Context) {
+	log.C(c).Infow("Get published post function called")
+
+	var r v1.GetPostRequest
+	if err := c.ShouldBindQuery(&r); err != nil {
+		core.WriteResponse(c, errno.ErrBind, nil)
+
+		return
+	}
+
+	post, err := ctrl.b.Posts().GetPublished(c, c.Param("postID"), &r)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, post)
+}
+
+// GetPublishedBySlug 通过作者的用户名和博客的 slug 获取已发布博客的详情，不需要认证.
+// 使用博客的旧 slug 访问时返回 301 重定向到博客当前的 slug.
+func (ctrl *PostController) GetPublishedBySlug(c *gin.Context) {
+	log.C(c).Infow("Get published post by slug function called")
+
+	var r v1.GetPostRequest
+	if err := c.ShouldBindQuery(&r); err != nil {
+		core.WriteResponse(c, errno.ErrBind, nil)
+
+		return
+	}
+
+	post, err := ctrl.b.Posts().GetPublishedBySlug(c, c.Param("username"), c.Param("slug"), &r)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	if post.Slug != c.Param("slug") {
+		location := url.URL{
+			Path:     "/v1/public/" + post.Username + "/" + post.Slug,
+			RawQuery: c.Request.URL.RawQuery,
+		}
+		c.Redirect(http.StatusMovedPermanently, location.String())
+
+		return
+	}
+
+	core.WriteResponse(c, nil, post)
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/likexian/gokit/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/post"
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+)
+
+func TestPostController_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPostBiz := post.NewMockPostBiz(ctrl)
+	mockBiz := biz.NewMockIBiz(ctrl)
+	mockPostBiz.EXPECT().Restore(gomock.Any(), gomock.Any(), "post-22vtll").Return(nil).Times(1)
+	mockPostBiz.EXPECT().Restore(gomock.Any(), gomock.Any(), "post-notfound").Return(errno.ErrPostNotFound).Times(1)
+	mockBiz.EXPECT().Posts().AnyTimes().Return(mockPostBiz)
+
+	pc := &PostController{b: mockBiz}
+	g := gin.New()
+	g.POST("/v1/posts/:postID", core.CustomVerbs("postID", map[string]gin.HandlerFunc{"restore": pc.Restore}))
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "default", path: "/v1/posts/post-22vtll:restore", want: http.StatusOK},
+		{name: "not in trash", path: "/v1/posts/post-notfound:restore", want: http.StatusNotFound},
+		{name: "unknown verb", path: "/v1/posts/post-22vtll:publish", want: http.StatusNotFound},
+		{name: "missing verb", path: "/v1/posts/post-22vtll", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, httptest.NewRequest("POST", tt.path, nil))
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
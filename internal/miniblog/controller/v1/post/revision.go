@@ -0,0 +1,87 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"strconv"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// ListRevisions 返回博客的历史版本列表.
+func (ctrl *PostController) ListRevisions(c *gin.Context) {
+	log.C(c).Infow("List post revisions function called")
+
+	var r v1.ListRevisionRequest
+	if err := c.ShouldBindQuery(&r); err != nil {
+		core.WriteResponse(c, errno.ErrBind, nil)
+
+		return
+	}
+
+	resp, err := ctrl.b.Posts().ListRevisions(c, c.GetString(known.XUsernameKey), c.Param("postID"), &r)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
+
+// DiffRevisions 返回博客两个历史版本之间内容的差异.
+func (ctrl *PostController) DiffRevisions(c *gin.Context) {
+	log.C(c).Infow("Diff post revisions function called")
+
+	var r v1.DiffRevisionRequest
+	if err := c.ShouldBindQuery(&r); err != nil {
+		core.WriteResponse(c, errno.ErrBind, nil)
+
+		return
+	}
+
+	if _, err := govalidator.ValidateStruct(r); err != nil {
+		core.WriteResponse(c, errno.ErrInvalidParameter.SetMessage(err.Error()), nil)
+
+		return
+	}
+
+	resp, err := ctrl.b.Posts().DiffRevisions(c, c.GetString(known.XUsernameKey), c.Param("postID"), &r)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
+
+// RestoreRevision 将博客的标题和内容恢复为指定的历史版本，恢复后的内容会保存为一个新的版本.
+func (ctrl *PostController) RestoreRevision(c *gin.Context) {
+	log.C(c).Infow("Restore post revision function called")
+
+	revision, err := strconv.ParseInt(c.Param("revision"), 10, 64)
+	if err != nil {
+		core.WriteResponse(c, errno.ErrRevisionNotFound, nil)
+
+		return
+	}
+
+	if err := ctrl.b.Posts().RestoreRevision(c, c.GetString(known.XUsernameKey), c.Param("postID"), revision); err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/likexian/gokit/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/post"
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+func TestPostController_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPostBiz := post.NewMockPostBiz(ctrl)
+	mockBiz := biz.NewMockIBiz(ctrl)
+	mockPostBiz.EXPECT().Search(gomock.Any(), gomock.Any(), &v1.SearchPostRequest{Query: "go modules", Limit: 5}).
+		Return(&v1.SearchPostResponse{}, nil).Times(1)
+	mockBiz.EXPECT().Posts().AnyTimes().Return(mockPostBiz)
+
+	pc := &PostController{b: mockBiz}
+	g := gin.New()
+	g.GET("/v1/posts:verb", core.CustomVerbs("verb", map[string]gin.HandlerFunc{"search": pc.Search}))
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "default", path: "/v1/posts:search?q=go+modules&limit=5", want: http.StatusOK},
+		{name: "missing query", path: "/v1/posts:search", want: http.StatusBadRequest},
+		{name: "unknown verb", path: "/v1/posts:find?q=go", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package post
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/likexian/gokit/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/post"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+func TestPostController_ListTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	want := &v1.ListTrashResponse{
+		TotalCount: 1,
+		Posts: []*v1.PostInfo{
+			{Username: "belm", PostID: "post-22vtll", Title: "miniblog installation guide", DeletedAt: "2022-11-20 10:00:00"},
+		},
+	}
+
+	mockPostBiz := post.NewMockPostBiz(ctrl)
+	mockBiz := biz.NewMockIBiz(ctrl)
+	mockPostBiz.EXPECT().ListTrash(gomock.Any(), gomock.Any(), 0, 10).Return(want, nil).Times(1)
+	mockBiz.EXPECT().Posts().AnyTimes().Return(mockPostBiz)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request, _ = http.NewRequest("GET", "/v1/trash?offset=0&limit=10", nil)
+
+	blw := &bodyLogWriter{
+		body:           bytes.NewBufferString(""),
+		ResponseWriter: c.Writer,
+	}
+	c.Writer = blw
+
+	type fields struct {
+		b biz.IBiz
+	}
+	type args struct {
+		c *gin.Context
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *v1.ListTrashResponse
+	}{
+		{
+			name:   "default",
+			fields: fields{b: mockBiz},
+			args: args{
+				c: c,
+			},
+			want: want,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := &PostController{
+				b: tt.fields.b,
+			}
+			ctrl.ListTrash(tt.args.c)
+			var resp v1.ListTrashResponse
+			err := json.Unmarshal(blw.body.Bytes(), &resp)
+			assert.Nil(t, err)
+			assert.Equal(t, &resp, tt.want)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package reaction
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// ReactComment 对评论添加 reaction，重复添加不会报错.
+func (ctrl *ReactionController) ReactComment(c *gin.Context) {
+	log.C(c).Infow("React comment function called")
+
+	err := ctrl.b.Reactions().ReactComment(c, c.GetString(known.XUsernameKey), c.Param("postID"), c.Param("commentID"), c.Param("type"))
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
+
+// UnreactComment 删除对评论添加的 reaction，reaction 不存在时不会报错.
+func (ctrl *ReactionController) UnreactComment(c *gin.Context) {
+	log.C(c).Infow("Unreact comment function called")
+
+	err := ctrl.b.Reactions().UnreactComment(c, c.GetString(known.XUsernameKey), c.Param("postID"), c.Param("commentID"), c.Param("type"))
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
@@ -0,0 +1,40 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package reaction
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// ReactPost 对博客添加 reaction，重复添加不会报错.
+func (ctrl *ReactionController) ReactPost(c *gin.Context) {
+	log.C(c).Infow("React post function called")
+
+	if err := ctrl.b.Reactions().ReactPost(c, c.GetString(known.XUsernameKey), c.Param("postID"), c.Param("type")); err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
+
+// UnreactPost 删除对博客添加的 reaction，reaction 不存在时不会报错.
+func (ctrl *ReactionController) UnreactPost(c *gin.Context) {
+	log.C(c).Infow("Unreact post function called")
+
+	if err := ctrl.b.Reactions().UnreactPost(c, c.GetString(known.XUsernameKey), c.Param("postID"), c.Param("type")); err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
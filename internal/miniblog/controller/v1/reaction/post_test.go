@@ -0,0 +1,58 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package reaction
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/likexian/gokit/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/reaction"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+)
+
+func TestReactionController_Post(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReactionBiz := reaction.NewMockReactionBiz(ctrl)
+	mockBiz := biz.NewMockIBiz(ctrl)
+	mockReactionBiz.EXPECT().ReactPost(gomock.Any(), gomock.Any(), "post-22vtll", "like").Return(nil).Times(2)
+	mockReactionBiz.EXPECT().ReactPost(gomock.Any(), gomock.Any(), "post-22vtll", "meh").Return(errno.ErrReactionTypeInvalid).Times(1)
+	mockReactionBiz.EXPECT().UnreactPost(gomock.Any(), gomock.Any(), "post-22vtll", "like").Return(nil).Times(1)
+	mockReactionBiz.EXPECT().UnreactPost(gomock.Any(), gomock.Any(), "post-none", "like").Return(errno.ErrPostNotFound).Times(1)
+	mockBiz.EXPECT().Reactions().AnyTimes().Return(mockReactionBiz)
+
+	rc := &ReactionController{b: mockBiz}
+	g := gin.New()
+	g.PUT("/v1/posts/:postID/reactions/:type", rc.ReactPost)
+	g.DELETE("/v1/posts/:postID/reactions/:type", rc.UnreactPost)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   int
+	}{
+		{name: "react", method: "PUT", path: "/v1/posts/post-22vtll/reactions/like", want: http.StatusOK},
+		{name: "react again", method: "PUT", path: "/v1/posts/post-22vtll/reactions/like", want: http.StatusOK},
+		{name: "unknown type", method: "PUT", path: "/v1/posts/post-22vtll/reactions/meh", want: http.StatusBadRequest},
+		{name: "unreact", method: "DELETE", path: "/v1/posts/post-22vtll/reactions/like", want: http.StatusOK},
+		{name: "post not found", method: "DELETE", path: "/v1/posts/post-none/reactions/like", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package stream
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/stream"
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// Events 打开当前用户的事件流，以 Server-Sent Events 的格式推送用户自己和关注的用户发布的新博客、用户博客下的新评论和新通知.
+// 客户端断线重连时通过 Last-Event-ID 请求头从最后收到的事件继续接收，缓冲区中已经没有的事件无法补发，此时会收到 reset 事件.
+// 事件流空闲时定期发送心跳注释，避免连接被代理服务器关闭.
+func (ctrl *StreamController) Events(c *gin.Context) {
+	log.C(c).Infow("Stream events function called")
+
+	s, backlog, err := ctrl.b.Streams().Open(c, c.GetString(known.XUsernameKey), c.GetHeader("Last-Event-ID"))
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+	defer s.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	// 禁止 Nginx 等反向代理缓冲响应，否则事件无法及时到达客户端
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	for _, m := range backlog {
+		writeMessage(c.Writer, m)
+	}
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(stream.Heartbeat())
+	defer ticker.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case m, ok := <-s.C:
+			// 事件流落后太多或服务器关闭时被关闭，客户端会重连并从缓冲区继续接收
+			if !ok {
+				return false
+			}
+
+			writeMessage(w, m)
+		case <-ticker.C:
+			_, _ = io.WriteString(w, ": heartbeat\n\n")
+		case <-c.Request.Context().Done():
+			return false
+		}
+
+		return true
+	})
+}
+
+// writeMessage 以 Server-Sent Events 的格式写入一个事件，事件数据是不包含换行的 JSON.
+func writeMessage(w io.Writer, m *stream.Message) {
+	_, _ = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", m.ID, m.Event, m.Data)
+}
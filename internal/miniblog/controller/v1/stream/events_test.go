@@ -0,0 +1,75 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package stream
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/likexian/gokit/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/stream"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+)
+
+func TestStreamController_Events(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub := stream.NewHub(10, 1)
+	_ = hub.Send("belm", stream.EventNotification, map[string]string{"notificationID": "notification-1"})
+	id := "unknown"
+
+	mockStreamBiz := stream.NewMockStreamBiz(ctrl)
+	mockStreamBiz.EXPECT().Open(gomock.Any(), "belm", id).DoAndReturn(
+		func(ctx interface{}, username, lastEventID string) (*stream.Subscription, []*stream.Message, error) {
+			s, backlog, err := hub.Subscribe(username, nil, lastEventID)
+
+			// The stream ends after the queued messages when the hub is closed.
+			_ = hub.Send("belm", stream.EventPost, map[string]string{"postID": "post-1"})
+			hub.Close()
+
+			return s, backlog, err
+		}).Times(1)
+	mockStreamBiz.EXPECT().Open(gomock.Any(), "colin", "").Return(nil, nil, errno.ErrStreamLimitExceeded).Times(1)
+
+	mockBiz := biz.NewMockIBiz(ctrl)
+	mockBiz.EXPECT().Streams().AnyTimes().Return(mockStreamBiz)
+
+	sc := &StreamController{b: mockBiz}
+	g := gin.New()
+	g.GET("/v1/events", func(c *gin.Context) {
+		c.Set(known.XUsernameKey, c.Query("username"))
+	}, sc.Events)
+
+	srv := httptest.NewServer(g)
+	defer srv.Close()
+
+	req, _ := http.NewRequest("GET", srv.URL+"/v1/events?username=belm", nil)
+	req.Header.Set("Last-Event-ID", id)
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// The client resuming from an unknown ID is told to reset before receiving the new messages.
+	assert.Contains(t, string(body), "event: reset\ndata: {}\n\n")
+	assert.Contains(t, string(body), "event: post\ndata: {\"postID\":\"post-1\"}\n\n")
+
+	resp, err = http.Get(srv.URL + "/v1/events?username=colin")
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+}
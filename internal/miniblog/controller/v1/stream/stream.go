@@ -0,0 +1,21 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package stream
+
+import (
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+)
+
+// StreamController 是 stream 模块在 Controller 层的实现，用来通过 Server-Sent Events 向用户推送新博客、评论和通知.
+type StreamController struct {
+	b biz.IBiz
+}
+
+// New 创建一个 stream controller.
+func New(ds store.IStore) *StreamController {
+	return &StreamController{b: biz.NewBiz(ds)}
+}
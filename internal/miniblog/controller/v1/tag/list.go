@@ -0,0 +1,27 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package tag
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// List 返回所有 tag 以及使用每个 tag 的已发布公开博客数.
+func (ctrl *TagController) List(c *gin.Context) {
+	log.C(c).Infow("List tag function called")
+
+	resp, err := ctrl.b.Tags().List(c)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package tag
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/likexian/gokit/assert"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/tag"
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+func TestTagController_Merge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTagBiz := tag.NewMockTagBiz(ctrl)
+	mockBiz := biz.NewMockIBiz(ctrl)
+	mockTagBiz.EXPECT().Merge(gomock.Any(), "golang", &v1.MergeTagRequest{Into: "go"}).Return(nil).Times(1)
+	mockTagBiz.EXPECT().Merge(gomock.Any(), "none", gomock.Any()).Return(errno.ErrTagNotFound).Times(1)
+	mockTagBiz.EXPECT().Rename(gomock.Any(), "golang", &v1.RenameTagRequest{Name: "go"}).Return(errno.ErrTagAlreadyExist).Times(1)
+	mockBiz.EXPECT().Tags().AnyTimes().Return(mockTagBiz)
+
+	tc := &TagController{b: mockBiz}
+	g := gin.New()
+	g.POST("/v1/tags/:name", core.CustomVerbs("name", map[string]gin.HandlerFunc{"rename": tc.Rename, "merge": tc.Merge}))
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want int
+	}{
+		{name: "merge", path: "/v1/tags/golang:merge", body: `{"into":"go"}`, want: http.StatusOK},
+		{name: "merge not found", path: "/v1/tags/none:merge", body: `{"into":"go"}`, want: http.StatusNotFound},
+		{name: "merge without target", path: "/v1/tags/golang:merge", body: `{}`, want: http.StatusBadRequest},
+		{name: "rename to existing tag", path: "/v1/tags/golang:rename", body: `{"name":"go"}`, want: http.StatusBadRequest},
+		{name: "unknown verb", path: "/v1/tags/golang:delete", body: `{}`, want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, httptest.NewRequest("POST", tt.path, bytes.NewBufferString(tt.body)))
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
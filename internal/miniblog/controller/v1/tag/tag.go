@@ -0,0 +1,21 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package tag
+
+import (
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+)
+
+// TagController 是 tag 模块在 Controller 层的实现，用来处理 tag 模块的请求.
+type TagController struct {
+	b biz.IBiz
+}
+
+// New 创建一个 tag controller.
+func New(ds store.IStore) *TagController {
+	return &TagController{b: biz.NewBiz(ds)}
+}
@@ -0,0 +1,100 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/user"
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// multipartOverhead 是请求体中除文件内容之外的 multipart 头部等内容允许的最大长度.
+const multipartOverhead = 64 << 10
+
+// UploadAvatar 上传用户的头像，图片通过 multipart/form-data 请求的 file 字段上传. 用户只能修改自己的头像.
+func (ctrl *UserController) UploadAvatar(c *gin.Context) {
+	log.C(c).Infow("Upload avatar function called")
+
+	if c.Param("name") != c.GetString(known.XUsernameKey) {
+		core.WriteResponse(c, errno.ErrUnauthorized, nil)
+
+		return
+	}
+
+	// 在解析请求之前限制请求体的大小，避免超大的请求占用磁盘和内存
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, user.MaxAvatarSize+multipartOverhead)
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			core.WriteResponse(c, errno.ErrAvatarTooLarge, nil)
+		} else {
+			core.WriteResponse(c, errno.ErrBind, nil)
+		}
+
+		return
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+	defer f.Close()
+
+	resp, err := ctrl.b.Users().UploadAvatar(c, c.Param("name"), f)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
+
+// DeleteAvatar 删除用户的头像. 用户只能删除自己的头像.
+func (ctrl *UserController) DeleteAvatar(c *gin.Context) {
+	log.C(c).Infow("Delete avatar function called")
+
+	if c.Param("name") != c.GetString(known.XUsernameKey) {
+		core.WriteResponse(c, errno.ErrUnauthorized, nil)
+
+		return
+	}
+
+	if err := ctrl.b.Users().DeleteAvatar(c, c.Param("name")); err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
+
+// GetAvatar 返回头像文件的内容，不需要认证. 每次上传的头像使用新的文件名，因此头像文件的内容不会改变.
+func (ctrl *UserController) GetAvatar(c *gin.Context) {
+	log.C(c).Infow("Get avatar function called")
+
+	avatar, err := ctrl.b.Users().OpenAvatar(c, c.Param("avatar"))
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+	defer avatar.Close()
+
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, -1, avatar.ContentType, avatar, nil)
+}
@@ -15,8 +15,6 @@ import (
 	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
 )
 
-const defaultMethods = "(GET)|(POST)|(PUT)|(DELETE)"
-
 // Create 创建一个新的用户.
 func (ctrl *UserController) Create(c *gin.Context) {
 	log.C(c).Infow("Create user function called")
@@ -33,9 +31,9 @@ func (ctrl *UserController) Create(c *gin.Context) {
 		core.WriteResponse(c, err, nil)
 		return
 	}
-	if _, err := ctrl.a.AddNamedPolicy("p", r.Username, "/v1/users/"+r.Username, defaultMethods); err != nil {
-		core.WriteResponse(c, err, nil)
-		return
+	// 用户的授权策略已在事务中添加，这里重新加载策略使其立即生效
+	if err := ctrl.a.LoadPolicy(); err != nil {
+		log.C(c).Errorw("Failed to reload authorization policy", "err", err)
 	}
 	core.WriteResponse(c, nil, nil)
 }
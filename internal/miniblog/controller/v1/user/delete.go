@@ -6,29 +6,42 @@
 package user
 
 import (
+	"github.com/asaskevich/govalidator"
 	"github.com/gin-gonic/gin"
 
 	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
 	"github.com/marmotedu/miniblog/internal/pkg/log"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
 )
 
 // Delete 删除一个用户.
 func (ctrl *UserController) Delete(c *gin.Context) {
 	log.C(c).Infow("Delete user function called")
 
-	username := c.Param("name")
+	var r v1.DeleteUserRequest
+	if err := c.ShouldBindQuery(&r); err != nil {
+		core.WriteResponse(c, errno.ErrBind, nil)
 
-	if err := ctrl.b.Users().Delete(c, username); err != nil {
-		core.WriteResponse(c, err, nil)
+		return
+	}
+
+	if _, err := govalidator.ValidateStruct(r); err != nil {
+		core.WriteResponse(c, errno.ErrInvalidParameter.SetMessage(err.Error()), nil)
 
 		return
 	}
 
-	if _, err := ctrl.a.RemoveNamedPolicy("p", username, "", ""); err != nil {
+	if err := ctrl.b.Users().Delete(c, c.Param("name"), &r); err != nil {
 		core.WriteResponse(c, err, nil)
 
 		return
 	}
 
+	// 用户的授权策略已在事务中删除，这里重新加载策略使其立即生效
+	if err := ctrl.a.LoadPolicy(); err != nil {
+		log.C(c).Errorw("Failed to reload authorization policy", "err", err)
+	}
+
 	core.WriteResponse(c, nil, nil)
 }
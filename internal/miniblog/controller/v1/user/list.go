@@ -30,7 +30,7 @@ func (ctrl *UserController) List(c *gin.Context) {
 		return
 	}
 
-	resp, err := ctrl.b.Users().List(c, r.Offset, r.Limit)
+	resp, err := ctrl.b.Users().List(c, &r)
 	if err != nil {
 		core.WriteResponse(c, err, nil)
 
@@ -44,7 +44,12 @@ func (ctrl *UserController) List(c *gin.Context) {
 func (ctrl *UserController) ListUser(ctx context.Context, r *pb.ListUserRequest) (*pb.ListUserResponse, error) {
 	log.C(ctx).Infow("ListUser function called")
 
-	resp, err := ctrl.b.Users().List(ctx, int(r.Offset), int(r.Limit))
+	resp, err := ctrl.b.Users().List(ctx, &v1.ListUserRequest{
+		Offset:         int(r.Offset),
+		Limit:          int(r.Limit),
+		PageToken:      r.PageToken,
+		SkipTotalCount: r.SkipTotalCount,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -54,19 +59,22 @@ func (ctrl *UserController) ListUser(ctx context.Context, r *pb.ListUserRequest)
 		createdAt, _ := time.Parse("2006-01-02 15:04:05", u.CreatedAt)
 		updatedAt, _ := time.Parse("2006-01-02 15:04:05", u.UpdatedAt)
 		users = append(users, &pb.UserInfo{
-			Username:  u.Username,
-			Nickname:  u.Nickname,
-			Email:     u.Email,
-			Phone:     u.Phone,
-			PostCount: u.PostCount,
-			CreatedAt: timestamppb.New(createdAt),
-			UpdatedAt: timestamppb.New(updatedAt),
+			Username:       u.Username,
+			Nickname:       u.Nickname,
+			Email:          u.Email,
+			Phone:          u.Phone,
+			PostCount:      u.PostCount,
+			FollowerCount:  u.FollowerCount,
+			FollowingCount: u.FollowingCount,
+			CreatedAt:      timestamppb.New(createdAt),
+			UpdatedAt:      timestamppb.New(updatedAt),
 		})
 	}
 
 	ret := &pb.ListUserResponse{
-		TotalCount: resp.TotalCount,
-		Users:      users,
+		TotalCount:    resp.TotalCount,
+		Users:         users,
+		NextPageToken: resp.NextPageToken,
 	}
 
 	return ret, nil
@@ -0,0 +1,27 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package user
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// GetProfile 获取一个用户的公开资料，不需要认证. 联系方式和时区只有在用户选择公开时才返回.
+func (ctrl *UserController) GetProfile(c *gin.Context) {
+	log.C(c).Infow("Get user profile function called")
+
+	profile, err := ctrl.b.Users().GetProfile(c, c.Param("name"))
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, profile)
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package user
+
+import (
+	"github.com/asaskevich/govalidator"
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// Rename 修改用户的用户名. 用户可以修改自己的用户名，root 用户可以修改任何用户的用户名.
+func (ctrl *UserController) Rename(c *gin.Context) {
+	log.C(c).Infow("Rename user function called")
+
+	// 请求路径带有自定义方法，不在用户的授权策略覆盖范围内，因此按用户资源的路径授权
+	name := c.Param("name")
+	if allowed, _ := ctrl.a.Authorize(c.GetString(known.XUsernameKey), "/v1/users/"+name, c.Request.Method); !allowed {
+		core.WriteResponse(c, errno.ErrUnauthorized, nil)
+
+		return
+	}
+
+	var r v1.RenameUserRequest
+	if err := c.ShouldBindJSON(&r); err != nil {
+		core.WriteResponse(c, errno.ErrBind, nil)
+
+		return
+	}
+
+	if _, err := govalidator.ValidateStruct(r); err != nil {
+		core.WriteResponse(c, errno.ErrInvalidParameter.SetMessage(err.Error()), nil)
+
+		return
+	}
+
+	resp, err := ctrl.b.Users().Rename(c, name, &r)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	// 用户的授权策略已在事务中修改，这里重新加载策略使其立即生效
+	if err := ctrl.a.LoadPolicy(); err != nil {
+		log.C(c).Errorw("Failed to reload authorization policy", "err", err)
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package user
+
+import (
+	"github.com/asaskevich/govalidator"
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// Suspend 停用一个用户，只有 root 用户才能停用用户.
+func (ctrl *UserController) Suspend(c *gin.Context) {
+	log.C(c).Infow("Suspend user function called")
+
+	var r v1.SuspendUserRequest
+	if err := c.ShouldBindJSON(&r); err != nil {
+		core.WriteResponse(c, errno.ErrBind, nil)
+
+		return
+	}
+
+	if _, err := govalidator.ValidateStruct(r); err != nil {
+		core.WriteResponse(c, errno.ErrInvalidParameter.SetMessage(err.Error()), nil)
+
+		return
+	}
+
+	if err := ctrl.b.Users().Suspend(c, c.Param("name"), &r); err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
+
+// Unsuspend 恢复一个被停用或需要重置密码的用户，只有 root 用户才能恢复用户.
+func (ctrl *UserController) Unsuspend(c *gin.Context) {
+	log.C(c).Infow("Unsuspend user function called")
+
+	if err := ctrl.b.Users().Unsuspend(c, c.Param("name")); err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
+
+// ResetPassword 将用户的密码重置为临时密码，只有 root 用户才能重置用户的密码.
+func (ctrl *UserController) ResetPassword(c *gin.Context) {
+	log.C(c).Infow("Reset password function called")
+
+	resp, err := ctrl.b.Users().ResetPassword(c, c.Param("name"))
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
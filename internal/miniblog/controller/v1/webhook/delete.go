@@ -0,0 +1,27 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package webhook
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// Delete 删除当前用户的一个 webhook 以及它的投递记录，还没有投递的事件不再投递.
+func (ctrl *WebhookController) Delete(c *gin.Context) {
+	log.C(c).Infow("Delete webhook function called")
+
+	if err := ctrl.b.Webhooks().Delete(c, c.GetString(known.XUsernameKey), c.Param("webhookID")); err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
@@ -0,0 +1,51 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package webhook
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+)
+
+// ListDeliveries 返回 webhook 的投递记录，包括每次投递的请求体和最近一次投递的结果.
+func (ctrl *WebhookController) ListDeliveries(c *gin.Context) {
+	log.C(c).Infow("List webhook deliveries function called")
+
+	var r v1.ListWebhookDeliveryRequest
+	if err := c.ShouldBindQuery(&r); err != nil {
+		core.WriteResponse(c, errno.ErrBind, nil)
+
+		return
+	}
+
+	resp, err := ctrl.b.Webhooks().ListDeliveries(c, c.GetString(known.XUsernameKey), c.Param("webhookID"), &r)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
+
+// Redeliver 重新投递一条投递记录，例如接收方修复问题后重新投递失败的事件.
+func (ctrl *WebhookController) Redeliver(c *gin.Context) {
+	log.C(c).Infow("Redeliver webhook function called")
+
+	resp, err := ctrl.b.Webhooks().Redeliver(c, c.GetString(known.XUsernameKey), c.Param("webhookID"), c.Param("deliveryID"))
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
@@ -0,0 +1,28 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package webhook
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/core"
+	"github.com/marmotedu/miniblog/internal/pkg/known"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// Get 获取当前用户的一个 webhook 的详情，不包括密钥.
+func (ctrl *WebhookController) Get(c *gin.Context) {
+	log.C(c).Infow("Get webhook function called")
+
+	resp, err := ctrl.b.Webhooks().Get(c, c.GetString(known.XUsernameKey), c.Param("webhookID"))
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+
+		return
+	}
+
+	core.WriteResponse(c, nil, resp)
+}
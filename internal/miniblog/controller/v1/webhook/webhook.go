@@ -0,0 +1,21 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package webhook
+
+import (
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+)
+
+// WebhookController 是 webhook 模块在 Controller 层的实现，用来处理 webhook 的管理和投递记录的查询请求.
+type WebhookController struct {
+	b biz.IBiz
+}
+
+// New 创建一个 webhook controller.
+func New(ds store.IStore) *WebhookController {
+	return &WebhookController{b: biz.NewBiz(ds)}
+}
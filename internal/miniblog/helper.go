@@ -6,9 +6,11 @@
 package miniblog
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -24,6 +26,9 @@ const (
 
 	// defaultConfigName specifies the default configuration file name for miniblog service.
 	defaultConfigName = "miniblog.yaml"
+
+	// defaultMediaPath defines the default directory where the uploaded media are saved by the local blob store.
+	defaultMediaPath = "/var/lib/miniblog/media"
 )
 
 // initConfig sets the configuration file name to be read, environment variables, and reads the configuration file content into viper.
@@ -82,7 +87,7 @@ func logOptions() *log.Options {
 	}
 }
 
-// initStore reads the db configuration, creates a gorm.DB instance, and initializes the miniblog store layer.
+// initStore reads the db, search and media configuration, creates a gorm.DB instance, and initializes the miniblog store layer.
 func initStore() error {
 	dbOptions := &db.MySQLOptions{
 		Host:                  viper.GetString("db.host"),
@@ -100,7 +105,65 @@ func initStore() error {
 		return err
 	}
 
-	_ = store.NewStore(ins)
+	ds := store.NewStore(ins)
+
+	if viper.IsSet("revision.max-count") {
+		ds.SetMaxRevisions(viper.GetInt("revision.max-count"))
+	}
+
+	// The MySQL FULLTEXT index is used by default, switch to the embedded local index if configured.
+	if viper.GetString("search.driver") == "local" {
+		idx, err := store.NewLocalSearchIndex(viper.GetString("search.path"))
+		if err != nil {
+			return err
+		}
+
+		ds.SetSearchIndex(idx)
+	}
+
+	blobs, err := newBlobStore()
+	if err != nil {
+		return err
+	}
+
+	ds.SetBlobStore(blobs)
 
 	return nil
 }
+
+// newBlobStore creates the blob store where the uploaded media are saved, the local filesystem is used by default.
+func newBlobStore() (store.BlobStore, error) {
+	switch driver := viper.GetString("media.driver"); driver {
+	case "s3":
+		return store.NewS3BlobStore(store.S3Options{
+			Endpoint:  viper.GetString("media.s3.endpoint"),
+			Bucket:    viper.GetString("media.s3.bucket"),
+			Region:    viper.GetString("media.s3.region"),
+			AccessKey: viper.GetString("media.s3.access-key"),
+			SecretKey: viper.GetString("media.s3.secret-key"),
+			PathStyle: viper.GetBool("media.s3.path-style"),
+		})
+	case "", "local":
+		return store.NewLocalBlobStore(stringOrDefault("media.path", defaultMediaPath))
+	default:
+		return nil, fmt.Errorf("unsupported media driver %q", driver)
+	}
+}
+
+// stringOrDefault reads a string from viper, and returns def if the key is not set or empty.
+func stringOrDefault(key string, def string) string {
+	if s := viper.GetString(key); s != "" {
+		return s
+	}
+
+	return def
+}
+
+// durationOrDefault reads a duration from viper, and returns def if the key is not set or not positive.
+func durationOrDefault(key string, def time.Duration) time.Duration {
+	if d := viper.GetDuration(key); d > 0 {
+		return d
+	}
+
+	return def
+}
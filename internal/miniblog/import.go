@@ -0,0 +1,266 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package miniblog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/post"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+	v1 "github.com/marmotedu/miniblog/pkg/api/miniblog/v1"
+	"github.com/marmotedu/miniblog/pkg/archive"
+)
+
+// importPollInterval is the interval between two checks of the progress of an import.
+const importPollInterval = 500 * time.Millisecond
+
+// importOptions are the flags of the `miniblog import` command.
+type importOptions struct {
+	username string
+	format   string
+	authors  string
+	dryRun   bool
+}
+
+// newImportCommand creates the `miniblog import` command, which imports posts from an archive file or a content directory.
+func newImportCommand() *cobra.Command {
+	opts := &importOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "import FILE|DIR",
+		Short: "Import posts from an archive, a WordPress export or a Hugo/Jekyll site",
+		Long: `Import posts from an archive file or a content directory. The supported formats are:
+
+  markdown  a zip of Markdown files with YAML front matter, or a single Markdown file,
+            as the export API (GET /v1/posts:export) produces
+  jsonl     JSON Lines, a post per line, as the export API produces
+  wxr       a WordPress export (Tools > Export), only the posts are imported
+  hugo      the content directory of a Hugo site or the _posts directory of a
+            Jekyll site, or a zip of it; front matter can be YAML or TOML
+
+The format is detected from the content of the file if --format is not specified,
+directories are always read as hugo.
+
+Posts whose ID or slug is already used by a post of the same user are skipped, so
+the same archive can be imported again after fixing the failed posts.
+
+The posts belong to the user given by --username, unless their author (the login in
+WordPress, author or the first of authors in the front matter) is mapped to another
+user in the file given by --authors, a YAML mapping from authors to usernames:
+
+  jane: jane
+  "John Smith": colin
+
+Use --dry-run to check what would be imported without changing anything.
+
+The imported posts are added to the search index by the server, when it dispatches
+the events of the import.`,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Init(logOptions())
+			defer log.Sync()
+
+			return runImport(opts, args[0])
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.username, "username", "u", "", "The user that owns the imported posts whose author is not mapped to a user.")
+	cmd.Flags().StringVarP(&opts.format, "format", "f", "", "The format of the archive, markdown, jsonl, wxr or hugo. Detected from the content if empty.")
+	cmd.Flags().StringVarP(&opts.authors, "authors", "a", "", "A YAML file which maps the authors in the archive to usernames.")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Only print what would be imported.")
+	_ = cmd.MarkFlagRequired("username")
+
+	return cmd
+}
+
+// runImport imports the posts in name, which is an archive file or a content directory.
+func runImport(opts *importOptions, name string) error {
+	format, entries, err := readArchive(name, opts.format)
+	if err != nil {
+		return err
+	}
+
+	authors := map[string]string{}
+	if opts.authors != "" {
+		data, err := os.ReadFile(opts.authors)
+		if err != nil {
+			return err
+		}
+
+		if err := yaml.Unmarshal(data, &authors); err != nil {
+			return fmt.Errorf("invalid author mapping file %s: %w", opts.authors, err)
+		}
+	}
+
+	if err := initStore(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	// Group the entries by the users they are imported to, keeping the order of the users as they first appear.
+	var usernames []string
+	groups := map[string][]*archive.Entry{}
+	counts := map[string]int{}
+	for _, entry := range entries {
+		username := opts.username
+		if entry.Post != nil && entry.Post.Author != "" {
+			if u, ok := authors[entry.Post.Author]; ok {
+				username = u
+			}
+			counts[entry.Post.Author]++
+		}
+
+		if _, ok := groups[username]; !ok {
+			if _, err := store.S.Users().Get(ctx, username); err != nil {
+				return fmt.Errorf("failed to get user %q: %w", username, err)
+			}
+
+			usernames = append(usernames, username)
+		}
+		groups[username] = append(groups[username], entry)
+	}
+
+	if len(counts) > 0 {
+		names := make([]string, 0, len(counts))
+		for author := range counts {
+			names = append(names, author)
+		}
+		sort.Strings(names)
+
+		table := uitable.New()
+		table.AddRow("AUTHOR", "USERNAME", "POSTS")
+		for _, author := range names {
+			username, ok := authors[author]
+			if !ok {
+				username = opts.username + " (not mapped)"
+			}
+			table.AddRow(author, username, counts[author])
+		}
+		fmt.Println(table)
+		fmt.Println()
+	}
+
+	posts := biz.NewBiz(store.S).Posts()
+
+	// Only the posts which are not created are listed, unless in a dry run.
+	table := uitable.New()
+	table.MaxColWidth = 80
+	table.AddRow("NAME", "USERNAME", "RESULT", "POSTID", "MESSAGE")
+
+	var created, skipped, failed int
+	for _, username := range usernames {
+		var items []*v1.ImportItem
+		if opts.dryRun {
+			items = posts.DryRunImport(ctx, username, groups[username])
+		} else {
+			fmt.Printf("Importing %d posts of %s.\n", len(groups[username]), username)
+
+			imp, err := waitImport(ctx, posts, username, format, groups[username])
+			if err != nil {
+				return err
+			}
+			items = imp.Items
+		}
+
+		for _, item := range items {
+			switch item.Result {
+			case "created":
+				created++
+			case "skipped":
+				skipped++
+			default:
+				failed++
+			}
+
+			if opts.dryRun || item.Result != "created" {
+				table.AddRow(item.Name, username, item.Result, item.PostID, item.Message)
+			}
+		}
+	}
+
+	if opts.dryRun {
+		fmt.Printf("%d would be created, %d would be skipped, %d would fail.\n", created, skipped, failed)
+	} else {
+		fmt.Printf("%d created, %d skipped, %d failed.\n", created, skipped, failed)
+	}
+
+	if len(table.Rows) > 1 {
+		fmt.Println(table)
+	}
+
+	if failed > 0 && !opts.dryRun {
+		return fmt.Errorf("%d posts failed to import", failed)
+	}
+
+	return nil
+}
+
+// readArchive reads the archive file or the content directory name. Directories are read as Hugo or Jekyll sites.
+func readArchive(name, format string) (string, []*archive.Entry, error) {
+	fi, err := os.Stat(name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if fi.IsDir() {
+		if format != "" && format != archive.FormatHugo {
+			return "", nil, fmt.Errorf("a directory can only be imported in the %s format", archive.FormatHugo)
+		}
+
+		entries, err := archive.ReadFS(os.DirFS(name))
+
+		return archive.FormatHugo, entries, err
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return archive.Read(data, format)
+}
+
+// waitImport imports entries to the posts of username and waits for the import to finish.
+func waitImport(ctx context.Context, posts post.PostBiz, username, format string, entries []*archive.Entry) (*v1.GetImportResponse, error) {
+	resp, err := posts.ImportEntries(ctx, username, format, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		time.Sleep(importPollInterval)
+
+		imp, err := posts.GetImport(ctx, username, resp.ImportID)
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Printf("\rImported %d/%d posts.", imp.Processed, imp.Total)
+		if imp.Status == model.ImportStatusRunning {
+			continue
+		}
+		fmt.Println()
+
+		if imp.Status == model.ImportStatusFailed {
+			return nil, fmt.Errorf("import %s failed: %s", imp.ImportID, imp.Error)
+		}
+
+		return imp, nil
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package miniblog
+
+import (
+	"context"
+	"time"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/event"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+const (
+	// defaultTrashRetention defines how long a post stays in the trash before it is permanently deleted.
+	defaultTrashRetention = 30 * 24 * time.Hour
+
+	// defaultTrashPurgeInterval defines how often the trash purge job runs.
+	defaultTrashPurgeInterval = time.Hour
+
+	// defaultPublishInterval defines how often the scheduled posts are checked and published.
+	defaultPublishInterval = time.Minute
+
+	// defaultMediaGCInterval defines how often the unreferenced media are garbage collected.
+	defaultMediaGCInterval = time.Hour
+
+	// defaultMediaGCGrace defines how long an uploaded media can stay unreferenced before it is garbage collected.
+	defaultMediaGCGrace = 24 * time.Hour
+
+	// defaultWebhookDeliverInterval defines how often the due webhook deliveries are sent.
+	defaultWebhookDeliverInterval = 10 * time.Second
+
+	// defaultWebhookRetention defines how long the finished webhook deliveries are kept in the delivery log.
+	defaultWebhookRetention = 30 * 24 * time.Hour
+
+	// defaultWebhookPurgeInterval defines how often the expired webhook deliveries are deleted.
+	defaultWebhookPurgeInterval = time.Hour
+
+	// defaultEventDispatchInterval defines how often the outbox is polled for due events, new events are
+	// also dispatched as soon as they are committed.
+	defaultEventDispatchInterval = 5 * time.Second
+
+	// defaultEventRetention defines how long the dispatched events are kept in the outbox.
+	defaultEventRetention = 7 * 24 * time.Hour
+
+	// defaultEventPurgeInterval defines how often the expired events are deleted.
+	defaultEventPurgeInterval = time.Hour
+
+	// defaultImportCheckInterval defines how often the imports interrupted by a stopped server are marked as failed.
+	defaultImportCheckInterval = time.Minute
+)
+
+// startJobs starts the background jobs of miniblog. All jobs exit when ctx is canceled.
+func startJobs(ctx context.Context) {
+	b := biz.NewBiz(store.S)
+
+	// Permanently delete the posts which have been in the trash longer than the retention period.
+	retention := durationOrDefault("trash.retention", defaultTrashRetention)
+	runPeriodically(ctx, "PurgeTrash", durationOrDefault("trash.purge-interval", defaultTrashPurgeInterval), func(ctx context.Context) error {
+		count, err := b.Posts().PurgeTrash(ctx, retention)
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			log.Infow("Purged posts from trash", "count", count, "retention", retention.String())
+		}
+
+		return nil
+	})
+
+	// Publish the scheduled posts whose publish time has come.
+	runPeriodically(ctx, "PublishScheduledPosts", durationOrDefault("publish.interval", defaultPublishInterval), func(ctx context.Context) error {
+		count, err := b.Posts().PublishScheduled(ctx)
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			log.Infow("Published scheduled posts", "count", count)
+		}
+
+		return nil
+	})
+
+	// Mark the imports whose server stopped while running them as failed, the imports running on the
+	// other instances keep their leases.
+	runPeriodically(ctx, "FailExpiredImports", defaultImportCheckInterval, func(ctx context.Context) error {
+		count, err := b.Posts().FailExpiredImports(ctx)
+		if count > 0 {
+			log.Infow("Marked interrupted imports as failed", "count", count)
+		}
+
+		return err
+	})
+
+	// Delete the uploaded media which are not referenced by any post after the grace period.
+	grace := durationOrDefault("media.gc-grace", defaultMediaGCGrace)
+	runPeriodically(ctx, "CollectMedia", durationOrDefault("media.gc-interval", defaultMediaGCInterval), func(ctx context.Context) error {
+		count, err := b.Media().CollectGarbage(ctx, grace)
+		if count > 0 {
+			log.Infow("Deleted unreferenced media", "count", count, "grace", grace.String())
+		}
+
+		return err
+	})
+
+	// Send the webhook deliveries which are due, including the retries of the failed ones.
+	runPeriodically(ctx, "DeliverWebhooks", durationOrDefault("webhook.deliver-interval", defaultWebhookDeliverInterval), func(ctx context.Context) error {
+		count, err := b.Webhooks().Deliver(ctx)
+		if count > 0 {
+			log.Debugw("Sent webhook deliveries", "count", count)
+		}
+
+		return err
+	})
+
+	// Delete the finished webhook deliveries after the retention period.
+	webhookRetention := durationOrDefault("webhook.retention", defaultWebhookRetention)
+	runPeriodically(ctx, "PurgeWebhookDeliveries", defaultWebhookPurgeInterval, func(ctx context.Context) error {
+		count, err := b.Webhooks().PurgeDeliveries(ctx, webhookRetention)
+		if count > 0 {
+			log.Infow("Purged webhook deliveries", "count", count, "retention", webhookRetention.String())
+		}
+
+		return err
+	})
+
+	// Dispatch the domain events in the outbox to their handlers, including the retries of the failed ones.
+	interval := durationOrDefault("event.dispatch-interval", defaultEventDispatchInterval)
+	runOnNotify(ctx, "DispatchEvents", interval, event.Default.Notified(), func(ctx context.Context) error {
+		count, err := event.Default.Dispatch(ctx, store.S)
+		if count > 0 {
+			log.Debugw("Dispatched events", "count", count)
+		}
+
+		return err
+	})
+
+	// Delete the dispatched events after the retention period.
+	eventRetention := durationOrDefault("event.retention", defaultEventRetention)
+	runPeriodically(ctx, "PurgeEvents", defaultEventPurgeInterval, func(ctx context.Context) error {
+		count, err := event.Purge(ctx, store.S, eventRetention)
+		if count > 0 {
+			log.Infow("Purged events", "count", count, "retention", eventRetention.String())
+		}
+
+		return err
+	})
+}
+
+// runPeriodically calls fn immediately and then every interval in a new goroutine until ctx is canceled.
+func runPeriodically(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	runOnNotify(ctx, name, interval, nil, fn)
+}
+
+// runOnNotify is like runPeriodically, but fn is also called as soon as notify receives a value.
+func runOnNotify(ctx context.Context, name string, interval time.Duration, notify <-chan struct{}, fn func(ctx context.Context) error) {
+	log.Infow("Start background job", "job", name, "interval", interval.String())
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := fn(ctx); err != nil {
+				log.Errorw("Failed to run background job", "job", name, "err", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				log.Infow("Background job exiting", "job", name)
+				return
+			case <-ticker.C:
+			case <-notify:
+			}
+		}
+	}()
+}
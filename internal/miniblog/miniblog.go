@@ -21,6 +21,11 @@ import (
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/media"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/stream"
+	userbiz "github.com/marmotedu/miniblog/internal/miniblog/biz/user"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/webhook"
+	"github.com/marmotedu/miniblog/internal/miniblog/controller/v1/post"
 	"github.com/marmotedu/miniblog/internal/miniblog/controller/v1/user"
 	"github.com/marmotedu/miniblog/internal/miniblog/store"
 	"github.com/marmotedu/miniblog/internal/pkg/known"
@@ -86,6 +91,9 @@ Find more miniblog information at:
 	// Add the --version flag.
 	verflag.AddFlags(cmd.PersistentFlags())
 
+	// Add the sub-commands used to administrate miniblog.
+	cmd.AddCommand(newReindexCommand(), newImportCommand())
+
 	return cmd
 }
 
@@ -99,21 +107,42 @@ func run() error {
 	// Set the signing key for the token package, used for token signing and parsing
 	token.Init(viper.GetString("jwt-secret"), known.XUsernameKey)
 
+	// Set the limits of the media uploads
+	media.Init(int64(viper.GetSizeInBytes("media.max-size")), int64(viper.GetSizeInBytes("media.quota")))
+
+	// Set the options of the webhook deliveries
+	webhook.Init(viper.GetDuration("webhook.timeout"), viper.GetBool("webhook.allow-private"))
+
+	// Set how long the old username of a renamed user is reserved
+	userbiz.Init(viper.GetDuration("user.rename-cooldown"))
+
+	// Set the options of the event streams
+	stream.Init(viper.GetInt("stream.buffer-size"), viper.GetInt("stream.max-streams-per-user"), viper.GetDuration("stream.heartbeat-interval"))
+
 	// Set Gin mode
 	gin.SetMode(viper.GetString("runmode"))
 
 	// Create a Gin engine
 	g := gin.New()
 
-	// Middleware functions for Gin: gin.Recovery(), mw.NoCache, mw.Cors, mw.Secure, mw.RequestID()
-	mws := []gin.HandlerFunc{gin.Recovery(), mw.NoCache, mw.Cors, mw.Secure, mw.RequestID()}
+	// Middleware functions for Gin: gin.Recovery(), mw.Cors, mw.Secure, mw.RequestID().
+	// The caching headers are set by the route groups, see installRouters.
+	mws := []gin.HandlerFunc{gin.Recovery(), mw.Cors, mw.Secure, mw.RequestID()}
 
 	g.Use(mws...)
 
+	// Register the handlers of the domain events before the events are dispatched by the background jobs.
+	subscribe(store.S)
+
 	if err := installRouters(g); err != nil {
 		return err
 	}
 
+	// Start the background jobs, they will be stopped when the server exits.
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	startJobs(jobCtx)
+
 	// Create and run an HTTP server
 	httpsrv := startInsecureServer(g)
 
@@ -136,6 +165,9 @@ func run() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// The event streams never complete, close them so that the servers can shut down
+	stream.Default.Close()
+
 	// Gracefully shut down the server within 10 seconds (by completing the ongoing requests before shutting down)
 	// If it takes more than 10 seconds, the server will time out and exit
 	if err := httpsrv.Shutdown(ctx); err != nil {
@@ -149,6 +181,11 @@ func run() error {
 
 	grpcsrv.GracefulStop()
 
+	// Persist the changes of the search index not yet written out
+	if err := store.S.Search().Close(); err != nil {
+		log.Errorw("Failed to close the search index", "err", err)
+	}
+
 	log.Infow("Server exiting")
 
 	return nil
@@ -191,6 +228,12 @@ func startSecureServer(g *gin.Engine) *http.Server {
 	return httpssrv
 }
 
+// grpcServer combines the controllers of all modules to implement the pb.MiniBlogServer interface.
+type grpcServer struct {
+	*user.UserController
+	*post.PostController
+}
+
 // startGRPCServer creates and runs a gRPC server.
 func startGRPCServer() *grpc.Server {
 	lis, err := net.Listen("tcp", viper.GetString("grpc.addr"))
@@ -200,7 +243,7 @@ func startGRPCServer() *grpc.Server {
 
 	// Create an instance of GRPC Server
 	grpcsrv := grpc.NewServer()
-	pb.RegisterMiniBlogServer(grpcsrv, user.New(store.S, nil))
+	pb.RegisterMiniBlogServer(grpcsrv, &grpcServer{user.New(store.S, nil), post.New(store.S)})
 
 	// Run the GRPC server. Start the server in a goroutine, so it doesn't block the normal shutdown process below.
 	// Print a log message to indicate that the GRPC service is up and running, for troubleshooting purposes.
@@ -0,0 +1,50 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package miniblog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// newReindexCommand creates the `miniblog reindex` command, which rebuilds the full-text search index of posts.
+func newReindexCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reindex",
+		Short: "Rebuild the full-text search index of posts",
+		Long: `Rebuild the full-text search index of posts from the database.
+
+The MySQL FULLTEXT index is maintained by MySQL itself, so this command is only
+useful for the local search index (search.driver: local). The local index is
+loaded into memory when the server starts, so stop the server before running
+this command, otherwise the rebuilt index may be overwritten by the server.`,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Init(logOptions())
+			defer log.Sync()
+
+			if err := initStore(); err != nil {
+				return err
+			}
+
+			count, err := biz.NewBiz(store.S).Posts().Reindex(context.Background())
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Reindexed %d posts.\n", count)
+
+			return nil
+		},
+	}
+}
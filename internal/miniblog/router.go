@@ -6,11 +6,22 @@
 package miniblog
 
 import (
+	"time"
+
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
 
+	"github.com/marmotedu/miniblog/internal/miniblog/biz"
+	"github.com/marmotedu/miniblog/internal/miniblog/controller/v1/category"
+	"github.com/marmotedu/miniblog/internal/miniblog/controller/v1/comment"
+	"github.com/marmotedu/miniblog/internal/miniblog/controller/v1/media"
+	"github.com/marmotedu/miniblog/internal/miniblog/controller/v1/notification"
 	"github.com/marmotedu/miniblog/internal/miniblog/controller/v1/post"
+	"github.com/marmotedu/miniblog/internal/miniblog/controller/v1/reaction"
+	"github.com/marmotedu/miniblog/internal/miniblog/controller/v1/stream"
+	"github.com/marmotedu/miniblog/internal/miniblog/controller/v1/tag"
 	"github.com/marmotedu/miniblog/internal/miniblog/controller/v1/user"
+	"github.com/marmotedu/miniblog/internal/miniblog/controller/v1/webhook"
 	"github.com/marmotedu/miniblog/internal/miniblog/store"
 	"github.com/marmotedu/miniblog/internal/pkg/core"
 	"github.com/marmotedu/miniblog/internal/pkg/errno"
@@ -19,6 +30,9 @@ import (
 	"github.com/marmotedu/miniblog/pkg/auth"
 )
 
+// defaultPublicCacheMaxAge 是公开接口的响应可以被缓存的时长.
+const defaultPublicCacheMaxAge = time.Minute
+
 // installRouters 安装 miniblog 接口路由.
 func installRouters(g *gin.Engine) error {
 	// 注册 404 Handler.
@@ -27,7 +41,7 @@ func installRouters(g *gin.Engine) error {
 	})
 
 	// 注册 /healthz handler.
-	g.GET("/healthz", func(c *gin.Context) {
+	g.GET("/healthz", mw.NoCache, func(c *gin.Context) {
 		log.C(c).Infow("Healthz function called")
 
 		core.WriteResponse(c, nil, map[string]string{"status": "ok"})
@@ -43,18 +57,53 @@ func installRouters(g *gin.Engine) error {
 
 	uc := user.New(store.S, authz)
 	pc := post.New(store.S)
+	tc := tag.New(store.S)
+	cc := category.New(store.S)
+	cmc := comment.New(store.S)
+	rc := reaction.New(store.S)
+	mc := media.New(store.S)
+	wc := webhook.New(store.S)
+	nc := notification.New(store.S)
+	sc := stream.New(store.S)
 
 	g.POST("/login", uc.Login)
 
+	// 认证时检查账号是否已被停用以及 token 是否已被吊销
+	users := biz.NewBiz(store.S).Users()
+	authn := mw.Authn(users)
+
+	// 访问用户修改前的用户名的公开接口时重定向到新用户名
+	renamed, renamedAuthor := mw.RedirectRenamed(users, "name"), mw.RedirectRenamed(users, "username")
+
+	// 公开接口的响应可以被客户端和代理缓存，其余接口使用 mw.NoCache 禁止缓存
+	cache := mw.Cache(durationOrDefault("public.cache-max-age", defaultPublicCacheMaxAge))
+
 	// 创建 v1 路由分组
 	v1 := g.Group("/v1")
 	{
 		// 创建 users 路由分组
 		userv1 := v1.Group("/users")
 		{
-			userv1.POST("", uc.Create)                             // 创建用户
-			userv1.PUT(":name/change-password", uc.ChangePassword) // 修改用户密码
-			userv1.Use(mw.Authn(), mw.Authz(authz))
+			userv1.POST("", uc.Create)                                      // 创建用户
+			userv1.PUT(":name/change-password", uc.ChangePassword)          // 修改用户密码
+			userv1.GET(":name/posts", cache, renamed, pc.ListPublished)     // 获取用户已发布的公开博客列表，不需要认证
+			userv1.GET(":name/followers", cache, renamed, uc.ListFollowers) // 获取关注了用户的用户列表，不需要认证
+			userv1.GET(":name/following", cache, renamed, uc.ListFollowing) // 获取用户关注的用户列表，不需要认证
+			userv1.GET(":name/feed.rss", cache, renamed, pc.RSS)            // 获取用户博客的 RSS 订阅源，不需要认证
+			userv1.GET(":name/feed.atom", cache, renamed, pc.Atom)          // 获取用户博客的 Atom 订阅源，不需要认证
+			userv1.GET(":name/feed.json", cache, renamed, pc.JSONFeed)      // 获取用户博客的 JSON Feed 订阅源，不需要认证
+			// 头像不在授权策略覆盖的资源路径下，由 controller 检查只能修改自己的头像
+			userv1.PUT(":name/avatar", mw.NoCache, authn, uc.UploadAvatar)    // 上传头像
+			userv1.DELETE(":name/avatar", mw.NoCache, authn, uc.DeleteAvatar) // 删除头像
+			// 自定义方法的路径不在普通用户的授权策略覆盖范围内，账号管理接口只有 root 用户才能访问，
+			// 修改用户名由 controller 按用户资源的路径授权
+			userv1.POST(":name", mw.NoCache, authn, core.CustomVerbs("name", map[string]gin.HandlerFunc{
+				"rename":        uc.Rename,                           // 修改用户名：POST /v1/users/{name}:rename
+				"suspend":       authorized(authz, uc.Suspend),       // 停用用户：POST /v1/users/{name}:suspend
+				"unsuspend":     authorized(authz, uc.Unsuspend),     // 恢复用户：POST /v1/users/{name}:unsuspend
+				"resetPassword": authorized(authz, uc.ResetPassword), // 重置用户密码：POST /v1/users/{name}:resetPassword
+			}))
+			userv1.Use(mw.NoCache, authn, mw.Authz(authz))
 			userv1.GET(":name", uc.Get)       // 获取用户详情
 			userv1.PUT(":name", uc.Update)    // 更新用户
 			userv1.GET("", uc.List)           // 列出用户列表，只有 root 用户才能访问
@@ -62,16 +111,155 @@ func installRouters(g *gin.Engine) error {
 		}
 
 		// 创建 posts 路由分组
-		postv1 := v1.Group("/posts", mw.Authn())
+		postv1 := v1.Group("/posts", mw.NoCache, authn)
 		{
 			postv1.POST("", pc.Create)             // 创建博客
 			postv1.GET(":postID", pc.Get)          // 获取博客详情
 			postv1.PUT(":postID", pc.Update)       // 更新用户
-			postv1.DELETE("", pc.DeleteCollection) // 批量删除博客
+			postv1.DELETE("", pc.DeleteCollection) // 批量删除博客（移入回收站）
 			postv1.GET("", pc.List)                // 获取博客列表
-			postv1.DELETE(":postID", pc.Delete)    // 删除博客
+			postv1.DELETE(":postID", pc.Delete)    // 删除博客（移入回收站）
+			postv1.POST(":postID", core.CustomVerbs("postID", map[string]gin.HandlerFunc{
+				"restore": pc.Restore, // 从回收站恢复博客：POST /v1/posts/{postID}:restore
+			}))
+
+			// 博客的历史版本，每次修改标题或内容都会保存一个新的版本
+			postv1.GET(":postID/revisions", pc.ListRevisions) // 获取历史版本列表
+			postv1.GET(":postID/revisions:verb", core.CustomVerbs("verb", map[string]gin.HandlerFunc{
+				"diff": pc.DiffRevisions, // 比较两个版本：GET /v1/posts/{postID}/revisions:diff?from=1&to=2
+			}))
+			postv1.POST(":postID/revisions/:revision", core.CustomVerbs("revision", map[string]gin.HandlerFunc{
+				"restore": pc.RestoreRevision, // 恢复到指定版本：POST /v1/posts/{postID}/revisions/{revision}:restore
+			}))
+
+			// 博客评论，任何登录用户都可以评论其他用户已发布的非私密博客
+			postv1.POST(":postID/comments", cmc.Create)              // 发表评论或回复
+			postv1.GET(":postID/comments", cmc.List)                 // 获取评论列表
+			postv1.PUT(":postID/comments/:commentID", cmc.Update)    // 修改评论
+			postv1.DELETE(":postID/comments/:commentID", cmc.Delete) // 删除评论
+			postv1.POST(":postID/comments/:commentID", core.CustomVerbs("commentID", map[string]gin.HandlerFunc{
+				"approve": cmc.Approve, // 公开评论：POST /v1/posts/{postID}/comments/{commentID}:approve
+				"hide":    cmc.Hide,    // 隐藏评论：POST /v1/posts/{postID}/comments/{commentID}:hide
+			}))
+
+			// 博客和评论的 reaction，添加和删除都是幂等的
+			postv1.PUT(":postID/reactions/:type", rc.ReactPost)
+			postv1.DELETE(":postID/reactions/:type", rc.UnreactPost)
+			postv1.PUT(":postID/comments/:commentID/reactions/:type", rc.ReactComment)
+			postv1.DELETE(":postID/comments/:commentID/reactions/:type", rc.UnreactComment)
+		}
+
+		// 博客集合上的自定义方法，例如全文搜索：GET /v1/posts:search?q=xxx，点赞过的博客：GET /v1/posts:reacted?type=like
+		v1.GET("/posts:verb", mw.NoCache, authn, core.CustomVerbs("verb", map[string]gin.HandlerFunc{
+			"search":  pc.Search,
+			"reacted": pc.ListReacted,
+			"export":  pc.Export, // 导出博客：GET /v1/posts:export?format=markdown
+		}))
+		v1.POST("/posts:verb", mw.NoCache, authn, core.CustomVerbs("verb", map[string]gin.HandlerFunc{
+			"import": pc.Import, // 导入博客：POST /v1/posts:import
+		}))
+
+		// 获取博客导入任务的进度和结果
+		v1.GET("/imports/:importID", mw.NoCache, authn, pc.GetImport)
+
+		// 创建 following 路由分组，关注和取消关注都是幂等的
+		followingv1 := v1.Group("/following", mw.NoCache, authn)
+		{
+			followingv1.PUT(":name", uc.Follow)      // 关注用户
+			followingv1.DELETE(":name", uc.Unfollow) // 取消关注用户
+		}
+
+		// 获取当前用户的首页时间线
+		v1.GET("/timeline", mw.NoCache, authn, pc.Timeline)
+
+		// 创建 trash 路由分组
+		trashv1 := v1.Group("/trash", mw.NoCache, authn)
+		{
+			trashv1.GET("", pc.ListTrash) // 获取回收站中的博客列表
+		}
+
+		// 创建 tags 路由分组，列出 tag 不需要认证，修改 tag 只有 root 用户才能访问
+		tagv1 := v1.Group("/tags")
+		{
+			tagv1.GET("", cache, tc.List) // 获取 tag 列表以及每个 tag 的博客数
+			tagv1.POST(":name", mw.NoCache, authn, mw.Authz(authz), core.CustomVerbs("name", map[string]gin.HandlerFunc{
+				"rename": tc.Rename, // 重命名 tag：POST /v1/tags/{name}:rename
+				"merge":  tc.Merge,  // 合并 tag：POST /v1/tags/{name}:merge
+			}))
+		}
+
+		// 创建 categories 路由分组，列出分类不需要认证，创建分类只有 root 用户才能访问
+		categoryv1 := v1.Group("/categories")
+		{
+			categoryv1.GET("", cache, cc.List)                                 // 获取分类树
+			categoryv1.POST("", mw.NoCache, authn, mw.Authz(authz), cc.Create) // 创建分类
+		}
+
+		// 创建 media 路由分组，上传的媒体文件通过 public 路由分组公开访问
+		mediav1 := v1.Group("/media", mw.NoCache, authn)
+		{
+			mediav1.POST("", mc.Upload)           // 上传媒体文件
+			mediav1.GET("", mc.List)              // 获取媒体文件列表
+			mediav1.DELETE(":mediaID", mc.Delete) // 删除媒体文件
+		}
+
+		// 创建 webhooks 路由分组，用户订阅的博客和用户事件通过 webhook 投递
+		webhookv1 := v1.Group("/webhooks", mw.NoCache, authn)
+		{
+			webhookv1.POST("", wc.Create)                             // 创建 webhook
+			webhookv1.GET("", wc.List)                                // 获取 webhook 列表
+			webhookv1.GET(":webhookID", wc.Get)                       // 获取 webhook 详情
+			webhookv1.PUT(":webhookID", wc.Update)                    // 更新 webhook
+			webhookv1.DELETE(":webhookID", wc.Delete)                 // 删除 webhook
+			webhookv1.GET(":webhookID/deliveries", wc.ListDeliveries) // 获取 webhook 的投递记录
+			webhookv1.POST(":webhookID/deliveries/:deliveryID", core.CustomVerbs("deliveryID", map[string]gin.HandlerFunc{
+				"redeliver": wc.Redeliver, // 重新投递：POST /v1/webhooks/{webhookID}/deliveries/{deliveryID}:redeliver
+			}))
+		}
+
+		// 创建 notifications 路由分组，用户被提及、关注和评论时收到通知
+		notificationv1 := v1.Group("/notifications", mw.NoCache, authn)
+		{
+			notificationv1.GET("", nc.List)                          // 获取通知列表
+			notificationv1.GET("/preferences", nc.GetPreferences)    // 获取通知偏好
+			notificationv1.PUT("/preferences", nc.UpdatePreferences) // 更新通知偏好
+			notificationv1.POST(":notificationID", core.CustomVerbs("notificationID", map[string]gin.HandlerFunc{
+				"read": nc.Read, // 标记为已读：POST /v1/notifications/{notificationID}:read
+			}))
+		}
+		v1.POST("/notifications:verb", mw.NoCache, authn, core.CustomVerbs("verb", map[string]gin.HandlerFunc{
+			"readAll": nc.ReadAll, // 全部标记为已读：POST /v1/notifications:readAll
+		}))
+
+		// 事件流：GET /v1/events，通过 Server-Sent Events 推送当前用户的新博客、评论和通知
+		v1.GET("/events", mw.NoCache, authn, sc.Events)
+
+		// 创建 public 路由分组，只读且不需要认证，只返回已发布的博客
+		publicv1 := v1.Group("/public", cache)
+		{
+			publicv1.GET("/posts", pc.ListPublished)                               // 获取所有用户最新发布的公开博客列表
+			publicv1.GET("/posts/:postID", pc.GetPublished)                        // 获取已发布博客详情
+			publicv1.GET("/posts/:postID/comments", cmc.ListPublished)             // 获取已发布博客的公开评论列表
+			publicv1.GET("/media/:mediaID", mc.Get)                                // 获取媒体文件
+			publicv1.GET("/media/:mediaID/thumbnail", mc.GetThumbnail)             // 获取媒体文件的缩略图
+			publicv1.GET("/users/:name", renamed, uc.GetProfile)                   // 获取用户的公开资料
+			publicv1.GET("/avatars/:avatar", uc.GetAvatar)                         // 获取用户头像
+			publicv1.GET("/:username/:slug", renamedAuthor, pc.GetPublishedBySlug) // 通过博客的永久链接获取已发布博客详情
 		}
 	}
 
 	return nil
 }
+
+// authorized 返回先使用 authz 授权、授权通过后再调用 h 的 handler，用于同一个路由下授权方式不同的自定义方法.
+func authorized(authz *auth.Authz, h gin.HandlerFunc) gin.HandlerFunc {
+	authorize := mw.Authz(authz)
+
+	return func(c *gin.Context) {
+		if authorize(c); c.IsAborted() {
+			return
+		}
+
+		h(c)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// AuditLogStore 定义了 audit log 模块在 store 层所实现的方法.
+type AuditLogStore interface {
+	Create(ctx context.Context, auditLog *model.AuditLogM) error
+}
+
+// AuditLogStore 接口的实现.
+type auditLogs struct {
+	ds *datastore
+}
+
+// 确保 auditLogs 实现了 AuditLogStore 接口.
+var _ AuditLogStore = (*auditLogs)(nil)
+
+func newAuditLogs(ds *datastore) *auditLogs {
+	return &auditLogs{ds}
+}
+
+// Create 插入一条审计日志记录.
+func (a *auditLogs) Create(ctx context.Context, auditLog *model.AuditLogM) error {
+	return a.ds.core(ctx).Create(auditLog).Error
+}
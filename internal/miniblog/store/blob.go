@@ -0,0 +1,114 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrBlobNotFound 表示对象存储中不存在指定的对象.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore 定义了保存媒体文件内容的对象存储需要实现的方法. key 是使用 `/` 分隔的相对路径.
+type BlobStore interface {
+	// Put 保存 key 对应的对象，size 是 r 中内容的长度，对象已经存在时覆盖.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get 读取 key 对应的对象，对象不存在时返回 ErrBlobNotFound，调用者需要关闭返回的 io.ReadCloser.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete 删除 key 对应的对象，对象不存在时不返回错误.
+	Delete(ctx context.Context, key string) error
+}
+
+// localBlobStore 是将对象保存在本地文件系统 root 目录中的 BlobStore 实现.
+type localBlobStore struct {
+	root string
+}
+
+// 确保 localBlobStore 实现了 BlobStore 接口.
+var _ BlobStore = (*localBlobStore)(nil)
+
+// NewLocalBlobStore 创建一个将对象保存在本地目录 root 中的 BlobStore，root 不存在时自动创建.
+func NewLocalBlobStore(root string) (BlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &localBlobStore{root: root}, nil
+}
+
+// Put 先将内容写入临时文件再重命名，避免读取到写了一半的对象.
+func (s *localBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), name)
+}
+
+// Get 打开 key 对应的文件.
+func (s *localBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+
+	return f, err
+}
+
+// Delete 删除 key 对应的文件.
+func (s *localBlobStore) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// path 返回 key 对应的文件路径，key 不能是绝对路径，也不能指向 root 之外的文件.
+func (s *localBlobStore) path(key string) (string, error) {
+	cleaned := path.Clean(key)
+	if key == "" || path.IsAbs(cleaned) || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", errors.New("invalid blob key: " + key)
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
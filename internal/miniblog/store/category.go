@@ -0,0 +1,53 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// CategoryStore 定义了 category 模块在 store 层所实现的方法.
+type CategoryStore interface {
+	Create(ctx context.Context, category *model.CategoryM) error
+	Get(ctx context.Context, id int64) (*model.CategoryM, error)
+	List(ctx context.Context) ([]*model.CategoryM, error)
+}
+
+// CategoryStore 接口的实现.
+type categories struct {
+	ds *datastore
+}
+
+// 确保 categories 实现了 CategoryStore 接口.
+var _ CategoryStore = (*categories)(nil)
+
+func newCategories(ds *datastore) *categories {
+	return &categories{ds}
+}
+
+// Create 插入一条 category 记录.
+func (c *categories) Create(ctx context.Context, category *model.CategoryM) error {
+	return c.ds.core(ctx).Create(category).Error
+}
+
+// Get 根据 id 查询 category 数据库记录.
+func (c *categories) Get(ctx context.Context, id int64) (*model.CategoryM, error) {
+	var category model.CategoryM
+	if err := c.ds.core(ctx).Where("id = ?", id).First(&category).Error; err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// List 返回所有 category 记录. 分类的数量通常很少，由调用者在内存中组装分类树.
+func (c *categories) List(ctx context.Context) (ret []*model.CategoryM, err error) {
+	err = c.ds.core(ctx).Order("parentID, name").Find(&ret).Error
+
+	return
+}
@@ -0,0 +1,155 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// CommentStore 定义了 comment 模块在 store 层所实现的方法.
+type CommentStore interface {
+	Create(ctx context.Context, comment *model.CommentM) error
+	Get(ctx context.Context, postID, commentID string) (*model.CommentM, error)
+	Update(ctx context.Context, comment *model.CommentM) error
+	Delete(ctx context.Context, postID, commentID string) error
+	ListThreads(ctx context.Context, postID string, filter *CommentFilter, opts *ListOptions) (int64, []*model.CommentM, error)
+	ListReplies(ctx context.Context, postID string, rootIDs []string, filter *CommentFilter) ([]*model.CommentM, error)
+	CountByPostIDs(ctx context.Context, postIDs []string) (map[string]int64, error)
+	DeleteByUsername(ctx context.Context, username string) (int64, error)
+	UpdateUsername(ctx context.Context, from, to string) (int64, error)
+}
+
+// CommentFilter 定义了列出 comment 时的过滤条件，nil 表示不过滤.
+type CommentFilter struct {
+	// Status 过滤指定审核状态的 comment.
+	Status string
+	// Viewer 不为空时只返回已公开的 comment 以及 Viewer 自己发表的待审核 comment.
+	Viewer string
+}
+
+// apply 将过滤条件添加到 db 中.
+func (f *CommentFilter) apply(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+
+	if f.Status != "" {
+		db = db.Where("status = ?", f.Status)
+	}
+	if f.Viewer != "" {
+		db = db.Where("(status = ? or (status = ? and username = ?))", model.CommentStatusApproved, model.CommentStatusPending, f.Viewer)
+	}
+
+	return db
+}
+
+// CommentStore 接口的实现.
+type comments struct {
+	ds *datastore
+}
+
+// 确保 comments 实现了 CommentStore 接口.
+var _ CommentStore = (*comments)(nil)
+
+func newComments(ds *datastore) *comments {
+	return &comments{ds}
+}
+
+// Create 插入一条 comment 记录.
+func (c *comments) Create(ctx context.Context, comment *model.CommentM) error {
+	return c.ds.core(ctx).Create(comment).Error
+}
+
+// Get 根据 postID 和 commentID 查询 comment 数据库记录.
+func (c *comments) Get(ctx context.Context, postID, commentID string) (*model.CommentM, error) {
+	var comment model.CommentM
+	if err := c.ds.core(ctx).Where("postID = ? and commentID = ?", postID, commentID).First(&comment).Error; err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// Update 更新一条 comment 数据库记录.
+func (c *comments) Update(ctx context.Context, comment *model.CommentM) error {
+	return c.ds.core(ctx).Save(comment).Error
+}
+
+// Delete 删除一条 comment 记录. 删除楼层的第一条评论时，整个楼层的评论都会被删除.
+func (c *comments) Delete(ctx context.Context, postID, commentID string) error {
+	return c.ds.core(ctx).Where("postID = ? and (commentID = ? or rootID = ?)", postID, commentID, commentID).Delete(&model.CommentM{}).Error
+}
+
+// ListThreads 按发表时间从早到晚分页列出博客中每个楼层的第一条评论.
+func (c *comments) ListThreads(ctx context.Context, postID string, filter *CommentFilter, opts *ListOptions) (count int64, ret []*model.CommentM, err error) {
+	db := filter.apply(c.ds.core(ctx).Model(&model.CommentM{}).Where("postID = ? and rootID = ''", postID)).Session(&gorm.Session{})
+	if !opts.SkipCount {
+		if err = db.Count(&count).Error; err != nil {
+			return
+		}
+	}
+
+	err = paginate(db, opts).Find(&ret).Error
+
+	return
+}
+
+// ListReplies 列出 rootIDs 指定的楼层中的回复，按发表时间从早到晚排列.
+func (c *comments) ListReplies(ctx context.Context, postID string, rootIDs []string, filter *CommentFilter) (ret []*model.CommentM, err error) {
+	if len(rootIDs) == 0 {
+		return nil, nil
+	}
+
+	err = filter.apply(c.ds.core(ctx).Where("postID = ? and rootID in (?)", postID, rootIDs)).Order("createdAt, id").Find(&ret).Error
+
+	return
+}
+
+// CountByPostIDs 返回 postIDs 中每个 post 已公开的 comment 数.
+func (c *comments) CountByPostIDs(ctx context.Context, postIDs []string) (map[string]int64, error) {
+	ret := make(map[string]int64, len(postIDs))
+	if len(postIDs) == 0 {
+		return ret, nil
+	}
+
+	var rows []struct {
+		PostID string `gorm:"column:postID"`
+		Count  int64  `gorm:"column:count"`
+	}
+	err := c.ds.core(ctx).Model(&model.CommentM{}).
+		Select("postID, COUNT(*) AS count").
+		Where("postID in (?) and status = ?", postIDs, model.CommentStatusApproved).
+		Group("postID").
+		Scan(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		ret[row.PostID] = row.Count
+	}
+
+	return ret, nil
+}
+
+// DeleteByUsername 永久删除指定用户发表的所有 comment 记录，返回被删除的记录数.
+func (c *comments) DeleteByUsername(ctx context.Context, username string) (int64, error) {
+	result := c.ds.core(ctx).Unscoped().Where("username = ?", username).Delete(&model.CommentM{})
+
+	return result.RowsAffected, result.Error
+}
+
+// UpdateUsername 将用户 from 发表的所有 comment 记录转移给用户 to，返回被更新的记录数.
+func (c *comments) UpdateUsername(ctx context.Context, from, to string) (int64, error) {
+	result := c.ds.core(ctx).Unscoped().Model(&model.CommentM{}).Where("username = ?", from).Update("username", to)
+
+	return result.RowsAffected, result.Error
+}
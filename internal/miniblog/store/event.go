@@ -0,0 +1,75 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// EventStore 定义了领域事件 outbox 在 store 层所实现的方法.
+type EventStore interface {
+	Create(ctx context.Context, event *model.EventM) error
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*model.EventM, error)
+	Claim(ctx context.Context, event *model.EventM, until time.Time) (bool, error)
+	Update(ctx context.Context, event *model.EventM) error
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// EventStore 接口的实现.
+type events struct {
+	ds *datastore
+}
+
+// 确保 events 实现了 EventStore 接口.
+var _ EventStore = (*events)(nil)
+
+func newEvents(ds *datastore) *events {
+	return &events{ds}
+}
+
+// Create 插入一条事件记录. 在事务中调用时，事件和事务中的其它数据变更一起提交或回滚.
+func (e *events) Create(ctx context.Context, event *model.EventM) error {
+	return e.ds.core(ctx).Create(event).Error
+}
+
+// ListDue 按创建顺序返回最多 limit 条到期的等待分发的事件记录.
+func (e *events) ListDue(ctx context.Context, now time.Time, limit int) (ret []*model.EventM, err error) {
+	err = e.ds.core(ctx).Where("status = ? and nextAttemptAt <= ?", model.EventStatusPending, now).
+		Order("id").Limit(limit).Find(&ret).Error
+
+	return
+}
+
+// Claim 认领一条到期的事件记录：分发次数加 1，并将下次分发时间推迟到 until，在此之前其它进程不会再分发该记录.
+// 分发进程在分发过程中退出时，记录在 until 之后会被重新分发. 记录已经被其它进程认领时返回 false.
+func (e *events) Claim(ctx context.Context, event *model.EventM, until time.Time) (bool, error) {
+	ret := e.ds.core(ctx).Model(&model.EventM{}).
+		Where("id = ? and status = ? and attempts = ?", event.ID, model.EventStatusPending, event.Attempts).
+		Updates(map[string]interface{}{"attempts": event.Attempts + 1, "nextAttemptAt": until})
+	if ret.Error != nil || ret.RowsAffected == 0 {
+		return false, ret.Error
+	}
+
+	event.Attempts++
+	event.NextAttemptAt = until
+
+	return true, nil
+}
+
+// Update 更新事件记录的分发状态.
+func (e *events) Update(ctx context.Context, event *model.EventM) error {
+	return e.ds.core(ctx).Save(event).Error
+}
+
+// DeleteBefore 删除创建时间早于 before 的已结束的事件记录，返回删除的记录数.
+func (e *events) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	ret := e.ds.core(ctx).Where("status <> ? and createdAt < ?", model.EventStatusPending, before).Delete(&model.EventM{})
+
+	return ret.RowsAffected, ret.Error
+}
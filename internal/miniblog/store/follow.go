@@ -0,0 +1,144 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// FollowStore 定义了 follow 模块在 store 层所实现的方法.
+type FollowStore interface {
+	Create(ctx context.Context, follow *model.FollowM) (bool, error)
+	Delete(ctx context.Context, username, followee string) (bool, error)
+	ListFollowers(ctx context.Context, username string, opts *ListOptions) (int64, []*model.FollowM, error)
+	ListFollowing(ctx context.Context, username string, opts *ListOptions) (int64, []*model.FollowM, error)
+	Count(ctx context.Context, username string) (followers int64, following int64, err error)
+	CountAll(ctx context.Context, usernames []string) (followers map[string]int64, following map[string]int64, err error)
+	DeleteByUsername(ctx context.Context, username string) (int64, error)
+	UpdateUsername(ctx context.Context, from, to string) (int64, error)
+}
+
+// FollowStore 接口的实现.
+type follows struct {
+	ds *datastore
+}
+
+// 确保 follows 实现了 FollowStore 接口.
+var _ FollowStore = (*follows)(nil)
+
+func newFollows(ds *datastore) *follows {
+	return &follows{ds}
+}
+
+// Create 添加一条关注记录，已经关注时不做任何修改. 返回值表示是否添加了新的记录.
+func (f *follows) Create(ctx context.Context, follow *model.FollowM) (bool, error) {
+	result := f.ds.core(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(follow)
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// Delete 删除一条关注记录，未关注时不做任何修改. 返回值表示是否删除了记录.
+func (f *follows) Delete(ctx context.Context, username, followee string) (bool, error) {
+	result := f.ds.core(ctx).Where("username = ? and followee = ?", username, followee).Delete(&model.FollowM{})
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListFollowers 按关注时间从新到旧分页列出关注了 username 的记录.
+func (f *follows) ListFollowers(ctx context.Context, username string, opts *ListOptions) (int64, []*model.FollowM, error) {
+	return f.list(f.ds.core(ctx).Model(&model.FollowM{}).Where("followee = ?", username), opts)
+}
+
+// ListFollowing 按关注时间从新到旧分页列出 username 关注其他用户的记录.
+func (f *follows) ListFollowing(ctx context.Context, username string, opts *ListOptions) (int64, []*model.FollowM, error) {
+	return f.list(f.ds.core(ctx).Model(&model.FollowM{}).Where("username = ?", username), opts)
+}
+
+func (f *follows) list(db *gorm.DB, opts *ListOptions) (count int64, ret []*model.FollowM, err error) {
+	db = db.Session(&gorm.Session{})
+	if !opts.SkipCount {
+		if err = db.Count(&count).Error; err != nil {
+			return
+		}
+	}
+
+	err = paginate(db, opts).Find(&ret).Error
+
+	return
+}
+
+// Count 返回 username 的关注者数量和关注的用户数量.
+func (f *follows) Count(ctx context.Context, username string) (followers int64, following int64, err error) {
+	db := f.ds.core(ctx).Model(&model.FollowM{})
+	if err = db.Session(&gorm.Session{}).Where("followee = ?", username).Count(&followers).Error; err != nil {
+		return
+	}
+
+	err = db.Session(&gorm.Session{}).Where("username = ?", username).Count(&following).Error
+
+	return
+}
+
+// CountAll 返回 usernames 中每个用户的关注者数量和关注的用户数量，没有关注记录的用户不在返回的 map 中.
+// 每种数量只使用一条 GROUP BY 查询，用于列表接口.
+func (f *follows) CountAll(ctx context.Context, usernames []string) (followers map[string]int64, following map[string]int64, err error) {
+	if followers, err = f.countBy(ctx, "followee", usernames); err != nil {
+		return
+	}
+
+	following, err = f.countBy(ctx, "username", usernames)
+
+	return
+}
+
+// countBy 按 column 分组统计 column 为 usernames 中用户的关注记录数.
+func (f *follows) countBy(ctx context.Context, column string, usernames []string) (map[string]int64, error) {
+	var rows []struct {
+		Username string
+		Count    int64
+	}
+	err := f.ds.core(ctx).Model(&model.FollowM{}).
+		Select(column+" AS username, COUNT(*) AS count").
+		Where(column+" IN ?", usernames).
+		Group(column).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Username] = row.Count
+	}
+
+	return counts, nil
+}
+
+// DeleteByUsername 删除 username 关注其他用户以及其他用户关注 username 的所有记录，返回被删除的记录数.
+func (f *follows) DeleteByUsername(ctx context.Context, username string) (int64, error) {
+	result := f.ds.core(ctx).Where("username = ? or followee = ?", username, username).Delete(&model.FollowM{})
+
+	return result.RowsAffected, result.Error
+}
+
+// UpdateUsername 将 from 关注其他用户以及其他用户关注 from 的所有记录转移给 to，返回被更新的记录数.
+func (f *follows) UpdateUsername(ctx context.Context, from, to string) (int64, error) {
+	db := f.ds.core(ctx)
+
+	followers := db.Model(&model.FollowM{}).Where("followee = ?", from).Update("followee", to)
+	if followers.Error != nil {
+		return 0, followers.Error
+	}
+
+	following := db.Model(&model.FollowM{}).Where("username = ?", from).Update("username", to)
+
+	return followers.RowsAffected + following.RowsAffected, following.Error
+}
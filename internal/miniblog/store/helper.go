@@ -5,8 +5,22 @@
 
 package store
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
 const defaultLimitValue = 20
 
+// ErrInvalidCursor 表示分页游标格式错误.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
 // defaultLimit 设置默认查询记录数.
 func defaultLimit(limit int) int {
 	if limit == 0 {
@@ -15,3 +29,169 @@ func defaultLimit(limit int) int {
 
 	return limit
 }
+
+// ListOptions 定义了列表查询的分页、排序和字段选项.
+type ListOptions struct {
+	// Offset 和 Limit 用于偏移量分页，Cursor 不为空时忽略 Offset.
+	Offset int
+	Limit  int
+
+	// Cursor 不为空时使用游标分页，只返回排在 Cursor 之后的记录.
+	Cursor *Cursor
+
+	// SkipCount 为 true 时不统计记录总数.
+	SkipCount bool
+
+	// SortBy 指定排序字段（数据库列名），为空时按 createdAt 排序. 排序字段相同的记录再按 id 排序.
+	SortBy string
+
+	// Ascending 为 true 时升序排列，否则降序排列.
+	Ascending bool
+
+	// Fields 指定需要查询的字段（数据库列名），为空时查询所有字段.
+	// id 和排序字段总会被查询，用于生成下一页的游标.
+	Fields []string
+}
+
+// timeColumns 记录了时间类型的列，游标中这些列的值使用 RFC3339Nano 格式保存.
+var timeColumns = map[string]bool{
+	"createdAt": true,
+	"updatedAt": true,
+	"deletedAt": true,
+	"publishAt": true,
+}
+
+// NewListOptions 根据客户端传入的分页参数创建 ListOptions，pageToken 不为空时使用游标分页.
+func NewListOptions(offset, limit int, pageToken string, skipCount bool) (*ListOptions, error) {
+	opts := &ListOptions{Offset: offset, Limit: limit, SkipCount: skipCount}
+	if pageToken != "" {
+		cursor, err := DecodeCursor(pageToken)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.Cursor = cursor
+	}
+
+	return opts, nil
+}
+
+// PageSize 返回每页的记录数，未指定 Limit 时返回默认值.
+// List 类方法最多返回 PageSize()+1 条记录，多出的一条记录用来判断是否还有下一页.
+func (o *ListOptions) PageSize() int {
+	if o.Limit < 0 {
+		return defaultLimitValue
+	}
+
+	return defaultLimit(o.Limit)
+}
+
+// sortColumn 返回排序字段，未指定时返回 createdAt.
+func (o *ListOptions) sortColumn() string {
+	if o.SortBy == "" {
+		return "createdAt"
+	}
+
+	return o.SortBy
+}
+
+// sort 返回排序方式，例如 "createdAt desc"，用来校验游标是否由相同的排序方式生成.
+func (o *ListOptions) sort() string {
+	if o.Ascending {
+		return o.sortColumn() + " asc"
+	}
+
+	return o.sortColumn() + " desc"
+}
+
+// Cursor 记录了游标分页中上一页最后一条记录的位置.
+type Cursor struct {
+	// Sort 是生成游标时使用的排序方式，只能用于相同排序方式的查询.
+	Sort string `json:"sort"`
+	// Value 是上一页最后一条记录排序字段的值.
+	Value string `json:"value"`
+	ID    int64  `json:"id"`
+}
+
+// NewCursor 根据 opts 的排序方式和上一页最后一条记录的排序字段值 value、id 创建 Cursor.
+func NewCursor(opts *ListOptions, value interface{}, id int64) *Cursor {
+	c := &Cursor{Sort: opts.sort(), ID: id}
+	switch v := value.(type) {
+	case time.Time:
+		c.Value = v.Format(time.RFC3339Nano)
+	default:
+		c.Value = fmt.Sprint(v)
+	}
+
+	return c
+}
+
+// Encode 将 Cursor 编码为对客户端不透明的字符串.
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor 将 Encode 返回的字符串解码为 Cursor.
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID <= 0 || c.Sort == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// paginate 根据 opts 为查询添加字段、排序和分页条件.
+// 游标与 opts 的排序方式不一致时，查询返回 ErrInvalidCursor.
+func paginate(db *gorm.DB, opts *ListOptions) *gorm.DB {
+	col := clause.Column{Name: opts.sortColumn()}
+	idCol := clause.Column{Name: "id"}
+
+	if len(opts.Fields) > 0 {
+		db = db.Select(append([]string{"id", col.Name}, opts.Fields...))
+	}
+
+	if opts.Cursor != nil {
+		if opts.Cursor.Sort != opts.sort() {
+			_ = db.AddError(ErrInvalidCursor)
+			return db
+		}
+
+		var value interface{} = opts.Cursor.Value
+		if timeColumns[col.Name] {
+			t, err := time.Parse(time.RFC3339Nano, opts.Cursor.Value)
+			if err != nil {
+				_ = db.AddError(ErrInvalidCursor)
+				return db
+			}
+
+			value = t
+		}
+
+		// 排在游标之后的记录: (col, id) 严格大于（升序）或小于（降序）游标位置.
+		if opts.Ascending {
+			db = db.Where(clause.Or(
+				clause.Gt{Column: col, Value: value},
+				clause.And(clause.Eq{Column: col, Value: value}, clause.Gt{Column: idCol, Value: opts.Cursor.ID}),
+			))
+		} else {
+			db = db.Where(clause.Or(
+				clause.Lt{Column: col, Value: value},
+				clause.And(clause.Eq{Column: col, Value: value}, clause.Lt{Column: idCol, Value: opts.Cursor.ID}),
+			))
+		}
+	} else {
+		db = db.Offset(opts.Offset)
+	}
+
+	return db.Order(clause.OrderByColumn{Column: col, Desc: !opts.Ascending}).
+		Order(clause.OrderByColumn{Column: idCol, Desc: !opts.Ascending}).
+		Limit(opts.PageSize() + 1)
+}
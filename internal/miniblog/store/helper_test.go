@@ -6,9 +6,15 @@
 package store
 
 import (
+	"encoding/base64"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
 )
 
 // FuzzDefaultLimit 模糊测试用例.
@@ -27,3 +33,88 @@ func FuzzDefaultLimit(f *testing.F) {
 		}
 	})
 }
+
+func TestCursor(t *testing.T) {
+	want := NewCursor(&ListOptions{}, time.Date(2022, 11, 20, 10, 0, 0, 0, time.UTC), 141)
+	assert.Equal(t, &Cursor{Sort: "createdAt desc", Value: "2022-11-20T10:00:00Z", ID: 141}, want)
+
+	got, err := DecodeCursor(want.Encode())
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+
+	assert.Equal(t, &Cursor{Sort: "title asc", Value: "hello", ID: 1}, NewCursor(&ListOptions{SortBy: "title", Ascending: true}, "hello", 1))
+
+	for _, token := range []string{"invalid token", "e30", base64.RawURLEncoding.EncodeToString([]byte(`{"sort":"createdAt desc","id":-1}`))} {
+		_, err := DecodeCursor(token)
+		assert.Equal(t, ErrInvalidCursor, err)
+	}
+}
+
+func TestNewListOptions(t *testing.T) {
+	opts, err := NewListOptions(10, 0, "", true)
+	assert.Nil(t, err)
+	assert.Equal(t, &ListOptions{Offset: 10, SkipCount: true}, opts)
+	assert.Equal(t, defaultLimitValue, opts.PageSize())
+
+	cursor := NewCursor(&ListOptions{}, time.Now(), 1)
+	opts, err = NewListOptions(10, 5, cursor.Encode(), false)
+	assert.Nil(t, err)
+	assert.Equal(t, cursor.ID, opts.Cursor.ID)
+	assert.Equal(t, 5, opts.PageSize())
+
+	_, err = NewListOptions(0, 0, "invalid token", false)
+	assert.Equal(t, ErrInvalidCursor, err)
+}
+
+func TestPaginate(t *testing.T) {
+	db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.Nil(t, err)
+
+	created := time.Date(2022, 11, 20, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		opts    *ListOptions
+		wantSQL string
+		wantErr error
+	}{
+		{
+			name:    "offset",
+			opts:    &ListOptions{Offset: 10, Limit: 5},
+			wantSQL: "SELECT * FROM `post` WHERE `post`.`deletedAt` IS NULL ORDER BY `createdAt` DESC,`id` DESC LIMIT 6 OFFSET 10",
+		},
+		{
+			name:    "cursor",
+			opts:    &ListOptions{Cursor: NewCursor(&ListOptions{}, created, 3)},
+			wantSQL: "SELECT * FROM `post` WHERE (`createdAt` < ? OR (`createdAt` = ? AND `id` < ?)) AND `post`.`deletedAt` IS NULL ORDER BY `createdAt` DESC,`id` DESC LIMIT 21",
+		},
+		{
+			name: "sort and fields",
+			opts: &ListOptions{
+				SortBy:    "title",
+				Ascending: true,
+				Fields:    []string{"postID"},
+				Cursor:    NewCursor(&ListOptions{SortBy: "title", Ascending: true}, "hello", 3),
+			},
+			wantSQL: "SELECT `id`,`title`,`postID` FROM `post` WHERE (`title` > ? OR (`title` = ? AND `id` > ?)) AND `post`.`deletedAt` IS NULL ORDER BY `title`,`id` LIMIT 21",
+		},
+		{
+			name:    "cursor of another sort",
+			opts:    &ListOptions{SortBy: "updatedAt", Cursor: NewCursor(&ListOptions{}, created, 3)},
+			wantErr: ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ret []*model.PostM
+			stmt := paginate(db.Model(&model.PostM{}), tt.opts).Find(&ret)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, stmt.Error, tt.wantErr)
+				return
+			}
+
+			assert.Nil(t, stmt.Error)
+			assert.Equal(t, tt.wantSQL, stmt.Statement.SQL.String())
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// MediaStore 定义了 media 模块在 store 层所实现的方法.
+type MediaStore interface {
+	Create(ctx context.Context, media *model.MediaM) error
+	Get(ctx context.Context, mediaID string) (*model.MediaM, error)
+	Lock(ctx context.Context, mediaID string) (*model.MediaM, error)
+	Referenced(ctx context.Context, mediaID string) (bool, error)
+	List(ctx context.Context, username string, offset, limit int) (int64, []*model.MediaM, error)
+	Delete(ctx context.Context, mediaID string) error
+	Usage(ctx context.Context, username string) (int64, error)
+	SetPostMedia(ctx context.Context, postID string, mediaIDs []string) error
+	ListUnreferenced(ctx context.Context, before time.Time, limit int) ([]*model.MediaM, error)
+	UpdateUsername(ctx context.Context, from, to string) (int64, error)
+}
+
+// MediaStore 接口的实现.
+type medias struct {
+	ds *datastore
+}
+
+// 确保 medias 实现了 MediaStore 接口.
+var _ MediaStore = (*medias)(nil)
+
+func newMedias(ds *datastore) *medias {
+	return &medias{ds}
+}
+
+// Create 插入一条 media 记录.
+func (m *medias) Create(ctx context.Context, media *model.MediaM) error {
+	return m.ds.core(ctx).Create(media).Error
+}
+
+// Get 根据 mediaID 查询 media 记录.
+func (m *medias) Get(ctx context.Context, mediaID string) (*model.MediaM, error) {
+	var media model.MediaM
+	if err := m.ds.core(ctx).Where("mediaID = ?", mediaID).First(&media).Error; err != nil {
+		return nil, err
+	}
+
+	return &media, nil
+}
+
+// Lock 查询 media 记录并加排他锁直到事务结束，在此期间 post 无法引用该 media. 应在事务中调用.
+func (m *medias) Lock(ctx context.Context, mediaID string) (*model.MediaM, error) {
+	var media model.MediaM
+	if err := m.ds.core(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("mediaID = ?", mediaID).First(&media).Error; err != nil {
+		return nil, err
+	}
+
+	return &media, nil
+}
+
+// Referenced 返回 media 是否被 post 引用. 使用加锁读，以读取到其它事务已提交的最新引用记录.
+func (m *medias) Referenced(ctx context.Context, mediaID string) (bool, error) {
+	var postIDs []string
+	err := m.ds.core(ctx).Model(&model.PostMediaM{}).Clauses(clause.Locking{Strength: "SHARE"}).
+		Where("mediaID = ?", mediaID).
+		Limit(1).
+		Pluck("postID", &postIDs).Error
+
+	return len(postIDs) > 0, err
+}
+
+// List 按上传时间从新到旧列出用户上传的 media.
+func (m *medias) List(ctx context.Context, username string, offset, limit int) (count int64, ret []*model.MediaM, err error) {
+	err = m.ds.core(ctx).Where("username = ?", username).Offset(offset).Limit(defaultLimit(limit)).Order("id desc").Find(&ret).
+		Offset(-1).
+		Limit(-1).
+		Count(&count).
+		Error
+
+	return
+}
+
+// Delete 删除 media 记录以及 post 对它的引用记录.
+func (m *medias) Delete(ctx context.Context, mediaID string) error {
+	if err := m.ds.core(ctx).Where("mediaID = ?", mediaID).Delete(&model.PostMediaM{}).Error; err != nil {
+		return err
+	}
+
+	return m.ds.core(ctx).Where("mediaID = ?", mediaID).Delete(&model.MediaM{}).Error
+}
+
+// Usage 返回用户上传的所有 media（包括缩略图）占用的字节数.
+func (m *medias) Usage(ctx context.Context, username string) (int64, error) {
+	var used int64
+	err := m.ds.core(ctx).Model(&model.MediaM{}).Where("username = ?", username).
+		Select("COALESCE(SUM(size + thumbnailSize), 0)").
+		Scan(&used).Error
+
+	return used, err
+}
+
+// SetPostMedia 将 post 引用的 media 替换为 mediaIDs. 引用的 media 记录会被加共享锁，
+// 避免与正在删除这些 media 的事务交错执行. 应在事务中调用.
+func (m *medias) SetPostMedia(ctx context.Context, postID string, mediaIDs []string) error {
+	db := m.ds.core(ctx)
+	if err := db.Where("postID = ?", postID).Delete(&model.PostMediaM{}).Error; err != nil {
+		return err
+	}
+
+	if len(mediaIDs) == 0 {
+		return nil
+	}
+
+	var locked []string
+	err := db.Model(&model.MediaM{}).Clauses(clause.Locking{Strength: "SHARE"}).
+		Where("mediaID IN ?", mediaIDs).
+		Pluck("mediaID", &locked).Error
+	if err != nil {
+		return err
+	}
+
+	rows := make([]*model.PostMediaM, 0, len(mediaIDs))
+	for _, mediaID := range mediaIDs {
+		rows = append(rows, &model.PostMediaM{PostID: postID, MediaID: mediaID})
+	}
+
+	return db.Create(&rows).Error
+}
+
+// ListUnreferenced 返回在 before 之前上传、且没有被任何 post 引用的 media，最多返回 limit 条.
+func (m *medias) ListUnreferenced(ctx context.Context, before time.Time, limit int) (ret []*model.MediaM, err error) {
+	referenced := m.ds.core(ctx).Model(&model.PostMediaM{}).Select("mediaID")
+	err = m.ds.core(ctx).Where("createdAt < ? and mediaID not in (?)", before, referenced).
+		Order("id").
+		Limit(defaultLimit(limit)).
+		Find(&ret).
+		Error
+
+	return
+}
+
+// UpdateUsername 将用户 from 上传的所有 media 转移给用户 to，返回被更新的记录数.
+func (m *medias) UpdateUsername(ctx context.Context, from, to string) (int64, error) {
+	result := m.ds.core(ctx).Model(&model.MediaM{}).Where("username = ?", from).Update("username", to)
+
+	return result.RowsAffected, result.Error
+}
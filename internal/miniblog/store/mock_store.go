@@ -4,14 +4,16 @@
 // this file is https://github.com/marmotedu/miniblog.
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/marmotedu/miniblog/internal/miniblog/store (interfaces: IStore,UserStore,PostStore)
+// Source: github.com/marmotedu/miniblog/internal/miniblog/store (interfaces: IStore,UserStore,PostStore,PolicyStore,AuditLogStore,SearchIndex,TagStore,CategoryStore,CommentStore,ReactionStore,FollowStore,TimelineStore,RevisionStore,SlugStore,MediaStore,BlobStore,ImportStore,WebhookStore,DeliveryStore,EventStore,NotificationStore,AliasStore)
 
 // Package store is a generated GoMock package.
 package store
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	gorm "gorm.io/gorm"
@@ -42,6 +44,76 @@ func (m *MockIStore) EXPECT() *MockIStoreMockRecorder {
 	return m.recorder
 }
 
+// Aliases mocks base method.
+func (m *MockIStore) Aliases() AliasStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Aliases")
+	ret0, _ := ret[0].(AliasStore)
+	return ret0
+}
+
+// Aliases indicates an expected call of Aliases.
+func (mr *MockIStoreMockRecorder) Aliases() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aliases", reflect.TypeOf((*MockIStore)(nil).Aliases))
+}
+
+// AuditLogs mocks base method.
+func (m *MockIStore) AuditLogs() AuditLogStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditLogs")
+	ret0, _ := ret[0].(AuditLogStore)
+	return ret0
+}
+
+// AuditLogs indicates an expected call of AuditLogs.
+func (mr *MockIStoreMockRecorder) AuditLogs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditLogs", reflect.TypeOf((*MockIStore)(nil).AuditLogs))
+}
+
+// Blobs mocks base method.
+func (m *MockIStore) Blobs() BlobStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Blobs")
+	ret0, _ := ret[0].(BlobStore)
+	return ret0
+}
+
+// Blobs indicates an expected call of Blobs.
+func (mr *MockIStoreMockRecorder) Blobs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Blobs", reflect.TypeOf((*MockIStore)(nil).Blobs))
+}
+
+// Categories mocks base method.
+func (m *MockIStore) Categories() CategoryStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Categories")
+	ret0, _ := ret[0].(CategoryStore)
+	return ret0
+}
+
+// Categories indicates an expected call of Categories.
+func (mr *MockIStoreMockRecorder) Categories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Categories", reflect.TypeOf((*MockIStore)(nil).Categories))
+}
+
+// Comments mocks base method.
+func (m *MockIStore) Comments() CommentStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Comments")
+	ret0, _ := ret[0].(CommentStore)
+	return ret0
+}
+
+// Comments indicates an expected call of Comments.
+func (mr *MockIStoreMockRecorder) Comments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Comments", reflect.TypeOf((*MockIStore)(nil).Comments))
+}
+
 // DB mocks base method.
 func (m *MockIStore) DB() *gorm.DB {
 	m.ctrl.T.Helper()
@@ -56,6 +128,104 @@ func (mr *MockIStoreMockRecorder) DB() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DB", reflect.TypeOf((*MockIStore)(nil).DB))
 }
 
+// Deliveries mocks base method.
+func (m *MockIStore) Deliveries() DeliveryStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliveries")
+	ret0, _ := ret[0].(DeliveryStore)
+	return ret0
+}
+
+// Deliveries indicates an expected call of Deliveries.
+func (mr *MockIStoreMockRecorder) Deliveries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliveries", reflect.TypeOf((*MockIStore)(nil).Deliveries))
+}
+
+// Events mocks base method.
+func (m *MockIStore) Events() EventStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Events")
+	ret0, _ := ret[0].(EventStore)
+	return ret0
+}
+
+// Events indicates an expected call of Events.
+func (mr *MockIStoreMockRecorder) Events() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Events", reflect.TypeOf((*MockIStore)(nil).Events))
+}
+
+// Follows mocks base method.
+func (m *MockIStore) Follows() FollowStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Follows")
+	ret0, _ := ret[0].(FollowStore)
+	return ret0
+}
+
+// Follows indicates an expected call of Follows.
+func (mr *MockIStoreMockRecorder) Follows() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Follows", reflect.TypeOf((*MockIStore)(nil).Follows))
+}
+
+// Imports mocks base method.
+func (m *MockIStore) Imports() ImportStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Imports")
+	ret0, _ := ret[0].(ImportStore)
+	return ret0
+}
+
+// Imports indicates an expected call of Imports.
+func (mr *MockIStoreMockRecorder) Imports() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Imports", reflect.TypeOf((*MockIStore)(nil).Imports))
+}
+
+// Media mocks base method.
+func (m *MockIStore) Media() MediaStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Media")
+	ret0, _ := ret[0].(MediaStore)
+	return ret0
+}
+
+// Media indicates an expected call of Media.
+func (mr *MockIStoreMockRecorder) Media() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Media", reflect.TypeOf((*MockIStore)(nil).Media))
+}
+
+// Notifications mocks base method.
+func (m *MockIStore) Notifications() NotificationStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notifications")
+	ret0, _ := ret[0].(NotificationStore)
+	return ret0
+}
+
+// Notifications indicates an expected call of Notifications.
+func (mr *MockIStoreMockRecorder) Notifications() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notifications", reflect.TypeOf((*MockIStore)(nil).Notifications))
+}
+
+// Policies mocks base method.
+func (m *MockIStore) Policies() PolicyStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Policies")
+	ret0, _ := ret[0].(PolicyStore)
+	return ret0
+}
+
+// Policies indicates an expected call of Policies.
+func (mr *MockIStoreMockRecorder) Policies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Policies", reflect.TypeOf((*MockIStore)(nil).Policies))
+}
+
 // Posts mocks base method.
 func (m *MockIStore) Posts() PostStore {
 	m.ctrl.T.Helper()
@@ -70,6 +240,104 @@ func (mr *MockIStoreMockRecorder) Posts() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Posts", reflect.TypeOf((*MockIStore)(nil).Posts))
 }
 
+// Reactions mocks base method.
+func (m *MockIStore) Reactions() ReactionStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reactions")
+	ret0, _ := ret[0].(ReactionStore)
+	return ret0
+}
+
+// Reactions indicates an expected call of Reactions.
+func (mr *MockIStoreMockRecorder) Reactions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reactions", reflect.TypeOf((*MockIStore)(nil).Reactions))
+}
+
+// Revisions mocks base method.
+func (m *MockIStore) Revisions() RevisionStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revisions")
+	ret0, _ := ret[0].(RevisionStore)
+	return ret0
+}
+
+// Revisions indicates an expected call of Revisions.
+func (mr *MockIStoreMockRecorder) Revisions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revisions", reflect.TypeOf((*MockIStore)(nil).Revisions))
+}
+
+// Search mocks base method.
+func (m *MockIStore) Search() SearchIndex {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search")
+	ret0, _ := ret[0].(SearchIndex)
+	return ret0
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockIStoreMockRecorder) Search() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockIStore)(nil).Search))
+}
+
+// Slugs mocks base method.
+func (m *MockIStore) Slugs() SlugStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Slugs")
+	ret0, _ := ret[0].(SlugStore)
+	return ret0
+}
+
+// Slugs indicates an expected call of Slugs.
+func (mr *MockIStoreMockRecorder) Slugs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Slugs", reflect.TypeOf((*MockIStore)(nil).Slugs))
+}
+
+// TX mocks base method.
+func (m *MockIStore) TX(arg0 context.Context, arg1 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TX", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TX indicates an expected call of TX.
+func (mr *MockIStoreMockRecorder) TX(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TX", reflect.TypeOf((*MockIStore)(nil).TX), arg0, arg1)
+}
+
+// Tags mocks base method.
+func (m *MockIStore) Tags() TagStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tags")
+	ret0, _ := ret[0].(TagStore)
+	return ret0
+}
+
+// Tags indicates an expected call of Tags.
+func (mr *MockIStoreMockRecorder) Tags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tags", reflect.TypeOf((*MockIStore)(nil).Tags))
+}
+
+// Timelines mocks base method.
+func (m *MockIStore) Timelines() TimelineStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Timelines")
+	ret0, _ := ret[0].(TimelineStore)
+	return ret0
+}
+
+// Timelines indicates an expected call of Timelines.
+func (mr *MockIStoreMockRecorder) Timelines() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Timelines", reflect.TypeOf((*MockIStore)(nil).Timelines))
+}
+
 // Users mocks base method.
 func (m *MockIStore) Users() UserStore {
 	m.ctrl.T.Helper()
@@ -84,6 +352,20 @@ func (mr *MockIStoreMockRecorder) Users() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockIStore)(nil).Users))
 }
 
+// Webhooks mocks base method.
+func (m *MockIStore) Webhooks() WebhookStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Webhooks")
+	ret0, _ := ret[0].(WebhookStore)
+	return ret0
+}
+
+// Webhooks indicates an expected call of Webhooks.
+func (mr *MockIStoreMockRecorder) Webhooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Webhooks", reflect.TypeOf((*MockIStore)(nil).Webhooks))
+}
+
 // MockUserStore is a mock of UserStore interface.
 type MockUserStore struct {
 	ctrl     *gomock.Controller
@@ -151,7 +433,7 @@ func (mr *MockUserStoreMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
 }
 
 // List mocks base method.
-func (m *MockUserStore) List(arg0 context.Context, arg1, arg2 int) (int64, []*model.UserM, error) {
+func (m *MockUserStore) List(arg0 context.Context, arg1 *UserFilter, arg2 *ListOptions) (int64, []*model.UserM, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2)
 	ret0, _ := ret[0].(int64)
@@ -166,6 +448,20 @@ func (mr *MockUserStoreMockRecorder) List(arg0, arg1, arg2 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserStore)(nil).List), arg0, arg1, arg2)
 }
 
+// Lock mocks base method.
+func (m *MockUserStore) Lock(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockUserStoreMockRecorder) Lock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockUserStore)(nil).Lock), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockUserStore) Update(arg0 context.Context, arg1 *model.UserM) error {
 	m.ctrl.T.Helper()
@@ -231,6 +527,35 @@ func (mr *MockPostStoreMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPostStore)(nil).Delete), arg0, arg1, arg2)
 }
 
+// DeleteByUsername mocks base method.
+func (m *MockPostStore) DeleteByUsername(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUsername", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUsername indicates an expected call of DeleteByUsername.
+func (mr *MockPostStoreMockRecorder) DeleteByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUsername", reflect.TypeOf((*MockPostStore)(nil).DeleteByUsername), arg0, arg1)
+}
+
+// ForEach mocks base method.
+func (m *MockPostStore) ForEach(arg0 context.Context, arg1 int, arg2 func([]*model.PostM) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEach", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEach indicates an expected call of ForEach.
+func (mr *MockPostStoreMockRecorder) ForEach(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEach", reflect.TypeOf((*MockPostStore)(nil).ForEach), arg0, arg1, arg2)
+}
+
 // Get mocks base method.
 func (m *MockPostStore) Get(arg0 context.Context, arg1, arg2 string) (*model.PostM, error) {
 	m.ctrl.T.Helper()
@@ -246,8 +571,38 @@ func (mr *MockPostStoreMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPostStore)(nil).Get), arg0, arg1, arg2)
 }
 
+// GetByPostID mocks base method.
+func (m *MockPostStore) GetByPostID(arg0 context.Context, arg1 string) (*model.PostM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPostID", arg0, arg1)
+	ret0, _ := ret[0].(*model.PostM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPostID indicates an expected call of GetByPostID.
+func (mr *MockPostStoreMockRecorder) GetByPostID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPostID", reflect.TypeOf((*MockPostStore)(nil).GetByPostID), arg0, arg1)
+}
+
+// GetByPostIDs mocks base method.
+func (m *MockPostStore) GetByPostIDs(arg0 context.Context, arg1 []string) ([]*model.PostM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPostIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*model.PostM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPostIDs indicates an expected call of GetByPostIDs.
+func (mr *MockPostStoreMockRecorder) GetByPostIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPostIDs", reflect.TypeOf((*MockPostStore)(nil).GetByPostIDs), arg0, arg1)
+}
+
 // List mocks base method.
-func (m *MockPostStore) List(arg0 context.Context, arg1 string, arg2, arg3 int) (int64, []*model.PostM, error) {
+func (m *MockPostStore) List(arg0 context.Context, arg1 string, arg2 *PostFilter, arg3 *ListOptions) (int64, []*model.PostM, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(int64)
@@ -262,6 +617,112 @@ func (mr *MockPostStoreMockRecorder) List(arg0, arg1, arg2, arg3 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPostStore)(nil).List), arg0, arg1, arg2, arg3)
 }
 
+// ListAll mocks base method.
+func (m *MockPostStore) ListAll(arg0 context.Context, arg1 *PostFilter, arg2 *ListOptions) (int64, []*model.PostM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]*model.PostM)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockPostStoreMockRecorder) ListAll(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockPostStore)(nil).ListAll), arg0, arg1, arg2)
+}
+
+// ListByPostIDs mocks base method.
+func (m *MockPostStore) ListByPostIDs(arg0 context.Context, arg1 string, arg2 []string) ([]*model.PostM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByPostIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.PostM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByPostIDs indicates an expected call of ListByPostIDs.
+func (mr *MockPostStoreMockRecorder) ListByPostIDs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPostIDs", reflect.TypeOf((*MockPostStore)(nil).ListByPostIDs), arg0, arg1, arg2)
+}
+
+// ListDeleted mocks base method.
+func (m *MockPostStore) ListDeleted(arg0 context.Context, arg1 string, arg2, arg3 int) (int64, []*model.PostM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeleted", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]*model.PostM)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockPostStoreMockRecorder) ListDeleted(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockPostStore)(nil).ListDeleted), arg0, arg1, arg2, arg3)
+}
+
+// ListDue mocks base method.
+func (m *MockPostStore) ListDue(arg0 context.Context, arg1 time.Time) ([]*model.PostM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDue", arg0, arg1)
+	ret0, _ := ret[0].([]*model.PostM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDue indicates an expected call of ListDue.
+func (mr *MockPostStoreMockRecorder) ListDue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDue", reflect.TypeOf((*MockPostStore)(nil).ListDue), arg0, arg1)
+}
+
+// Publish mocks base method.
+func (m *MockPostStore) Publish(arg0 context.Context, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPostStoreMockRecorder) Publish(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPostStore)(nil).Publish), arg0, arg1)
+}
+
+// Purge mocks base method.
+func (m *MockPostStore) Purge(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockPostStoreMockRecorder) Purge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockPostStore)(nil).Purge), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockPostStore) Restore(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockPostStoreMockRecorder) Restore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockPostStore)(nil).Restore), arg0, arg1, arg2)
+}
+
 // Update mocks base method.
 func (m *MockPostStore) Update(arg0 context.Context, arg1 *model.PostM) error {
 	m.ctrl.T.Helper()
@@ -275,3 +736,2241 @@ func (mr *MockPostStoreMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPostStore)(nil).Update), arg0, arg1)
 }
+
+// UpdateUsername mocks base method.
+func (m *MockPostStore) UpdateUsername(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockPostStoreMockRecorder) UpdateUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockPostStore)(nil).UpdateUsername), arg0, arg1, arg2)
+}
+
+// MockPolicyStore is a mock of PolicyStore interface.
+type MockPolicyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyStoreMockRecorder
+}
+
+// MockPolicyStoreMockRecorder is the mock recorder for MockPolicyStore.
+type MockPolicyStoreMockRecorder struct {
+	mock *MockPolicyStore
+}
+
+// NewMockPolicyStore creates a new mock instance.
+func NewMockPolicyStore(ctrl *gomock.Controller) *MockPolicyStore {
+	mock := &MockPolicyStore{ctrl: ctrl}
+	mock.recorder = &MockPolicyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyStore) EXPECT() *MockPolicyStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPolicyStore) Create(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPolicyStoreMockRecorder) Create(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPolicyStore)(nil).Create), arg0, arg1, arg2, arg3)
+}
+
+// DeleteBySubject mocks base method.
+func (m *MockPolicyStore) DeleteBySubject(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBySubject", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBySubject indicates an expected call of DeleteBySubject.
+func (mr *MockPolicyStoreMockRecorder) DeleteBySubject(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBySubject", reflect.TypeOf((*MockPolicyStore)(nil).DeleteBySubject), arg0, arg1)
+}
+
+// UpdateObject mocks base method.
+func (m *MockPolicyStore) UpdateObject(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateObject", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateObject indicates an expected call of UpdateObject.
+func (mr *MockPolicyStoreMockRecorder) UpdateObject(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateObject", reflect.TypeOf((*MockPolicyStore)(nil).UpdateObject), arg0, arg1, arg2)
+}
+
+// UpdateSubject mocks base method.
+func (m *MockPolicyStore) UpdateSubject(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubject", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubject indicates an expected call of UpdateSubject.
+func (mr *MockPolicyStoreMockRecorder) UpdateSubject(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubject", reflect.TypeOf((*MockPolicyStore)(nil).UpdateSubject), arg0, arg1, arg2)
+}
+
+// MockAuditLogStore is a mock of AuditLogStore interface.
+type MockAuditLogStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogStoreMockRecorder
+}
+
+// MockAuditLogStoreMockRecorder is the mock recorder for MockAuditLogStore.
+type MockAuditLogStoreMockRecorder struct {
+	mock *MockAuditLogStore
+}
+
+// NewMockAuditLogStore creates a new mock instance.
+func NewMockAuditLogStore(ctrl *gomock.Controller) *MockAuditLogStore {
+	mock := &MockAuditLogStore{ctrl: ctrl}
+	mock.recorder = &MockAuditLogStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogStore) EXPECT() *MockAuditLogStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAuditLogStore) Create(arg0 context.Context, arg1 *model.AuditLogM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuditLogStoreMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuditLogStore)(nil).Create), arg0, arg1)
+}
+
+// MockSearchIndex is a mock of SearchIndex interface.
+type MockSearchIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchIndexMockRecorder
+}
+
+// MockSearchIndexMockRecorder is the mock recorder for MockSearchIndex.
+type MockSearchIndexMockRecorder struct {
+	mock *MockSearchIndex
+}
+
+// NewMockSearchIndex creates a new mock instance.
+func NewMockSearchIndex(ctrl *gomock.Controller) *MockSearchIndex {
+	mock := &MockSearchIndex{ctrl: ctrl}
+	mock.recorder = &MockSearchIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchIndex) EXPECT() *MockSearchIndexMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockSearchIndex) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockSearchIndexMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSearchIndex)(nil).Close))
+}
+
+// Delete mocks base method.
+func (m *MockSearchIndex) Delete(arg0 context.Context, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSearchIndexMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSearchIndex)(nil).Delete), arg0, arg1, arg2)
+}
+
+// DeleteByUsername mocks base method.
+func (m *MockSearchIndex) DeleteByUsername(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUsername", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUsername indicates an expected call of DeleteByUsername.
+func (mr *MockSearchIndexMockRecorder) DeleteByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUsername", reflect.TypeOf((*MockSearchIndex)(nil).DeleteByUsername), arg0, arg1)
+}
+
+// Flush mocks base method.
+func (m *MockSearchIndex) Flush(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockSearchIndexMockRecorder) Flush(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockSearchIndex)(nil).Flush), arg0)
+}
+
+// Index mocks base method.
+func (m *MockSearchIndex) Index(arg0 context.Context, arg1 *model.PostM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockSearchIndexMockRecorder) Index(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockSearchIndex)(nil).Index), arg0, arg1)
+}
+
+// Reset mocks base method.
+func (m *MockSearchIndex) Reset(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockSearchIndexMockRecorder) Reset(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockSearchIndex)(nil).Reset), arg0)
+}
+
+// Search mocks base method.
+func (m *MockSearchIndex) Search(arg0 context.Context, arg1, arg2 string, arg3, arg4 int) (int64, []*SearchHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]*SearchHit)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchIndexMockRecorder) Search(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchIndex)(nil).Search), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockSearchIndex) UpdateUsername(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockSearchIndexMockRecorder) UpdateUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockSearchIndex)(nil).UpdateUsername), arg0, arg1, arg2)
+}
+
+// MockTagStore is a mock of TagStore interface.
+type MockTagStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagStoreMockRecorder
+}
+
+// MockTagStoreMockRecorder is the mock recorder for MockTagStore.
+type MockTagStoreMockRecorder struct {
+	mock *MockTagStore
+}
+
+// NewMockTagStore creates a new mock instance.
+func NewMockTagStore(ctrl *gomock.Controller) *MockTagStore {
+	mock := &MockTagStore{ctrl: ctrl}
+	mock.recorder = &MockTagStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagStore) EXPECT() *MockTagStoreMockRecorder {
+	return m.recorder
+}
+
+// FirstOrCreate mocks base method.
+func (m *MockTagStore) FirstOrCreate(arg0 context.Context, arg1 []string) ([]*model.TagM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FirstOrCreate", arg0, arg1)
+	ret0, _ := ret[0].([]*model.TagM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FirstOrCreate indicates an expected call of FirstOrCreate.
+func (mr *MockTagStoreMockRecorder) FirstOrCreate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FirstOrCreate", reflect.TypeOf((*MockTagStore)(nil).FirstOrCreate), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockTagStore) Get(arg0 context.Context, arg1 string) (*model.TagM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*model.TagM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTagStoreMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTagStore)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockTagStore) List(arg0 context.Context) ([]*TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTagStoreMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTagStore)(nil).List), arg0)
+}
+
+// ListByPostIDs mocks base method.
+func (m *MockTagStore) ListByPostIDs(arg0 context.Context, arg1 []string) (map[string][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByPostIDs", arg0, arg1)
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByPostIDs indicates an expected call of ListByPostIDs.
+func (mr *MockTagStoreMockRecorder) ListByPostIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPostIDs", reflect.TypeOf((*MockTagStore)(nil).ListByPostIDs), arg0, arg1)
+}
+
+// Merge mocks base method.
+func (m *MockTagStore) Merge(arg0 context.Context, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockTagStoreMockRecorder) Merge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockTagStore)(nil).Merge), arg0, arg1, arg2)
+}
+
+// Rename mocks base method.
+func (m *MockTagStore) Rename(arg0 context.Context, arg1 int64, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockTagStoreMockRecorder) Rename(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockTagStore)(nil).Rename), arg0, arg1, arg2)
+}
+
+// SetPostTags mocks base method.
+func (m *MockTagStore) SetPostTags(arg0 context.Context, arg1 string, arg2 []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPostTags", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPostTags indicates an expected call of SetPostTags.
+func (mr *MockTagStoreMockRecorder) SetPostTags(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPostTags", reflect.TypeOf((*MockTagStore)(nil).SetPostTags), arg0, arg1, arg2)
+}
+
+// MockCategoryStore is a mock of CategoryStore interface.
+type MockCategoryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryStoreMockRecorder
+}
+
+// MockCategoryStoreMockRecorder is the mock recorder for MockCategoryStore.
+type MockCategoryStoreMockRecorder struct {
+	mock *MockCategoryStore
+}
+
+// NewMockCategoryStore creates a new mock instance.
+func NewMockCategoryStore(ctrl *gomock.Controller) *MockCategoryStore {
+	mock := &MockCategoryStore{ctrl: ctrl}
+	mock.recorder = &MockCategoryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryStore) EXPECT() *MockCategoryStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCategoryStore) Create(arg0 context.Context, arg1 *model.CategoryM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCategoryStoreMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategoryStore)(nil).Create), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockCategoryStore) Get(arg0 context.Context, arg1 int64) (*model.CategoryM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*model.CategoryM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCategoryStoreMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCategoryStore)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockCategoryStore) List(arg0 context.Context) ([]*model.CategoryM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*model.CategoryM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCategoryStoreMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCategoryStore)(nil).List), arg0)
+}
+
+// MockCommentStore is a mock of CommentStore interface.
+type MockCommentStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentStoreMockRecorder
+}
+
+// MockCommentStoreMockRecorder is the mock recorder for MockCommentStore.
+type MockCommentStoreMockRecorder struct {
+	mock *MockCommentStore
+}
+
+// NewMockCommentStore creates a new mock instance.
+func NewMockCommentStore(ctrl *gomock.Controller) *MockCommentStore {
+	mock := &MockCommentStore{ctrl: ctrl}
+	mock.recorder = &MockCommentStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentStore) EXPECT() *MockCommentStoreMockRecorder {
+	return m.recorder
+}
+
+// CountByPostIDs mocks base method.
+func (m *MockCommentStore) CountByPostIDs(arg0 context.Context, arg1 []string) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByPostIDs", arg0, arg1)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByPostIDs indicates an expected call of CountByPostIDs.
+func (mr *MockCommentStoreMockRecorder) CountByPostIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByPostIDs", reflect.TypeOf((*MockCommentStore)(nil).CountByPostIDs), arg0, arg1)
+}
+
+// Create mocks base method.
+func (m *MockCommentStore) Create(arg0 context.Context, arg1 *model.CommentM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentStoreMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentStore)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockCommentStore) Delete(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentStoreMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentStore)(nil).Delete), arg0, arg1, arg2)
+}
+
+// DeleteByUsername mocks base method.
+func (m *MockCommentStore) DeleteByUsername(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUsername", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUsername indicates an expected call of DeleteByUsername.
+func (mr *MockCommentStoreMockRecorder) DeleteByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUsername", reflect.TypeOf((*MockCommentStore)(nil).DeleteByUsername), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockCommentStore) Get(arg0 context.Context, arg1, arg2 string) (*model.CommentM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.CommentM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCommentStoreMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCommentStore)(nil).Get), arg0, arg1, arg2)
+}
+
+// ListReplies mocks base method.
+func (m *MockCommentStore) ListReplies(arg0 context.Context, arg1 string, arg2 []string, arg3 *CommentFilter) ([]*model.CommentM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReplies", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.CommentM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReplies indicates an expected call of ListReplies.
+func (mr *MockCommentStoreMockRecorder) ListReplies(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReplies", reflect.TypeOf((*MockCommentStore)(nil).ListReplies), arg0, arg1, arg2, arg3)
+}
+
+// ListThreads mocks base method.
+func (m *MockCommentStore) ListThreads(arg0 context.Context, arg1 string, arg2 *CommentFilter, arg3 *ListOptions) (int64, []*model.CommentM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListThreads", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]*model.CommentM)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListThreads indicates an expected call of ListThreads.
+func (mr *MockCommentStoreMockRecorder) ListThreads(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListThreads", reflect.TypeOf((*MockCommentStore)(nil).ListThreads), arg0, arg1, arg2, arg3)
+}
+
+// Update mocks base method.
+func (m *MockCommentStore) Update(arg0 context.Context, arg1 *model.CommentM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCommentStoreMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCommentStore)(nil).Update), arg0, arg1)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockCommentStore) UpdateUsername(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockCommentStoreMockRecorder) UpdateUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockCommentStore)(nil).UpdateUsername), arg0, arg1, arg2)
+}
+
+// MockReactionStore is a mock of ReactionStore interface.
+type MockReactionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockReactionStoreMockRecorder
+}
+
+// MockReactionStoreMockRecorder is the mock recorder for MockReactionStore.
+type MockReactionStoreMockRecorder struct {
+	mock *MockReactionStore
+}
+
+// NewMockReactionStore creates a new mock instance.
+func NewMockReactionStore(ctrl *gomock.Controller) *MockReactionStore {
+	mock := &MockReactionStore{ctrl: ctrl}
+	mock.recorder = &MockReactionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReactionStore) EXPECT() *MockReactionStoreMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockReactionStore) Add(arg0 context.Context, arg1 *model.ReactionM) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockReactionStoreMockRecorder) Add(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockReactionStore)(nil).Add), arg0, arg1)
+}
+
+// Counts mocks base method.
+func (m *MockReactionStore) Counts(arg0 context.Context, arg1 []string) (map[string]map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Counts", arg0, arg1)
+	ret0, _ := ret[0].(map[string]map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Counts indicates an expected call of Counts.
+func (mr *MockReactionStoreMockRecorder) Counts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Counts", reflect.TypeOf((*MockReactionStore)(nil).Counts), arg0, arg1)
+}
+
+// DeleteByUsername mocks base method.
+func (m *MockReactionStore) DeleteByUsername(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUsername", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUsername indicates an expected call of DeleteByUsername.
+func (mr *MockReactionStoreMockRecorder) DeleteByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUsername", reflect.TypeOf((*MockReactionStore)(nil).DeleteByUsername), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockReactionStore) List(arg0 context.Context, arg1, arg2, arg3 string, arg4 *ListOptions) (int64, []*model.ReactionM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]*model.ReactionM)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockReactionStoreMockRecorder) List(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReactionStore)(nil).List), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Remove mocks base method.
+func (m *MockReactionStore) Remove(arg0 context.Context, arg1, arg2, arg3 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockReactionStoreMockRecorder) Remove(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockReactionStore)(nil).Remove), arg0, arg1, arg2, arg3)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockReactionStore) UpdateUsername(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockReactionStoreMockRecorder) UpdateUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockReactionStore)(nil).UpdateUsername), arg0, arg1, arg2)
+}
+
+// MockFollowStore is a mock of FollowStore interface.
+type MockFollowStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockFollowStoreMockRecorder
+}
+
+// MockFollowStoreMockRecorder is the mock recorder for MockFollowStore.
+type MockFollowStoreMockRecorder struct {
+	mock *MockFollowStore
+}
+
+// NewMockFollowStore creates a new mock instance.
+func NewMockFollowStore(ctrl *gomock.Controller) *MockFollowStore {
+	mock := &MockFollowStore{ctrl: ctrl}
+	mock.recorder = &MockFollowStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFollowStore) EXPECT() *MockFollowStoreMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockFollowStore) Count(arg0 context.Context, arg1 string) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockFollowStoreMockRecorder) Count(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockFollowStore)(nil).Count), arg0, arg1)
+}
+
+// CountAll mocks base method.
+func (m *MockFollowStore) CountAll(arg0 context.Context, arg1 []string) (map[string]int64, map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", arg0, arg1)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(map[string]int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockFollowStoreMockRecorder) CountAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockFollowStore)(nil).CountAll), arg0, arg1)
+}
+
+// Create mocks base method.
+func (m *MockFollowStore) Create(arg0 context.Context, arg1 *model.FollowM) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFollowStoreMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFollowStore)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockFollowStore) Delete(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFollowStoreMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFollowStore)(nil).Delete), arg0, arg1, arg2)
+}
+
+// DeleteByUsername mocks base method.
+func (m *MockFollowStore) DeleteByUsername(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUsername", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUsername indicates an expected call of DeleteByUsername.
+func (mr *MockFollowStoreMockRecorder) DeleteByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUsername", reflect.TypeOf((*MockFollowStore)(nil).DeleteByUsername), arg0, arg1)
+}
+
+// ListFollowers mocks base method.
+func (m *MockFollowStore) ListFollowers(arg0 context.Context, arg1 string, arg2 *ListOptions) (int64, []*model.FollowM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowers", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]*model.FollowM)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListFollowers indicates an expected call of ListFollowers.
+func (mr *MockFollowStoreMockRecorder) ListFollowers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowers", reflect.TypeOf((*MockFollowStore)(nil).ListFollowers), arg0, arg1, arg2)
+}
+
+// ListFollowing mocks base method.
+func (m *MockFollowStore) ListFollowing(arg0 context.Context, arg1 string, arg2 *ListOptions) (int64, []*model.FollowM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowing", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]*model.FollowM)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListFollowing indicates an expected call of ListFollowing.
+func (mr *MockFollowStoreMockRecorder) ListFollowing(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowing", reflect.TypeOf((*MockFollowStore)(nil).ListFollowing), arg0, arg1, arg2)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockFollowStore) UpdateUsername(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockFollowStoreMockRecorder) UpdateUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockFollowStore)(nil).UpdateUsername), arg0, arg1, arg2)
+}
+
+// MockTimelineStore is a mock of TimelineStore interface.
+type MockTimelineStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimelineStoreMockRecorder
+}
+
+// MockTimelineStoreMockRecorder is the mock recorder for MockTimelineStore.
+type MockTimelineStoreMockRecorder struct {
+	mock *MockTimelineStore
+}
+
+// NewMockTimelineStore creates a new mock instance.
+func NewMockTimelineStore(ctrl *gomock.Controller) *MockTimelineStore {
+	mock := &MockTimelineStore{ctrl: ctrl}
+	mock.recorder = &MockTimelineStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimelineStore) EXPECT() *MockTimelineStoreMockRecorder {
+	return m.recorder
+}
+
+// Backfill mocks base method.
+func (m *MockTimelineStore) Backfill(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backfill", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backfill indicates an expected call of Backfill.
+func (mr *MockTimelineStoreMockRecorder) Backfill(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backfill", reflect.TypeOf((*MockTimelineStore)(nil).Backfill), arg0, arg1, arg2)
+}
+
+// DeleteByUsername mocks base method.
+func (m *MockTimelineStore) DeleteByUsername(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUsername", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUsername indicates an expected call of DeleteByUsername.
+func (mr *MockTimelineStoreMockRecorder) DeleteByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUsername", reflect.TypeOf((*MockTimelineStore)(nil).DeleteByUsername), arg0, arg1)
+}
+
+// FanOut mocks base method.
+func (m *MockTimelineStore) FanOut(arg0 context.Context, arg1 *model.PostM) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FanOut", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FanOut indicates an expected call of FanOut.
+func (mr *MockTimelineStoreMockRecorder) FanOut(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FanOut", reflect.TypeOf((*MockTimelineStore)(nil).FanOut), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockTimelineStore) List(arg0 context.Context, arg1 string, arg2 *ListOptions) ([]*model.TimelineM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.TimelineM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTimelineStoreMockRecorder) List(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTimelineStore)(nil).List), arg0, arg1, arg2)
+}
+
+// Remove mocks base method.
+func (m *MockTimelineStore) Remove(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockTimelineStoreMockRecorder) Remove(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockTimelineStore)(nil).Remove), arg0, arg1, arg2)
+}
+
+// UpdateAuthor mocks base method.
+func (m *MockTimelineStore) UpdateAuthor(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuthor", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAuthor indicates an expected call of UpdateAuthor.
+func (mr *MockTimelineStoreMockRecorder) UpdateAuthor(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthor", reflect.TypeOf((*MockTimelineStore)(nil).UpdateAuthor), arg0, arg1, arg2)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockTimelineStore) UpdateUsername(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockTimelineStoreMockRecorder) UpdateUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockTimelineStore)(nil).UpdateUsername), arg0, arg1, arg2)
+}
+
+// MockRevisionStore is a mock of RevisionStore interface.
+type MockRevisionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionStoreMockRecorder
+}
+
+// MockRevisionStoreMockRecorder is the mock recorder for MockRevisionStore.
+type MockRevisionStoreMockRecorder struct {
+	mock *MockRevisionStore
+}
+
+// NewMockRevisionStore creates a new mock instance.
+func NewMockRevisionStore(ctrl *gomock.Controller) *MockRevisionStore {
+	mock := &MockRevisionStore{ctrl: ctrl}
+	mock.recorder = &MockRevisionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionStore) EXPECT() *MockRevisionStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRevisionStore) Create(arg0 context.Context, arg1 *model.PostRevisionM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRevisionStoreMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRevisionStore)(nil).Create), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockRevisionStore) Get(arg0 context.Context, arg1 string, arg2 int64) (*model.PostRevisionM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.PostRevisionM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRevisionStoreMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRevisionStore)(nil).Get), arg0, arg1, arg2)
+}
+
+// List mocks base method.
+func (m *MockRevisionStore) List(arg0 context.Context, arg1 string, arg2, arg3 int) (int64, []*model.PostRevisionM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]*model.PostRevisionM)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockRevisionStoreMockRecorder) List(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRevisionStore)(nil).List), arg0, arg1, arg2, arg3)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockRevisionStore) UpdateUsername(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockRevisionStoreMockRecorder) UpdateUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockRevisionStore)(nil).UpdateUsername), arg0, arg1, arg2)
+}
+
+// MockSlugStore is a mock of SlugStore interface.
+type MockSlugStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSlugStoreMockRecorder
+}
+
+// MockSlugStoreMockRecorder is the mock recorder for MockSlugStore.
+type MockSlugStoreMockRecorder struct {
+	mock *MockSlugStore
+}
+
+// NewMockSlugStore creates a new mock instance.
+func NewMockSlugStore(ctrl *gomock.Controller) *MockSlugStore {
+	mock := &MockSlugStore{ctrl: ctrl}
+	mock.recorder = &MockSlugStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSlugStore) EXPECT() *MockSlugStoreMockRecorder {
+	return m.recorder
+}
+
+// AddRedirect mocks base method.
+func (m *MockSlugStore) AddRedirect(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRedirect", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRedirect indicates an expected call of AddRedirect.
+func (mr *MockSlugStoreMockRecorder) AddRedirect(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRedirect", reflect.TypeOf((*MockSlugStore)(nil).AddRedirect), arg0, arg1, arg2, arg3)
+}
+
+// Available mocks base method.
+func (m *MockSlugStore) Available(arg0 context.Context, arg1, arg2, arg3 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Available", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Available indicates an expected call of Available.
+func (mr *MockSlugStoreMockRecorder) Available(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Available", reflect.TypeOf((*MockSlugStore)(nil).Available), arg0, arg1, arg2, arg3)
+}
+
+// DeleteRedirect mocks base method.
+func (m *MockSlugStore) DeleteRedirect(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRedirect", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRedirect indicates an expected call of DeleteRedirect.
+func (mr *MockSlugStoreMockRecorder) DeleteRedirect(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRedirect", reflect.TypeOf((*MockSlugStore)(nil).DeleteRedirect), arg0, arg1, arg2)
+}
+
+// Resolve mocks base method.
+func (m *MockSlugStore) Resolve(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockSlugStoreMockRecorder) Resolve(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockSlugStore)(nil).Resolve), arg0, arg1, arg2)
+}
+
+// MockMediaStore is a mock of MediaStore interface.
+type MockMediaStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaStoreMockRecorder
+}
+
+// MockMediaStoreMockRecorder is the mock recorder for MockMediaStore.
+type MockMediaStoreMockRecorder struct {
+	mock *MockMediaStore
+}
+
+// NewMockMediaStore creates a new mock instance.
+func NewMockMediaStore(ctrl *gomock.Controller) *MockMediaStore {
+	mock := &MockMediaStore{ctrl: ctrl}
+	mock.recorder = &MockMediaStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaStore) EXPECT() *MockMediaStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMediaStore) Create(arg0 context.Context, arg1 *model.MediaM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMediaStoreMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMediaStore)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockMediaStore) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMediaStoreMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMediaStore)(nil).Delete), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockMediaStore) Get(arg0 context.Context, arg1 string) (*model.MediaM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*model.MediaM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMediaStoreMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMediaStore)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockMediaStore) List(arg0 context.Context, arg1 string, arg2, arg3 int) (int64, []*model.MediaM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]*model.MediaM)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockMediaStoreMockRecorder) List(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMediaStore)(nil).List), arg0, arg1, arg2, arg3)
+}
+
+// ListUnreferenced mocks base method.
+func (m *MockMediaStore) ListUnreferenced(arg0 context.Context, arg1 time.Time, arg2 int) ([]*model.MediaM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnreferenced", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.MediaM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnreferenced indicates an expected call of ListUnreferenced.
+func (mr *MockMediaStoreMockRecorder) ListUnreferenced(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnreferenced", reflect.TypeOf((*MockMediaStore)(nil).ListUnreferenced), arg0, arg1, arg2)
+}
+
+// Lock mocks base method.
+func (m *MockMediaStore) Lock(arg0 context.Context, arg1 string) (*model.MediaM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", arg0, arg1)
+	ret0, _ := ret[0].(*model.MediaM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockMediaStoreMockRecorder) Lock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockMediaStore)(nil).Lock), arg0, arg1)
+}
+
+// Referenced mocks base method.
+func (m *MockMediaStore) Referenced(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Referenced", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Referenced indicates an expected call of Referenced.
+func (mr *MockMediaStoreMockRecorder) Referenced(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Referenced", reflect.TypeOf((*MockMediaStore)(nil).Referenced), arg0, arg1)
+}
+
+// SetPostMedia mocks base method.
+func (m *MockMediaStore) SetPostMedia(arg0 context.Context, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPostMedia", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPostMedia indicates an expected call of SetPostMedia.
+func (mr *MockMediaStoreMockRecorder) SetPostMedia(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPostMedia", reflect.TypeOf((*MockMediaStore)(nil).SetPostMedia), arg0, arg1, arg2)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockMediaStore) UpdateUsername(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockMediaStoreMockRecorder) UpdateUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockMediaStore)(nil).UpdateUsername), arg0, arg1, arg2)
+}
+
+// Usage mocks base method.
+func (m *MockMediaStore) Usage(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usage", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage.
+func (mr *MockMediaStoreMockRecorder) Usage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockMediaStore)(nil).Usage), arg0, arg1)
+}
+
+// MockBlobStore is a mock of BlobStore interface.
+type MockBlobStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobStoreMockRecorder
+}
+
+// MockBlobStoreMockRecorder is the mock recorder for MockBlobStore.
+type MockBlobStoreMockRecorder struct {
+	mock *MockBlobStore
+}
+
+// NewMockBlobStore creates a new mock instance.
+func NewMockBlobStore(ctrl *gomock.Controller) *MockBlobStore {
+	mock := &MockBlobStore{ctrl: ctrl}
+	mock.recorder = &MockBlobStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobStore) EXPECT() *MockBlobStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBlobStore) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBlobStoreMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlobStore)(nil).Delete), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockBlobStore) Get(arg0 context.Context, arg1 string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBlobStoreMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBlobStore)(nil).Get), arg0, arg1)
+}
+
+// Put mocks base method.
+func (m *MockBlobStore) Put(arg0 context.Context, arg1 string, arg2 io.Reader, arg3 int64, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockBlobStoreMockRecorder) Put(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockBlobStore)(nil).Put), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MockImportStore is a mock of ImportStore interface.
+type MockImportStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportStoreMockRecorder
+}
+
+// MockImportStoreMockRecorder is the mock recorder for MockImportStore.
+type MockImportStoreMockRecorder struct {
+	mock *MockImportStore
+}
+
+// NewMockImportStore creates a new mock instance.
+func NewMockImportStore(ctrl *gomock.Controller) *MockImportStore {
+	mock := &MockImportStore{ctrl: ctrl}
+	mock.recorder = &MockImportStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportStore) EXPECT() *MockImportStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockImportStore) Create(arg0 context.Context, arg1 *model.PostImportM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImportStoreMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImportStore)(nil).Create), arg0, arg1)
+}
+
+// DeleteByUsername mocks base method.
+func (m *MockImportStore) DeleteByUsername(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUsername", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUsername indicates an expected call of DeleteByUsername.
+func (mr *MockImportStoreMockRecorder) DeleteByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUsername", reflect.TypeOf((*MockImportStore)(nil).DeleteByUsername), arg0, arg1)
+}
+
+// FailExpired mocks base method.
+func (m *MockImportStore) FailExpired(arg0 context.Context, arg1 time.Time, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailExpired", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailExpired indicates an expected call of FailExpired.
+func (mr *MockImportStoreMockRecorder) FailExpired(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailExpired", reflect.TypeOf((*MockImportStore)(nil).FailExpired), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockImportStore) Get(arg0 context.Context, arg1, arg2 string) (*model.PostImportM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.PostImportM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockImportStoreMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockImportStore)(nil).Get), arg0, arg1, arg2)
+}
+
+// Renew mocks base method.
+func (m *MockImportStore) Renew(arg0 context.Context, arg1 int64, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockImportStoreMockRecorder) Renew(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockImportStore)(nil).Renew), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockImportStore) Update(arg0 context.Context, arg1 *model.PostImportM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockImportStoreMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockImportStore)(nil).Update), arg0, arg1)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockImportStore) UpdateUsername(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockImportStoreMockRecorder) UpdateUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockImportStore)(nil).UpdateUsername), arg0, arg1, arg2)
+}
+
+// MockWebhookStore is a mock of WebhookStore interface.
+type MockWebhookStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookStoreMockRecorder
+}
+
+// MockWebhookStoreMockRecorder is the mock recorder for MockWebhookStore.
+type MockWebhookStoreMockRecorder struct {
+	mock *MockWebhookStore
+}
+
+// NewMockWebhookStore creates a new mock instance.
+func NewMockWebhookStore(ctrl *gomock.Controller) *MockWebhookStore {
+	mock := &MockWebhookStore{ctrl: ctrl}
+	mock.recorder = &MockWebhookStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookStore) EXPECT() *MockWebhookStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookStore) Create(arg0 context.Context, arg1 *model.WebhookM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookStoreMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookStore)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookStore) Delete(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookStoreMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookStore)(nil).Delete), arg0, arg1, arg2)
+}
+
+// DeleteByUsername mocks base method.
+func (m *MockWebhookStore) DeleteByUsername(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUsername", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUsername indicates an expected call of DeleteByUsername.
+func (mr *MockWebhookStoreMockRecorder) DeleteByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUsername", reflect.TypeOf((*MockWebhookStore)(nil).DeleteByUsername), arg0, arg1)
+}
+
+// DisableFailing mocks base method.
+func (m *MockWebhookStore) DisableFailing(arg0 context.Context, arg1 string, arg2 int, arg3 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableFailing", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableFailing indicates an expected call of DisableFailing.
+func (mr *MockWebhookStoreMockRecorder) DisableFailing(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableFailing", reflect.TypeOf((*MockWebhookStore)(nil).DisableFailing), arg0, arg1, arg2, arg3)
+}
+
+// Get mocks base method.
+func (m *MockWebhookStore) Get(arg0 context.Context, arg1, arg2 string) (*model.WebhookM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.WebhookM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWebhookStoreMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWebhookStore)(nil).Get), arg0, arg1, arg2)
+}
+
+// GetByWebhookID mocks base method.
+func (m *MockWebhookStore) GetByWebhookID(arg0 context.Context, arg1 string) (*model.WebhookM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByWebhookID", arg0, arg1)
+	ret0, _ := ret[0].(*model.WebhookM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByWebhookID indicates an expected call of GetByWebhookID.
+func (mr *MockWebhookStoreMockRecorder) GetByWebhookID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByWebhookID", reflect.TypeOf((*MockWebhookStore)(nil).GetByWebhookID), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockWebhookStore) List(arg0 context.Context, arg1 string) ([]*model.WebhookM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]*model.WebhookM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhookStoreMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhookStore)(nil).List), arg0, arg1)
+}
+
+// RecordDelivery mocks base method.
+func (m *MockWebhookStore) RecordDelivery(arg0 context.Context, arg1 string, arg2 bool, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordDelivery", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordDelivery indicates an expected call of RecordDelivery.
+func (mr *MockWebhookStoreMockRecorder) RecordDelivery(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordDelivery", reflect.TypeOf((*MockWebhookStore)(nil).RecordDelivery), arg0, arg1, arg2, arg3)
+}
+
+// Update mocks base method.
+func (m *MockWebhookStore) Update(arg0 context.Context, arg1 *model.WebhookM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookStoreMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookStore)(nil).Update), arg0, arg1)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockWebhookStore) UpdateUsername(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockWebhookStoreMockRecorder) UpdateUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockWebhookStore)(nil).UpdateUsername), arg0, arg1, arg2)
+}
+
+// MockDeliveryStore is a mock of DeliveryStore interface.
+type MockDeliveryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryStoreMockRecorder
+}
+
+// MockDeliveryStoreMockRecorder is the mock recorder for MockDeliveryStore.
+type MockDeliveryStoreMockRecorder struct {
+	mock *MockDeliveryStore
+}
+
+// NewMockDeliveryStore creates a new mock instance.
+func NewMockDeliveryStore(ctrl *gomock.Controller) *MockDeliveryStore {
+	mock := &MockDeliveryStore{ctrl: ctrl}
+	mock.recorder = &MockDeliveryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryStore) EXPECT() *MockDeliveryStoreMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockDeliveryStore) Claim(arg0 context.Context, arg1 *model.WebhookDeliveryM, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockDeliveryStoreMockRecorder) Claim(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockDeliveryStore)(nil).Claim), arg0, arg1, arg2)
+}
+
+// Create mocks base method.
+func (m *MockDeliveryStore) Create(arg0 context.Context, arg1 *model.WebhookDeliveryM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDeliveryStoreMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDeliveryStore)(nil).Create), arg0, arg1)
+}
+
+// DeleteBefore mocks base method.
+func (m *MockDeliveryStore) DeleteBefore(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockDeliveryStoreMockRecorder) DeleteBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockDeliveryStore)(nil).DeleteBefore), arg0, arg1)
+}
+
+// DeleteByUsername mocks base method.
+func (m *MockDeliveryStore) DeleteByUsername(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUsername", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUsername indicates an expected call of DeleteByUsername.
+func (mr *MockDeliveryStoreMockRecorder) DeleteByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUsername", reflect.TypeOf((*MockDeliveryStore)(nil).DeleteByUsername), arg0, arg1)
+}
+
+// DeleteByWebhookID mocks base method.
+func (m *MockDeliveryStore) DeleteByWebhookID(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByWebhookID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByWebhookID indicates an expected call of DeleteByWebhookID.
+func (mr *MockDeliveryStoreMockRecorder) DeleteByWebhookID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByWebhookID", reflect.TypeOf((*MockDeliveryStore)(nil).DeleteByWebhookID), arg0, arg1)
+}
+
+// FailPending mocks base method.
+func (m *MockDeliveryStore) FailPending(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailPending", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailPending indicates an expected call of FailPending.
+func (mr *MockDeliveryStoreMockRecorder) FailPending(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailPending", reflect.TypeOf((*MockDeliveryStore)(nil).FailPending), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockDeliveryStore) Get(arg0 context.Context, arg1, arg2 string) (*model.WebhookDeliveryM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.WebhookDeliveryM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDeliveryStoreMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeliveryStore)(nil).Get), arg0, arg1, arg2)
+}
+
+// List mocks base method.
+func (m *MockDeliveryStore) List(arg0 context.Context, arg1 string, arg2, arg3 int) (int64, []*model.WebhookDeliveryM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]*model.WebhookDeliveryM)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockDeliveryStoreMockRecorder) List(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDeliveryStore)(nil).List), arg0, arg1, arg2, arg3)
+}
+
+// ListDue mocks base method.
+func (m *MockDeliveryStore) ListDue(arg0 context.Context, arg1 time.Time, arg2 int) ([]*model.WebhookDeliveryM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDue", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.WebhookDeliveryM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDue indicates an expected call of ListDue.
+func (mr *MockDeliveryStoreMockRecorder) ListDue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDue", reflect.TypeOf((*MockDeliveryStore)(nil).ListDue), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockDeliveryStore) Update(arg0 context.Context, arg1 *model.WebhookDeliveryM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDeliveryStoreMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeliveryStore)(nil).Update), arg0, arg1)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockDeliveryStore) UpdateUsername(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockDeliveryStoreMockRecorder) UpdateUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockDeliveryStore)(nil).UpdateUsername), arg0, arg1, arg2)
+}
+
+// MockEventStore is a mock of EventStore interface.
+type MockEventStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventStoreMockRecorder
+}
+
+// MockEventStoreMockRecorder is the mock recorder for MockEventStore.
+type MockEventStoreMockRecorder struct {
+	mock *MockEventStore
+}
+
+// NewMockEventStore creates a new mock instance.
+func NewMockEventStore(ctrl *gomock.Controller) *MockEventStore {
+	mock := &MockEventStore{ctrl: ctrl}
+	mock.recorder = &MockEventStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventStore) EXPECT() *MockEventStoreMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockEventStore) Claim(arg0 context.Context, arg1 *model.EventM, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockEventStoreMockRecorder) Claim(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockEventStore)(nil).Claim), arg0, arg1, arg2)
+}
+
+// Create mocks base method.
+func (m *MockEventStore) Create(arg0 context.Context, arg1 *model.EventM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEventStoreMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEventStore)(nil).Create), arg0, arg1)
+}
+
+// DeleteBefore mocks base method.
+func (m *MockEventStore) DeleteBefore(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockEventStoreMockRecorder) DeleteBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockEventStore)(nil).DeleteBefore), arg0, arg1)
+}
+
+// ListDue mocks base method.
+func (m *MockEventStore) ListDue(arg0 context.Context, arg1 time.Time, arg2 int) ([]*model.EventM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDue", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.EventM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDue indicates an expected call of ListDue.
+func (mr *MockEventStoreMockRecorder) ListDue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDue", reflect.TypeOf((*MockEventStore)(nil).ListDue), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockEventStore) Update(arg0 context.Context, arg1 *model.EventM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEventStoreMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEventStore)(nil).Update), arg0, arg1)
+}
+
+// MockNotificationStore is a mock of NotificationStore interface.
+type MockNotificationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationStoreMockRecorder
+}
+
+// MockNotificationStoreMockRecorder is the mock recorder for MockNotificationStore.
+type MockNotificationStoreMockRecorder struct {
+	mock *MockNotificationStore
+}
+
+// NewMockNotificationStore creates a new mock instance.
+func NewMockNotificationStore(ctrl *gomock.Controller) *MockNotificationStore {
+	mock := &MockNotificationStore{ctrl: ctrl}
+	mock.recorder = &MockNotificationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationStore) EXPECT() *MockNotificationStoreMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockNotificationStore) CountUnread(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockNotificationStoreMockRecorder) CountUnread(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockNotificationStore)(nil).CountUnread), arg0, arg1)
+}
+
+// Create mocks base method.
+func (m *MockNotificationStore) Create(arg0 context.Context, arg1 *model.NotificationM) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNotificationStoreMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNotificationStore)(nil).Create), arg0, arg1)
+}
+
+// DeleteByUsername mocks base method.
+func (m *MockNotificationStore) DeleteByUsername(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUsername", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUsername indicates an expected call of DeleteByUsername.
+func (mr *MockNotificationStoreMockRecorder) DeleteByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUsername", reflect.TypeOf((*MockNotificationStore)(nil).DeleteByUsername), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockNotificationStore) Get(arg0 context.Context, arg1, arg2 string) (*model.NotificationM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.NotificationM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockNotificationStoreMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockNotificationStore)(nil).Get), arg0, arg1, arg2)
+}
+
+// GetPreference mocks base method.
+func (m *MockNotificationStore) GetPreference(arg0 context.Context, arg1 string) (*model.NotificationPreferenceM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreference", arg0, arg1)
+	ret0, _ := ret[0].(*model.NotificationPreferenceM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreference indicates an expected call of GetPreference.
+func (mr *MockNotificationStoreMockRecorder) GetPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreference", reflect.TypeOf((*MockNotificationStore)(nil).GetPreference), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockNotificationStore) List(arg0 context.Context, arg1 string, arg2 bool, arg3, arg4 int) (int64, []*model.NotificationM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]*model.NotificationM)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockNotificationStoreMockRecorder) List(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNotificationStore)(nil).List), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationStore) MarkRead(arg0 context.Context, arg1 string, arg2 []string, arg3 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationStoreMockRecorder) MarkRead(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationStore)(nil).MarkRead), arg0, arg1, arg2, arg3)
+}
+
+// SavePreference mocks base method.
+func (m *MockNotificationStore) SavePreference(arg0 context.Context, arg1 *model.NotificationPreferenceM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreference", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePreference indicates an expected call of SavePreference.
+func (mr *MockNotificationStoreMockRecorder) SavePreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreference", reflect.TypeOf((*MockNotificationStore)(nil).SavePreference), arg0, arg1)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockNotificationStore) UpdateUsername(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockNotificationStoreMockRecorder) UpdateUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockNotificationStore)(nil).UpdateUsername), arg0, arg1, arg2)
+}
+
+// MockAliasStore is a mock of AliasStore interface.
+type MockAliasStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockAliasStoreMockRecorder
+}
+
+// MockAliasStoreMockRecorder is the mock recorder for MockAliasStore.
+type MockAliasStoreMockRecorder struct {
+	mock *MockAliasStore
+}
+
+// NewMockAliasStore creates a new mock instance.
+func NewMockAliasStore(ctrl *gomock.Controller) *MockAliasStore {
+	mock := &MockAliasStore{ctrl: ctrl}
+	mock.recorder = &MockAliasStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAliasStore) EXPECT() *MockAliasStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAliasStore) Create(arg0 context.Context, arg1 *model.UserAliasM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAliasStoreMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAliasStore)(nil).Create), arg0, arg1)
+}
+
+// DeleteByUsername mocks base method.
+func (m *MockAliasStore) DeleteByUsername(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUsername", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUsername indicates an expected call of DeleteByUsername.
+func (mr *MockAliasStoreMockRecorder) DeleteByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUsername", reflect.TypeOf((*MockAliasStore)(nil).DeleteByUsername), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockAliasStore) List(arg0 context.Context, arg1 string) ([]*model.UserAliasM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]*model.UserAliasM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAliasStoreMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAliasStore)(nil).List), arg0, arg1)
+}
+
+// Resolve mocks base method.
+func (m *MockAliasStore) Resolve(arg0 context.Context, arg1 string, arg2 time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockAliasStoreMockRecorder) Resolve(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockAliasStore)(nil).Resolve), arg0, arg1, arg2)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockAliasStore) UpdateUsername(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockAliasStoreMockRecorder) UpdateUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockAliasStore)(nil).UpdateUsername), arg0, arg1, arg2)
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// NotificationStore 定义了 notification 模块在 store 层所实现的方法.
+type NotificationStore interface {
+	Create(ctx context.Context, notification *model.NotificationM) (bool, error)
+	Get(ctx context.Context, username, notificationID string) (*model.NotificationM, error)
+	List(ctx context.Context, username string, unread bool, offset, limit int) (int64, []*model.NotificationM, error)
+	CountUnread(ctx context.Context, username string) (int64, error)
+	MarkRead(ctx context.Context, username string, notificationIDs []string, at time.Time) (int64, error)
+	GetPreference(ctx context.Context, username string) (*model.NotificationPreferenceM, error)
+	SavePreference(ctx context.Context, preference *model.NotificationPreferenceM) error
+	DeleteByUsername(ctx context.Context, username string) (int64, error)
+	UpdateUsername(ctx context.Context, from, to string) (int64, error)
+}
+
+// NotificationStore 接口的实现.
+type notifications struct {
+	ds *datastore
+}
+
+// 确保 notifications 实现了 NotificationStore 接口.
+var _ NotificationStore = (*notifications)(nil)
+
+func newNotifications(ds *datastore) *notifications {
+	return &notifications{ds}
+}
+
+// Create 插入一条通知记录. 用户已经有同一对象的同一类型的通知时不插入，返回 false.
+func (n *notifications) Create(ctx context.Context, notification *model.NotificationM) (bool, error) {
+	result := n.ds.core(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(notification)
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// Get 根据 username 和 notificationID 查询通知记录.
+func (n *notifications) Get(ctx context.Context, username, notificationID string) (*model.NotificationM, error) {
+	var notification model.NotificationM
+	if err := n.ds.core(ctx).Where("username = ? and notificationID = ?", username, notificationID).First(&notification).Error; err != nil {
+		return nil, err
+	}
+
+	return &notification, nil
+}
+
+// List 从新到旧列出用户的通知，unread 为 true 时只列出未读的通知.
+func (n *notifications) List(ctx context.Context, username string, unread bool, offset, limit int) (count int64, ret []*model.NotificationM, err error) {
+	db := n.ds.core(ctx).Where("username = ?", username)
+	if unread {
+		db = db.Where("readAt is null")
+	}
+
+	err = db.Offset(offset).Limit(defaultLimit(limit)).Order("id desc").Find(&ret).
+		Offset(-1).
+		Limit(-1).
+		Count(&count).
+		Error
+
+	return
+}
+
+// CountUnread 返回用户未读的通知数.
+func (n *notifications) CountUnread(ctx context.Context, username string) (count int64, err error) {
+	err = n.ds.core(ctx).Model(&model.NotificationM{}).Where("username = ? and readAt is null", username).Count(&count).Error
+
+	return
+}
+
+// MarkRead 将用户指定的未读通知标记为在 at 时已读，notificationIDs 为空时标记用户所有的未读通知，返回被标记的记录数.
+func (n *notifications) MarkRead(ctx context.Context, username string, notificationIDs []string, at time.Time) (int64, error) {
+	db := n.ds.core(ctx).Model(&model.NotificationM{}).Where("username = ? and readAt is null", username)
+	if len(notificationIDs) > 0 {
+		db = db.Where("notificationID in ?", notificationIDs)
+	}
+
+	ret := db.Update("readAt", at)
+
+	return ret.RowsAffected, ret.Error
+}
+
+// GetPreference 查询用户的通知偏好，用户没有设置过通知偏好时返回 gorm.ErrRecordNotFound.
+func (n *notifications) GetPreference(ctx context.Context, username string) (*model.NotificationPreferenceM, error) {
+	var preference model.NotificationPreferenceM
+	if err := n.ds.core(ctx).Where("username = ?", username).First(&preference).Error; err != nil {
+		return nil, err
+	}
+
+	return &preference, nil
+}
+
+// SavePreference 创建或更新用户的通知偏好.
+func (n *notifications) SavePreference(ctx context.Context, preference *model.NotificationPreferenceM) error {
+	return n.ds.core(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "username"}},
+		DoUpdates: clause.AssignmentColumns([]string{"mention", "follow", "comment"}),
+	}).Create(preference).Error
+}
+
+// DeleteByUsername 删除发给用户和由用户引起的所有通知以及用户的通知偏好，返回删除的通知数.
+func (n *notifications) DeleteByUsername(ctx context.Context, username string) (int64, error) {
+	ret := n.ds.core(ctx).Where("username = ? or actor = ?", username, username).Delete(&model.NotificationM{})
+	if ret.Error != nil {
+		return 0, ret.Error
+	}
+
+	return ret.RowsAffected, n.ds.core(ctx).Where("username = ?", username).Delete(&model.NotificationPreferenceM{}).Error
+}
+
+// UpdateUsername 将发给 from 和由 from 引起的所有通知以及 from 的通知偏好转移给 to，返回被更新的通知数.
+func (n *notifications) UpdateUsername(ctx context.Context, from, to string) (int64, error) {
+	db := n.ds.core(ctx)
+
+	caused := db.Model(&model.NotificationM{}).Where("actor = ?", from).Update("actor", to)
+	if caused.Error != nil {
+		return 0, caused.Error
+	}
+
+	received := db.Model(&model.NotificationM{}).Where("username = ?", from).Update("username", to)
+	if received.Error != nil {
+		return 0, received.Error
+	}
+
+	err := db.Model(&model.NotificationPreferenceM{}).Where("username = ?", from).Update("username", to).Error
+
+	return caused.RowsAffected + received.RowsAffected, err
+}
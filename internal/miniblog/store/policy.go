@@ -0,0 +1,53 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+
+	adapter "github.com/casbin/gorm-adapter/v3"
+)
+
+// PolicyStore 定义了 casbin 授权策略在 store 层所实现的方法.
+// 通过 PolicyStore 修改的策略可以和其它数据变更放在同一个事务中，授权器会定期从数据库中重新加载策略.
+type PolicyStore interface {
+	Create(ctx context.Context, sub, obj, act string) error
+	DeleteBySubject(ctx context.Context, sub string) error
+	UpdateSubject(ctx context.Context, from, to string) error
+	UpdateObject(ctx context.Context, from, to string) error
+}
+
+// PolicyStore 接口的实现.
+type policies struct {
+	ds *datastore
+}
+
+// 确保 policies 实现了 PolicyStore 接口.
+var _ PolicyStore = (*policies)(nil)
+
+func newPolicies(ds *datastore) *policies {
+	return &policies{ds}
+}
+
+// Create 为授权主体 sub 添加一条访问 obj 的策略，act 是允许的请求方法.
+func (p *policies) Create(ctx context.Context, sub, obj, act string) error {
+	return p.ds.core(ctx).Create(&adapter.CasbinRule{Ptype: "p", V0: sub, V1: obj, V2: act}).Error
+}
+
+// DeleteBySubject 删除授权主体为 sub 的所有策略.
+func (p *policies) DeleteBySubject(ctx context.Context, sub string) error {
+	return p.ds.core(ctx).Where("ptype = ? and v0 = ?", "p", sub).Delete(&adapter.CasbinRule{}).Error
+}
+
+// UpdateSubject 将授权主体为 from 的所有策略的授权主体修改为 to.
+func (p *policies) UpdateSubject(ctx context.Context, from, to string) error {
+	return p.ds.core(ctx).Model(&adapter.CasbinRule{}).Where("ptype = ? and v0 = ?", "p", from).Update("v0", to).Error
+}
+
+// UpdateObject 将授权对象为 from 的所有策略的授权对象修改为 to.
+func (p *policies) UpdateObject(ctx context.Context, from, to string) error {
+	return p.ds.core(ctx).Model(&adapter.CasbinRule{}).Where("ptype = ? and v1 = ?", "p", from).Update("v1", to).Error
+}
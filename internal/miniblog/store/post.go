@@ -8,8 +8,11 @@ package store
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/marmotedu/miniblog/internal/pkg/model"
 )
@@ -19,31 +22,122 @@ type PostStore interface {
 	Create(ctx context.Context, post *model.PostM) error
 	Get(ctx context.Context, username, postID string) (*model.PostM, error)
 	Update(ctx context.Context, post *model.PostM) error
-	List(ctx context.Context, username string, offset, limit int) (int64, []*model.PostM, error)
+	GetByPostID(ctx context.Context, postID string) (*model.PostM, error)
+	List(ctx context.Context, username string, filter *PostFilter, opts *ListOptions) (int64, []*model.PostM, error)
+	ListAll(ctx context.Context, filter *PostFilter, opts *ListOptions) (int64, []*model.PostM, error)
 	Delete(ctx context.Context, username string, postIDs []string) error
+	ListByPostIDs(ctx context.Context, username string, postIDs []string) ([]*model.PostM, error)
+	GetByPostIDs(ctx context.Context, postIDs []string) ([]*model.PostM, error)
+	ForEach(ctx context.Context, batchSize int, fn func(posts []*model.PostM) error) error
+	ListDeleted(ctx context.Context, username string, offset, limit int) (int64, []*model.PostM, error)
+	Restore(ctx context.Context, username, postID string) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	DeleteByUsername(ctx context.Context, username string) (int64, error)
+	UpdateUsername(ctx context.Context, from, to string) (int64, error)
+	ListDue(ctx context.Context, now time.Time) ([]*model.PostM, error)
+	Publish(ctx context.Context, id int64) (bool, error)
+}
+
+// PostFilter 定义了查询 post 列表时的过滤条件，零值表示不过滤.
+type PostFilter struct {
+	// Title 和 Content 分别过滤标题和内容中包含指定子串的 post.
+	Title   string
+	Content string
+
+	// Status 和 Visibility 分别过滤指定发布状态和可见性的 post.
+	Status     string
+	Visibility string
+
+	// Tag 过滤使用指定 tag 的 post，CategoryIDs 过滤属于指定分类之一的 post.
+	Tag         string
+	CategoryIDs []int64
+
+	// CreatedAfter 和 CreatedBefore 过滤创建时间在 [CreatedAfter, CreatedBefore) 范围内的 post.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// UpdatedAfter 和 UpdatedBefore 过滤更新时间在 [UpdatedAfter, UpdatedBefore) 范围内的 post.
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+}
+
+// likeEscaper 转义 LIKE 语句中的通配符，使子串按字面匹配.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// apply 将过滤条件添加到查询中.
+func (f *PostFilter) apply(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+
+	if f.Title != "" {
+		db = db.Where("title LIKE ?", "%"+likeEscaper.Replace(f.Title)+"%")
+	}
+	if f.Content != "" {
+		db = db.Where("content LIKE ?", "%"+likeEscaper.Replace(f.Content)+"%")
+	}
+	if f.Status != "" {
+		db = db.Where("status = ?", f.Status)
+	}
+	if f.Visibility != "" {
+		db = db.Where("visibility = ?", f.Visibility)
+	}
+	if f.Tag != "" {
+		db = db.Where("postID in (?)", db.Session(&gorm.Session{NewDB: true}).Model(&model.PostTagM{}).
+			Select("post_tag.postID").
+			Joins("JOIN tag ON tag.id = post_tag.tagID").
+			Where("tag.name = ?", f.Tag))
+	}
+	if len(f.CategoryIDs) > 0 {
+		db = db.Where("categoryID in (?)", f.CategoryIDs)
+	}
+	if !f.CreatedAfter.IsZero() {
+		db = db.Where("createdAt >= ?", f.CreatedAfter)
+	}
+	if !f.CreatedBefore.IsZero() {
+		db = db.Where("createdAt < ?", f.CreatedBefore)
+	}
+	if !f.UpdatedAfter.IsZero() {
+		db = db.Where("updatedAt >= ?", f.UpdatedAfter)
+	}
+	if !f.UpdatedBefore.IsZero() {
+		db = db.Where("updatedAt < ?", f.UpdatedBefore)
+	}
+
+	return db
 }
 
 // PostStore 接口的实现.
 type posts struct {
-	db *gorm.DB
+	ds *datastore
 }
 
 // 确保 posts 实现了 PostStore 接口.
 var _ PostStore = (*posts)(nil)
 
-func newPosts(db *gorm.DB) *posts {
-	return &posts{db}
+func newPosts(ds *datastore) *posts {
+	return &posts{ds}
 }
 
 // Create 插入一条 post 记录.
 func (u *posts) Create(ctx context.Context, post *model.PostM) error {
-	return u.db.Create(&post).Error
+	return u.ds.core(ctx).Create(&post).Error
 }
 
 // Get 根据 postID 查询指定用户的 post 数据库记录.
 func (u *posts) Get(ctx context.Context, username, postID string) (*model.PostM, error) {
 	var post model.PostM
-	if err := u.db.Where("username = ? and postID = ?", username, postID).First(&post).Error; err != nil {
+	if err := u.ds.core(ctx).Where("username = ? and postID = ?", username, postID).First(&post).Error; err != nil {
+		return nil, err
+	}
+
+	return &post, nil
+}
+
+// GetByPostID 根据 postID 查询 post 数据库记录，不限定 post 所属的用户.
+func (u *posts) GetByPostID(ctx context.Context, postID string) (*model.PostM, error) {
+	var post model.PostM
+	if err := u.ds.core(ctx).Where("postID = ?", postID).First(&post).Error; err != nil {
 		return nil, err
 	}
 
@@ -52,12 +146,72 @@ func (u *posts) Get(ctx context.Context, username, postID string) (*model.PostM,
 
 // Update 更新一条 post 数据库记录.
 func (u *posts) Update(ctx context.Context, post *model.PostM) error {
-	return u.db.Save(post).Error
+	return u.ds.core(ctx).Save(post).Error
+}
+
+// List 根据过滤条件 filter 和分页选项 opts 返回指定用户的 post 列表，filter 为 nil 时不过滤.
+func (u *posts) List(ctx context.Context, username string, filter *PostFilter, opts *ListOptions) (int64, []*model.PostM, error) {
+	return u.list(u.ds.core(ctx).Where("username = ?", username), filter, opts)
+}
+
+// ListAll 根据过滤条件 filter 和分页选项 opts 返回所有用户的 post 列表，filter 为 nil 时不过滤.
+func (u *posts) ListAll(ctx context.Context, filter *PostFilter, opts *ListOptions) (int64, []*model.PostM, error) {
+	return u.list(u.ds.core(ctx), filter, opts)
+}
+
+func (u *posts) list(db *gorm.DB, filter *PostFilter, opts *ListOptions) (count int64, ret []*model.PostM, err error) {
+	db = filter.apply(db.Model(&model.PostM{})).Session(&gorm.Session{})
+	if !opts.SkipCount {
+		if err = db.Count(&count).Error; err != nil {
+			return
+		}
+	}
+
+	err = paginate(db, opts).Find(&ret).Error
+
+	return
+}
+
+// Delete 根据 username, postID 将 post 记录移入回收站（软删除）.
+func (u *posts) Delete(ctx context.Context, username string, postIDs []string) error {
+	err := u.ds.core(ctx).Where("username = ? and postID in (?)", username, postIDs).Delete(&model.PostM{}).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// ListByPostIDs 返回指定用户 postID 在 postIDs 中的 post 列表，不存在的 postID 会被忽略.
+func (u *posts) ListByPostIDs(ctx context.Context, username string, postIDs []string) (ret []*model.PostM, err error) {
+	err = u.ds.core(ctx).Where("username = ? and postID in (?)", username, postIDs).Find(&ret).Error
+
+	return
+}
+
+// GetByPostIDs 根据 postID 查询多条 post 记录，不限制 post 所属的用户.
+func (u *posts) GetByPostIDs(ctx context.Context, postIDs []string) (ret []*model.PostM, err error) {
+	if len(postIDs) == 0 {
+		return nil, nil
+	}
+
+	err = u.ds.core(ctx).Where("postID in (?)", postIDs).Find(&ret).Error
+
+	return
+}
+
+// ForEach 按 id 顺序分批遍历所有用户的 post，每批最多 batchSize 条记录，fn 返回错误时停止遍历.
+func (u *posts) ForEach(ctx context.Context, batchSize int, fn func(posts []*model.PostM) error) error {
+	var batch []*model.PostM
+
+	return u.ds.core(ctx).FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
 }
 
-// List 根据 offset 和 limit 返回指定用户的 post 列表.
-func (u *posts) List(ctx context.Context, username string, offset, limit int) (count int64, ret []*model.PostM, err error) {
-	err = u.db.Where("username = ?", username).Offset(offset).Limit(defaultLimit(limit)).Order("id desc").Find(&ret).
+// ListDeleted 根据 offset 和 limit 返回指定用户回收站中的 post 列表.
+func (u *posts) ListDeleted(ctx context.Context, username string, offset, limit int) (count int64, ret []*model.PostM, err error) {
+	err = u.ds.core(ctx).Unscoped().Where("username = ? and deletedAt IS NOT NULL", username).Offset(offset).Limit(defaultLimit(limit)).Order("deletedAt desc").Find(&ret).
 		Offset(-1).
 		Limit(-1).
 		Count(&count).
@@ -66,12 +220,117 @@ func (u *posts) List(ctx context.Context, username string, offset, limit int) (c
 	return
 }
 
-// Delete 根据 username, postID 删除数据库 post 记录.
-func (u *posts) Delete(ctx context.Context, username string, postIDs []string) error {
-	err := u.db.Where("username = ? and postID in (?)", username, postIDs).Delete(&model.PostM{}).Error
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return err
+// Restore 将指定用户回收站中的 post 记录恢复，记录不在回收站中时返回 gorm.ErrRecordNotFound.
+func (u *posts) Restore(ctx context.Context, username, postID string) error {
+	result := u.ds.core(ctx).Unscoped().Model(&model.PostM{}).
+		Where("username = ? and postID = ? and deletedAt IS NOT NULL", username, postID).
+		Update("deletedAt", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
 
 	return nil
 }
+
+// Purge 永久删除在 before 之前被移入回收站的 post 记录，返回被删除的记录数.
+func (u *posts) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return u.purge(ctx, "deletedAt IS NOT NULL and deletedAt < ?", before)
+}
+
+// DeleteByUsername 永久删除指定用户的所有 post 记录（包括回收站中的记录），返回被删除的记录数.
+func (u *posts) DeleteByUsername(ctx context.Context, username string) (int64, error) {
+	return u.purge(ctx, "username = ?", username)
+}
+
+// purge 永久删除 query 和 args 条件匹配的 post 记录以及这些 post 的 tag 关联、comment、reaction、时间线、历史版本、旧 slug 和 media 引用记录，
+// 返回被删除的 post 记录数. 所有记录在同一个事务中删除，删除失败时不会留下只删除了部分关联记录的 post.
+func (u *posts) purge(ctx context.Context, query string, args ...interface{}) (count int64, err error) {
+	err = u.ds.TX(ctx, func(ctx context.Context) error {
+		db := u.ds.core(ctx).Unscoped().Where(query, args...)
+
+		// 先锁定要删除的 post，避免 post 在删除关联记录的过程中被恢复或修改
+		var ids []int64
+		err := db.Session(&gorm.Session{}).Model(&model.PostM{}).Clauses(clause.Locking{Strength: "UPDATE"}).Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		postIDs := db.Session(&gorm.Session{}).Model(&model.PostM{}).Select("postID")
+		for _, m := range []interface{}{&model.PostTagM{}, &model.TimelineM{}, &model.PostRevisionM{}, &model.PostSlugM{}, &model.PostMediaM{}} {
+			if err := db.Session(&gorm.Session{NewDB: true}).Where("postID in (?)", postIDs).Delete(m).Error; err != nil {
+				return err
+			}
+		}
+
+		// post 和 post 下的 comment 的 reaction 都需要删除
+		commentIDs := db.Session(&gorm.Session{NewDB: true}).Unscoped().Model(&model.CommentM{}).Where("postID in (?)", postIDs).Select("commentID")
+		for _, m := range []interface{}{&model.ReactionM{}, &model.ReactionCountM{}} {
+			err := db.Session(&gorm.Session{NewDB: true}).Where("targetID in (?) or targetID in (?)", postIDs, commentIDs).Delete(m).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := db.Session(&gorm.Session{NewDB: true}).Unscoped().Where("postID in (?)", postIDs).Delete(&model.CommentM{}).Error; err != nil {
+			return err
+		}
+
+		result := db.Delete(&model.PostM{})
+		count = result.RowsAffected
+
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// UpdateUsername 将用户 from 的所有 post 记录（包括回收站中的记录）以及旧 slug 记录转移给用户 to，返回被更新的 post 记录数.
+// 与用户 to 已经使用的 slug 冲突的 post 会在 slug 后添加 id，冲突的旧 slug 会被删除.
+func (u *posts) UpdateUsername(ctx context.Context, from, to string) (int64, error) {
+	db := u.ds.core(ctx)
+
+	// MySQL 不允许在 UPDATE 和 DELETE 语句的子查询中直接读取被修改的表，因此使用派生表
+	taken := gorm.Expr("SELECT slug FROM (SELECT slug FROM post WHERE username = ? UNION SELECT slug FROM post_slug WHERE username = ?) t", to, to)
+	err := db.Unscoped().Model(&model.PostM{}).Where("username = ? and slug in (?)", from, taken).
+		Update("slug", gorm.Expr("CONCAT(slug, '-', id)")).Error
+	if err != nil {
+		return 0, err
+	}
+
+	if err := db.Where("username = ? and slug in (?)", from, taken).Delete(&model.PostSlugM{}).Error; err != nil {
+		return 0, err
+	}
+
+	if err := db.Model(&model.PostSlugM{}).Where("username = ?", from).Update("username", to).Error; err != nil {
+		return 0, err
+	}
+
+	result := db.Unscoped().Model(&model.PostM{}).Where("username = ?", from).Update("username", to)
+
+	return result.RowsAffected, result.Error
+}
+
+// ListDue 返回所有计划发布时间不晚于 now 的定时发布 post.
+// 在事务中调用时返回的记录会被锁定到事务结束，多个实例同时调用时，已经被其它事务锁定的记录会被跳过.
+func (u *posts) ListDue(ctx context.Context, now time.Time) (ret []*model.PostM, err error) {
+	err = u.ds.core(ctx).Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? and publishAt <= ?", model.PostStatusScheduled, now).Order("id").Find(&ret).Error
+
+	return
+}
+
+// Publish 将 id 对应的定时发布 post 修改为已发布. post 已经不是定时发布状态时返回 false.
+func (u *posts) Publish(ctx context.Context, id int64) (bool, error) {
+	result := u.ds.core(ctx).Model(&model.PostM{}).
+		Where("id = ? and status = ?", id, model.PostStatusScheduled).
+		Update("status", model.PostStatusPublished)
+
+	return result.RowsAffected > 0, result.Error
+}
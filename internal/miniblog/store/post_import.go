@@ -0,0 +1,86 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// ImportStore 定义了博客导入任务模块在 store 层所实现的方法.
+type ImportStore interface {
+	Create(ctx context.Context, imp *model.PostImportM) error
+	Get(ctx context.Context, username, importID string) (*model.PostImportM, error)
+	Update(ctx context.Context, imp *model.PostImportM) error
+	Renew(ctx context.Context, id int64, until time.Time) error
+	FailExpired(ctx context.Context, now time.Time, reason string) (int64, error)
+	DeleteByUsername(ctx context.Context, username string) (int64, error)
+	UpdateUsername(ctx context.Context, from, to string) (int64, error)
+}
+
+// ImportStore 接口的实现.
+type imports struct {
+	ds *datastore
+}
+
+// 确保 imports 实现了 ImportStore 接口.
+var _ ImportStore = (*imports)(nil)
+
+func newImports(ds *datastore) *imports {
+	return &imports{ds}
+}
+
+// Create 插入一条导入任务记录.
+func (i *imports) Create(ctx context.Context, imp *model.PostImportM) error {
+	return i.ds.core(ctx).Create(imp).Error
+}
+
+// Get 根据 username 和 importID 查询导入任务记录.
+func (i *imports) Get(ctx context.Context, username, importID string) (*model.PostImportM, error) {
+	var imp model.PostImportM
+	if err := i.ds.core(ctx).Where("username = ? and importID = ?", username, importID).First(&imp).Error; err != nil {
+		return nil, err
+	}
+
+	return &imp, nil
+}
+
+// Update 更新导入任务记录的进度和状态.
+func (i *imports) Update(ctx context.Context, imp *model.PostImportM) error {
+	return i.ds.core(ctx).Save(imp).Error
+}
+
+// Renew 将正在执行的导入任务的租约延长到 until.
+func (i *imports) Renew(ctx context.Context, id int64, until time.Time) error {
+	return i.ds.core(ctx).Model(&model.PostImportM{}).Where("id = ? and status = ?", id, model.ImportStatusRunning).
+		Update("leaseExpiresAt", until).Error
+}
+
+// FailExpired 将租约在 now 之前过期的正在执行的导入任务标记为失败，reason 是失败原因，返回被标记的任务数.
+// 导入任务在服务进程中执行，租约过期说明执行任务的进程已经退出，其它进程中正在执行的任务不受影响.
+func (i *imports) FailExpired(ctx context.Context, now time.Time, reason string) (int64, error) {
+	ret := i.ds.core(ctx).Model(&model.PostImportM{}).
+		Where("status = ? and leaseExpiresAt < ?", model.ImportStatusRunning, now).
+		Updates(map[string]interface{}{"status": model.ImportStatusFailed, "error": reason})
+
+	return ret.RowsAffected, ret.Error
+}
+
+// DeleteByUsername 删除用户的所有导入任务记录，返回删除的记录数.
+func (i *imports) DeleteByUsername(ctx context.Context, username string) (int64, error) {
+	ret := i.ds.core(ctx).Where("username = ?", username).Delete(&model.PostImportM{})
+
+	return ret.RowsAffected, ret.Error
+}
+
+// UpdateUsername 将用户 from 的所有导入任务记录转移给用户 to，返回被更新的记录数.
+func (i *imports) UpdateUsername(ctx context.Context, from, to string) (int64, error) {
+	ret := i.ds.core(ctx).Model(&model.PostImportM{}).Where("username = ?", from).Update("username", to)
+
+	return ret.RowsAffected, ret.Error
+}
@@ -0,0 +1,149 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// ReactionStore 定义了 reaction 模块在 store 层所实现的方法.
+// Add 和 Remove 会同时更新 reaction 表和 reaction_count 表，调用者应在事务中调用.
+type ReactionStore interface {
+	Add(ctx context.Context, reaction *model.ReactionM) (bool, error)
+	Remove(ctx context.Context, username, targetID, typ string) (bool, error)
+	Counts(ctx context.Context, targetIDs []string) (map[string]map[string]int64, error)
+	List(ctx context.Context, username, targetType, typ string, opts *ListOptions) (int64, []*model.ReactionM, error)
+	DeleteByUsername(ctx context.Context, username string) (int64, error)
+	UpdateUsername(ctx context.Context, from, to string) (int64, error)
+}
+
+// ReactionStore 接口的实现.
+type reactions struct {
+	ds *datastore
+}
+
+// 确保 reactions 实现了 ReactionStore 接口.
+var _ ReactionStore = (*reactions)(nil)
+
+func newReactions(ds *datastore) *reactions {
+	return &reactions{ds}
+}
+
+// Add 添加一条 reaction 记录，reaction 已经存在时不做任何修改. 返回值表示是否添加了新的记录.
+func (r *reactions) Add(ctx context.Context, reaction *model.ReactionM) (bool, error) {
+	db := r.ds.core(ctx)
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+
+	err := db.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("count + 1")}),
+	}).Create(&model.ReactionCountM{TargetID: reaction.TargetID, Type: reaction.Type, Count: 1}).Error
+
+	return err == nil, err
+}
+
+// Remove 删除一条 reaction 记录，reaction 不存在时不做任何修改. 返回值表示是否删除了记录.
+func (r *reactions) Remove(ctx context.Context, username, targetID, typ string) (bool, error) {
+	db := r.ds.core(ctx)
+	result := db.Where("username = ? and targetID = ? and type = ?", username, targetID, typ).Delete(&model.ReactionM{})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+
+	err := db.Model(&model.ReactionCountM{}).
+		Where("targetID = ? and type = ? and count > 0", targetID, typ).
+		Update("count", gorm.Expr("count - 1")).
+		Error
+
+	return err == nil, err
+}
+
+// Counts 返回 targetIDs 中每个对象每种 reaction 的数量，数量为 0 的 reaction 不会被返回.
+func (r *reactions) Counts(ctx context.Context, targetIDs []string) (map[string]map[string]int64, error) {
+	ret := make(map[string]map[string]int64, len(targetIDs))
+	if len(targetIDs) == 0 {
+		return ret, nil
+	}
+
+	var rows []*model.ReactionCountM
+	if err := r.ds.core(ctx).Where("targetID in (?) and count > 0", targetIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if ret[row.TargetID] == nil {
+			ret[row.TargetID] = map[string]int64{}
+		}
+
+		ret[row.TargetID][row.Type] = row.Count
+	}
+
+	return ret, nil
+}
+
+// List 按添加时间从新到旧分页列出用户对指定类型对象添加的指定 reaction.
+func (r *reactions) List(ctx context.Context, username, targetType, typ string, opts *ListOptions) (count int64, ret []*model.ReactionM, err error) {
+	db := r.ds.core(ctx).Model(&model.ReactionM{}).
+		Where("username = ? and targetType = ? and type = ?", username, targetType, typ).
+		Session(&gorm.Session{})
+	if !opts.SkipCount {
+		if err = db.Count(&count).Error; err != nil {
+			return
+		}
+	}
+
+	err = paginate(db, opts).Find(&ret).Error
+
+	return
+}
+
+// DeleteByUsername 删除指定用户添加的所有 reaction 记录，并重新统计受影响对象的 reaction 数量，返回被删除的记录数.
+func (r *reactions) DeleteByUsername(ctx context.Context, username string) (int64, error) {
+	db := r.ds.core(ctx)
+
+	var targetIDs []string
+	if err := db.Model(&model.ReactionM{}).Where("username = ?", username).Distinct().Pluck("targetID", &targetIDs).Error; err != nil {
+		return 0, err
+	}
+
+	result := db.Where("username = ?", username).Delete(&model.ReactionM{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if len(targetIDs) > 0 {
+		err := db.Exec("UPDATE reaction_count SET count = "+
+			"(SELECT COUNT(*) FROM reaction WHERE reaction.targetID = reaction_count.targetID AND reaction.type = reaction_count.type) "+
+			"WHERE targetID IN (?)", targetIDs).Error
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return result.RowsAffected, nil
+}
+
+// UpdateUsername 将用户 from 添加的所有 reaction 记录转移给用户 to，返回被转移的记录数.
+// to 已经对同一对象添加过相同的 reaction 时，from 的记录会被删除.
+func (r *reactions) UpdateUsername(ctx context.Context, from, to string) (int64, error) {
+	result := r.ds.core(ctx).Exec("UPDATE IGNORE reaction SET username = ? WHERE username = ?", to, from)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if _, err := r.DeleteByUsername(ctx, from); err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// RevisionStore 定义了 post revision 模块在 store 层所实现的方法.
+type RevisionStore interface {
+	Create(ctx context.Context, revision *model.PostRevisionM) error
+	Get(ctx context.Context, postID string, revision int64) (*model.PostRevisionM, error)
+	List(ctx context.Context, postID string, offset, limit int) (int64, []*model.PostRevisionM, error)
+	UpdateUsername(ctx context.Context, from, to string) (int64, error)
+}
+
+// RevisionStore 接口的实现.
+type revisions struct {
+	ds *datastore
+}
+
+// 确保 revisions 实现了 RevisionStore 接口.
+var _ RevisionStore = (*revisions)(nil)
+
+func newRevisions(ds *datastore) *revisions {
+	return &revisions{ds}
+}
+
+// Create 为 post 添加一个新版本，版本号为该 post 最新的版本号加 1.
+// post 的版本数超过 datastore 允许保留的最大版本数时，最旧的版本会被删除. 应在事务中调用.
+func (r *revisions) Create(ctx context.Context, revision *model.PostRevisionM) error {
+	db := r.ds.core(ctx)
+
+	var latest int64
+	err := db.Model(&model.PostRevisionM{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("postID = ?", revision.PostID).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&latest).Error
+	if err != nil {
+		return err
+	}
+
+	revision.Revision = latest + 1
+	if err := db.Create(revision).Error; err != nil {
+		return err
+	}
+
+	if r.ds.maxRevisions <= 0 || revision.Revision <= int64(r.ds.maxRevisions) {
+		return nil
+	}
+
+	return db.Where("postID = ? and revision <= ?", revision.PostID, revision.Revision-int64(r.ds.maxRevisions)).
+		Delete(&model.PostRevisionM{}).Error
+}
+
+// Get 获取 post 的指定版本.
+func (r *revisions) Get(ctx context.Context, postID string, revision int64) (*model.PostRevisionM, error) {
+	var ret model.PostRevisionM
+	if err := r.ds.core(ctx).Where("postID = ? and revision = ?", postID, revision).First(&ret).Error; err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// List 按版本号从新到旧列出 post 的版本.
+func (r *revisions) List(ctx context.Context, postID string, offset, limit int) (count int64, ret []*model.PostRevisionM, err error) {
+	err = r.ds.core(ctx).Where("postID = ?", postID).Offset(offset).Limit(defaultLimit(limit)).Order("revision desc").Find(&ret).
+		Offset(-1).
+		Limit(-1).
+		Count(&count).
+		Error
+
+	return
+}
+
+// UpdateUsername 将用户 from 保存的所有历史版本记录转移给用户 to，返回被更新的记录数.
+func (r *revisions) UpdateUsername(ctx context.Context, from, to string) (int64, error) {
+	ret := r.ds.core(ctx).Model(&model.PostRevisionM{}).Where("username = ?", from).Update("username", to)
+
+	return ret.RowsAffected, ret.Error
+}
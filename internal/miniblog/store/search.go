@@ -0,0 +1,107 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// SearchIndex 定义了博客全文搜索索引需要实现的方法. 索引中只包含未被删除（不在回收站中）的 post.
+type SearchIndex interface {
+	// Index 将 post 加入索引，post 已经在索引中时更新索引.
+	Index(ctx context.Context, post *model.PostM) error
+	// Delete 从索引中删除指定用户的 post.
+	Delete(ctx context.Context, username string, postIDs []string) error
+	// DeleteByUsername 从索引中删除指定用户的所有 post.
+	DeleteByUsername(ctx context.Context, username string) error
+	// UpdateUsername 将索引中用户 from 的所有 post 转移给用户 to.
+	UpdateUsername(ctx context.Context, from, to string) error
+	// Search 在指定用户的 post 中搜索 query，按相关度从高到低返回命中的 post.
+	Search(ctx context.Context, username, query string, offset, limit int) (int64, []*SearchHit, error)
+	// Reset 清空索引，用于重建索引.
+	Reset(ctx context.Context) error
+	// Flush 将尚未持久化的修改写入存储.
+	Flush(ctx context.Context) error
+	// Close 持久化尚未保存的修改并释放索引占用的资源.
+	Close() error
+}
+
+// SearchHit 表示一条搜索结果.
+type SearchHit struct {
+	PostID string  `gorm:"column:postID"`
+	Score  float64 `gorm:"column:score"`
+}
+
+// fulltextIndex 是基于 MySQL FULLTEXT 索引的 SearchIndex 实现.
+// FULLTEXT 索引由 MySQL 在写入 post 表时自动维护，因此除 Search 外的方法都不需要做任何事情.
+type fulltextIndex struct {
+	ds *datastore
+}
+
+// 确保 fulltextIndex 实现了 SearchIndex 接口.
+var _ SearchIndex = (*fulltextIndex)(nil)
+
+func newFulltextIndex(ds *datastore) *fulltextIndex {
+	return &fulltextIndex{ds}
+}
+
+// Index 由 MySQL 自动维护索引，不需要做任何事情.
+func (s *fulltextIndex) Index(ctx context.Context, post *model.PostM) error {
+	return nil
+}
+
+// Delete 由 MySQL 自动维护索引，不需要做任何事情.
+func (s *fulltextIndex) Delete(ctx context.Context, username string, postIDs []string) error {
+	return nil
+}
+
+// DeleteByUsername 由 MySQL 自动维护索引，不需要做任何事情.
+func (s *fulltextIndex) DeleteByUsername(ctx context.Context, username string) error {
+	return nil
+}
+
+// UpdateUsername 由 MySQL 自动维护索引，不需要做任何事情.
+func (s *fulltextIndex) UpdateUsername(ctx context.Context, from, to string) error {
+	return nil
+}
+
+// Search 使用 post 表 (title, content) 上的 FULLTEXT 索引，以自然语言模式搜索 query.
+func (s *fulltextIndex) Search(ctx context.Context, username, query string, offset, limit int) (count int64, ret []*SearchHit, err error) {
+	const match = "MATCH(title, content) AGAINST(? IN NATURAL LANGUAGE MODE)"
+
+	db := s.ds.core(ctx).Model(&model.PostM{}).Where("username = ?", username).Where(match, query).Session(&gorm.Session{})
+	if err = db.Count(&count).Error; err != nil {
+		return
+	}
+
+	err = db.Select("postID, "+match+" AS score", query).
+		Order("score desc, id desc").
+		Offset(offset).
+		Limit(defaultLimit(limit)).
+		Scan(&ret).
+		Error
+
+	return
+}
+
+// Reset 由 MySQL 自动维护索引，不需要做任何事情.
+func (s *fulltextIndex) Reset(ctx context.Context) error {
+	return nil
+}
+
+// Flush 由 MySQL 自动维护索引，不需要做任何事情.
+func (s *fulltextIndex) Flush(ctx context.Context) error {
+	return nil
+}
+
+// Close 由 MySQL 自动维护索引，不需要做任何事情.
+func (s *fulltextIndex) Close() error {
+	return nil
+}
@@ -0,0 +1,408 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+const (
+	// BM25 算法的参数.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// titleBoost 表示标题中的词相对于内容中的词的权重.
+	titleBoost = 3
+
+	// localFlushInterval 是将修改后的索引持久化到文件中的时间间隔.
+	localFlushInterval = 5 * time.Second
+)
+
+// localDoc 是本地索引中的一篇 post.
+type localDoc struct {
+	Username string
+	// Terms 记录了 post 中每个词（加权后）的词频.
+	Terms map[string]int
+	// Length 是 post 中所有词（加权后）的词频之和.
+	Length int
+}
+
+// localIndex 是内嵌在 miniblog 进程中的 SearchIndex 实现，使用倒排索引和 BM25 算法计算相关度，
+// 适用于数据库不支持 FULLTEXT 索引的场景. path 不为空时，修改索引只会将索引标记为已修改，
+// 由后台协程每隔 localFlushInterval 将索引持久化到 path 指定的文件中，Flush 和 Close 也会持久化索引.
+type localIndex struct {
+	mu       sync.RWMutex
+	path     string
+	docs     map[string]*localDoc      // postID -> doc
+	postings map[string]map[string]int // term -> postID -> term frequency
+	length   int                       // 所有 doc 的 Length 之和
+
+	// version 在每次修改索引时加 1，saved 是最近一次持久化的索引的 version.
+	version uint64
+	saved   uint64
+	// flushMu 保证同一时间只有一个协程在写索引文件.
+	flushMu sync.Mutex
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// 确保 localIndex 实现了 SearchIndex 接口.
+var _ SearchIndex = (*localIndex)(nil)
+
+// NewLocalSearchIndex 创建一个本地全文搜索索引，path 为索引的持久化文件，为空时索引只保存在内存中.
+// path 指定的文件存在时从文件中加载索引.
+func NewLocalSearchIndex(path string) (SearchIndex, error) {
+	idx := &localIndex{path: path}
+	idx.reset()
+
+	if path == "" {
+		return idx, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return idx, nil
+		}
+
+		return nil, err
+	}
+	defer f.Close()
+
+	var docs map[string]*localDoc
+	if err := gob.NewDecoder(f).Decode(&docs); err != nil {
+		return nil, err
+	}
+
+	for postID, doc := range docs {
+		idx.add(postID, doc)
+	}
+
+	idx.stop = make(chan struct{})
+	idx.done = make(chan struct{})
+	go idx.run(localFlushInterval)
+
+	return idx, nil
+}
+
+// run 每隔 interval 持久化一次修改后的索引，直到 Close 被调用.
+func (s *localIndex) run(interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// 失败时索引仍被标记为已修改，下一次会重试
+			if err := s.Flush(context.Background()); err != nil {
+				log.Errorw("Failed to flush the local search index", "path", s.path, "err", err)
+			}
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// Index 将 post 加入索引，post 已经在索引中时更新索引.
+func (s *localIndex) Index(ctx context.Context, post *model.PostM) error {
+	terms := map[string]int{}
+	for _, term := range tokenize(post.Title) {
+		terms[term] += titleBoost
+	}
+	for _, term := range tokenize(post.Content) {
+		terms[term]++
+	}
+
+	doc := &localDoc{Username: post.Username, Terms: terms}
+	for _, tf := range terms {
+		doc.Length += tf
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(post.PostID)
+	s.add(post.PostID, doc)
+
+	s.version++
+
+	return nil
+}
+
+// Delete 从索引中删除指定用户的 post.
+func (s *localIndex) Delete(ctx context.Context, username string, postIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, postID := range postIDs {
+		if doc, ok := s.docs[postID]; ok && doc.Username == username {
+			s.remove(postID)
+		}
+	}
+
+	s.version++
+
+	return nil
+}
+
+// DeleteByUsername 从索引中删除指定用户的所有 post.
+func (s *localIndex) DeleteByUsername(ctx context.Context, username string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for postID, doc := range s.docs {
+		if doc.Username == username {
+			s.remove(postID)
+		}
+	}
+
+	s.version++
+
+	return nil
+}
+
+// UpdateUsername 将索引中用户 from 的所有 post 转移给用户 to.
+func (s *localIndex) UpdateUsername(ctx context.Context, from, to string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, doc := range s.docs {
+		if doc.Username == from {
+			doc.Username = to
+		}
+	}
+
+	s.version++
+
+	return nil
+}
+
+// Search 在指定用户的 post 中搜索 query，命中 query 中任意一个词的 post 都会被返回，按 BM25 相关度从高到低排列.
+func (s *localIndex) Search(ctx context.Context, username, query string, offset, limit int) (int64, []*SearchHit, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.docs) == 0 {
+		return 0, nil, nil
+	}
+
+	n := float64(len(s.docs))
+	avgLength := float64(s.length) / n
+
+	scores := map[string]float64{}
+	seen := map[string]bool{}
+	for _, term := range tokenize(query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+
+		postings := s.postings[term]
+		idf := math.Log(1 + (n-float64(len(postings))+0.5)/(float64(len(postings))+0.5))
+		for postID, tf := range postings {
+			doc := s.docs[postID]
+			if doc.Username != username {
+				continue
+			}
+
+			f := float64(tf)
+			scores[postID] += idf * f * (bm25K1 + 1) / (f + bm25K1*(1-bm25B+bm25B*float64(doc.Length)/avgLength))
+		}
+	}
+
+	hits := make([]*SearchHit, 0, len(scores))
+	for postID, score := range scores {
+		hits = append(hits, &SearchHit{PostID: postID, Score: score})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+
+		return hits[i].PostID > hits[j].PostID
+	})
+
+	count := int64(len(hits))
+	if offset >= len(hits) {
+		return count, nil, nil
+	}
+
+	hits = hits[offset:]
+	if limit = defaultLimit(limit); limit > 0 && limit < len(hits) {
+		hits = hits[:limit]
+	}
+
+	return count, hits, nil
+}
+
+// Reset 清空索引.
+func (s *localIndex) Reset(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reset()
+
+	s.version++
+
+	return nil
+}
+
+func (s *localIndex) reset() {
+	s.docs = map[string]*localDoc{}
+	s.postings = map[string]map[string]int{}
+	s.length = 0
+}
+
+// add 将 doc 加入倒排索引，调用者需要持有写锁.
+func (s *localIndex) add(postID string, doc *localDoc) {
+	s.docs[postID] = doc
+	s.length += doc.Length
+
+	for term, tf := range doc.Terms {
+		if s.postings[term] == nil {
+			s.postings[term] = map[string]int{}
+		}
+
+		s.postings[term][postID] = tf
+	}
+}
+
+// remove 将 doc 从倒排索引中删除，调用者需要持有写锁.
+func (s *localIndex) remove(postID string) {
+	doc, ok := s.docs[postID]
+	if !ok {
+		return
+	}
+
+	for term := range doc.Terms {
+		delete(s.postings[term], postID)
+		if len(s.postings[term]) == 0 {
+			delete(s.postings, term)
+		}
+	}
+
+	s.length -= doc.Length
+	delete(s.docs, postID)
+}
+
+// Flush 将修改后的索引持久化到文件中，索引没有被修改时不做任何事情.
+func (s *localIndex) Flush(ctx context.Context) error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	// 只在编码索引时持有读锁，写文件时不阻塞对索引的修改
+	s.mu.RLock()
+	version := s.version
+	if version == s.saved {
+		s.mu.RUnlock()
+		return nil
+	}
+
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(s.docs)
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	if err := s.save(buf.Bytes()); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.saved = version
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Close 停止后台的持久化协程，并持久化尚未保存的修改.
+func (s *localIndex) Close() error {
+	if s.stop != nil {
+		s.closeOnce.Do(func() { close(s.stop) })
+		<-s.done
+	}
+
+	return s.Flush(context.Background())
+}
+
+// save 将编码后的索引写入文件，先写临时文件再重命名，避免写入过程中出错导致索引文件损坏.
+func (s *localIndex) save(data []byte) error {
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.path)
+}
+
+// tokenize 将文本切分为小写的词. 连续的字母和数字组成一个词，中日韩文字没有分隔符，每个字作为一个词.
+func tokenize(text string) []string {
+	var (
+		terms []string
+		word  []rune
+	)
+
+	flush := func() {
+		if len(word) > 0 {
+			terms = append(terms, string(word))
+			word = word[:0]
+		}
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flush()
+			terms = append(terms, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word = append(word, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return terms
+}
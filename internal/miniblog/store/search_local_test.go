@@ -0,0 +1,114 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"hello", "go1", "20", "世", "界", "miniblog"}, tokenize("Hello, Go1.20 世界miniblog!"))
+	assert.Nil(t, tokenize(" ,.! "))
+}
+
+func TestLocalIndex(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "search.idx")
+
+	idx, err := NewLocalSearchIndex(path)
+	assert.Nil(t, err)
+
+	posts := []*model.PostM{
+		{Username: "belm", PostID: "post-1", Title: "Go modules", Content: "How to use go modules in a project."},
+		{Username: "belm", PostID: "post-2", Title: "Gin", Content: "A web framework written in go."},
+		{Username: "belm", PostID: "post-3", Title: "MySQL", Content: "Full-text search in MySQL."},
+		{Username: "colin", PostID: "post-4", Title: "Go", Content: "Posts of another user."},
+	}
+	for _, post := range posts {
+		assert.Nil(t, idx.Index(ctx, post))
+	}
+
+	// The title is weighted higher than the content.
+	count, hits, err := idx.Search(ctx, "belm", "go", 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, "post-1", hits[0].PostID)
+	assert.Equal(t, "post-2", hits[1].PostID)
+
+	count, hits, err = idx.Search(ctx, "belm", "go", 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, 1, len(hits))
+
+	// Updating a post replaces the old index of the post.
+	assert.Nil(t, idx.Index(ctx, &model.PostM{Username: "belm", PostID: "post-2", Title: "Gin", Content: "A web framework."}))
+	count, _, _ = idx.Search(ctx, "belm", "go", 0, 10)
+	assert.Equal(t, int64(1), count)
+
+	assert.Nil(t, idx.Delete(ctx, "belm", []string{"post-1", "post-4"}))
+	count, _, _ = idx.Search(ctx, "belm", "go", 0, 10)
+	assert.Equal(t, int64(0), count)
+
+	// Deleting a post of another user has no effect.
+	count, _, _ = idx.Search(ctx, "colin", "go", 0, 10)
+	assert.Equal(t, int64(1), count)
+
+	assert.Nil(t, idx.UpdateUsername(ctx, "colin", "belm"))
+	count, _, _ = idx.Search(ctx, "belm", "go", 0, 10)
+	assert.Equal(t, int64(1), count)
+
+	// The changes are not written to the file until the index is closed.
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, idx.Close())
+
+	// The index is loaded from the file.
+	idx, err = NewLocalSearchIndex(path)
+	assert.Nil(t, err)
+	defer idx.Close()
+	count, hits, _ = idx.Search(ctx, "belm", "mysql", 0, 10)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, "post-3", hits[0].PostID)
+
+	assert.Nil(t, idx.DeleteByUsername(ctx, "belm"))
+	count, _, _ = idx.Search(ctx, "belm", "mysql go", 0, 10)
+	assert.Equal(t, int64(0), count)
+
+	assert.Nil(t, idx.Index(ctx, posts[0]))
+	assert.Nil(t, idx.Reset(ctx))
+	count, _, _ = idx.Search(ctx, "belm", "go", 0, 10)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestLocalIndex_Flush(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "search.idx")
+
+	idx, err := NewLocalSearchIndex(path)
+	assert.Nil(t, err)
+	defer idx.Close()
+
+	// Nothing is written if the index is not changed.
+	assert.Nil(t, idx.Flush(ctx))
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+
+	assert.Nil(t, idx.Index(ctx, &model.PostM{Username: "belm", PostID: "post-1", Title: "Go"}))
+	assert.Nil(t, idx.Flush(ctx))
+
+	loaded, err := NewLocalSearchIndex(path)
+	assert.Nil(t, err)
+	defer loaded.Close()
+	count, _, _ := loaded.Search(ctx, "belm", "go", 0, 10)
+	assert.Equal(t, int64(1), count)
+}
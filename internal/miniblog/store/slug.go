@@ -0,0 +1,86 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// SlugStore 定义了 post slug 模块在 store 层所实现的方法.
+type SlugStore interface {
+	Resolve(ctx context.Context, username, slug string) (string, error)
+	Available(ctx context.Context, username, slug, postID string) (bool, error)
+	AddRedirect(ctx context.Context, username, slug, postID string) error
+	DeleteRedirect(ctx context.Context, username, slug string) error
+}
+
+// SlugStore 接口的实现.
+type slugs struct {
+	ds *datastore
+}
+
+// 确保 slugs 实现了 SlugStore 接口.
+var _ SlugStore = (*slugs)(nil)
+
+func newSlugs(ds *datastore) *slugs {
+	return &slugs{ds}
+}
+
+// Resolve 返回 username 的 slug 对应的 postID. 优先匹配 post 当前的 slug，其次匹配 post 使用过的旧 slug，
+// 都不匹配时返回 gorm.ErrRecordNotFound.
+func (s *slugs) Resolve(ctx context.Context, username, slug string) (string, error) {
+	var post model.PostM
+	err := s.ds.core(ctx).Select("postID").Where("username = ? and slug = ?", username, slug).Take(&post).Error
+	if err == nil {
+		return post.PostID, nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+
+	var old model.PostSlugM
+	if err := s.ds.core(ctx).Where("username = ? and slug = ?", username, slug).Take(&old).Error; err != nil {
+		return "", err
+	}
+
+	return old.PostID, nil
+}
+
+// Available 判断 slug 是否可以被 username 的 post postID 使用，即没有被该用户的其它 post（包括回收站中的 post）
+// 用作当前的 slug 或旧的 slug. postID 为空表示新创建的 post.
+func (s *slugs) Available(ctx context.Context, username, slug, postID string) (bool, error) {
+	for _, m := range []interface{}{&model.PostM{}, &model.PostSlugM{}} {
+		var count int64
+		err := s.ds.core(ctx).Unscoped().Model(m).Where("username = ? and slug = ? and postID <> ?", username, slug, postID).Count(&count).Error
+		if err != nil {
+			return false, err
+		}
+
+		if count > 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// AddRedirect 记录 post postID 使用过的旧 slug.
+func (s *slugs) AddRedirect(ctx context.Context, username, slug, postID string) error {
+	return s.ds.core(ctx).Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"postID"})}).
+		Create(&model.PostSlugM{Username: username, Slug: slug, PostID: postID}).Error
+}
+
+// DeleteRedirect 删除 username 的旧 slug 记录，用于 post 重新使用之前的 slug 时.
+func (s *slugs) DeleteRedirect(ctx context.Context, username, slug string) error {
+	return s.ds.core(ctx).Where("username = ? and slug = ?", username, slug).Delete(&model.PostSlugM{}).Error
+}
@@ -5,9 +5,10 @@
 
 package store
 
-//go:generate mockgen -destination mock_store.go -package store github.com/marmotedu/miniblog/internal/miniblog/store IStore,UserStore,PostStore
+//go:generate mockgen -destination mock_store.go -package store github.com/marmotedu/miniblog/internal/miniblog/store IStore,UserStore,PostStore,PolicyStore,AuditLogStore,SearchIndex,TagStore,CategoryStore,CommentStore,ReactionStore,FollowStore,TimelineStore,RevisionStore,SlugStore,MediaStore,BlobStore,ImportStore,WebhookStore,DeliveryStore,EventStore,NotificationStore,AliasStore
 
 import (
+	"context"
 	"sync"
 
 	"gorm.io/gorm"
@@ -19,16 +20,50 @@ var (
 	S *datastore
 )
 
+// transactionKey 用于在 context.Context 中保存当前事务的 *gorm.DB.
+type transactionKey struct{}
+
+// commitHooksKey 用于在 context.Context 中保存当前事务提交后需要执行的函数.
+type commitHooksKey struct{}
+
 // IStore 定义了 Store 层需要实现的方法.
 type IStore interface {
 	DB() *gorm.DB
+	TX(ctx context.Context, fn func(ctx context.Context) error) error
 	Users() UserStore
 	Posts() PostStore
+	Policies() PolicyStore
+	AuditLogs() AuditLogStore
+	Search() SearchIndex
+	Tags() TagStore
+	Categories() CategoryStore
+	Comments() CommentStore
+	Reactions() ReactionStore
+	Follows() FollowStore
+	Timelines() TimelineStore
+	Revisions() RevisionStore
+	Slugs() SlugStore
+	Media() MediaStore
+	Blobs() BlobStore
+	Imports() ImportStore
+	Webhooks() WebhookStore
+	Deliveries() DeliveryStore
+	Events() EventStore
+	Notifications() NotificationStore
+	Aliases() AliasStore
 }
 
+// defaultMaxRevisions 是每篇博客默认保留的最大版本数.
+const defaultMaxRevisions = 50
+
 // datastore 是 IStore 的一个具体实现.
 type datastore struct {
-	db *gorm.DB
+	db     *gorm.DB
+	search SearchIndex
+	// blobs 保存上传的媒体文件内容，由 SetBlobStore 设置.
+	blobs BlobStore
+	// maxRevisions 是每篇博客保留的最大版本数，不大于 0 时保留所有版本.
+	maxRevisions int
 }
 
 // 确保 datastore 实现了 IStore 接口.
@@ -38,7 +73,8 @@ var _ IStore = (*datastore)(nil)
 func NewStore(db *gorm.DB) *datastore {
 	// 确保 S 只被初始化一次
 	once.Do(func() {
-		S = &datastore{db}
+		S = &datastore{db: db, maxRevisions: defaultMaxRevisions}
+		S.search = newFulltextIndex(S)
 	})
 
 	return S
@@ -49,12 +85,167 @@ func (ds *datastore) DB() *gorm.DB {
 	return ds.db
 }
 
+// TX 在一个数据库事务中执行 fn. fn 中使用传入的 ctx 调用的 store 方法都会在该事务中执行，
+// fn 返回错误时事务回滚，否则事务提交.
+// 嵌套的事务使用 savepoint，通过 AfterCommit 注册的函数在最外层的事务提交后执行.
+func (ds *datastore) TX(ctx context.Context, fn func(ctx context.Context) error) error {
+	hooks, nested := ctx.Value(commitHooksKey{}).(*[]func())
+	if !nested {
+		hooks = new([]func())
+		ctx = context.WithValue(ctx, commitHooksKey{}, hooks)
+	}
+
+	err := ds.core(ctx).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, transactionKey{}, tx))
+	})
+	if err != nil || nested {
+		return err
+	}
+
+	for _, hook := range *hooks {
+		hook()
+	}
+
+	return nil
+}
+
+// AfterCommit 在 ctx 中的事务提交后执行 fn，事务回滚时 fn 不会被执行. ctx 中没有事务时立即执行 fn.
+func AfterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(commitHooksKey{}).(*[]func())
+	if !ok {
+		fn()
+
+		return
+	}
+
+	*hooks = append(*hooks, fn)
+}
+
+// core 返回 ctx 中携带的事务，如果 ctx 中没有事务，则返回 datastore 中的 *gorm.DB.
+func (ds *datastore) core(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return tx
+	}
+
+	return ds.db
+}
+
 // Users 返回一个实现了 UserStore 接口的实例.
 func (ds *datastore) Users() UserStore {
-	return newUsers(ds.db)
+	return newUsers(ds)
 }
 
 // Posts 返回一个实现了 PostStore 接口的实例.
 func (ds *datastore) Posts() PostStore {
-	return newPosts(ds.db)
+	return newPosts(ds)
+}
+
+// Policies 返回一个实现了 PolicyStore 接口的实例.
+func (ds *datastore) Policies() PolicyStore {
+	return newPolicies(ds)
+}
+
+// AuditLogs 返回一个实现了 AuditLogStore 接口的实例.
+func (ds *datastore) AuditLogs() AuditLogStore {
+	return newAuditLogs(ds)
+}
+
+// Tags 返回一个实现了 TagStore 接口的实例.
+func (ds *datastore) Tags() TagStore {
+	return newTags(ds)
+}
+
+// Categories 返回一个实现了 CategoryStore 接口的实例.
+func (ds *datastore) Categories() CategoryStore {
+	return newCategories(ds)
+}
+
+// Comments 返回一个实现了 CommentStore 接口的实例.
+func (ds *datastore) Comments() CommentStore {
+	return newComments(ds)
+}
+
+// Reactions 返回一个实现了 ReactionStore 接口的实例.
+func (ds *datastore) Reactions() ReactionStore {
+	return newReactions(ds)
+}
+
+// Follows 返回一个实现了 FollowStore 接口的实例.
+func (ds *datastore) Follows() FollowStore {
+	return newFollows(ds)
+}
+
+// Timelines 返回一个实现了 TimelineStore 接口的实例.
+func (ds *datastore) Timelines() TimelineStore {
+	return newTimelines(ds)
+}
+
+// Revisions 返回一个实现了 RevisionStore 接口的实例.
+func (ds *datastore) Revisions() RevisionStore {
+	return newRevisions(ds)
+}
+
+// Slugs 返回一个实现了 SlugStore 接口的实例.
+func (ds *datastore) Slugs() SlugStore {
+	return newSlugs(ds)
+}
+
+// Media 返回一个实现了 MediaStore 接口的实例.
+func (ds *datastore) Media() MediaStore {
+	return newMedias(ds)
+}
+
+// Imports 返回一个实现了 ImportStore 接口的实例.
+func (ds *datastore) Imports() ImportStore {
+	return newImports(ds)
+}
+
+// Webhooks 返回一个实现了 WebhookStore 接口的实例.
+func (ds *datastore) Webhooks() WebhookStore {
+	return newWebhooks(ds)
+}
+
+// Deliveries 返回一个实现了 DeliveryStore 接口的实例.
+func (ds *datastore) Deliveries() DeliveryStore {
+	return newDeliveries(ds)
+}
+
+// Events 返回一个实现了 EventStore 接口的实例.
+func (ds *datastore) Events() EventStore {
+	return newEvents(ds)
+}
+
+// Notifications 返回一个实现了 NotificationStore 接口的实例.
+func (ds *datastore) Notifications() NotificationStore {
+	return newNotifications(ds)
+}
+
+// Aliases 返回一个实现了 AliasStore 接口的实例.
+func (ds *datastore) Aliases() AliasStore {
+	return newAliases(ds)
+}
+
+// Blobs 返回保存媒体文件内容的对象存储.
+func (ds *datastore) Blobs() BlobStore {
+	return ds.blobs
+}
+
+// SetBlobStore 设置保存媒体文件内容的对象存储的实现.
+func (ds *datastore) SetBlobStore(blobs BlobStore) {
+	ds.blobs = blobs
+}
+
+// Search 返回博客全文搜索索引，默认使用 MySQL FULLTEXT 索引.
+func (ds *datastore) Search() SearchIndex {
+	return ds.search
+}
+
+// SetSearchIndex 设置博客全文搜索索引的实现.
+func (ds *datastore) SetSearchIndex(idx SearchIndex) {
+	ds.search = idx
+}
+
+// SetMaxRevisions 设置每篇博客保留的最大版本数，n 不大于 0 时保留所有版本.
+func (ds *datastore) SetMaxRevisions(n int) {
+	ds.maxRevisions = n
 }
@@ -0,0 +1,161 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// TagStore 定义了 tag 模块在 store 层所实现的方法.
+type TagStore interface {
+	Get(ctx context.Context, name string) (*model.TagM, error)
+	FirstOrCreate(ctx context.Context, names []string) ([]*model.TagM, error)
+	List(ctx context.Context) ([]*TagCount, error)
+	Rename(ctx context.Context, tagID int64, name string) error
+	Merge(ctx context.Context, from, into int64) error
+	SetPostTags(ctx context.Context, postID string, tagIDs []int64) error
+	ListByPostIDs(ctx context.Context, postIDs []string) (map[string][]string, error)
+}
+
+// TagCount 记录了一个 tag 和使用该 tag 的已发布公开 post 数.
+type TagCount struct {
+	Name      string `gorm:"column:name"`
+	PostCount int64  `gorm:"column:postCount"`
+}
+
+// TagStore 接口的实现.
+type tags struct {
+	ds *datastore
+}
+
+// 确保 tags 实现了 TagStore 接口.
+var _ TagStore = (*tags)(nil)
+
+func newTags(ds *datastore) *tags {
+	return &tags{ds}
+}
+
+// Get 根据名称查询 tag 数据库记录.
+func (t *tags) Get(ctx context.Context, name string) (*model.TagM, error) {
+	var tag model.TagM
+	if err := t.ds.core(ctx).Where("name = ?", name).First(&tag).Error; err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// FirstOrCreate 返回名称在 names 中的 tag 记录，不存在的 tag 会被创建.
+func (t *tags) FirstOrCreate(ctx context.Context, names []string) ([]*model.TagM, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	create := make([]*model.TagM, 0, len(names))
+	for _, name := range names {
+		create = append(create, &model.TagM{Name: name})
+	}
+
+	db := t.ds.core(ctx)
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&create).Error; err != nil {
+		return nil, err
+	}
+
+	var ret []*model.TagM
+	if err := db.Where("name in (?)", names).Find(&ret).Error; err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// List 返回所有 tag 以及使用每个 tag 的已发布公开 post 数，按 post 数从多到少排列.
+func (t *tags) List(ctx context.Context) (ret []*TagCount, err error) {
+	err = t.ds.core(ctx).Model(&model.TagM{}).
+		Select("tag.name, COUNT(post.id) AS postCount").
+		Joins("LEFT JOIN post_tag ON post_tag.tagID = tag.id").
+		Joins("LEFT JOIN post ON post.postID = post_tag.postID AND post.status = ? AND post.visibility = ? AND post.deletedAt IS NULL",
+			model.PostStatusPublished, model.PostVisibilityPublic).
+		Group("tag.id, tag.name").
+		Order("postCount desc, tag.name").
+		Scan(&ret).
+		Error
+
+	return
+}
+
+// Rename 修改 tag 的名称.
+func (t *tags) Rename(ctx context.Context, tagID int64, name string) error {
+	return t.ds.core(ctx).Model(&model.TagM{}).Where("id = ?", tagID).Update("name", name).Error
+}
+
+// Merge 将使用 tag from 的 post 改为使用 tag into，然后删除 tag from.
+func (t *tags) Merge(ctx context.Context, from, into int64) error {
+	db := t.ds.core(ctx)
+
+	// 同时使用两个 tag 的 post 只保留一条 into 的关联
+	if err := db.Exec("INSERT IGNORE INTO post_tag (postID, tagID) SELECT postID, ? FROM post_tag WHERE tagID = ?", into, from).Error; err != nil {
+		return err
+	}
+
+	if err := db.Where("tagID = ?", from).Delete(&model.PostTagM{}).Error; err != nil {
+		return err
+	}
+
+	return db.Where("id = ?", from).Delete(&model.TagM{}).Error
+}
+
+// SetPostTags 将 post 使用的 tag 替换为 tagIDs.
+func (t *tags) SetPostTags(ctx context.Context, postID string, tagIDs []int64) error {
+	db := t.ds.core(ctx)
+	if err := db.Where("postID = ?", postID).Delete(&model.PostTagM{}).Error; err != nil {
+		return err
+	}
+
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	rows := make([]*model.PostTagM, 0, len(tagIDs))
+	for _, tagID := range tagIDs {
+		rows = append(rows, &model.PostTagM{PostID: postID, TagID: tagID})
+	}
+
+	return db.Create(&rows).Error
+}
+
+// ListByPostIDs 返回 postIDs 中每个 post 使用的 tag 名称，按名称排列.
+func (t *tags) ListByPostIDs(ctx context.Context, postIDs []string) (map[string][]string, error) {
+	ret := make(map[string][]string, len(postIDs))
+	if len(postIDs) == 0 {
+		return ret, nil
+	}
+
+	var rows []struct {
+		PostID string `gorm:"column:postID"`
+		Name   string `gorm:"column:name"`
+	}
+	err := t.ds.core(ctx).Model(&model.PostTagM{}).
+		Select("post_tag.postID, tag.name").
+		Joins("JOIN tag ON tag.id = post_tag.tagID").
+		Where("post_tag.postID in (?)", postIDs).
+		Order("tag.name").
+		Scan(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		ret[row.PostID] = append(ret[row.PostID], row.Name)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// timelineBackfillLimit 是关注用户时写入时间线的被关注用户最近博客的最大数量.
+const timelineBackfillLimit = 1000
+
+// TimelineStore 定义了首页时间线在 store 层所实现的方法.
+type TimelineStore interface {
+	FanOut(ctx context.Context, post *model.PostM) (int64, error)
+	Backfill(ctx context.Context, username, followee string) (int64, error)
+	Remove(ctx context.Context, username, author string) (int64, error)
+	List(ctx context.Context, username string, opts *ListOptions) ([]*model.TimelineM, error)
+	DeleteByUsername(ctx context.Context, username string) (int64, error)
+	UpdateUsername(ctx context.Context, from, to string) (int64, error)
+	UpdateAuthor(ctx context.Context, from, to string) (int64, error)
+}
+
+// TimelineStore 接口的实现.
+type timelines struct {
+	ds *datastore
+}
+
+// 确保 timelines 实现了 TimelineStore 接口.
+var _ TimelineStore = (*timelines)(nil)
+
+func newTimelines(ds *datastore) *timelines {
+	return &timelines{ds}
+}
+
+// FanOut 将博客写入作者所有关注者的时间线，博客已经在时间线中时更新发布时间. post.PublishAt 不能为空.
+// 无论作者有多少关注者，都只需要执行一条 INSERT ... SELECT 语句.
+func (t *timelines) FanOut(ctx context.Context, post *model.PostM) (int64, error) {
+	result := t.ds.core(ctx).Exec("INSERT INTO timeline (username, postID, author, publishAt) "+
+		"SELECT username, ?, ?, ? FROM follow WHERE followee = ? "+
+		"ON DUPLICATE KEY UPDATE publishAt = VALUES(publishAt)",
+		post.PostID, post.Username, post.PublishAt, post.Username)
+
+	return result.RowsAffected, result.Error
+}
+
+// Backfill 将 followee 最近的博客写入 username 的时间线，在 username 关注 followee 时调用.
+func (t *timelines) Backfill(ctx context.Context, username, followee string) (int64, error) {
+	result := t.ds.core(ctx).Exec("INSERT IGNORE INTO timeline (username, postID, author, publishAt) "+
+		"SELECT ?, postID, username, publishAt FROM post "+
+		"WHERE username = ? AND publishAt IS NOT NULL AND deletedAt IS NULL ORDER BY publishAt DESC LIMIT ?",
+		username, followee, timelineBackfillLimit)
+
+	return result.RowsAffected, result.Error
+}
+
+// Remove 从 username 的时间线中删除 author 的所有博客，在 username 取消关注 author 时调用.
+func (t *timelines) Remove(ctx context.Context, username, author string) (int64, error) {
+	result := t.ds.core(ctx).Where("username = ? and author = ?", username, author).Delete(&model.TimelineM{})
+
+	return result.RowsAffected, result.Error
+}
+
+// List 按发布时间从新到旧分页列出 username 时间线中已发布的公开博客，opts.SortBy 必须为 publishAt.
+// 为了避免统计总数时扫描整个时间线，List 不返回记录总数.
+func (t *timelines) List(ctx context.Context, username string, opts *ListOptions) (ret []*model.TimelineM, err error) {
+	db := t.ds.core(ctx)
+	published := db.Session(&gorm.Session{NewDB: true}).Model(&model.PostM{}).
+		Where("status = ? and visibility = ?", model.PostStatusPublished, model.PostVisibilityPublic).
+		Select("postID")
+
+	err = paginate(db.Model(&model.TimelineM{}).Where("username = ? and postID in (?)", username, published), opts).
+		Find(&ret).
+		Error
+
+	return
+}
+
+// DeleteByUsername 删除 username 的时间线以及其他用户时间线中 username 的博客，返回被删除的记录数.
+func (t *timelines) DeleteByUsername(ctx context.Context, username string) (int64, error) {
+	result := t.ds.core(ctx).Where("username = ? or author = ?", username, username).Delete(&model.TimelineM{})
+
+	return result.RowsAffected, result.Error
+}
+
+// UpdateUsername 将 from 的时间线以及其他用户时间线中 from 的博客转移给 to，返回被更新的记录数.
+func (t *timelines) UpdateUsername(ctx context.Context, from, to string) (int64, error) {
+	db := t.ds.core(ctx)
+
+	authored := db.Model(&model.TimelineM{}).Where("author = ?", from).Update("author", to)
+	if authored.Error != nil {
+		return 0, authored.Error
+	}
+
+	owned := db.Model(&model.TimelineM{}).Where("username = ?", from).Update("username", to)
+
+	return authored.RowsAffected + owned.RowsAffected, owned.Error
+}
+
+// UpdateAuthor 将其他用户时间线中 from 的博客的作者修改为 to，from 自己的时间线保持不变，返回被更新的记录数.
+// 用于 from 的博客被转移给 to 时，时间线中已有的博客继续展示.
+func (t *timelines) UpdateAuthor(ctx context.Context, from, to string) (int64, error) {
+	result := t.ds.core(ctx).Model(&model.TimelineM{}).Where("author = ?", from).Update("author", to)
+
+	return result.RowsAffected, result.Error
+}
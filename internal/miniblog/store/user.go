@@ -8,8 +8,10 @@ package store
 import (
 	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/marmotedu/miniblog/internal/pkg/model"
 )
@@ -19,31 +21,58 @@ type UserStore interface {
 	Create(ctx context.Context, user *model.UserM) error
 	Get(ctx context.Context, username string) (*model.UserM, error)
 	Update(ctx context.Context, user *model.UserM) error
-	List(ctx context.Context, offset, limit int) (int64, []*model.UserM, error)
+	List(ctx context.Context, filter *UserFilter, opts *ListOptions) (int64, []*model.UserM, error)
 	Delete(ctx context.Context, username string) error
+	Lock(ctx context.Context, username string) error
+}
+
+// UserFilter 定义了查询 user 列表时的过滤条件，零值表示不过滤.
+type UserFilter struct {
+	// State 过滤在 Now 时刻处于指定状态的用户，停用已经到期的用户视为 active.
+	State string
+	Now   time.Time
+}
+
+// apply 将过滤条件添加到查询中.
+func (f *UserFilter) apply(db *gorm.DB) *gorm.DB {
+	if f == nil {
+		return db
+	}
+
+	switch f.State {
+	case "":
+	case model.UserStateActive:
+		db = db.Where("(state = ? OR (state = ? AND stateExpiresAt <= ?))", model.UserStateActive, model.UserStateSuspended, f.Now)
+	case model.UserStateSuspended:
+		db = db.Where("state = ? AND (stateExpiresAt IS NULL OR stateExpiresAt > ?)", model.UserStateSuspended, f.Now)
+	default:
+		db = db.Where("state = ?", f.State)
+	}
+
+	return db
 }
 
 // users is the implementation of the UserStore interface.
 type users struct {
-	db *gorm.DB
+	ds *datastore
 }
 
 // Ensure that users implements the UserStore interface.
 var _ UserStore = (*users)(nil)
 
-func newUsers(db *gorm.DB) *users {
-	return &users{db}
+func newUsers(ds *datastore) *users {
+	return &users{ds}
 }
 
 // Create inserts a user record.
 func (u *users) Create(ctx context.Context, user *model.UserM) error {
-	return u.db.Create(&user).Error
+	return u.ds.core(ctx).Create(&user).Error
 }
 
 // Get retrieves the specified user's database record by username.
 func (u *users) Get(ctx context.Context, username string) (*model.UserM, error) {
 	var user model.UserM
-	if err := u.db.Where("username = ?", username).First(&user).Error; err != nil {
+	if err := u.ds.core(ctx).Where("username = ?", username).First(&user).Error; err != nil {
 		return nil, err
 	}
 
@@ -52,23 +81,34 @@ func (u *users) Get(ctx context.Context, username string) (*model.UserM, error)
 
 // Update updates a user database record.
 func (u *users) Update(ctx context.Context, user *model.UserM) error {
-	return u.db.Save(user).Error
+	return u.ds.core(ctx).Save(user).Error
 }
 
-// List returns a list of users based on the offset and limit.
-func (u *users) List(ctx context.Context, offset, limit int) (count int64, ret []*model.UserM, err error) {
-	err = u.db.Offset(offset).Limit(defaultLimit(limit)).Order("id desc").Find(&ret).
-		Offset(-1).
-		Limit(-1).
-		Count(&count).
-		Error
+// List returns a list of users based on the filter and pagination options.
+func (u *users) List(ctx context.Context, filter *UserFilter, opts *ListOptions) (count int64, ret []*model.UserM, err error) {
+	db := filter.apply(u.ds.core(ctx).Model(&model.UserM{})).Session(&gorm.Session{})
+	if !opts.SkipCount {
+		if err = db.Count(&count).Error; err != nil {
+			return
+		}
+	}
+
+	err = paginate(db, opts).Find(&ret).Error
 
 	return
 }
 
+// Lock locks the user's record until the end of the transaction, so that the operations of the user which
+// must not run concurrently are serialized. It must be called in a transaction.
+func (u *users) Lock(ctx context.Context, username string) error {
+	var user model.UserM
+
+	return u.ds.core(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("username = ?", username).First(&user).Error
+}
+
 // Delete deletes a database user record based on the username.
 func (u *users) Delete(ctx context.Context, username string) error {
-	err := u.db.Where("username = ?", username).Delete(&model.UserM{}).Error
+	err := u.ds.core(ctx).Where("username = ?", username).Delete(&model.UserM{}).Error
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return err
 	}
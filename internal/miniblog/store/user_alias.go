@@ -0,0 +1,79 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// AliasStore 定义了用户旧用户名在 store 层所实现的方法.
+type AliasStore interface {
+	Create(ctx context.Context, alias *model.UserAliasM) error
+	Resolve(ctx context.Context, alias string, now time.Time) (string, error)
+	List(ctx context.Context, username string) ([]*model.UserAliasM, error)
+	UpdateUsername(ctx context.Context, from, to string) (int64, error)
+	DeleteByUsername(ctx context.Context, username string) (int64, error)
+}
+
+// AliasStore 接口的实现.
+type aliases struct {
+	ds *datastore
+}
+
+// 确保 aliases 实现了 AliasStore 接口.
+var _ AliasStore = (*aliases)(nil)
+
+func newAliases(ds *datastore) *aliases {
+	return &aliases{ds}
+}
+
+// Create 插入一条旧用户名记录，旧用户名已经存在（已经过期或者属于同一个用户）时覆盖原有的记录.
+func (a *aliases) Create(ctx context.Context, alias *model.UserAliasM) error {
+	return a.ds.core(ctx).Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"username", "expiresAt", "createdAt"})}).
+		Create(alias).Error
+}
+
+// Resolve 返回在 now 时刻使用旧用户名 alias 的用户，alias 不是旧用户名或者已经过期时返回 gorm.ErrRecordNotFound.
+func (a *aliases) Resolve(ctx context.Context, alias string, now time.Time) (string, error) {
+	var m model.UserAliasM
+	if err := a.ds.core(ctx).Where("alias = ? and expiresAt > ?", alias, now).Take(&m).Error; err != nil {
+		return "", err
+	}
+
+	return m.Username, nil
+}
+
+// List 返回用户 username 的所有旧用户名记录，包括已经过期的记录.
+func (a *aliases) List(ctx context.Context, username string) (ret []*model.UserAliasM, err error) {
+	err = a.ds.core(ctx).Where("username = ?", username).Order("id desc").Find(&ret).Error
+
+	return
+}
+
+// UpdateUsername 将用户 from 的所有旧用户名记录转移给用户 to，返回被更新的记录数.
+// to 本身是 from 的旧用户名时，该记录会被删除.
+func (a *aliases) UpdateUsername(ctx context.Context, from, to string) (int64, error) {
+	db := a.ds.core(ctx)
+	if err := db.Where("alias = ? and username = ?", to, from).Delete(&model.UserAliasM{}).Error; err != nil {
+		return 0, err
+	}
+
+	ret := db.Model(&model.UserAliasM{}).Where("username = ?", from).Update("username", to)
+
+	return ret.RowsAffected, ret.Error
+}
+
+// DeleteByUsername 删除用户的所有旧用户名记录，返回删除的记录数.
+func (a *aliases) DeleteByUsername(ctx context.Context, username string) (int64, error) {
+	ret := a.ds.core(ctx).Where("username = ?", username).Delete(&model.UserAliasM{})
+
+	return ret.RowsAffected, ret.Error
+}
@@ -0,0 +1,242 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package store
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/pkg/model"
+)
+
+// WebhookStore 定义了 webhook 模块在 store 层所实现的方法.
+type WebhookStore interface {
+	Create(ctx context.Context, webhook *model.WebhookM) error
+	Get(ctx context.Context, username, webhookID string) (*model.WebhookM, error)
+	GetByWebhookID(ctx context.Context, webhookID string) (*model.WebhookM, error)
+	List(ctx context.Context, username string) ([]*model.WebhookM, error)
+	Update(ctx context.Context, webhook *model.WebhookM) error
+	Delete(ctx context.Context, username, webhookID string) error
+	RecordDelivery(ctx context.Context, webhookID string, succeeded bool, at time.Time) error
+	DisableFailing(ctx context.Context, webhookID string, threshold int, reason string) (bool, error)
+	DeleteByUsername(ctx context.Context, username string) (int64, error)
+	UpdateUsername(ctx context.Context, from, to string) (int64, error)
+}
+
+// DeliveryStore 定义了 webhook 投递记录在 store 层所实现的方法.
+type DeliveryStore interface {
+	Create(ctx context.Context, delivery *model.WebhookDeliveryM) error
+	Get(ctx context.Context, webhookID, deliveryID string) (*model.WebhookDeliveryM, error)
+	List(ctx context.Context, webhookID string, offset, limit int) (int64, []*model.WebhookDeliveryM, error)
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDeliveryM, error)
+	Claim(ctx context.Context, delivery *model.WebhookDeliveryM, until time.Time) (bool, error)
+	Update(ctx context.Context, delivery *model.WebhookDeliveryM) error
+	FailPending(ctx context.Context, webhookID, reason string) (int64, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+	DeleteByWebhookID(ctx context.Context, webhookID string) (int64, error)
+	DeleteByUsername(ctx context.Context, username string) (int64, error)
+	UpdateUsername(ctx context.Context, from, to string) (int64, error)
+}
+
+// WebhookStore 接口的实现.
+type webhooks struct {
+	ds *datastore
+}
+
+// 确保 webhooks 实现了 WebhookStore 接口.
+var _ WebhookStore = (*webhooks)(nil)
+
+func newWebhooks(ds *datastore) *webhooks {
+	return &webhooks{ds}
+}
+
+// Create 插入一条 webhook 记录.
+func (w *webhooks) Create(ctx context.Context, webhook *model.WebhookM) error {
+	return w.ds.core(ctx).Create(webhook).Error
+}
+
+// Get 根据 username 和 webhookID 查询 webhook 记录.
+func (w *webhooks) Get(ctx context.Context, username, webhookID string) (*model.WebhookM, error) {
+	var webhook model.WebhookM
+	if err := w.ds.core(ctx).Where("username = ? and webhookID = ?", username, webhookID).First(&webhook).Error; err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// GetByWebhookID 根据 webhookID 查询 webhook 记录，用于投递时查询 webhook 的地址和密钥.
+func (w *webhooks) GetByWebhookID(ctx context.Context, webhookID string) (*model.WebhookM, error) {
+	var webhook model.WebhookM
+	if err := w.ds.core(ctx).Where("webhookID = ?", webhookID).First(&webhook).Error; err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// List 按创建顺序返回用户的所有 webhook 记录. 每个用户的 webhook 数量有上限，所以不分页.
+func (w *webhooks) List(ctx context.Context, username string) (ret []*model.WebhookM, err error) {
+	err = w.ds.core(ctx).Where("username = ?", username).Order("id").Find(&ret).Error
+
+	return
+}
+
+// Update 更新一条 webhook 记录.
+func (w *webhooks) Update(ctx context.Context, webhook *model.WebhookM) error {
+	return w.ds.core(ctx).Save(webhook).Error
+}
+
+// Delete 删除一条 webhook 记录，记录不存在时返回 gorm.ErrRecordNotFound.
+func (w *webhooks) Delete(ctx context.Context, username, webhookID string) error {
+	ret := w.ds.core(ctx).Where("username = ? and webhookID = ?", username, webhookID).Delete(&model.WebhookM{})
+	if ret.Error == nil && ret.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return ret.Error
+}
+
+// RecordDelivery 记录一次投递的结果：投递成功时清零连续失败次数，失败时连续失败次数加 1.
+// 使用原子更新，避免并发的投递互相覆盖计数.
+func (w *webhooks) RecordDelivery(ctx context.Context, webhookID string, succeeded bool, at time.Time) error {
+	failures := gorm.Expr("consecutiveFailures + 1")
+	if succeeded {
+		failures = gorm.Expr("0")
+	}
+
+	return w.ds.core(ctx).Model(&model.WebhookM{}).Where("webhookID = ?", webhookID).
+		Updates(map[string]interface{}{"consecutiveFailures": failures, "lastDeliveryAt": at}).Error
+}
+
+// DisableFailing 在连续失败次数达到 threshold 时停用 webhook，reason 是停用原因. 返回 webhook 是否被本次调用停用.
+func (w *webhooks) DisableFailing(ctx context.Context, webhookID string, threshold int, reason string) (bool, error) {
+	ret := w.ds.core(ctx).Model(&model.WebhookM{}).
+		Where("webhookID = ? and active = ? and consecutiveFailures >= ?", webhookID, true, threshold).
+		Updates(map[string]interface{}{"active": false, "disabledReason": reason})
+
+	return ret.RowsAffected > 0, ret.Error
+}
+
+// DeleteByUsername 删除用户的所有 webhook 记录，返回删除的记录数.
+func (w *webhooks) DeleteByUsername(ctx context.Context, username string) (int64, error) {
+	ret := w.ds.core(ctx).Where("username = ?", username).Delete(&model.WebhookM{})
+
+	return ret.RowsAffected, ret.Error
+}
+
+// UpdateUsername 将用户 from 的所有 webhook 记录转移给用户 to，返回被更新的记录数.
+func (w *webhooks) UpdateUsername(ctx context.Context, from, to string) (int64, error) {
+	ret := w.ds.core(ctx).Model(&model.WebhookM{}).Where("username = ?", from).Update("username", to)
+
+	return ret.RowsAffected, ret.Error
+}
+
+// DeliveryStore 接口的实现.
+type deliveries struct {
+	ds *datastore
+}
+
+// 确保 deliveries 实现了 DeliveryStore 接口.
+var _ DeliveryStore = (*deliveries)(nil)
+
+func newDeliveries(ds *datastore) *deliveries {
+	return &deliveries{ds}
+}
+
+// Create 插入一条投递记录.
+func (d *deliveries) Create(ctx context.Context, delivery *model.WebhookDeliveryM) error {
+	return d.ds.core(ctx).Create(delivery).Error
+}
+
+// Get 根据 webhookID 和 deliveryID 查询投递记录.
+func (d *deliveries) Get(ctx context.Context, webhookID, deliveryID string) (*model.WebhookDeliveryM, error) {
+	var delivery model.WebhookDeliveryM
+	if err := d.ds.core(ctx).Where("webhookID = ? and deliveryID = ?", webhookID, deliveryID).First(&delivery).Error; err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// List 从新到旧列出 webhook 的投递记录.
+func (d *deliveries) List(ctx context.Context, webhookID string, offset, limit int) (count int64, ret []*model.WebhookDeliveryM, err error) {
+	err = d.ds.core(ctx).Where("webhookID = ?", webhookID).Offset(offset).Limit(defaultLimit(limit)).Order("id desc").Find(&ret).
+		Offset(-1).
+		Limit(-1).
+		Count(&count).
+		Error
+
+	return
+}
+
+// ListDue 按下次投递时间从早到晚返回最多 limit 条到期的等待投递记录.
+func (d *deliveries) ListDue(ctx context.Context, now time.Time, limit int) (ret []*model.WebhookDeliveryM, err error) {
+	err = d.ds.core(ctx).Where("status = ? and nextAttemptAt <= ?", model.DeliveryStatusPending, now).
+		Order("nextAttemptAt, id").Limit(limit).Find(&ret).Error
+
+	return
+}
+
+// Claim 认领一条到期的投递记录：投递次数加 1，并将下次投递时间推迟到 until，在此之前其它进程不会再投递该记录.
+// 投递进程在投递过程中退出时，记录在 until 之后会被重新投递. 记录已经被其它进程认领时返回 false.
+func (d *deliveries) Claim(ctx context.Context, delivery *model.WebhookDeliveryM, until time.Time) (bool, error) {
+	ret := d.ds.core(ctx).Model(&model.WebhookDeliveryM{}).
+		Where("id = ? and status = ? and attempts = ?", delivery.ID, model.DeliveryStatusPending, delivery.Attempts).
+		Updates(map[string]interface{}{"attempts": delivery.Attempts + 1, "nextAttemptAt": until})
+	if ret.Error != nil || ret.RowsAffected == 0 {
+		return false, ret.Error
+	}
+
+	delivery.Attempts++
+	delivery.NextAttemptAt = until
+
+	return true, nil
+}
+
+// Update 更新投递记录的状态和结果.
+func (d *deliveries) Update(ctx context.Context, delivery *model.WebhookDeliveryM) error {
+	return d.ds.core(ctx).Save(delivery).Error
+}
+
+// FailPending 将 webhook 所有等待投递的记录标记为失败，reason 是失败原因，返回被标记的记录数.
+func (d *deliveries) FailPending(ctx context.Context, webhookID, reason string) (int64, error) {
+	ret := d.ds.core(ctx).Model(&model.WebhookDeliveryM{}).
+		Where("webhookID = ? and status = ?", webhookID, model.DeliveryStatusPending).
+		Updates(map[string]interface{}{"status": model.DeliveryStatusFailed, "error": reason})
+
+	return ret.RowsAffected, ret.Error
+}
+
+// DeleteBefore 删除创建时间早于 before 的已结束的投递记录，返回删除的记录数.
+func (d *deliveries) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	ret := d.ds.core(ctx).Where("status <> ? and createdAt < ?", model.DeliveryStatusPending, before).Delete(&model.WebhookDeliveryM{})
+
+	return ret.RowsAffected, ret.Error
+}
+
+// DeleteByWebhookID 删除 webhook 的所有投递记录，返回删除的记录数.
+func (d *deliveries) DeleteByWebhookID(ctx context.Context, webhookID string) (int64, error) {
+	ret := d.ds.core(ctx).Where("webhookID = ?", webhookID).Delete(&model.WebhookDeliveryM{})
+
+	return ret.RowsAffected, ret.Error
+}
+
+// DeleteByUsername 删除用户的所有 webhook 的投递记录，返回删除的记录数.
+func (d *deliveries) DeleteByUsername(ctx context.Context, username string) (int64, error) {
+	ret := d.ds.core(ctx).Where("username = ?", username).Delete(&model.WebhookDeliveryM{})
+
+	return ret.RowsAffected, ret.Error
+}
+
+// UpdateUsername 将用户 from 的所有 webhook 的投递记录转移给用户 to，返回被更新的记录数.
+func (d *deliveries) UpdateUsername(ctx context.Context, from, to string) (int64, error) {
+	ret := d.ds.core(ctx).Model(&model.WebhookDeliveryM{}).Where("username = ?", from).Update("username", to)
+
+	return ret.RowsAffected, ret.Error
+}
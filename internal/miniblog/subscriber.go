@@ -0,0 +1,71 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package miniblog
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/event"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/notification"
+	"github.com/marmotedu/miniblog/internal/miniblog/biz/stream"
+	"github.com/marmotedu/miniblog/internal/miniblog/store"
+)
+
+// subscribe registers the handlers of the domain events on the default bus. The handlers keep the data
+// derived from the storage in sync, so the biz layer does not need to know about them.
+func subscribe(ds store.IStore) {
+	// Keep the search index in sync with the posts. The current state of the post is indexed, so that
+	// the events can be handled more than once and in any order.
+	event.Subscribe("IndexPost", func(ctx context.Context, e *event.Event) error {
+		var p event.Post
+		if err := e.Decode(&p); err != nil {
+			return err
+		}
+
+		post, err := ds.Posts().Get(ctx, p.Username, p.PostID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ds.Search().Delete(ctx, p.Username, []string{p.PostID})
+		}
+		if err != nil {
+			return err
+		}
+
+		return ds.Search().Index(ctx, post)
+	}, event.PostCreated, event.PostUpdated, event.PostPublished, event.PostRestored, event.PostDeleted)
+
+	// Remove or transfer the indexed posts of a deleted user.
+	event.Subscribe("IndexUserPosts", func(ctx context.Context, e *event.Event) error {
+		var u event.User
+		if err := e.Decode(&u); err != nil {
+			return err
+		}
+
+		if u.Heir == "" {
+			return ds.Search().DeleteByUsername(ctx, u.Username)
+		}
+
+		return ds.Search().UpdateUsername(ctx, u.Username, u.Heir)
+	}, event.UserDeleted)
+
+	// Transfer the indexed posts of a renamed user.
+	event.Subscribe("RenameUserPosts", func(ctx context.Context, e *event.Event) error {
+		var r event.Rename
+		if err := e.Decode(&r); err != nil {
+			return err
+		}
+
+		return ds.Search().UpdateUsername(ctx, r.Username, r.NewUsername)
+	}, event.UserRenamed)
+
+	// Notify the users of the mentions, follows and comments.
+	notification.Subscribe(ds)
+
+	// Push the new posts, comments and notifications to the event streams.
+	stream.Subscribe(ds)
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package core
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/errno"
+)
+
+// CustomVerbs 返回一个 Gin handler，用来处理形如 `/v1/posts/{postID}:restore` 的自定义方法请求.
+// Gin 无法直接注册 `:postID:restore` 这样的路由，因此由该 handler 将路径参数 param 按最后一个 `:` 拆分为
+// 资源名和自定义方法名，把路径参数还原为资源名后，再分发给 handlers 中对应的 handler.
+// 没有匹配的自定义方法时返回 errno.ErrPageNotFound.
+func CustomVerbs(param string, handlers map[string]gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value := c.Param(param)
+
+		idx := strings.LastIndex(value, ":")
+		if idx < 0 {
+			WriteResponse(c, errno.ErrPageNotFound, nil)
+
+			return
+		}
+
+		handler, ok := handlers[value[idx+1:]]
+		if !ok {
+			WriteResponse(c, errno.ErrPageNotFound, nil)
+
+			return
+		}
+
+		for i := range c.Params {
+			if c.Params[i].Key == param {
+				c.Params[i].Value = value[:idx]
+			}
+		}
+
+		handler(c)
+	}
+}
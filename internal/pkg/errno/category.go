@@ -0,0 +1,14 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package errno
+
+var (
+	// ErrCategoryNotFound 表示未找到分类.
+	ErrCategoryNotFound = &Errno{HTTP: 404, Code: "ResourceNotFound.CategoryNotFound", Message: "Category was not found."}
+
+	// ErrCategoryAlreadyExist 代表同一父分类下已经存在同名分类.
+	ErrCategoryAlreadyExist = &Errno{HTTP: 400, Code: "FailedOperation.CategoryAlreadyExist", Message: "Category already exist."}
+)
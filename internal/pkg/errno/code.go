@@ -21,6 +21,9 @@ var (
 	// ErrInvalidParameter 表示所有验证失败的错误.
 	ErrInvalidParameter = &Errno{HTTP: 400, Code: "InvalidParameter", Message: "Parameter verification failed."}
 
+	// ErrPageTokenInvalid 表示分页游标无效.
+	ErrPageTokenInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.PageTokenInvalid", Message: "Page token was invalid."}
+
 	// ErrSignToken 表示签发 JWT Token 时出错.
 	ErrSignToken = &Errno{HTTP: 401, Code: "AuthFailure.SignTokenError", Message: "Error occurred while signing the JSON web token."}
 
@@ -0,0 +1,14 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package errno
+
+var (
+	// ErrCommentNotFound 表示未找到评论.
+	ErrCommentNotFound = &Errno{HTTP: 404, Code: "ResourceNotFound.CommentNotFound", Message: "Comment was not found."}
+
+	// ErrCommentClosed 表示博客已关闭评论.
+	ErrCommentClosed = &Errno{HTTP: 400, Code: "FailedOperation.CommentClosed", Message: "Comments are closed for this post."}
+)
@@ -0,0 +1,9 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package errno
+
+// ErrFollowSelf 表示用户不能关注自己.
+var ErrFollowSelf = &Errno{HTTP: 400, Code: "InvalidParameter.FollowSelf", Message: "Users can not follow themselves."}
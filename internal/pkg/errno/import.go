@@ -0,0 +1,20 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package errno
+
+var (
+	// ErrImportNotFound 表示未找到博客导入任务.
+	ErrImportNotFound = &Errno{HTTP: 404, Code: "ResourceNotFound.ImportNotFound", Message: "Import was not found."}
+
+	// ErrImportFileRequired 表示导入请求中没有 file 字段.
+	ErrImportFileRequired = &Errno{HTTP: 400, Code: "InvalidParameter.ImportFileRequired", Message: "The archive to import must be sent in the multipart form field 'file'."}
+
+	// ErrImportTooLarge 表示导入的归档文件超过了大小限制.
+	ErrImportTooLarge = &Errno{HTTP: 413, Code: "InvalidParameter.ImportTooLarge", Message: "The archive to import is too large."}
+
+	// ErrImportInvalid 表示导入的归档文件无法解析.
+	ErrImportInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.ImportInvalid", Message: "The archive to import is invalid."}
+)
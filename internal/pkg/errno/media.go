@@ -0,0 +1,23 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package errno
+
+var (
+	// ErrMediaNotFound 表示未找到媒体文件.
+	ErrMediaNotFound = &Errno{HTTP: 404, Code: "ResourceNotFound.MediaNotFound", Message: "Media was not found."}
+
+	// ErrMediaFileRequired 表示上传请求中没有 file 字段.
+	ErrMediaFileRequired = &Errno{HTTP: 400, Code: "InvalidParameter.MediaFileRequired", Message: "The file to upload must be sent in the multipart form field 'file'."}
+
+	// ErrMediaTooLarge 表示上传的文件超过了大小限制.
+	ErrMediaTooLarge = &Errno{HTTP: 413, Code: "InvalidParameter.MediaTooLarge", Message: "The uploaded file is too large."}
+
+	// ErrMediaTypeUnsupported 表示上传的文件类型不支持.
+	ErrMediaTypeUnsupported = &Errno{HTTP: 415, Code: "InvalidParameter.MediaTypeUnsupported", Message: "Only PNG, JPEG, GIF and WebP images are supported."}
+
+	// ErrMediaQuotaExceeded 表示用户上传的文件超过了存储空间配额.
+	ErrMediaQuotaExceeded = &Errno{HTTP: 403, Code: "FailedOperation.MediaQuotaExceeded", Message: "Media storage quota exceeded."}
+)
@@ -0,0 +1,9 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package errno
+
+// ErrNotificationNotFound 表示未找到通知.
+var ErrNotificationNotFound = &Errno{HTTP: 404, Code: "ResourceNotFound.NotificationNotFound", Message: "Notification was not found."}
@@ -7,3 +7,12 @@ package errno
 
 // ErrPostNotFound 表示未找到博客.
 var ErrPostNotFound = &Errno{HTTP: 404, Code: "ResourceNotFound.PostNotFound", Message: "Post was not found."}
+
+// ErrPostSlugInvalid 表示博客的 slug 不合法.
+var ErrPostSlugInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.PostSlugInvalid", Message: "Slug must be at most 80 characters long and consist of lower case letters, digits and hyphens."}
+
+// ErrPostSlugAlreadyExist 表示作者的其它博客已经使用了该 slug.
+var ErrPostSlugAlreadyExist = &Errno{HTTP: 400, Code: "FailedOperation.PostSlugAlreadyExist", Message: "Slug is already used by another post."}
+
+// ErrPublishAtInvalid 表示博客的计划发布时间无效.
+var ErrPublishAtInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.PublishAtInvalid", Message: "PublishAt must be a future time and can only be set for scheduled posts."}
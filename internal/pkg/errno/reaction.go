@@ -0,0 +1,9 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package errno
+
+// ErrReactionTypeInvalid 表示 reaction 类型不支持.
+var ErrReactionTypeInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.ReactionTypeInvalid", Message: "Reaction type must be one of like, love, laugh, wow, sad and angry."}
@@ -0,0 +1,9 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package errno
+
+// ErrRevisionNotFound 表示未找到博客的历史版本，可能已经因为超过保留的版本数被删除.
+var ErrRevisionNotFound = &Errno{HTTP: 404, Code: "ResourceNotFound.RevisionNotFound", Message: "Post revision was not found."}
@@ -0,0 +1,9 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package errno
+
+// ErrStreamLimitExceeded 表示用户同时打开的事件流数量达到了上限.
+var ErrStreamLimitExceeded = &Errno{HTTP: 429, Code: "LimitExceeded.StreamLimitExceeded", Message: "The number of concurrent event streams has reached the limit."}
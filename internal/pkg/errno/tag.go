@@ -0,0 +1,17 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package errno
+
+var (
+	// ErrTagNotFound 表示未找到 tag.
+	ErrTagNotFound = &Errno{HTTP: 404, Code: "ResourceNotFound.TagNotFound", Message: "Tag was not found."}
+
+	// ErrTagAlreadyExist 代表 tag 已经存在.
+	ErrTagAlreadyExist = &Errno{HTTP: 400, Code: "FailedOperation.TagAlreadyExist", Message: "Tag already exist."}
+
+	// ErrTagInvalid 表示 tag 不合法.
+	ErrTagInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.TagInvalid", Message: "Tags must be 1 to 32 characters long and a post can have at most 10 tags."}
+)
@@ -14,4 +14,46 @@ var (
 
 	// ErrPasswordIncorrect 表示密码不正确.
 	ErrPasswordIncorrect = &Errno{HTTP: 401, Code: "InvalidParameter.PasswordIncorrect", Message: "Password was incorrect."}
+
+	// ErrReassignTargetInvalid 表示删除用户时指定的数据接收用户无效.
+	ErrReassignTargetInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.ReassignTargetInvalid", Message: "The user to reassign data to is invalid."}
+
+	// ErrProfileLinkInvalid 表示个人资料中的链接无效或数量过多.
+	ErrProfileLinkInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.ProfileLinkInvalid", Message: "Profile links must be at most 5 http or https URLs."}
+
+	// ErrTimezoneInvalid 表示时区不是有效的 IANA 时区名.
+	ErrTimezoneInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.TimezoneInvalid", Message: "Timezone was invalid."}
+
+	// ErrLocaleInvalid 表示语言不是有效的 BCP 47 语言标签.
+	ErrLocaleInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.LocaleInvalid", Message: "Locale was invalid."}
+
+	// ErrAvatarInvalid 表示头像不是支持的图片格式（PNG、JPEG 或 GIF）.
+	ErrAvatarInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.AvatarInvalid", Message: "Avatar must be a PNG, JPEG or GIF image."}
+
+	// ErrAvatarTooLarge 表示头像文件超过了大小限制.
+	ErrAvatarTooLarge = &Errno{HTTP: 413, Code: "InvalidParameter.AvatarTooLarge", Message: "Avatar file is too large."}
+
+	// ErrAvatarNotFound 表示未找到头像.
+	ErrAvatarNotFound = &Errno{HTTP: 404, Code: "ResourceNotFound.AvatarNotFound", Message: "Avatar was not found."}
+
+	// ErrUserSuspended 表示用户账号已被停用.
+	ErrUserSuspended = &Errno{HTTP: 403, Code: "FailedOperation.UserSuspended", Message: "User account was suspended."}
+
+	// ErrPasswordResetRequired 表示用户的密码已被管理员重置，需要先修改密码.
+	ErrPasswordResetRequired = &Errno{HTTP: 403, Code: "FailedOperation.PasswordResetRequired", Message: "Password was reset by an administrator and must be changed before signing in."}
+
+	// ErrUserStateInvalid 表示指定的用户状态无效.
+	ErrUserStateInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.UserStateInvalid", Message: "User state must be one of active, suspended or pending."}
+
+	// ErrSuspendUntilInvalid 表示停用的截止时间格式错误或早于当前时间.
+	ErrSuspendUntilInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.SuspendUntilInvalid", Message: "Suspension end time must be a future time in the format 2006-01-02 15:04:05."}
+
+	// ErrSuspendSelf 表示管理员不能停用或重置自己的账号.
+	ErrSuspendSelf = &Errno{HTTP: 400, Code: "FailedOperation.SuspendSelf", Message: "You cannot suspend or reset your own account."}
+
+	// ErrUsernameReserved 表示用户名是其他用户修改前的用户名，在保留期内不能使用.
+	ErrUsernameReserved = &Errno{HTTP: 400, Code: "FailedOperation.UsernameReserved", Message: "Username is reserved by another user."}
+
+	// ErrRenameTooFrequent 表示用户在冷却期内已经修改过用户名.
+	ErrRenameTooFrequent = &Errno{HTTP: 429, Code: "FailedOperation.RenameTooFrequent", Message: "Username was changed recently, please try again later."}
 )
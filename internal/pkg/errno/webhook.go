@@ -0,0 +1,26 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package errno
+
+var (
+	// ErrWebhookNotFound 表示未找到 webhook.
+	ErrWebhookNotFound = &Errno{HTTP: 404, Code: "ResourceNotFound.WebhookNotFound", Message: "Webhook was not found."}
+
+	// ErrDeliveryNotFound 表示未找到 webhook 的投递记录.
+	ErrDeliveryNotFound = &Errno{HTTP: 404, Code: "ResourceNotFound.DeliveryNotFound", Message: "Webhook delivery was not found."}
+
+	// ErrWebhookLimitExceeded 表示用户的 webhook 数量达到了上限.
+	ErrWebhookLimitExceeded = &Errno{HTTP: 400, Code: "LimitExceeded.WebhookLimitExceeded", Message: "The number of webhooks has reached the limit."}
+
+	// ErrWebhookEventInvalid 表示订阅的事件类型不存在.
+	ErrWebhookEventInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.WebhookEventInvalid", Message: "Unknown webhook event type."}
+
+	// ErrWebhookURLInvalid 表示 webhook 的地址不是 http 或 https 地址，或者是内部网络的地址.
+	ErrWebhookURLInvalid = &Errno{HTTP: 400, Code: "InvalidParameter.WebhookURLInvalid", Message: "The webhook URL must be a public http or https URL."}
+
+	// ErrWebhookDisabled 表示 webhook 已被停用，不能重新投递.
+	ErrWebhookDisabled = &Errno{HTTP: 400, Code: "FailedOperation.WebhookDisabled", Message: "The webhook is disabled, enable it before redelivering."}
+)
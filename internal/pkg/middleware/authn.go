@@ -6,6 +6,9 @@
 package middleware
 
 import (
+	"context"
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/marmotedu/miniblog/internal/pkg/core"
@@ -14,12 +17,19 @@ import (
 	"github.com/marmotedu/miniblog/pkg/token"
 )
 
+// AccountChecker is used to check whether the account a token was issued to can still access the API,
+// e.g. the account is not suspended and the token was not revoked.
+type AccountChecker interface {
+	CheckAccount(ctx context.Context, username string, issuedAt time.Time) error
+}
+
 // Authn is an authentication middleware used to extract the token from gin.Context and validate its legality.
-// If the token is valid, the sub (username) from the token is stored in the gin.Context under the XUsernameKey key.
-func Authn() gin.HandlerFunc {
+// If the token is valid and the account passes the check of a, the sub (username) from the token is stored
+// in the gin.Context under the XUsernameKey key.
+func Authn(a AccountChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 解析 JWT Token
-		username, err := token.ParseRequest(c)
+		username, issuedAt, err := token.ParseRequestWithIssuedAt(c)
 		if err != nil {
 			core.WriteResponse(c, errno.ErrTokenInvalid, nil)
 			c.Abort()
@@ -27,6 +37,14 @@ func Authn() gin.HandlerFunc {
 			return
 		}
 
+		// 检查账号是否已被停用以及 token 是否已被吊销
+		if err := a.CheckAccount(c, username, issuedAt); err != nil {
+			core.WriteResponse(c, err, nil)
+			c.Abort()
+
+			return
+		}
+
 		c.Set(known.XUsernameKey, username)
 		c.Next()
 	}
@@ -6,6 +6,10 @@
 package middleware
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -44,3 +48,82 @@ func Secure(c *gin.Context) {
 		c.Header("Strict-Transport-Security", "max-age=31536000")
 	}
 }
+
+// Cache returns a Gin middleware that allows the successful responses to be cached by clients and shared
+// caches for maxAge. It sets a weak ETag computed from the response body, and responds with
+// 304 Not Modified if the ETag matches the If-None-Match request header. If the handler sets the
+// Last-Modified response header, requests with If-Modified-Since but without If-None-Match are
+// answered with 304 Not Modified as well when the response has not been modified since then.
+// Error responses are not cached.
+func Cache(maxAge time.Duration) gin.HandlerFunc {
+	cacheControl := fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		w := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		if w.Status() != http.StatusOK {
+			c.Header("Cache-Control", "no-store")
+			_, _ = w.ResponseWriter.Write(w.body.Bytes())
+
+			return
+		}
+
+		sum := sha256.Sum256(w.body.Bytes())
+		etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+		c.Header("Cache-Control", cacheControl)
+		c.Header("ETag", etag)
+
+		notModified := false
+		if match := c.GetHeader("If-None-Match"); match != "" {
+			notModified = match == etag || match == "*"
+		} else {
+			notModified = notModifiedSince(w.Header().Get("Last-Modified"), c.GetHeader("If-Modified-Since"))
+		}
+
+		if notModified {
+			w.ResponseWriter.WriteHeader(http.StatusNotModified)
+			w.ResponseWriter.WriteHeaderNow()
+
+			return
+		}
+
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
+
+// notModifiedSince reports whether a response last modified at lastModified has not been modified
+// since ifModifiedSince. Both are HTTP dates, false is returned if either of them is missing or invalid.
+func notModifiedSince(lastModified, ifModifiedSince string) bool {
+	if lastModified == "" || ifModifiedSince == "" {
+		return false
+	}
+
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+
+	return !modified.After(since)
+}
+
+// bufferedWriter buffers the response body, so that the headers can still be changed after the handlers return.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
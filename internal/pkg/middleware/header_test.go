@@ -0,0 +1,74 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	g := gin.New()
+	g.Use(Cache(time.Minute))
+	g.GET("/ok", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"title": "hello"}) })
+	g.GET("/error", func(c *gin.Context) { c.JSON(http.StatusNotFound, gin.H{"code": "NotFound"}) })
+	g.GET("/modified", func(c *gin.Context) {
+		c.Header("Last-Modified", "Sun, 20 Nov 2022 08:30:00 GMT")
+		c.String(http.StatusOK, "hello")
+	})
+
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, httptest.NewRequest("GET", "/ok", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "public, max-age=60", w.Header().Get("Cache-Control"))
+	assert.Equal(t, `{"title":"hello"}`, w.Body.String())
+
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	req := httptest.NewRequest("GET", "/ok", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	g.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	// If-Modified-Since is checked against Last-Modified, and ignored if If-None-Match is present.
+	tests := []struct {
+		ifModifiedSince string
+		ifNoneMatch     string
+		want            int
+	}{
+		{ifModifiedSince: "Sun, 20 Nov 2022 08:30:00 GMT", want: http.StatusNotModified},
+		{ifModifiedSince: "Mon, 21 Nov 2022 00:00:00 GMT", want: http.StatusNotModified},
+		{ifModifiedSince: "Sun, 20 Nov 2022 08:29:59 GMT", want: http.StatusOK},
+		{ifModifiedSince: "invalid", want: http.StatusOK},
+		{ifModifiedSince: "Mon, 21 Nov 2022 00:00:00 GMT", ifNoneMatch: `W/"stale"`, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/modified", nil)
+		req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+		if tt.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+		}
+
+		w = httptest.NewRecorder()
+		g.ServeHTTP(w, req)
+		assert.Equal(t, tt.want, w.Code, tt.ifModifiedSince)
+	}
+
+	w = httptest.NewRecorder()
+	g.ServeHTTP(w, httptest.NewRequest("GET", "/error", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Equal(t, `{"code":"NotFound"}`, w.Body.String())
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marmotedu/miniblog/internal/pkg/log"
+)
+
+// AliasResolver is used to resolve an old username of a renamed user to the current username.
+type AliasResolver interface {
+	// ResolveAlias returns the current username of the user who used alias, or an empty string if
+	// alias is not a reserved old username.
+	ResolveAlias(ctx context.Context, alias string) (string, error)
+}
+
+// RedirectRenamed is a Gin middleware which permanently redirects the requests for the resources of a
+// renamed user to the same resources under the current username. param is the name of the path parameter
+// holding the username.
+func RedirectRenamed(a AliasResolver, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		username, err := a.ResolveAlias(c, c.Param(param))
+		if err != nil {
+			// 重定向只是为了兼容旧的链接，查询失败时按原来的用户名处理请求
+			log.C(c).Errorw("Failed to resolve username alias", "alias", c.Param(param), "err", err)
+		}
+
+		if username == "" {
+			return
+		}
+
+		// 路由模板和请求路径的路径段一一对应，替换用户名所在的路径段
+		segments := strings.Split(c.Request.URL.Path, "/")
+		for i, pattern := range strings.Split(c.FullPath(), "/") {
+			if pattern == ":"+param && i < len(segments) {
+				segments[i] = username
+			}
+		}
+
+		location := url.URL{Path: strings.Join(segments, "/"), RawQuery: c.Request.URL.RawQuery}
+		c.Redirect(http.StatusMovedPermanently, location.String())
+		c.Abort()
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeResolver map[string]string
+
+func (r fakeResolver) ResolveAlias(ctx context.Context, alias string) (string, error) {
+	return r[alias], nil
+}
+
+func TestRedirectRenamed(t *testing.T) {
+	g := gin.New()
+	g.GET("/users/:name/posts", RedirectRenamed(fakeResolver{"bob": "belm1"}, "name"), func(c *gin.Context) {
+		c.String(http.StatusOK, c.Param("name"))
+	})
+
+	tests := []struct {
+		path     string
+		want     int
+		location string
+	}{
+		{path: "/users/bob/posts?limit=10", want: http.StatusMovedPermanently, location: "/users/belm1/posts?limit=10"},
+		{path: "/users/belm1/posts", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		g.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		assert.Equal(t, tt.want, w.Code)
+		assert.Equal(t, tt.location, w.Header().Get("Location"))
+	}
+}
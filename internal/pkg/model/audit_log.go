@@ -0,0 +1,23 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package model
+
+import "time"
+
+// AuditLogM 是数据库中 audit_log 记录 struct 格式的映射.
+type AuditLogM struct {
+	ID        int64     `gorm:"column:id;primary_key"`
+	Operator  string    `gorm:"column:operator;not null"`
+	Action    string    `gorm:"column:action;not null"`
+	Resource  string    `gorm:"column:resource;not null"`
+	Detail    string    `gorm:"column:detail"`
+	CreatedAt time.Time `gorm:"column:createdAt"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (a *AuditLogM) TableName() string {
+	return "audit_log"
+}
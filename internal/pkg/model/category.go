@@ -0,0 +1,23 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package model
+
+import "time"
+
+// CategoryM 是数据库中 category 记录 struct 格式的映射.
+// 分类组成一棵树，ParentID 为 0 的分类是顶级分类.
+type CategoryM struct {
+	ID        int64     `gorm:"column:id;primary_key"`
+	Name      string    `gorm:"column:name;not null"`
+	ParentID  int64     `gorm:"column:parentID;not null"`
+	CreatedAt time.Time `gorm:"column:createdAt"`
+	UpdatedAt time.Time `gorm:"column:updatedAt"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (c *CategoryM) TableName() string {
+	return "category"
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/pkg/util/id"
+)
+
+// 评论的审核状态.
+const (
+	CommentStatusApproved = "approved" // 已公开
+	CommentStatusPending  = "pending"  // 等待博客作者审核，只有评论作者和博客作者可见
+	CommentStatusHidden   = "hidden"   // 被博客作者隐藏，只有博客作者可见
+)
+
+// CommentM 是数据库中 comment 记录 struct 格式的映射.
+// 评论按楼层组织：RootID 为所在楼层第一条评论的 commentID，ParentID 为被回复的评论的 commentID，
+// 楼层的第一条评论 RootID 和 ParentID 都为空.
+type CommentM struct {
+	ID        int64          `gorm:"column:id;primary_key"`
+	CommentID string         `gorm:"column:commentID;not null"`
+	PostID    string         `gorm:"column:postID;not null"`
+	Username  string         `gorm:"column:username;not null"`
+	RootID    string         `gorm:"column:rootID;not null"`
+	ParentID  string         `gorm:"column:parentID;not null"`
+	Content   string         `gorm:"column:content;not null"`
+	Status    string         `gorm:"column:status;not null"`
+	CreatedAt time.Time      `gorm:"column:createdAt"`
+	UpdatedAt time.Time      `gorm:"column:updatedAt"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deletedAt;index"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (c *CommentM) TableName() string {
+	return "comment"
+}
+
+// BeforeCreate 在创建数据库记录之前生成 commentID.
+func (c *CommentM) BeforeCreate(tx *gorm.DB) error {
+	c.CommentID = "comment-" + id.GenShortID()
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/pkg/util/id"
+)
+
+// 领域事件的分发状态.
+const (
+	EventStatusPending    = "pending"    // 等待分发或等待重试
+	EventStatusDispatched = "dispatched" // 所有处理函数都已成功处理
+	EventStatusFailed     = "failed"     // 重试次数用尽
+)
+
+// EventM 是数据库中 event_outbox 记录 struct 格式的映射，表示一个领域事件.
+// 事件和引起事件的数据变更在同一个事务中写入，等待分发的记录同时是持久化的分发队列，
+// 后台任务分发 NextAttemptAt 不晚于当前时间的记录. Payload 是 JSON 格式的事件数据，Error 是最近一次分发的错误.
+type EventM struct {
+	ID            int64      `gorm:"column:id;primary_key"`
+	EventID       string     `gorm:"column:eventID;not null"`
+	Type          string     `gorm:"column:type;not null"`
+	Payload       string     `gorm:"column:payload;not null"`
+	Status        string     `gorm:"column:status;not null"`
+	Attempts      int        `gorm:"column:attempts;not null"`
+	NextAttemptAt time.Time  `gorm:"column:nextAttemptAt"`
+	Error         string     `gorm:"column:error;not null"`
+	DispatchedAt  *time.Time `gorm:"column:dispatchedAt"`
+	CreatedAt     time.Time  `gorm:"column:createdAt"`
+	UpdatedAt     time.Time  `gorm:"column:updatedAt"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (e *EventM) TableName() string {
+	return "event_outbox"
+}
+
+// BeforeCreate 在创建数据库记录之前生成 eventID.
+func (e *EventM) BeforeCreate(tx *gorm.DB) error {
+	e.EventID = "event-" + id.GenShortID()
+
+	return nil
+}
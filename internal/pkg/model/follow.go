@@ -0,0 +1,37 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package model
+
+import "time"
+
+// FollowM 是数据库中 follow 记录 struct 格式的映射，表示用户 Username 关注了用户 Followee.
+type FollowM struct {
+	ID        int64     `gorm:"column:id;primary_key"`
+	Username  string    `gorm:"column:username;not null"`
+	Followee  string    `gorm:"column:followee;not null"`
+	CreatedAt time.Time `gorm:"column:createdAt"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (f *FollowM) TableName() string {
+	return "follow"
+}
+
+// TimelineM 是数据库中 timeline 记录 struct 格式的映射，表示博客 PostID 出现在用户 Username 的首页时间线中.
+// 博客设置发布时间时会被写入所有关注者的时间线（写扩散），读取时间线时只需要按 PublishAt 顺序扫描索引.
+// 博客的状态和可见性在读取时间线时过滤，因此博客被撤回或移入回收站后不需要修改时间线.
+type TimelineM struct {
+	ID        int64     `gorm:"column:id;primary_key"`
+	Username  string    `gorm:"column:username;not null"`
+	PostID    string    `gorm:"column:postID;not null"`
+	Author    string    `gorm:"column:author;not null"`
+	PublishAt time.Time `gorm:"column:publishAt;not null"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (t *TimelineM) TableName() string {
+	return "timeline"
+}
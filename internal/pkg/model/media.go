@@ -0,0 +1,59 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/pkg/util/id"
+)
+
+// MediaM 是数据库中 media 记录 struct 格式的映射，表示用户上传的一个媒体文件.
+// 文件内容保存在 BlobStore 中，BlobKey 和 ThumbnailKey 分别是原文件和缩略图的对象 key，没有缩略图时 ThumbnailKey 为空.
+// Width 和 Height 是图片的尺寸，无法解析尺寸时为 0.
+type MediaM struct {
+	ID            int64     `gorm:"column:id;primary_key"`
+	MediaID       string    `gorm:"column:mediaID;not null"`
+	Username      string    `gorm:"column:username;not null"`
+	Filename      string    `gorm:"column:filename;not null"`
+	ContentType   string    `gorm:"column:contentType;not null"`
+	Size          int64     `gorm:"column:size;not null"`
+	Width         int       `gorm:"column:width;not null"`
+	Height        int       `gorm:"column:height;not null"`
+	BlobKey       string    `gorm:"column:blobKey;not null"`
+	ThumbnailKey  string    `gorm:"column:thumbnailKey;not null"`
+	ThumbnailType string    `gorm:"column:thumbnailType;not null"`
+	ThumbnailSize int64     `gorm:"column:thumbnailSize;not null"`
+	CreatedAt     time.Time `gorm:"column:createdAt"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (m *MediaM) TableName() string {
+	return "media"
+}
+
+// BeforeCreate 在创建数据库记录之前生成 mediaID，调用者已经指定 mediaID 时保持不变.
+func (m *MediaM) BeforeCreate(tx *gorm.DB) error {
+	if m.MediaID == "" {
+		m.MediaID = "media-" + id.GenShortID()
+	}
+
+	return nil
+}
+
+// PostMediaM 是数据库中 post_media 记录 struct 格式的映射，表示 post 的内容中引用了 media.
+// 没有被任何 post 引用的 media 会被后台任务清理.
+type PostMediaM struct {
+	PostID  string `gorm:"column:postID;primary_key"`
+	MediaID string `gorm:"column:mediaID;primary_key"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (p *PostMediaM) TableName() string {
+	return "post_media"
+}
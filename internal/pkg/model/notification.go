@@ -0,0 +1,81 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/pkg/util/id"
+)
+
+// 通知的类型.
+const (
+	NotificationTypeMention = "mention" // 用户在已发布的博客中被 @ 提及
+	NotificationTypeFollow  = "follow"  // 用户被关注
+	NotificationTypeComment = "comment" // 用户的博客被评论
+	NotificationTypeReply   = "reply"   // 用户的评论被回复
+)
+
+// NotificationM 是数据库中 notification 记录 struct 格式的映射，表示发给用户 Username 的一条通知.
+// Actor 是引起通知的用户. Resource 是通知的对象，用于去重，同一用户对同一对象的同一类型的通知只有一条：
+// 提及通知是博客 ID，关注通知是关注者的用户名，评论和回复通知是评论 ID. ReadAt 为空表示通知未读.
+type NotificationM struct {
+	ID             int64      `gorm:"column:id;primary_key"`
+	NotificationID string     `gorm:"column:notificationID;not null"`
+	Username       string     `gorm:"column:username;not null"`
+	Type           string     `gorm:"column:type;not null"`
+	Actor          string     `gorm:"column:actor;not null"`
+	Resource       string     `gorm:"column:resource;not null"`
+	PostID         string     `gorm:"column:postID;not null"`
+	CommentID      string     `gorm:"column:commentID;not null"`
+	ReadAt         *time.Time `gorm:"column:readAt"`
+	CreatedAt      time.Time  `gorm:"column:createdAt"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (n *NotificationM) TableName() string {
+	return "notification"
+}
+
+// BeforeCreate 在创建数据库记录之前生成 notificationID.
+func (n *NotificationM) BeforeCreate(tx *gorm.DB) error {
+	n.NotificationID = "notification-" + id.GenShortID()
+
+	return nil
+}
+
+// NotificationPreferenceM 是数据库中 notification_preference 记录 struct 格式的映射，表示用户接收哪些类型的通知.
+// 没有记录的用户接收所有类型的通知. Comment 同时控制评论和回复通知.
+type NotificationPreferenceM struct {
+	ID        int64     `gorm:"column:id;primary_key"`
+	Username  string    `gorm:"column:username;not null"`
+	Mention   bool      `gorm:"column:mention;not null"`
+	Follow    bool      `gorm:"column:follow;not null"`
+	Comment   bool      `gorm:"column:comment;not null"`
+	CreatedAt time.Time `gorm:"column:createdAt"`
+	UpdatedAt time.Time `gorm:"column:updatedAt"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (p *NotificationPreferenceM) TableName() string {
+	return "notification_preference"
+}
+
+// Allows 返回用户是否接收 typ 类型的通知.
+func (p *NotificationPreferenceM) Allows(typ string) bool {
+	switch typ {
+	case NotificationTypeMention:
+		return p.Mention
+	case NotificationTypeFollow:
+		return p.Follow
+	case NotificationTypeComment, NotificationTypeReply:
+		return p.Comment
+	}
+
+	return false
+}
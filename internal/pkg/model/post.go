@@ -13,15 +13,57 @@ import (
 	"github.com/marmotedu/miniblog/pkg/util/id"
 )
 
+// 博客的发布状态.
+const (
+	PostStatusDraft     = "draft"     // 草稿
+	PostStatusScheduled = "scheduled" // 定时发布，到达 PublishAt 后由后台任务发布
+	PostStatusPublished = "published" // 已发布
+	PostStatusArchived  = "archived"  // 已归档
+)
+
+// 博客的可见性，只对已发布的博客生效.
+const (
+	PostVisibilityPrivate  = "private"  // 仅作者可见
+	PostVisibilityUnlisted = "unlisted" // 知道链接的人可见，不出现在公开列表中
+	PostVisibilityPublic   = "public"   // 所有人可见
+)
+
+// 博客的评论设置.
+const (
+	PostCommentOpen      = "open"      // 允许评论，评论直接公开
+	PostCommentModerated = "moderated" // 允许评论，评论需要作者审核后才公开
+	PostCommentClosed    = "closed"    // 不允许评论
+)
+
+// 博客内容的格式.
+const (
+	PostFormatPlain    = "plain"    // 纯文本
+	PostFormatMarkdown = "markdown" // Markdown
+	PostFormatHTML     = "html"     // HTML，渲染时按白名单过滤
+)
+
 // PostM 是数据库中 post 记录 struct 格式的映射.
+// PublishAt 对定时发布的博客是计划发布时间，对已发布和已归档的博客是发布时间，对草稿为空.
+// CategoryID 为 0 表示博客不属于任何分类.
+// Slug 是博客在作者的所有博客中唯一的可读标识，用于生成博客的永久链接.
+// ContentHTML 缓存了按 ContentFormat 渲染 Content 得到的 HTML，在 Content 或 ContentFormat 修改时更新.
 type PostM struct {
-	ID        int64     `gorm:"column:id;primary_key"`
-	Username  string    `gorm:"column:username;not null"`
-	PostID    string    `gorm:"column:postID;not null"`
-	Title     string    `gorm:"column:title;not null"`
-	Content   string    `gorm:"column:content"`
-	CreatedAt time.Time `gorm:"column:createdAt"`
-	UpdatedAt time.Time `gorm:"column:updatedAt"`
+	ID            int64          `gorm:"column:id;primary_key"`
+	Username      string         `gorm:"column:username;not null"`
+	PostID        string         `gorm:"column:postID;not null"`
+	Slug          string         `gorm:"column:slug;not null"`
+	Title         string         `gorm:"column:title;not null"`
+	Content       string         `gorm:"column:content"`
+	ContentFormat string         `gorm:"column:contentFormat;not null"`
+	ContentHTML   string         `gorm:"column:contentHTML"`
+	CategoryID    int64          `gorm:"column:categoryID;not null"`
+	Status        string         `gorm:"column:status;not null"`
+	Visibility    string         `gorm:"column:visibility;not null"`
+	PublishAt     *time.Time     `gorm:"column:publishAt"`
+	CommentPolicy string         `gorm:"column:commentPolicy;not null"`
+	CreatedAt     time.Time      `gorm:"column:createdAt"`
+	UpdatedAt     time.Time      `gorm:"column:updatedAt"`
+	DeletedAt     gorm.DeletedAt `gorm:"column:deletedAt;index"`
 }
 
 // TableName 用来指定映射的 MySQL 表名.
@@ -29,9 +71,26 @@ func (p *PostM) TableName() string {
 	return "post"
 }
 
-// BeforeCreate 在创建数据库记录之前生成 postID.
+// BeforeCreate 在创建数据库记录之前生成 postID，调用者已经指定 postID 时（例如导入博客）保持不变.
 func (p *PostM) BeforeCreate(tx *gorm.DB) error {
-	p.PostID = "post-" + id.GenShortID()
+	if p.PostID == "" {
+		p.PostID = "post-" + id.GenShortID()
+	}
 
 	return nil
 }
+
+// PostSlugM 是数据库中 post_slug 记录 struct 格式的映射，保存博客修改前使用过的 slug，
+// 通过旧的 slug 访问博客时会被重定向到博客当前的 slug.
+type PostSlugM struct {
+	ID        int64     `gorm:"column:id;primary_key"`
+	Username  string    `gorm:"column:username;not null"`
+	Slug      string    `gorm:"column:slug;not null"`
+	PostID    string    `gorm:"column:postID;not null"`
+	CreatedAt time.Time `gorm:"column:createdAt"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (s *PostSlugM) TableName() string {
+	return "post_slug"
+}
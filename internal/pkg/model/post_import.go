@@ -0,0 +1,56 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/pkg/util/id"
+)
+
+// 博客导入任务的状态.
+const (
+	ImportStatusRunning   = "running"   // 正在导入
+	ImportStatusSucceeded = "succeeded" // 所有项都已处理，其中可能有导入失败的项
+	ImportStatusFailed    = "failed"    // 导入任务异常终止，例如执行任务的服务在导入过程中退出
+)
+
+// PostImportM 是数据库中 post_import 记录 struct 格式的映射，表示一次异步执行的博客导入任务.
+// Total 是归档中的博客数，Processed 是已处理的博客数，等于 Created、Skipped 和 Failed 之和.
+// Items 是 JSON 格式的每一项的导入结果，在任务结束时保存.
+// LeaseExpiresAt 是执行任务的服务进程持有任务的租约的到期时间，进程在执行期间定期续约，租约过期的任务已经被中断.
+type PostImportM struct {
+	ID        int64     `gorm:"column:id;primary_key"`
+	ImportID  string    `gorm:"column:importID;not null"`
+	Username  string    `gorm:"column:username;not null"`
+	Format    string    `gorm:"column:format;not null"`
+	Status    string    `gorm:"column:status;not null"`
+	Total     int       `gorm:"column:total;not null"`
+	Processed int       `gorm:"column:processed;not null"`
+	Created   int       `gorm:"column:created;not null"`
+	Skipped   int       `gorm:"column:skipped;not null"`
+	Failed    int       `gorm:"column:failed;not null"`
+	Items     string    `gorm:"column:items"`
+	Error     string    `gorm:"column:error;not null"`
+	CreatedAt time.Time `gorm:"column:createdAt"`
+	UpdatedAt time.Time `gorm:"column:updatedAt"`
+
+	LeaseExpiresAt time.Time `gorm:"column:leaseExpiresAt;not null"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (p *PostImportM) TableName() string {
+	return "post_import"
+}
+
+// BeforeCreate 在创建数据库记录之前生成 importID.
+func (p *PostImportM) BeforeCreate(tx *gorm.DB) error {
+	p.ImportID = "import-" + id.GenShortID()
+
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package model
+
+import "time"
+
+// 可以添加 reaction 的对象类型.
+const (
+	ReactionTargetPost    = "post"
+	ReactionTargetComment = "comment"
+)
+
+// ReactionTypes 是所有支持的 reaction 类型.
+var ReactionTypes = []string{"like", "love", "laugh", "wow", "sad", "angry"}
+
+// ReactionM 是数据库中 reaction 记录 struct 格式的映射. 每个用户对同一对象的每种 reaction 只能添加一次.
+// TargetID 为 postID 或 commentID.
+type ReactionM struct {
+	ID         int64     `gorm:"column:id;primary_key"`
+	Username   string    `gorm:"column:username;not null"`
+	TargetType string    `gorm:"column:targetType;not null"`
+	TargetID   string    `gorm:"column:targetID;not null"`
+	Type       string    `gorm:"column:type;not null"`
+	CreatedAt  time.Time `gorm:"column:createdAt"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (r *ReactionM) TableName() string {
+	return "reaction"
+}
+
+// ReactionCountM 是数据库中 reaction_count 记录 struct 格式的映射，记录了对象每种 reaction 的数量，
+// 在添加和删除 reaction 时同步更新，避免每次请求时重新统计.
+type ReactionCountM struct {
+	TargetID string `gorm:"column:targetID;primary_key"`
+	Type     string `gorm:"column:type;primary_key"`
+	Count    int64  `gorm:"column:count;not null"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (r *ReactionCountM) TableName() string {
+	return "reaction_count"
+}
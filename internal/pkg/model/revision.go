@@ -0,0 +1,26 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package model
+
+import "time"
+
+// PostRevisionM 是数据库中 post_revision 记录 struct 格式的映射，保存博客 PostID 的一个历史版本.
+// Revision 是博客内从 1 开始递增的版本号，Username 是创建该版本的用户.
+type PostRevisionM struct {
+	ID            int64     `gorm:"column:id;primary_key"`
+	PostID        string    `gorm:"column:postID;not null"`
+	Revision      int64     `gorm:"column:revision;not null"`
+	Username      string    `gorm:"column:username;not null"`
+	Title         string    `gorm:"column:title;not null"`
+	Content       string    `gorm:"column:content"`
+	ContentFormat string    `gorm:"column:contentFormat;not null"`
+	CreatedAt     time.Time `gorm:"column:createdAt"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (r *PostRevisionM) TableName() string {
+	return "post_revision"
+}
@@ -0,0 +1,32 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package model
+
+import "time"
+
+// TagM 是数据库中 tag 记录 struct 格式的映射.
+type TagM struct {
+	ID        int64     `gorm:"column:id;primary_key"`
+	Name      string    `gorm:"column:name;not null"`
+	CreatedAt time.Time `gorm:"column:createdAt"`
+	UpdatedAt time.Time `gorm:"column:updatedAt"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (t *TagM) TableName() string {
+	return "tag"
+}
+
+// PostTagM 是数据库中 post_tag 记录 struct 格式的映射，表示 post 和 tag 的多对多关系.
+type PostTagM struct {
+	PostID string `gorm:"column:postID;primary_key"`
+	TagID  int64  `gorm:"column:tagID;primary_key"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (p *PostTagM) TableName() string {
+	return "post_tag"
+}
@@ -13,16 +13,38 @@ import (
 	"github.com/marmotedu/miniblog/pkg/auth"
 )
 
+// 用户账号的状态.
+const (
+	UserStateActive    = "active"    // 正常
+	UserStateSuspended = "suspended" // 已被管理员停用，到达 StateExpiresAt 后自动恢复，StateExpiresAt 为空表示永久停用
+	UserStatePending   = "pending"   // 密码已被管理员重置，需要用户使用临时密码修改密码后才能使用
+)
+
 // UserM 是数据库中 user 记录 struct 格式的映射.
+// Avatar 是头像文件名，为空表示没有头像. Links 是换行分隔的个人网站链接.
+// ShowEmail、ShowPhone 和 ShowTimezone 表示是否在公开资料中显示对应的字段，默认不显示.
+// StateReason 是管理员修改账号状态的原因. TokensRevokedAt 之前签发的 token 不再有效.
 type UserM struct {
-	ID        int64     `gorm:"column:id;primary_key"`
-	Username  string    `gorm:"column:username;not null"`
-	Password  string    `gorm:"column:password;not null"`
-	Nickname  string    `gorm:"column:nickname"`
-	Email     string    `gorm:"column:email"`
-	Phone     string    `gorm:"column:phone"`
-	CreatedAt time.Time `gorm:"column:createdAt"`
-	UpdatedAt time.Time `gorm:"column:updatedAt"`
+	ID              int64      `gorm:"column:id;primary_key"`
+	Username        string     `gorm:"column:username;not null"`
+	Password        string     `gorm:"column:password;not null"`
+	Nickname        string     `gorm:"column:nickname"`
+	Email           string     `gorm:"column:email"`
+	Phone           string     `gorm:"column:phone"`
+	Bio             string     `gorm:"column:bio"`
+	Avatar          string     `gorm:"column:avatar"`
+	Links           string     `gorm:"column:links"`
+	Timezone        string     `gorm:"column:timezone"`
+	Locale          string     `gorm:"column:locale"`
+	ShowEmail       bool       `gorm:"column:showEmail"`
+	ShowPhone       bool       `gorm:"column:showPhone"`
+	ShowTimezone    bool       `gorm:"column:showTimezone"`
+	State           string     `gorm:"column:state"`
+	StateReason     string     `gorm:"column:stateReason"`
+	StateExpiresAt  *time.Time `gorm:"column:stateExpiresAt"`
+	TokensRevokedAt *time.Time `gorm:"column:tokensRevokedAt"`
+	CreatedAt       time.Time  `gorm:"column:createdAt"`
+	UpdatedAt       time.Time  `gorm:"column:updatedAt"`
 }
 
 // TableName 用来指定映射的 MySQL 表名.
@@ -30,8 +52,26 @@ func (u *UserM) TableName() string {
 	return "user"
 }
 
+// EffectiveState 返回用户账号在 now 时刻的状态，已经到期的停用视为正常.
+func (u *UserM) EffectiveState(now time.Time) string {
+	switch u.State {
+	case "":
+		return UserStateActive
+	case UserStateSuspended:
+		if u.StateExpiresAt != nil && !now.Before(*u.StateExpiresAt) {
+			return UserStateActive
+		}
+	}
+
+	return u.State
+}
+
 // BeforeCreate 在创建数据库记录之前加密明文密码.
 func (u *UserM) BeforeCreate(tx *gorm.DB) (err error) {
+	if u.State == "" {
+		u.State = UserStateActive
+	}
+
 	// Encrypt the user password.
 	u.Password, err = auth.Encrypt(u.Password)
 	if err != nil {
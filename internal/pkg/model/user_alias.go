@@ -0,0 +1,23 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package model
+
+import "time"
+
+// UserAliasM 是数据库中 user_alias 记录 struct 格式的映射，表示用户 Username 曾经使用过用户名 Alias.
+// 在 ExpiresAt 之前，Alias 只能被用户 Username 重新使用，访问 Alias 的公开接口会被重定向到 Username.
+type UserAliasM struct {
+	ID        int64     `gorm:"column:id;primary_key"`
+	Alias     string    `gorm:"column:alias;not null"`
+	Username  string    `gorm:"column:username;not null"`
+	ExpiresAt time.Time `gorm:"column:expiresAt"`
+	CreatedAt time.Time `gorm:"column:createdAt"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (a *UserAliasM) TableName() string {
+	return "user_alias"
+}
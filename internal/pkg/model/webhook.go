@@ -0,0 +1,87 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/marmotedu/miniblog/pkg/util/id"
+)
+
+// webhook 投递记录的状态.
+const (
+	DeliveryStatusPending   = "pending"   // 等待投递或等待重试
+	DeliveryStatusSucceeded = "succeeded" // 投递成功
+	DeliveryStatusFailed    = "failed"    // 重试次数用尽或 webhook 已被停用
+)
+
+// WebhookM 是数据库中 webhook 记录 struct 格式的映射，表示用户订阅的一个 webhook.
+// Events 是逗号分隔的订阅事件列表. Secret 用于对投递的请求体签名.
+// ConsecutiveFailures 是连续投递失败的次数，投递成功后清零，达到阈值后 webhook 被自动停用，
+// 此时 Active 为 false，DisabledReason 为停用原因.
+type WebhookM struct {
+	ID                  int64      `gorm:"column:id;primary_key"`
+	WebhookID           string     `gorm:"column:webhookID;not null"`
+	Username            string     `gorm:"column:username;not null"`
+	URL                 string     `gorm:"column:url;not null"`
+	Secret              string     `gorm:"column:secret;not null"`
+	Events              string     `gorm:"column:events;not null"`
+	Active              bool       `gorm:"column:active;not null"`
+	DisabledReason      string     `gorm:"column:disabledReason;not null"`
+	ConsecutiveFailures int        `gorm:"column:consecutiveFailures;not null"`
+	LastDeliveryAt      *time.Time `gorm:"column:lastDeliveryAt"`
+	CreatedAt           time.Time  `gorm:"column:createdAt"`
+	UpdatedAt           time.Time  `gorm:"column:updatedAt"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (w *WebhookM) TableName() string {
+	return "webhook"
+}
+
+// BeforeCreate 在创建数据库记录之前生成 webhookID.
+func (w *WebhookM) BeforeCreate(tx *gorm.DB) error {
+	w.WebhookID = "webhook-" + id.GenShortID()
+
+	return nil
+}
+
+// WebhookDeliveryM 是数据库中 webhook_delivery 记录 struct 格式的映射，表示一个事件到一个 webhook 的投递.
+// 等待投递的记录同时是持久化的投递队列，后台任务投递 NextAttemptAt 不晚于当前时间的记录.
+// Payload 是 JSON 格式的请求体，StatusCode、Response 和 Error 是最近一次投递的结果.
+type WebhookDeliveryM struct {
+	ID            int64      `gorm:"column:id;primary_key"`
+	DeliveryID    string     `gorm:"column:deliveryID;not null"`
+	WebhookID     string     `gorm:"column:webhookID;not null"`
+	Username      string     `gorm:"column:username;not null"`
+	Event         string     `gorm:"column:event;not null"`
+	Payload       string     `gorm:"column:payload;not null"`
+	Status        string     `gorm:"column:status;not null"`
+	Attempts      int        `gorm:"column:attempts;not null"`
+	NextAttemptAt time.Time  `gorm:"column:nextAttemptAt"`
+	StatusCode    int        `gorm:"column:statusCode;not null"`
+	Response      string     `gorm:"column:response;not null"`
+	Error         string     `gorm:"column:error;not null"`
+	DeliveredAt   *time.Time `gorm:"column:deliveredAt"`
+	CreatedAt     time.Time  `gorm:"column:createdAt"`
+	UpdatedAt     time.Time  `gorm:"column:updatedAt"`
+}
+
+// TableName 用来指定映射的 MySQL 表名.
+func (d *WebhookDeliveryM) TableName() string {
+	return "webhook_delivery"
+}
+
+// BeforeCreate 在创建数据库记录之前生成 deliveryID，调用者已经指定 deliveryID 时保持不变.
+func (d *WebhookDeliveryM) BeforeCreate(tx *gorm.DB) error {
+	if d.DeliveryID == "" {
+		d.DeliveryID = "delivery-" + id.GenShortID()
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package v1
+
+// CreateCategoryRequest 指定了 `POST /v1/categories` 接口的请求参数.
+// ParentID 为 0 表示创建顶级分类.
+type CreateCategoryRequest struct {
+	Name     string `json:"name" valid:"required,stringlength(1|64)"`
+	ParentID int64  `json:"parentID"`
+}
+
+// CreateCategoryResponse 指定了 `POST /v1/categories` 接口的返回参数.
+type CreateCategoryResponse struct {
+	ID int64 `json:"id"`
+}
+
+// CategoryInfo 指定了分类的详细信息，Children 为其子分类.
+type CategoryInfo struct {
+	ID       int64           `json:"id"`
+	Name     string          `json:"name"`
+	ParentID int64           `json:"parentID"`
+	Children []*CategoryInfo `json:"children,omitempty"`
+}
+
+// ListCategoryResponse 指定了 `GET /v1/categories` 接口的返回参数，Categories 为顶级分类组成的分类树.
+type ListCategoryResponse struct {
+	Categories []*CategoryInfo `json:"categories"`
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package v1
+
+// CreateCommentRequest 指定了 `POST /v1/posts/{postID}/comments` 接口的请求参数.
+// ParentID 为被回复的评论的 commentID，为空表示发表新的楼层.
+type CreateCommentRequest struct {
+	Content  string `json:"content" valid:"required,stringlength(1|2048)"`
+	ParentID string `json:"parentID"`
+}
+
+// CreateCommentResponse 指定了 `POST /v1/posts/{postID}/comments` 接口的返回参数.
+// 博客开启评论审核时，Status 为 pending，评论在博客作者审核通过后才会公开.
+type CreateCommentResponse struct {
+	CommentID string `json:"commentID"`
+	Status    string `json:"status"`
+}
+
+// UpdateCommentRequest 指定了 `PUT /v1/posts/{postID}/comments/{commentID}` 接口的请求参数.
+type UpdateCommentRequest struct {
+	Content string `json:"content" valid:"required,stringlength(1|2048)"`
+}
+
+// CommentInfo 指定了评论的详细信息，Reactions 为每种 reaction 的数量.
+// 楼层的第一条评论的 Replies 为该楼层中的所有回复，按发表时间从早到晚排列.
+type CommentInfo struct {
+	CommentID string           `json:"commentID"`
+	Username  string           `json:"username"`
+	ParentID  string           `json:"parentID,omitempty"`
+	Content   string           `json:"content"`
+	Status    string           `json:"status"`
+	CreatedAt string           `json:"createdAt"`
+	UpdatedAt string           `json:"updatedAt"`
+	Reactions map[string]int64 `json:"reactions,omitempty"`
+	Replies   []*CommentInfo   `json:"replies,omitempty"`
+}
+
+// ListCommentRequest 指定了 `GET /v1/posts/{postID}/comments` 接口的请求参数，分页的单位为楼层.
+// Status 只对博客作者生效，用于列出待审核或已隐藏的评论.
+type ListCommentRequest struct {
+	Offset         int    `form:"offset"`
+	Limit          int    `form:"limit"`
+	PageToken      string `form:"pageToken"`
+	SkipTotalCount bool   `form:"skipTotalCount"`
+	Status         string `form:"status" valid:"in(approved|pending|hidden)"`
+}
+
+// ListCommentResponse 指定了 `GET /v1/posts/{postID}/comments` 接口的返回参数，TotalCount 为楼层数.
+type ListCommentResponse struct {
+	TotalCount    int64          `json:"totalCount"`
+	Comments      []*CommentInfo `json:"comments"`
+	NextPageToken string         `json:"nextPageToken,omitempty"`
+}
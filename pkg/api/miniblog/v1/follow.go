@@ -0,0 +1,29 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package v1
+
+// ListFollowRequest 指定了 `GET /v1/users/{name}/followers` 和 `GET /v1/users/{name}/following` 接口的请求参数.
+// 指定 PageToken 时使用游标分页，此时忽略 Offset.
+type ListFollowRequest struct {
+	Offset         int    `form:"offset"`
+	Limit          int    `form:"limit"`
+	PageToken      string `form:"pageToken"`
+	SkipTotalCount bool   `form:"skipTotalCount"`
+}
+
+// ListFollowResponse 指定了 `GET /v1/users/{name}/followers` 和 `GET /v1/users/{name}/following` 接口的返回参数，
+// Users 按关注时间从新到旧排列. NextPageToken 为空表示没有下一页.
+type ListFollowResponse struct {
+	TotalCount    int64         `json:"totalCount"`
+	Users         []*FollowInfo `json:"users"`
+	NextPageToken string        `json:"nextPageToken,omitempty"`
+}
+
+// FollowInfo 指定了关注关系中另一方的用户名以及关注时间.
+type FollowInfo struct {
+	Username   string `json:"username"`
+	FollowedAt string `json:"followedAt"`
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package v1
+
+// ExportPostRequest 指定了 `GET /v1/posts:export` 接口的请求参数.
+// Format 为 markdown 时返回 zip 压缩包，每篇博客是一个带有 YAML front matter 的 Markdown 文件；
+// 为 jsonl 时返回 JSON Lines，每行是一篇博客. 默认为 markdown.
+type ExportPostRequest struct {
+	Format string `form:"format" valid:"in(markdown|jsonl)"`
+}
+
+// ImportPostRequest 指定了 `POST /v1/posts:import` 接口的请求参数，归档文件通过 multipart/form-data 请求的 file 字段上传.
+// Format 除 ExportPostRequest 中的格式外，还可以是 wxr（WordPress 导出的 XML 文件）或 hugo（Hugo 或 Jekyll 内容目录的 zip 压缩包），
+// 为空时根据文件内容自动识别，hugo 格式需要显式指定. 通过该接口导入时，归档中博客的作者被忽略，所有博客都属于当前用户.
+type ImportPostRequest struct {
+	Format string `form:"format" valid:"in(markdown|jsonl|wxr|hugo)"`
+}
+
+// ImportPostResponse 指定了 `POST /v1/posts:import` 接口的返回参数. 导入在后台执行，
+// 可以通过 `GET /v1/imports/{importID}` 查询导入进度.
+type ImportPostResponse struct {
+	ImportID string `json:"importID"`
+}
+
+// GetImportResponse 指定了 `GET /v1/imports/{importID}` 接口的返回参数.
+type GetImportResponse ImportInfo
+
+// ImportInfo 指定了博客导入任务的进度和结果.
+// Status 为 running、succeeded 或 failed，succeeded 表示所有项都已处理，其中可能有导入失败的项.
+// Processed 是已处理的项数，Created、Skipped 和 Failed 分别是导入成功、因为博客已经存在而跳过和导入失败的项数.
+// Items 是每一项的导入结果，在导入任务结束后返回. Error 是导入任务失败的原因.
+type ImportInfo struct {
+	ImportID  string        `json:"importID"`
+	Format    string        `json:"format"`
+	Status    string        `json:"status"`
+	Total     int           `json:"total"`
+	Processed int           `json:"processed"`
+	Created   int           `json:"created"`
+	Skipped   int           `json:"skipped"`
+	Failed    int           `json:"failed"`
+	Items     []*ImportItem `json:"items,omitempty"`
+	Error     string        `json:"error,omitempty"`
+	CreatedAt string        `json:"createdAt"`
+	UpdatedAt string        `json:"updatedAt"`
+}
+
+// ImportItem 指定了归档中一项的导入结果. Name 是 zip 中的文件名或 `line N` 形式的行号.
+// Result 为 created、skipped 或 failed；PostID 是创建的博客或已经存在的博客的 ID；Message 是跳过或失败的原因.
+type ImportItem struct {
+	Name    string `json:"name"`
+	Result  string `json:"result"`
+	PostID  string `json:"postID,omitempty"`
+	Message string `json:"message,omitempty"`
+}
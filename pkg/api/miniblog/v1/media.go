@@ -0,0 +1,36 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package v1
+
+// MediaInfo 指定了媒体文件的详细信息，也是 `POST /v1/media` 接口的返回参数.
+// URL 和 ThumbnailURL 是公开访问媒体文件和缩略图的地址，在博客内容中引用 URL 的媒体文件不会被清理.
+// 图片尺寸较小、不需要缩略图时 ThumbnailURL 与 URL 相同.
+type MediaInfo struct {
+	MediaID      string `json:"mediaID"`
+	Filename     string `json:"filename"`
+	ContentType  string `json:"contentType"`
+	Size         int64  `json:"size"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnailURL"`
+	CreatedAt    string `json:"createdAt"`
+}
+
+// ListMediaRequest 指定了 `GET /v1/media` 接口的请求参数.
+type ListMediaRequest struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+// ListMediaResponse 指定了 `GET /v1/media` 接口的返回参数，Media 按上传时间从新到旧排列.
+// UsedBytes 是用户所有媒体文件（包括缩略图）占用的空间，QuotaBytes 是允许占用的最大空间.
+type ListMediaResponse struct {
+	TotalCount int64        `json:"totalCount"`
+	UsedBytes  int64        `json:"usedBytes"`
+	QuotaBytes int64        `json:"quotaBytes"`
+	Media      []*MediaInfo `json:"media"`
+}
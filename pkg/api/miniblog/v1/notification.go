@@ -0,0 +1,57 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package v1
+
+// ListNotificationRequest 指定了 `GET /v1/notifications` 接口的请求参数，Unread 为 true 时只列出未读的通知.
+type ListNotificationRequest struct {
+	Offset int  `form:"offset"`
+	Limit  int  `form:"limit"`
+	Unread bool `form:"unread"`
+}
+
+// ListNotificationResponse 指定了 `GET /v1/notifications` 接口的返回参数，通知按时间从新到旧排列.
+// TotalCount 是符合条件的通知数，UnreadCount 是用户所有未读的通知数.
+type ListNotificationResponse struct {
+	TotalCount    int64               `json:"totalCount"`
+	UnreadCount   int64               `json:"unreadCount"`
+	Notifications []*NotificationInfo `json:"notifications"`
+}
+
+// NotificationInfo 指定了通知的详细信息.
+// Type 为 mention、follow、comment 或 reply，Actor 是引起通知的用户.
+// 提及、评论和回复通知的 PostID 是相关的博客，评论和回复通知的 CommentID 是新的评论.
+type NotificationInfo struct {
+	NotificationID string `json:"notificationID"`
+	Type           string `json:"type"`
+	Actor          string `json:"actor"`
+	PostID         string `json:"postID,omitempty"`
+	CommentID      string `json:"commentID,omitempty"`
+	Read           bool   `json:"read"`
+	ReadAt         string `json:"readAt,omitempty"`
+	CreatedAt      string `json:"createdAt"`
+}
+
+// ReadAllNotificationResponse 指定了 `POST /v1/notifications:readAll` 接口的返回参数，Count 是被标记为已读的通知数.
+type ReadAllNotificationResponse struct {
+	Count int64 `json:"count"`
+}
+
+// NotificationPreferences 指定了用户接收哪些类型的通知，Comment 同时控制评论和回复通知.
+type NotificationPreferences struct {
+	Mention bool `json:"mention"`
+	Follow  bool `json:"follow"`
+	Comment bool `json:"comment"`
+}
+
+// GetNotificationPreferencesResponse 指定了 `GET /v1/notifications/preferences` 接口的返回参数.
+type GetNotificationPreferencesResponse NotificationPreferences
+
+// UpdateNotificationPreferencesRequest 指定了 `PUT /v1/notifications/preferences` 接口的请求参数，为空的字段保持不变.
+type UpdateNotificationPreferencesRequest struct {
+	Mention *bool `json:"mention"`
+	Follow  *bool `json:"follow"`
+	Comment *bool `json:"comment"`
+}
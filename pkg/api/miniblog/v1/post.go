@@ -5,10 +5,25 @@
 
 package v1
 
+import "time"
+
 // CreatePostRequest 指定了 `POST /v1/posts` 接口的请求参数.
+// Status 默认为 draft，Visibility 默认为 public. Status 为 scheduled 时必须指定 PublishAt，
+// 格式为 `2006-01-02 15:04:05`，到达该时间后博客会被自动发布.
+// Tags 中的 tag 会被转换为小写，不存在的 tag 会被自动创建；CategoryID 为 0 表示不属于任何分类.
+// CommentPolicy 默认为 open. ContentFormat 指定 Content 的格式，默认为 plain.
+// Slug 为空时根据标题自动生成，与作者的其它博客重复时自动添加数字后缀；指定 Slug 时不能与作者的其它博客重复.
 type CreatePostRequest struct {
-	Title   string `json:"title" valid:"required,stringlength(1|256)"`
-	Content string `json:"content" valid:"required,stringlength(1|10240)"`
+	Title         string   `json:"title" valid:"required,stringlength(1|256)"`
+	Slug          string   `json:"slug"`
+	Content       string   `json:"content" valid:"required,stringlength(1|10240)"`
+	ContentFormat string   `json:"contentFormat" valid:"in(plain|markdown|html)"`
+	Status        string   `json:"status" valid:"in(draft|scheduled|published)"`
+	Visibility    string   `json:"visibility" valid:"in(private|unlisted|public)"`
+	PublishAt     string   `json:"publishAt"`
+	Tags          []string `json:"tags"`
+	CategoryID    int64    `json:"categoryID"`
+	CommentPolicy string   `json:"commentPolicy" valid:"in(open|closed|moderated)"`
 }
 
 // CreatePostResponse 指定了 `POST /v1/posts` 接口的返回参数.
@@ -16,33 +31,131 @@ type CreatePostResponse struct {
 	PostID string `json:"postID"`
 }
 
+// GetPostRequest 指定了 `GET /v1/posts/{postID}` 接口的请求参数.
+// ContentView 指定返回的内容形式：raw 只返回原始内容，rendered 只返回渲染后的 HTML，both 同时返回两者，默认为 raw.
+type GetPostRequest struct {
+	ContentView string `form:"contentView"`
+}
+
 // GetPostResponse 指定了 `GET /v1/posts/{postID}` 接口的返回参数.
 type GetPostResponse PostInfo
 
 // UpdatePostRequest 指定了 `PUT /v1/posts` 接口的请求参数.
+// 修改 PublishAt 时 Status 必须为（或被修改为）scheduled. 指定 Tags 时替换博客原有的所有 tag.
+// 未发布过的博客修改标题时会重新生成 Slug. 已发布过的博客修改 Slug 后，旧的 Slug 会被重定向到新的 Slug.
 type UpdatePostRequest struct {
-	Title   *string `json:"title" valid:"stringlength(1|256)"`
-	Content *string `json:"content" valid:"stringlength(1|10240)"`
+	Title         *string   `json:"title" valid:"stringlength(1|256)"`
+	Slug          *string   `json:"slug"`
+	Content       *string   `json:"content" valid:"stringlength(1|10240)"`
+	ContentFormat *string   `json:"contentFormat" valid:"in(plain|markdown|html)"`
+	Status        *string   `json:"status" valid:"in(draft|scheduled|published|archived)"`
+	Visibility    *string   `json:"visibility" valid:"in(private|unlisted|public)"`
+	PublishAt     *string   `json:"publishAt"`
+	Tags          *[]string `json:"tags"`
+	CategoryID    *int64    `json:"categoryID"`
+	CommentPolicy *string   `json:"commentPolicy" valid:"in(open|closed|moderated)"`
 }
 
-// PostInfo 指定了博客的详细信息.
+// PostInfo 指定了博客的详细信息，CommentCount 为已公开的评论数，Reactions 为每种 reaction 的数量.
+// ContentHTML 为渲染后经过安全过滤的 HTML，可以直接嵌入页面.
 type PostInfo struct {
-	Username  string `json:"username,omitempty"`
-	PostID    string `json:"postID,omitempty"`
-	Title     string `json:"title"`
-	Content   string `json:"content"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"updatedAt"`
+	Username      string           `json:"username,omitempty"`
+	PostID        string           `json:"postID,omitempty"`
+	Slug          string           `json:"slug,omitempty"`
+	Title         string           `json:"title,omitempty"`
+	Content       string           `json:"content,omitempty"`
+	ContentFormat string           `json:"contentFormat,omitempty"`
+	ContentHTML   string           `json:"contentHTML,omitempty"`
+	Status        string           `json:"status,omitempty"`
+	Visibility    string           `json:"visibility,omitempty"`
+	PublishAt     string           `json:"publishAt,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	CategoryID    int64            `json:"categoryID,omitempty"`
+	CommentPolicy string           `json:"commentPolicy,omitempty"`
+	CommentCount  int64            `json:"commentCount,omitempty"`
+	Reactions     map[string]int64 `json:"reactions,omitempty"`
+	CreatedAt     string           `json:"createdAt,omitempty"`
+	UpdatedAt     string           `json:"updatedAt,omitempty"`
+	DeletedAt     string           `json:"deletedAt,omitempty"`
 }
 
 // ListPostRequest 指定了 `GET /v1/posts` 接口的请求参数.
+// 指定 PageToken 时使用游标分页，此时忽略 Offset. 翻页时其它参数需要与获取 PageToken 时保持一致.
 type ListPostRequest struct {
-	Offset int `form:"offset"`
-	Limit  int `form:"limit"`
+	Offset         int    `form:"offset"`
+	Limit          int    `form:"limit"`
+	PageToken      string `form:"pageToken"`
+	SkipTotalCount bool   `form:"skipTotalCount"`
+
+	// Title 和 Content 分别过滤标题和内容中包含指定子串的博客.
+	Title   string `form:"title"`
+	Content string `form:"content"`
+
+	// 按创建时间和更新时间过滤博客，范围为 [After, Before)，格式与 PostInfo 中的时间相同.
+	CreatedAfter  time.Time `form:"createdAfter" time_format:"2006-01-02 15:04:05"`
+	CreatedBefore time.Time `form:"createdBefore" time_format:"2006-01-02 15:04:05"`
+	UpdatedAfter  time.Time `form:"updatedAfter" time_format:"2006-01-02 15:04:05"`
+	UpdatedBefore time.Time `form:"updatedBefore" time_format:"2006-01-02 15:04:05"`
+
+	// Status 和 Visibility 分别过滤指定发布状态和可见性的博客.
+	Status     string `form:"status"`
+	Visibility string `form:"visibility"`
+
+	// Tag 过滤使用指定 tag 的博客，CategoryID 过滤属于指定分类及其子分类的博客.
+	Tag        string `form:"tag"`
+	CategoryID int64  `form:"categoryID"`
+
+	// SortBy 指定排序字段，默认为 createdAt；Order 指定排序方向，默认为 desc.
+	SortBy string `form:"sortBy" valid:"in(createdAt|updatedAt|title)"`
+	Order  string `form:"order" valid:"in(asc|desc)"`
+
+	// Fields 指定返回的博客字段，多个字段使用逗号分隔，例如 `postID,title,createdAt`，为空时返回所有字段.
+	Fields string `form:"fields"`
+
+	// ContentView 与 GetPostRequest 中的含义相同，指定 Fields 时被忽略.
+	ContentView string `form:"contentView"`
 }
 
 // ListPostResponse 指定了 `GET /v1/posts` 接口的返回参数.
+// NextPageToken 为空表示没有下一页.
 type ListPostResponse struct {
+	TotalCount    int64       `json:"totalCount"`
+	Posts         []*PostInfo `json:"posts"`
+	NextPageToken string      `json:"nextPageToken,omitempty"`
+}
+
+// ListTrashRequest 指定了 `GET /v1/trash` 接口的请求参数.
+type ListTrashRequest struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+// ListTrashResponse 指定了 `GET /v1/trash` 接口的返回参数.
+type ListTrashResponse struct {
 	TotalCount int64       `json:"totalCount"`
 	Posts      []*PostInfo `json:"posts"`
 }
+
+// SearchPostRequest 指定了 `GET /v1/posts:search` 接口的请求参数.
+type SearchPostRequest struct {
+	Query  string `form:"q" valid:"required,stringlength(1|256)"`
+	Offset int    `form:"offset"`
+	Limit  int    `form:"limit"`
+}
+
+// SearchPostResponse 指定了 `GET /v1/posts:search` 接口的返回参数，Posts 按相关度从高到低排列.
+type SearchPostResponse struct {
+	TotalCount int64             `json:"totalCount"`
+	Posts      []*SearchPostInfo `json:"posts"`
+}
+
+// SearchPostInfo 指定了一条博客搜索结果.
+// Title 和 Snippet 中命中搜索词的部分使用 `<mark></mark>` 标记，其余部分已做 HTML 转义.
+type SearchPostInfo struct {
+	PostID    string  `json:"postID"`
+	Title     string  `json:"title"`
+	Snippet   string  `json:"snippet"`
+	Score     float64 `json:"score"`
+	CreatedAt string  `json:"createdAt"`
+	UpdatedAt string  `json:"updatedAt"`
+}
@@ -0,0 +1,15 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package v1
+
+// ListReactedPostRequest 指定了 `GET /v1/posts:reacted` 接口的请求参数，Type 默认为 like.
+type ListReactedPostRequest struct {
+	Type           string `form:"type" valid:"in(like|love|laugh|wow|sad|angry)"`
+	Offset         int    `form:"offset"`
+	Limit          int    `form:"limit"`
+	PageToken      string `form:"pageToken"`
+	SkipTotalCount bool   `form:"skipTotalCount"`
+}
@@ -0,0 +1,44 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package v1
+
+// ListRevisionRequest 指定了 `GET /v1/posts/{postID}/revisions` 接口的请求参数.
+type ListRevisionRequest struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+// ListRevisionResponse 指定了 `GET /v1/posts/{postID}/revisions` 接口的返回参数，Revisions 按版本号从新到旧排列.
+type ListRevisionResponse struct {
+	TotalCount int64           `json:"totalCount"`
+	Revisions  []*RevisionInfo `json:"revisions"`
+}
+
+// RevisionInfo 指定了博客的一个历史版本，Username 为创建该版本的用户.
+type RevisionInfo struct {
+	Revision      int64  `json:"revision"`
+	Username      string `json:"username"`
+	Title         string `json:"title"`
+	Content       string `json:"content"`
+	ContentFormat string `json:"contentFormat"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+// DiffRevisionRequest 指定了 `GET /v1/posts/{postID}/revisions:diff` 接口的请求参数.
+type DiffRevisionRequest struct {
+	From int64 `form:"from" valid:"required"`
+	To   int64 `form:"to" valid:"required"`
+}
+
+// DiffRevisionResponse 指定了 `GET /v1/posts/{postID}/revisions:diff` 接口的返回参数.
+// Diff 为从版本 From 到版本 To 的内容的 unified 格式差异，内容相同时为空.
+type DiffRevisionResponse struct {
+	From      int64  `json:"from"`
+	To        int64  `json:"to"`
+	FromTitle string `json:"fromTitle"`
+	ToTitle   string `json:"toTitle"`
+	Diff      string `json:"diff"`
+}
@@ -0,0 +1,23 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package v1
+
+// StreamPostEvent 指定了 `GET /v1/events` 事件流中 post 事件的数据，表示用户自己或关注的用户发布了新博客.
+type StreamPostEvent struct {
+	PostID      string `json:"postID"`
+	Username    string `json:"username"`
+	Title       string `json:"title"`
+	PublishedAt string `json:"publishedAt"`
+}
+
+// StreamCommentEvent 指定了 `GET /v1/events` 事件流中 comment 事件的数据，表示用户的博客收到了新评论.
+// Status 为 pending 时评论等待用户审核.
+type StreamCommentEvent struct {
+	PostID    string `json:"postID"`
+	CommentID string `json:"commentID"`
+	Username  string `json:"username"`
+	Status    string `json:"status"`
+}
@@ -0,0 +1,28 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package v1
+
+// TagInfo 指定了 tag 的详细信息，PostCount 为使用该 tag 的已发布公开博客数.
+type TagInfo struct {
+	Name      string `json:"name"`
+	PostCount int64  `json:"postCount"`
+}
+
+// ListTagResponse 指定了 `GET /v1/tags` 接口的返回参数，Tags 按 PostCount 从多到少排列.
+type ListTagResponse struct {
+	Tags []*TagInfo `json:"tags"`
+}
+
+// RenameTagRequest 指定了 `POST /v1/tags/{name}:rename` 接口的请求参数.
+type RenameTagRequest struct {
+	Name string `json:"name" valid:"required,stringlength(1|32)"`
+}
+
+// MergeTagRequest 指定了 `POST /v1/tags/{name}:merge` 接口的请求参数.
+// 合并后使用 {name} 的博客都改为使用 Into，{name} 被删除.
+type MergeTagRequest struct {
+	Into string `json:"into" valid:"required,stringlength(1|32)"`
+}
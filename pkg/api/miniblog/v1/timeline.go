@@ -0,0 +1,19 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package v1
+
+// ListTimelineRequest 指定了 `GET /v1/timeline` 接口的请求参数，时间线只支持游标分页.
+type ListTimelineRequest struct {
+	Limit     int    `form:"limit"`
+	PageToken string `form:"pageToken"`
+}
+
+// ListTimelineResponse 指定了 `GET /v1/timeline` 接口的返回参数，Posts 按发布时间从新到旧排列.
+// NextPageToken 为空表示没有下一页.
+type ListTimelineResponse struct {
+	Posts         []*PostInfo `json:"posts"`
+	NextPageToken string      `json:"nextPageToken,omitempty"`
+}
@@ -37,32 +37,129 @@ type CreateUserRequest struct {
 // GetUserResponse 指定了 `GET /v1/users/{name}` 接口的返回参数.
 type GetUserResponse UserInfo
 
-// UserInfo 指定了用户的详细信息.
+// UserInfo 指定了用户的详细信息，FollowerCount 为关注者数量，FollowingCount 为关注的用户数量.
+// AvatarURL 是头像的公开地址，没有头像时为空. ShowEmail、ShowPhone 和 ShowTimezone 是用户的隐私设置.
 type UserInfo struct {
-	Username  string `json:"username"`
-	Nickname  string `json:"nickname"`
-	Email     string `json:"email"`
-	Phone     string `json:"phone"`
-	PostCount int64  `json:"postCount"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"updatedAt"`
+	Username       string   `json:"username"`
+	Nickname       string   `json:"nickname"`
+	Email          string   `json:"email"`
+	Phone          string   `json:"phone"`
+	Bio            string   `json:"bio"`
+	AvatarURL      string   `json:"avatarURL"`
+	Links          []string `json:"links"`
+	Timezone       string   `json:"timezone"`
+	Locale         string   `json:"locale"`
+	ShowEmail      bool     `json:"showEmail"`
+	ShowPhone      bool     `json:"showPhone"`
+	ShowTimezone   bool     `json:"showTimezone"`
+	State          string   `json:"state"`
+	StateReason    string   `json:"stateReason,omitempty"`
+	StateExpiresAt string   `json:"stateExpiresAt,omitempty"`
+	PostCount      int64    `json:"postCount"`
+	FollowerCount  int64    `json:"followerCount"`
+	FollowingCount int64    `json:"followingCount"`
+	CreatedAt      string   `json:"createdAt"`
+	UpdatedAt      string   `json:"updatedAt"`
+}
+
+// GetProfileResponse 指定了 `GET /v1/public/users/{name}` 接口的返回参数.
+// 用户没有选择公开的 Email、Phone 和 Timezone 字段为空.
+type GetProfileResponse struct {
+	Username       string   `json:"username"`
+	Nickname       string   `json:"nickname"`
+	Bio            string   `json:"bio"`
+	AvatarURL      string   `json:"avatarURL"`
+	Links          []string `json:"links"`
+	Locale         string   `json:"locale"`
+	Email          string   `json:"email,omitempty"`
+	Phone          string   `json:"phone,omitempty"`
+	Timezone       string   `json:"timezone,omitempty"`
+	FollowerCount  int64    `json:"followerCount"`
+	FollowingCount int64    `json:"followingCount"`
+	CreatedAt      string   `json:"createdAt"`
+}
+
+// UploadAvatarResponse 指定了 `PUT /v1/users/{name}/avatar` 接口的返回参数.
+type UploadAvatarResponse struct {
+	AvatarURL string `json:"avatarURL"`
 }
 
 // ListUserRequest 指定了 `GET /v1/users` 接口的请求参数.
+// 指定 PageToken 时使用游标分页，此时忽略 Offset. State 过滤处于指定状态的用户，可选值：active, suspended, pending.
 type ListUserRequest struct {
-	Offset int `form:"offset"`
-	Limit  int `form:"limit"`
+	Offset         int    `form:"offset"`
+	Limit          int    `form:"limit"`
+	PageToken      string `form:"pageToken"`
+	SkipTotalCount bool   `form:"skipTotalCount"`
+	State          string `form:"state"`
 }
 
 // ListUserResponse 指定了 `GET /v1/users` 接口的返回参数.
+// NextPageToken 为空表示没有下一页.
 type ListUserResponse struct {
-	TotalCount int64       `json:"totalCount"`
-	Users      []*UserInfo `json:"users"`
+	TotalCount    int64       `json:"totalCount"`
+	Users         []*UserInfo `json:"users"`
+	NextPageToken string      `json:"nextPageToken,omitempty"`
+}
+
+// 删除用户时处理用户数据的方式.
+const (
+	// DeleteModeCascade 表示同时删除用户的博客等数据.
+	DeleteModeCascade = "cascade"
+
+	// DeleteModeReassign 表示将用户的博客等数据转移给 ReassignTo 指定的用户.
+	DeleteModeReassign = "reassign"
+
+	// DeleteModeAnonymize 表示保留用户的博客等数据，但将作者替换为匿名用户.
+	DeleteModeAnonymize = "anonymize"
+)
+
+// DeleteUserRequest 指定了 `DELETE /v1/users/{name}` 接口的请求参数.
+type DeleteUserRequest struct {
+	// 处理用户数据的方式，可选值：cascade（默认）, reassign, anonymize.
+	Mode string `form:"mode" valid:"in(cascade|reassign|anonymize)"`
+
+	// Mode 为 reassign 时，接收用户数据的用户名.
+	ReassignTo string `form:"reassign-to" valid:"alphanum,stringlength(1|255)"`
 }
 
-// UpdateUserRequest 指定了 `PUT /v1/users/{name}` 接口的请求参数.
+// UpdateUserRequest 指定了 `PUT /v1/users/{name}` 接口的请求参数，为空的字段保持不变.
+// Links 是 http 或 https 链接，会替换已有的所有链接. Timezone 是 IANA 时区名，例如 Asia/Shanghai，
+// Locale 是 BCP 47 语言标签，例如 zh-CN，设置为空字符串时清除.
 type UpdateUserRequest struct {
-	Nickname *string `json:"nickname" valid:"stringlength(1|255)"`
-	Email    *string `json:"email" valid:"email"`
-	Phone    *string `json:"phone" valid:"stringlength(11|11)"`
+	Nickname     *string   `json:"nickname" valid:"stringlength(1|255)"`
+	Email        *string   `json:"email" valid:"email"`
+	Phone        *string   `json:"phone" valid:"stringlength(11|11)"`
+	Bio          *string   `json:"bio" valid:"stringlength(0|500)"`
+	Links        *[]string `json:"links"`
+	Timezone     *string   `json:"timezone" valid:"stringlength(0|64)"`
+	Locale       *string   `json:"locale" valid:"stringlength(0|35)"`
+	ShowEmail    *bool     `json:"showEmail"`
+	ShowPhone    *bool     `json:"showPhone"`
+	ShowTimezone *bool     `json:"showTimezone"`
+}
+
+// RenameUserRequest 指定了 `POST /v1/users/{name}:rename` 接口的请求参数.
+type RenameUserRequest struct {
+	Username string `json:"username" valid:"alphanum,required,stringlength(1|255)"`
+}
+
+// RenameUserResponse 指定了 `POST /v1/users/{name}:rename` 接口的返回参数.
+// 使用旧用户名签发的 token 不再有效，Token 是使用新用户名签发的 token，只在用户修改自己的用户名时返回.
+type RenameUserResponse struct {
+	Username string `json:"username"`
+	Token    string `json:"token,omitempty"`
+}
+
+// SuspendUserRequest 指定了 `POST /v1/users/{name}:suspend` 接口的请求参数.
+// Until 是停用的截止时间，格式为 `2006-01-02 15:04:05`，为空表示永久停用，直到管理员恢复账号.
+type SuspendUserRequest struct {
+	Reason string `json:"reason" valid:"required,stringlength(1|255)"`
+	Until  string `json:"until"`
+}
+
+// ResetPasswordResponse 指定了 `POST /v1/users/{name}:resetPassword` 接口的返回参数.
+// Password 是临时密码，只在这里返回一次，用户需要使用它修改密码后才能登录.
+type ResetPasswordResponse struct {
+	Password string `json:"password"`
 }
@@ -0,0 +1,98 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package v1
+
+// CreateWebhookRequest 指定了 `POST /v1/webhooks` 接口的请求参数.
+// URL 必须是 http 或 https 地址. Events 是订阅的事件类型，例如 post.created，`*` 表示订阅所有事件.
+// Secret 用于对请求体签名，为空时自动生成.
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" valid:"required,requrl,stringlength(1|2048)"`
+	Secret string   `json:"secret" valid:"stringlength(0|255)"`
+	Events []string `json:"events"`
+}
+
+// CreateWebhookResponse 指定了 `POST /v1/webhooks` 接口的返回参数. Secret 只在创建时返回.
+type CreateWebhookResponse struct {
+	WebhookID string `json:"webhookID"`
+	Secret    string `json:"secret"`
+}
+
+// UpdateWebhookRequest 指定了 `PUT /v1/webhooks/{webhookID}` 接口的请求参数，为空的字段保持不变.
+// Active 为 true 时重新启用被自动停用的 webhook，连续失败次数被清零.
+type UpdateWebhookRequest struct {
+	URL    *string  `json:"url" valid:"requrl,stringlength(1|2048)"`
+	Secret *string  `json:"secret" valid:"stringlength(1|255)"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active"`
+}
+
+// WebhookInfo 指定了 webhook 的详细信息，不包括密钥.
+// DisabledReason 是 webhook 被自动停用的原因，ConsecutiveFailures 是连续投递失败的次数.
+type WebhookInfo struct {
+	WebhookID           string   `json:"webhookID"`
+	URL                 string   `json:"url"`
+	Events              []string `json:"events"`
+	Active              bool     `json:"active"`
+	DisabledReason      string   `json:"disabledReason,omitempty"`
+	ConsecutiveFailures int      `json:"consecutiveFailures"`
+	LastDeliveryAt      string   `json:"lastDeliveryAt,omitempty"`
+	CreatedAt           string   `json:"createdAt"`
+	UpdatedAt           string   `json:"updatedAt"`
+}
+
+// GetWebhookResponse 指定了 `GET /v1/webhooks/{webhookID}` 接口的返回参数.
+type GetWebhookResponse WebhookInfo
+
+// ListWebhookResponse 指定了 `GET /v1/webhooks` 接口的返回参数.
+type ListWebhookResponse struct {
+	TotalCount int64          `json:"totalCount"`
+	Webhooks   []*WebhookInfo `json:"webhooks"`
+}
+
+// ListWebhookDeliveryRequest 指定了 `GET /v1/webhooks/{webhookID}/deliveries` 接口的请求参数.
+type ListWebhookDeliveryRequest struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+// ListWebhookDeliveryResponse 指定了 `GET /v1/webhooks/{webhookID}/deliveries` 接口的返回参数，投递记录按创建时间从新到旧排列.
+type ListWebhookDeliveryResponse struct {
+	TotalCount int64                  `json:"totalCount"`
+	Deliveries []*WebhookDeliveryInfo `json:"deliveries"`
+}
+
+// WebhookDeliveryInfo 指定了一次投递的详细信息.
+// Status 为 pending、succeeded 或 failed. StatusCode、Response 和 Error 是最近一次投递的结果，
+// Response 是截断后的响应体. NextAttemptAt 是等待重试的投递下次投递的时间.
+type WebhookDeliveryInfo struct {
+	DeliveryID    string `json:"deliveryID"`
+	Event         string `json:"event"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	StatusCode    int    `json:"statusCode,omitempty"`
+	Response      string `json:"response,omitempty"`
+	Error         string `json:"error,omitempty"`
+	Payload       string `json:"payload"`
+	NextAttemptAt string `json:"nextAttemptAt,omitempty"`
+	DeliveredAt   string `json:"deliveredAt,omitempty"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+// RedeliverWebhookResponse 指定了 `POST /v1/webhooks/{webhookID}/deliveries/{deliveryID}:redeliver` 接口的返回参数.
+// 重新投递会创建一条新的投递记录，请求体与原投递相同.
+type RedeliverWebhookResponse struct {
+	DeliveryID string `json:"deliveryID"`
+}
+
+// WebhookPayload 是 webhook 请求的请求体. ID 与 X-Miniblog-Delivery 请求头相同，重新投递时是新的 ID.
+// Username 是 webhook 所属的用户，Data 是事件相关的数据，例如博客事件中的博客信息.
+type WebhookPayload struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	Username  string      `json:"username"`
+	CreatedAt string      `json:"createdAt"`
+	Data      interface{} `json:"data"`
+}
@@ -0,0 +1,190 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Package archive 读写用于导入和导出博客的归档文件，支持以下两种格式：
+//   - markdown：zip 压缩包，每篇博客是一个带有 YAML front matter 的 Markdown 文件；
+//   - jsonl：JSON Lines，每行是一篇博客的 JSON 对象.
+//
+// 此外还可以读取从其它博客系统迁移的内容（只读）：
+//   - wxr：WordPress 导出的 WXR（WordPress eXtended RSS）XML 文件；
+//   - hugo：Hugo 或 Jekyll 站点的内容目录（或其 zip 压缩包），Markdown 文件带有 YAML 或 TOML front matter.
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"time"
+)
+
+// 支持的归档格式.
+const (
+	FormatMarkdown = "markdown"
+	FormatJSONL    = "jsonl"
+	FormatWXR      = "wxr"
+	FormatHugo     = "hugo"
+)
+
+// MaxPostSize 是归档中单篇博客（包括 front matter）的最大字节数.
+const MaxPostSize = 1 << 20
+
+// ErrUnsupportedFormat 表示不支持的归档格式.
+var ErrUnsupportedFormat = errors.New("archive: unsupported format")
+
+// Post 是归档中的一篇博客，字段名与 API 中的博客字段保持一致. 除 Title 和 Content 外的字段都可以为空.
+type Post struct {
+	PostID        string     `json:"postID,omitempty" yaml:"postID,omitempty"`
+	Slug          string     `json:"slug,omitempty" yaml:"slug,omitempty"`
+	Title         string     `json:"title" yaml:"title"`
+	ContentFormat string     `json:"contentFormat,omitempty" yaml:"contentFormat,omitempty"`
+	Status        string     `json:"status,omitempty" yaml:"status,omitempty"`
+	Visibility    string     `json:"visibility,omitempty" yaml:"visibility,omitempty"`
+	CommentPolicy string     `json:"commentPolicy,omitempty" yaml:"commentPolicy,omitempty"`
+	Tags          []string   `json:"tags,omitempty" yaml:"tags,omitempty,flow"`
+	PublishAt     *time.Time `json:"publishAt,omitempty" yaml:"publishAt,omitempty"`
+	CreatedAt     *time.Time `json:"createdAt,omitempty" yaml:"createdAt,omitempty"`
+	UpdatedAt     *time.Time `json:"updatedAt,omitempty" yaml:"updatedAt,omitempty"`
+	// Author 是博客在原博客系统中的作者，例如 WordPress 的登录名. 导出的归档中不包含该字段.
+	Author string `json:"author,omitempty" yaml:"author,omitempty"`
+	// Content 在 markdown 格式中是 front matter 之后的文件内容.
+	Content string `json:"content" yaml:"-"`
+}
+
+// Entry 是从归档中读取的一项，Name 是 zip 中的文件名、`line N` 形式的行号或 `post N` 形式的 WordPress 博客 ID.
+// 无法解析的项 Post 为 nil，Err 为解析错误.
+type Entry struct {
+	Name string
+	Post *Post
+	Err  error
+}
+
+// ContentType 返回 format 格式归档文件的 Content-Type.
+func ContentType(format string) string {
+	if format == FormatMarkdown {
+		return "application/zip"
+	}
+
+	return "application/x-ndjson"
+}
+
+// Extension 返回 format 格式归档文件的扩展名.
+func Extension(format string) string {
+	if format == FormatMarkdown {
+		return ".zip"
+	}
+
+	return ".jsonl"
+}
+
+// Writer 将博客依次写入归档，写入完成后必须调用 Close.
+type Writer struct {
+	format string
+	w      io.Writer
+	zw     *zipWriter
+}
+
+// NewWriter 创建一个将 format 格式的归档写入 w 的 Writer.
+func NewWriter(w io.Writer, format string) (*Writer, error) {
+	switch format {
+	case FormatMarkdown:
+		return &Writer{format: format, w: w, zw: newZipWriter(w)}, nil
+	case FormatJSONL:
+		return &Writer{format: format, w: w}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// Write 将一篇博客写入归档.
+func (w *Writer) Write(p *Post) error {
+	if w.format == FormatMarkdown {
+		return w.zw.write(p)
+	}
+
+	return writeJSONL(w.w, p)
+}
+
+// Close 完成归档的写入，不会关闭底层的 io.Writer.
+func (w *Writer) Close() error {
+	if w.format == FormatMarkdown {
+		return w.zw.close()
+	}
+
+	return nil
+}
+
+// Read 解析 format 格式的归档. format 为空时根据内容自动识别：zip 文件按 markdown 格式解析，
+// 以 `---` 开头的文件按单个 Markdown 文件解析，XML 文件按 wxr 格式解析，其余按 jsonl 格式解析.
+// hugo 格式的归档必须是 zip 压缩包，需要显式指定.
+// 返回归档的格式和其中的所有项，归档整体无法解析时返回错误.
+func Read(data []byte, format string) (string, []*Entry, error) {
+	if format == "" {
+		format = detect(data)
+	}
+
+	switch format {
+	case FormatMarkdown:
+		if !isZip(data) {
+			post, err := parseMarkdown(data)
+			return format, []*Entry{{Name: "post.md", Post: post, Err: err}}, nil
+		}
+
+		entries, err := readZip(data)
+
+		return format, entries, err
+	case FormatJSONL:
+		return format, readJSONL(data), nil
+	case FormatWXR:
+		entries, err := readWXR(data)
+
+		return format, entries, err
+	case FormatHugo:
+		if !isZip(data) {
+			return format, nil, errors.New("hugo archive must be a zip file")
+		}
+
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return format, nil, err
+		}
+
+		entries, err := readHugo(zr)
+
+		return format, entries, err
+	default:
+		return format, nil, ErrUnsupportedFormat
+	}
+}
+
+// ReadFS 读取 fsys 中 Hugo 或 Jekyll 站点的内容，用于直接导入磁盘上的目录.
+func ReadFS(fsys fs.FS) ([]*Entry, error) {
+	return readHugo(fsys)
+}
+
+// detect 根据内容识别归档的格式.
+func detect(data []byte) string {
+	if isZip(data) || bytes.HasPrefix(data, []byte("---")) {
+		return FormatMarkdown
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\ufeff"))), []byte("<")) {
+		return FormatWXR
+	}
+
+	return FormatJSONL
+}
+
+// isZip 判断 data 是否是 zip 文件.
+func isZip(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04"))
+}
+
+// lineName 返回 jsonl 格式中第 n 行的名称.
+func lineName(n int) string {
+	return fmt.Sprintf("line %d", n)
+}
@@ -0,0 +1,120 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fakePosts() []*Post {
+	published := time.Date(2022, 11, 20, 8, 30, 0, 0, time.UTC)
+
+	return []*Post{
+		{
+			PostID:        "post-1",
+			Slug:          "hello",
+			Title:         "Hello: world",
+			ContentFormat: "markdown",
+			Status:        "published",
+			Visibility:    "public",
+			CommentPolicy: "open",
+			Tags:          []string{"go", "web"},
+			PublishAt:     &published,
+			CreatedAt:     &published,
+			UpdatedAt:     &published,
+			Content:       "# Hello\n\n---\n\n<b>bold</b>\n",
+		},
+		{PostID: "post-2", Title: "Draft", ContentFormat: "plain", Status: "draft", Content: "\nstarts with a blank line"},
+	}
+}
+
+func roundTrip(t *testing.T, format string) []*Entry {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, format)
+	assert.NoError(t, err)
+	for _, p := range fakePosts() {
+		assert.NoError(t, w.Write(p))
+	}
+	assert.NoError(t, w.Close())
+
+	got, entries, err := Read(buf.Bytes(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, format, got)
+
+	return entries
+}
+
+func TestMarkdown(t *testing.T) {
+	entries := roundTrip(t, FormatMarkdown)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, &Entry{Name: "hello.md", Post: fakePosts()[0]}, entries[0])
+		assert.Equal(t, &Entry{Name: "post-2.md", Post: fakePosts()[1]}, entries[1])
+	}
+}
+
+func TestJSONL(t *testing.T) {
+	entries := roundTrip(t, FormatJSONL)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, &Entry{Name: "line 1", Post: fakePosts()[0]}, entries[0])
+		assert.Equal(t, &Entry{Name: "line 2", Post: fakePosts()[1]}, entries[1])
+	}
+
+	_, entries, err := Read([]byte("{\"title\":\"a\",\"content\":\"b\"}\n\nnot json\n"), FormatJSONL)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, &Post{Title: "a", Content: "b"}, entries[0].Post)
+		assert.Equal(t, "line 3", entries[1].Name)
+		assert.Error(t, entries[1].Err)
+	}
+}
+
+func TestRead_markdownFiles(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"posts/a.md":          "---\r\ntitle: A\r\ntags: [x]\r\n---\r\nbody\r\n",
+		"posts/b.markdown":    "---\ntitle: B\n---",
+		"posts/c.md":          "no front matter",
+		"posts/d.md":          "---\ntitle: [\n---\n",
+		"posts/image.png":     "PNG",
+		"__MACOSX/posts/a.md": "junk",
+	} {
+		f, _ := zw.Create(name)
+		f.Write([]byte(content))
+	}
+	zw.Close()
+
+	_, entries, err := Read(buf.Bytes(), FormatMarkdown)
+	assert.NoError(t, err)
+
+	got := map[string]*Entry{}
+	for _, entry := range entries {
+		got[entry.Name] = entry
+	}
+
+	assert.Len(t, got, 4)
+	assert.Equal(t, &Post{Title: "A", Tags: []string{"x"}, ContentFormat: "markdown", Content: "body\n"}, got["posts/a.md"].Post)
+	assert.Equal(t, &Post{Title: "B", ContentFormat: "markdown"}, got["posts/b.markdown"].Post)
+	assert.EqualError(t, got["posts/c.md"].Err, "missing front matter")
+	assert.Error(t, got["posts/d.md"].Err)
+
+	// A single Markdown file is also accepted.
+	format, entries, err := Read([]byte("---\ntitle: Single\n---\n\nbody"), "")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatMarkdown, format)
+	assert.Equal(t, []*Entry{{Name: "post.md", Post: &Post{Title: "Single", ContentFormat: "markdown", Content: "body"}}}, entries)
+
+	_, _, err = Read([]byte("PK\x03\x04truncated"), "")
+	assert.Error(t, err)
+
+	_, _, err = Read(nil, "csv")
+	assert.Equal(t, ErrUnsupportedFormat, err)
+}
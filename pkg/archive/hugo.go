@@ -0,0 +1,253 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package archive
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/marmotedu/miniblog/pkg/util/slug"
+)
+
+// tomlFrontMatterDelimiter 是 Hugo 中 TOML front matter 开始和结束的分隔行.
+const tomlFrontMatterDelimiter = "+++"
+
+// jekyllPostName 匹配 Jekyll 的博客文件名 `YYYY-MM-DD-slug`.
+var jekyllPostName = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-(.+)$`)
+
+// hugoTimeLayouts 是 front matter 中时间字段支持的格式，没有时区的时间按 UTC 处理.
+var hugoTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// readHugo 读取 fsys 中的所有 Markdown 文件. `.` 开头的文件和目录被忽略，`_` 开头的目录中只读取 Jekyll 的
+// `_posts` 和 `_drafts`，Hugo 的 `_index.md` 是章节的首页，也被忽略.
+func readHugo(fsys fs.FS) ([]*Entry, error) {
+	var entries []*Entry
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		base := d.Name()
+		if d.IsDir() {
+			if name != "." && (strings.HasPrefix(base, ".") || base == "__MACOSX" ||
+				strings.HasPrefix(base, "_") && base != "_posts" && base != "_drafts") {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		if strings.HasPrefix(base, ".") || strings.HasPrefix(base, "_index.") || !isMarkdownFile(base) {
+			return nil
+		}
+
+		entry := &Entry{Name: name}
+		if data, err := readFSFile(fsys, name); err != nil {
+			entry.Err = err
+		} else {
+			entry.Post, entry.Err = parseHugo(name, data)
+		}
+
+		entries = append(entries, entry)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// readFSFile 读取 fsys 中的一个文件，超过 MaxPostSize 时返回错误.
+func readFSFile(fsys fs.FS, name string) ([]byte, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readLimited(f)
+}
+
+// parseHugo 解析 Hugo 或 Jekyll 的 Markdown 文件，name 是文件在内容目录中的路径.
+//
+// front matter 中的字段按以下规则转换：
+//   - slug 为空时使用文件名，page bundle（`post/index.md`）使用目录名，Jekyll 文件名中的日期被去掉；
+//   - date（或 Jekyll 文件名中的日期）是创建时间，publishDate 优先作为发布时间，lastmod 或 last_modified_at 是更新时间；
+//   - draft 为 true、Jekyll 的 published 为 false 或文件在 `_drafts` 目录中时是草稿；
+//   - 其余博客有发布时间时为 scheduled 状态，由导入方根据当前时间决定立即发布还是定时发布；
+//   - author 或 authors 中的第一个作者是博客的作者.
+func parseHugo(name string, data []byte) (*Post, error) {
+	text := normalizeNewlines(data)
+
+	var params map[string]interface{}
+	var content string
+	switch {
+	case strings.HasPrefix(text, tomlFrontMatterDelimiter+"\n"):
+		matter, rest, err := splitFrontMatter(text, tomlFrontMatterDelimiter)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := toml.Unmarshal([]byte(matter), &params); err != nil {
+			return nil, fmt.Errorf("invalid front matter: %w", err)
+		}
+		content = rest
+	default:
+		matter, rest, err := splitFrontMatter(text, frontMatterDelimiter)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := yaml.Unmarshal([]byte(matter), &params); err != nil {
+			return nil, fmt.Errorf("invalid front matter: %w", err)
+		}
+		content = rest
+	}
+
+	// Hugo 中 front matter 的字段名不区分大小写
+	fields := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		fields[strings.ToLower(k)] = v
+	}
+
+	p := &Post{
+		Slug:          hugoString(fields["slug"]),
+		Title:         strings.TrimSpace(hugoString(fields["title"])),
+		ContentFormat: "markdown",
+		Tags:          hugoStrings(fields["tags"]),
+		Content:       strings.TrimLeft(content, "\n"),
+	}
+
+	// Jekyll 文件名中的日期是默认的创建时间
+	base := strings.TrimSuffix(path.Base(name), path.Ext(name))
+	if base == "index" && path.Dir(name) != "." {
+		base = path.Base(path.Dir(name))
+	}
+
+	var err error
+	if m := jekyllPostName.FindStringSubmatch(base); m != nil {
+		base = m[2]
+		if p.CreatedAt, err = hugoTime(m[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Slug == "" {
+		p.Slug = base
+	}
+	p.Slug = slug.Make(p.Slug)
+
+	if t, err := hugoTime(fields["date"]); err != nil {
+		return nil, err
+	} else if t != nil {
+		p.CreatedAt = t
+	}
+
+	if p.PublishAt, err = hugoTime(fields["publishdate"]); err != nil {
+		return nil, err
+	}
+
+	if p.PublishAt == nil {
+		p.PublishAt = p.CreatedAt
+	}
+
+	updatedAt := fields["lastmod"]
+	if updatedAt == nil {
+		updatedAt = fields["last_modified_at"]
+	}
+
+	if p.UpdatedAt, err = hugoTime(updatedAt); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case fields["draft"] == true || fields["published"] == false || strings.Contains("/"+name, "/_drafts/"):
+		p.Status, p.PublishAt = "draft", nil
+	case p.PublishAt != nil:
+		p.Status = "scheduled"
+	default:
+		p.Status = "published"
+	}
+
+	if authors := hugoStrings(fields["author"]); len(authors) > 0 {
+		p.Author = authors[0]
+	} else if authors := hugoStrings(fields["authors"]); len(authors) > 0 {
+		p.Author = authors[0]
+	}
+
+	return p, nil
+}
+
+// hugoString 返回 front matter 中字符串字段的值，不是字符串时返回空字符串.
+func hugoString(v interface{}) string {
+	s, _ := v.(string)
+
+	return s
+}
+
+// hugoStrings 返回 front matter 中字符串列表字段的值. Jekyll 中的列表也可以是空格分隔的字符串.
+func hugoStrings(v interface{}) []string {
+	var ret []string
+	switch v := v.(type) {
+	case string:
+		ret = strings.Fields(v)
+	case []interface{}:
+		for _, s := range v {
+			if s, ok := s.(string); ok && strings.TrimSpace(s) != "" {
+				ret = append(ret, strings.TrimSpace(s))
+			}
+		}
+	}
+
+	return ret
+}
+
+// hugoTime 解析 front matter 中的时间字段，YAML 和 TOML 中的时间可能已经被解析为 time.Time 或 TOML 的本地时间.
+func hugoTime(v interface{}) (*time.Time, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		t := v.UTC()
+		return &t, nil
+	case toml.LocalDate:
+		t := v.AsTime(time.UTC)
+		return &t, nil
+	case toml.LocalDateTime:
+		t := v.AsTime(time.UTC)
+		return &t, nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil, nil
+		}
+
+		for _, layout := range hugoTimeLayouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				t = t.UTC()
+				return &t, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("invalid time %v", v)
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"posts/hello.md":        {Data: []byte("+++\ntitle = \"Hello\"\ndate = 2022-11-20T08:30:00+08:00\nlastmod = 2022-11-21\nTags = [\"Go\", \"Web\"]\nauthors = [\"Jane Doe\"]\n+++\n\nbody\n")},
+		"posts/bundle/index.md": {Data: []byte("---\ntitle: Bundle\ndraft: true\ndate: 2022-11-20\n---\nbody")},
+		"posts/custom.markdown": {Data: []byte("---\ntitle: Custom\nslug: My Slug\npublishDate: \"2022-11-22 10:00:00\"\n---\nbody")},
+		"posts/_index.md":       {Data: []byte("---\ntitle: Posts\n---\n")},
+		"posts/image.png":       {Data: []byte("PNG")},
+		".git/README.md":        {Data: []byte("---\ntitle: Git\n---\n")},
+		"_layouts/post.md":      {Data: []byte("---\ntitle: Layout\n---\n")},
+		"_posts/2022-11-19-jekyll-post.md": {Data: []byte("---\ntitle: Jekyll\ntags: go web\nauthor: bob\n" +
+			"last_modified_at: 2022-11-21 10:00:00 +0800\n---\nbody")},
+		"_posts/2022-11-18-unpublished.md": {Data: []byte("---\ntitle: Unpublished\npublished: false\n---\nbody")},
+		"_drafts/idea.md":                  {Data: []byte("---\ntitle: Idea\n---\nbody")},
+		"README.md":                        {Data: []byte("no front matter")},
+		"posts/bad-date.md":                {Data: []byte("---\ntitle: Bad\ndate: tomorrow\n---\n")},
+	}
+
+	entries, err := ReadFS(fsys)
+	assert.NoError(t, err)
+
+	got := map[string]*Entry{}
+	for _, entry := range entries {
+		got[entry.Name] = entry
+	}
+	assert.Len(t, got, 8)
+
+	date := func(s string) *time.Time {
+		t, _ := time.Parse(time.RFC3339, s)
+		return &t
+	}
+
+	assert.Equal(t, &Post{
+		Slug: "hello", Title: "Hello", ContentFormat: "markdown", Status: "scheduled", Tags: []string{"Go", "Web"},
+		PublishAt: date("2022-11-20T00:30:00Z"), CreatedAt: date("2022-11-20T00:30:00Z"), UpdatedAt: date("2022-11-21T00:00:00Z"),
+		Author: "Jane Doe", Content: "body\n",
+	}, got["posts/hello.md"].Post)
+	assert.Equal(t, &Post{
+		Slug: "bundle", Title: "Bundle", ContentFormat: "markdown", Status: "draft", CreatedAt: date("2022-11-20T00:00:00Z"), Content: "body",
+	}, got["posts/bundle/index.md"].Post)
+	assert.Equal(t, &Post{
+		Slug: "my-slug", Title: "Custom", ContentFormat: "markdown", Status: "scheduled", PublishAt: date("2022-11-22T10:00:00Z"), Content: "body",
+	}, got["posts/custom.markdown"].Post)
+	assert.Equal(t, &Post{
+		Slug: "jekyll-post", Title: "Jekyll", ContentFormat: "markdown", Status: "scheduled", Tags: []string{"go", "web"},
+		PublishAt: date("2022-11-19T00:00:00Z"), CreatedAt: date("2022-11-19T00:00:00Z"), UpdatedAt: date("2022-11-21T02:00:00Z"),
+		Author: "bob", Content: "body",
+	}, got["_posts/2022-11-19-jekyll-post.md"].Post)
+	assert.Equal(t, "draft", got["_posts/2022-11-18-unpublished.md"].Post.Status)
+	assert.Nil(t, got["_posts/2022-11-18-unpublished.md"].Post.PublishAt)
+	assert.Equal(t, "draft", got["_drafts/idea.md"].Post.Status)
+	assert.EqualError(t, got["README.md"].Err, "missing front matter")
+	assert.EqualError(t, got["posts/bad-date.md"].Err, "invalid time tomorrow")
+}
+
+func TestRead_hugo(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, _ := zw.Create("content/posts/a.md")
+	f.Write([]byte("---\ntitle: A\n---\nbody"))
+	zw.Close()
+
+	format, entries, err := Read(buf.Bytes(), FormatHugo)
+	assert.NoError(t, err)
+	assert.Equal(t, FormatHugo, format)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, &Post{Slug: "a", Title: "A", ContentFormat: "markdown", Status: "published", Content: "body"}, entries[0].Post)
+	}
+
+	_, _, err = Read([]byte("---\ntitle: A\n---\n"), FormatHugo)
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package archive
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// writeJSONL 将博客编码为一行 JSON 写入 w.
+func writeJSONL(w io.Writer, p *Post) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(p)
+}
+
+// readJSONL 逐行解析 JSON Lines，空行被忽略.
+func readJSONL(data []byte) []*Entry {
+	var entries []*Entry
+	for n, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		entry := &Entry{Name: lineName(n + 1)}
+		if len(line) > MaxPostSize {
+			entry.Err = fmt.Errorf("line is longer than %d bytes", MaxPostSize)
+		} else {
+			var p Post
+			if err := json.Unmarshal(line, &p); err != nil {
+				entry.Err = fmt.Errorf("invalid JSON: %w", err)
+			} else {
+				entry.Post = &p
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
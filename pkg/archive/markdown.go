@@ -0,0 +1,194 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatterDelimiter 是 front matter 开始和结束的分隔行.
+const frontMatterDelimiter = "---"
+
+// zipWriter 将博客写入 zip 压缩包，每篇博客一个 Markdown 文件.
+type zipWriter struct {
+	zw    *zip.Writer
+	names map[string]bool
+}
+
+func newZipWriter(w io.Writer) *zipWriter {
+	return &zipWriter{zw: zip.NewWriter(w), names: map[string]bool{}}
+}
+
+// write 将博客写入名为 `{slug}.md` 的文件，没有 slug 或文件名重复时使用 postID 作为文件名.
+func (w *zipWriter) write(p *Post) error {
+	name := p.Slug + ".md"
+	if p.Slug == "" || w.names[name] {
+		name = p.PostID + ".md"
+	}
+	w.names[name] = true
+
+	data, err := marshalMarkdown(p)
+	if err != nil {
+		return err
+	}
+
+	f, err := w.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified(p)})
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data)
+
+	return err
+}
+
+func (w *zipWriter) close() error {
+	return w.zw.Close()
+}
+
+// modified 返回博客文件在 zip 中的修改时间.
+func modified(p *Post) (t time.Time) {
+	if p.UpdatedAt != nil {
+		t = *p.UpdatedAt
+	}
+
+	return
+}
+
+// marshalMarkdown 将博客编码为带有 YAML front matter 的 Markdown 文件，front matter 与内容之间有一个空行.
+func marshalMarkdown(p *Post) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(frontMatterDelimiter + "\n")
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(p); err != nil {
+		return nil, err
+	}
+	_ = enc.Close()
+
+	buf.WriteString(frontMatterDelimiter + "\n\n")
+	buf.WriteString(p.Content)
+
+	return buf.Bytes(), nil
+}
+
+// parseMarkdown 解析带有 YAML front matter 的 Markdown 文件. 没有指定 contentFormat 时内容按 Markdown 处理.
+func parseMarkdown(data []byte) (*Post, error) {
+	matter, content, err := splitFrontMatter(normalizeNewlines(data), frontMatterDelimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	var p Post
+	if err := yaml.Unmarshal([]byte(matter), &p); err != nil {
+		return nil, fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	// marshalMarkdown 在 front matter 和内容之间添加的空行不属于内容
+	p.Content = strings.TrimPrefix(content, "\n")
+
+	if p.ContentFormat == "" {
+		p.ContentFormat = "markdown"
+	}
+
+	return &p, nil
+}
+
+// normalizeNewlines 将 data 中的 CRLF 换行转换为 LF，并去掉开头的 UTF-8 BOM.
+func normalizeNewlines(data []byte) string {
+	return strings.TrimPrefix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\ufeff")
+}
+
+// splitFrontMatter 将 Markdown 文件拆分为 front matter 和内容，front matter 的开始和结束是只包含 delimiter 的行.
+func splitFrontMatter(text, delimiter string) (matter, content string, err error) {
+	line := delimiter + "\n"
+	if !strings.HasPrefix(text, line) {
+		return "", "", errors.New("missing front matter")
+	}
+	text = "\n" + text[len(line):]
+
+	if end := strings.Index(text, "\n"+line); end >= 0 {
+		return text[:end], text[end+len(line)+1:], nil
+	}
+
+	if strings.HasSuffix(text, "\n"+delimiter) {
+		return text[:len(text)-len(delimiter)-1], "", nil
+	}
+
+	return "", "", errors.New("unterminated front matter")
+}
+
+// isMarkdownFile 判断文件名是否是 Markdown 文件.
+func isMarkdownFile(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+
+	return ext == ".md" || ext == ".markdown"
+}
+
+// readZip 读取 zip 压缩包中所有扩展名为 .md 或 .markdown 的文件，其余文件被忽略.
+func readZip(data []byte) ([]*Entry, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*Entry
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || strings.HasPrefix(f.Name, "__MACOSX/") || strings.HasPrefix(path.Base(f.Name), ".") {
+			continue
+		}
+
+		if !isMarkdownFile(f.Name) {
+			continue
+		}
+
+		entry := &Entry{Name: f.Name}
+		if content, err := readZipFile(f); err != nil {
+			entry.Err = err
+		} else {
+			entry.Post, entry.Err = parseMarkdown(content)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// readZipFile 读取 zip 中的一个文件，文件解压后超过 MaxPostSize 时返回错误.
+func readZipFile(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return readLimited(r)
+}
+
+// readLimited 读取 r 中的全部内容，超过 MaxPostSize 时返回错误.
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxPostSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > MaxPostSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", MaxPostSize)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package archive
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/marmotedu/miniblog/pkg/util/slug"
+)
+
+// wxrTimeLayout 是 WXR 中 wp:post_date 等时间字段的格式.
+const wxrTimeLayout = "2006-01-02 15:04:05"
+
+// blockTag 匹配以块级 HTML 元素开始的段落，这样的段落不需要再用 <p> 包裹.
+var blockTag = regexp.MustCompile(`^<(?i:h[1-6]|p|ul|ol|li|dl|blockquote|pre|div|table|figure|hr|iframe|script|style|form|address|section)\b`)
+
+// wxrRSS 是 WXR 文件的结构. WXR 各个版本中 wp 命名空间的 URL 不同，所以 wp 的字段只按名称匹配.
+type wxrRSS struct {
+	Items []*wxrItem `xml:"channel>item"`
+}
+
+// wxrItem 是 WXR 中的一项，除博客外，页面、附件和菜单等也以 item 的形式导出.
+type wxrItem struct {
+	Title         string         `xml:"title"`
+	PubDate       string         `xml:"pubDate"`
+	Creator       string         `xml:"creator"`
+	Content       string         `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PostID        string         `xml:"post_id"`
+	PostDate      string         `xml:"post_date"`
+	PostDateGMT   string         `xml:"post_date_gmt"`
+	ModifiedGMT   string         `xml:"post_modified_gmt"`
+	PostName      string         `xml:"post_name"`
+	Status        string         `xml:"status"`
+	PostType      string         `xml:"post_type"`
+	Password      string         `xml:"post_password"`
+	CommentStatus string         `xml:"comment_status"`
+	Categories    []*wxrCategory `xml:"category"`
+}
+
+// wxrCategory 是博客的分类或标签，Domain 为 post_tag 时是标签.
+type wxrCategory struct {
+	Domain string `xml:"domain,attr"`
+	Name   string `xml:",chardata"`
+}
+
+// readWXR 解析 WordPress 导出的 WXR 文件. 只读取类型为 post 的 item，回收站中的博客、自动草稿和页面等被忽略.
+// WordPress 的分类不会被导入，标签转换为博客的 tag.
+func readWXR(data []byte) ([]*Entry, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+
+	var rss wxrRSS
+	if err := dec.Decode(&rss); err != nil {
+		return nil, fmt.Errorf("invalid WXR file: %w", err)
+	}
+
+	var entries []*Entry
+	for _, item := range rss.Items {
+		if item.PostType != "post" {
+			continue
+		}
+
+		entry := &Entry{Name: "post " + item.PostID}
+		if entry.Post, entry.Err = wxrPost(item); entry.Post == nil && entry.Err == nil {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// wxrPost 将 WXR 中的博客转换为归档中的博客，博客不需要导入时返回 nil.
+// 私有和设置了密码的博客导入为 private 的博客.
+func wxrPost(item *wxrItem) (*Post, error) {
+	p := &Post{
+		Slug:          wxrSlug(item.PostName),
+		Title:         strings.TrimSpace(item.Title),
+		ContentFormat: "html",
+		Visibility:    "public",
+		Author:        strings.TrimSpace(item.Creator),
+		Content:       autop(item.Content),
+	}
+
+	switch item.Status {
+	case "publish":
+		p.Status = "published"
+	case "private":
+		p.Status, p.Visibility = "published", "private"
+	case "future":
+		p.Status = "scheduled"
+	case "draft", "pending":
+		p.Status = "draft"
+	default:
+		return nil, nil
+	}
+
+	if item.Password != "" {
+		p.Visibility = "private"
+	}
+
+	if item.CommentStatus == "closed" {
+		p.CommentPolicy = "closed"
+	} else {
+		p.CommentPolicy = "open"
+	}
+
+	for _, c := range item.Categories {
+		if c.Domain == "post_tag" {
+			p.Tags = append(p.Tags, strings.TrimSpace(c.Name))
+		}
+	}
+
+	var err error
+	if p.CreatedAt, err = wxrTime(item.PostDateGMT, item.PostDate, item.PubDate); err != nil {
+		return nil, err
+	}
+
+	if p.Status != "draft" {
+		p.PublishAt = p.CreatedAt
+	}
+
+	if p.UpdatedAt, err = wxrTime(item.ModifiedGMT); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// wxrTime 返回 values 中第一个有效的时间. 未发布的博客的 GMT 时间为 `0000-00-00 00:00:00`，
+// 此时使用站点时区的时间. pubDate 是 RFC 1123 格式的时间.
+func wxrTime(values ...string) (*time.Time, error) {
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || strings.HasPrefix(v, "0000-00-00") {
+			continue
+		}
+
+		t, err := time.Parse(wxrTimeLayout, v)
+		if err != nil {
+			if t, err = time.Parse(time.RFC1123Z, v); err != nil {
+				return nil, fmt.Errorf("invalid time %q", v)
+			}
+			t = t.UTC()
+		}
+
+		return &t, nil
+	}
+
+	return nil, nil
+}
+
+// wxrSlug 返回 WordPress 中博客的 slug，非 ASCII 字符在 post_name 中是 URL 编码的.
+func wxrSlug(postName string) string {
+	if s, err := url.PathUnescape(postName); err == nil {
+		postName = s
+	}
+
+	return slug.Make(postName)
+}
+
+// autop 模拟 WordPress 的 wpautop：经典编辑器保存的内容用空行分隔段落，显示时才转换为 <p>.
+// 已经包含 <p> 的内容（例如块编辑器保存的内容）保持不变.
+func autop(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if strings.Contains(content, "<p>") || strings.Contains(content, "<p ") {
+		return content
+	}
+
+	var b strings.Builder
+	for _, para := range strings.Split(content, "\n\n") {
+		para = strings.Trim(para, "\n")
+		if strings.TrimSpace(para) == "" {
+			continue
+		}
+
+		if blockTag.MatchString(strings.TrimSpace(para)) {
+			b.WriteString(para)
+		} else {
+			b.WriteString("<p>" + strings.ReplaceAll(para, "\n", "<br />\n") + "</p>")
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package archive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const fakeWXR = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0"
+	xmlns:excerpt="http://wordpress.org/export/1.2/excerpt/"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<title>Old blog</title>
+	<wp:wxr_version>1.2</wp:wxr_version>
+	<item>
+		<title>Hello &amp; welcome</title>
+		<pubDate>Sun, 20 Nov 2022 08:30:00 +0000</pubDate>
+		<dc:creator><![CDATA[jane]]></dc:creator>
+		<content:encoded><![CDATA[First line
+second line
+
+<ul><li>item</li></ul>]]></content:encoded>
+		<excerpt:encoded><![CDATA[excerpt]]></excerpt:encoded>
+		<wp:post_id>12</wp:post_id>
+		<wp:post_date><![CDATA[2022-11-20 16:30:00]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[2022-11-20 08:30:00]]></wp:post_date_gmt>
+		<wp:post_modified_gmt><![CDATA[2022-11-21 08:30:00]]></wp:post_modified_gmt>
+		<wp:comment_status><![CDATA[closed]]></wp:comment_status>
+		<wp:post_name><![CDATA[%e4%bd%a0%e5%a5%bd-world]]></wp:post_name>
+		<wp:status><![CDATA[publish]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+		<wp:post_password><![CDATA[]]></wp:post_password>
+		<category domain="category" nicename="uncategorized"><![CDATA[Uncategorized]]></category>
+		<category domain="post_tag" nicename="go"><![CDATA[Go]]></category>
+		<wp:comment>
+			<wp:comment_date_gmt><![CDATA[2022-11-22 00:00:00]]></wp:comment_date_gmt>
+		</wp:comment>
+	</item>
+	<item>
+		<title>Unfinished</title>
+		<dc:creator><![CDATA[bob]]></dc:creator>
+		<content:encoded><![CDATA[<!-- wp:paragraph --><p>Draft</p><!-- /wp:paragraph -->]]></content:encoded>
+		<wp:post_id>13</wp:post_id>
+		<wp:post_date><![CDATA[2022-11-23 10:00:00]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[0000-00-00 00:00:00]]></wp:post_date_gmt>
+		<wp:post_name><![CDATA[]]></wp:post_name>
+		<wp:status><![CDATA[draft]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+		<wp:post_password><![CDATA[secret]]></wp:post_password>
+	</item>
+	<item>
+		<title>About</title>
+		<wp:post_id>2</wp:post_id>
+		<wp:status><![CDATA[publish]]></wp:status>
+		<wp:post_type><![CDATA[page]]></wp:post_type>
+	</item>
+	<item>
+		<title>Deleted</title>
+		<wp:post_id>14</wp:post_id>
+		<wp:status><![CDATA[trash]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+	</item>
+	<item>
+		<title>Bad date</title>
+		<wp:post_id>15</wp:post_id>
+		<wp:post_date_gmt><![CDATA[yesterday]]></wp:post_date_gmt>
+		<wp:status><![CDATA[publish]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+	</item>
+</channel>
+</rss>
+`
+
+func TestReadWXR(t *testing.T) {
+	format, entries, err := Read([]byte(fakeWXR), "")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatWXR, format)
+
+	if !assert.Len(t, entries, 3) {
+		return
+	}
+
+	published := time.Date(2022, 11, 20, 8, 30, 0, 0, time.UTC)
+	modified := time.Date(2022, 11, 21, 8, 30, 0, 0, time.UTC)
+	assert.Equal(t, &Entry{Name: "post 12", Post: &Post{
+		Slug:          "你好-world",
+		Title:         "Hello & welcome",
+		ContentFormat: "html",
+		Status:        "published",
+		Visibility:    "public",
+		CommentPolicy: "closed",
+		Tags:          []string{"Go"},
+		PublishAt:     &published,
+		CreatedAt:     &published,
+		UpdatedAt:     &modified,
+		Author:        "jane",
+		Content:       "<p>First line<br />\nsecond line</p>\n<ul><li>item</li></ul>\n",
+	}}, entries[0])
+
+	created := time.Date(2022, 11, 23, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, &Entry{Name: "post 13", Post: &Post{
+		Title:         "Unfinished",
+		ContentFormat: "html",
+		Status:        "draft",
+		Visibility:    "private",
+		CommentPolicy: "open",
+		CreatedAt:     &created,
+		Author:        "bob",
+		Content:       "<!-- wp:paragraph --><p>Draft</p><!-- /wp:paragraph -->",
+	}}, entries[1])
+
+	assert.Equal(t, "post 15", entries[2].Name)
+	assert.EqualError(t, entries[2].Err, `invalid time "yesterday"`)
+
+	_, _, err = Read([]byte("<rss><channel>"), FormatWXR)
+	assert.Error(t, err)
+}
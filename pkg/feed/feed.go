@@ -0,0 +1,70 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Package feed 将博客列表生成 RSS 2.0、Atom 1.0 和 JSON Feed 1.1 格式的订阅源.
+package feed
+
+import (
+	"errors"
+	"time"
+)
+
+// 支持的订阅源格式.
+const (
+	FormatRSS  = "rss"
+	FormatAtom = "atom"
+	FormatJSON = "json"
+)
+
+// ErrUnsupportedFormat 表示不支持的订阅源格式.
+var ErrUnsupportedFormat = errors.New("feed: unsupported format")
+
+// Feed 是与格式无关的订阅源. 所有链接都必须是绝对地址.
+type Feed struct {
+	// Title 是订阅源的标题，Description 是订阅源的简介.
+	Title       string
+	Description string
+	// Link 是订阅源对应的网页地址，FeedURL 是订阅源自身的地址.
+	Link    string
+	FeedURL string
+	// Author 是订阅源的作者.
+	Author string
+	// Updated 是订阅源最后的更新时间.
+	Updated time.Time
+	// Items 按发布时间从新到旧排列.
+	Items []*Item
+}
+
+// Item 是订阅源中的一篇文章.
+type Item struct {
+	// ID 是文章永久不变的唯一标识，必须是 URL.
+	ID    string
+	Title string
+	// Link 是文章的网页地址.
+	Link string
+	// ContentHTML 是文章的 HTML 内容.
+	ContentHTML string
+	Author      string
+	Tags        []string
+	Published   time.Time
+	Updated     time.Time
+}
+
+// Render 按 format 生成订阅源，返回订阅源的内容和 Content-Type.
+func Render(f *Feed, format string) ([]byte, string, error) {
+	switch format {
+	case FormatRSS:
+		data, err := RSS(f)
+		return data, "application/rss+xml; charset=utf-8", err
+	case FormatAtom:
+		data, err := Atom(f)
+		return data, "application/atom+xml; charset=utf-8", err
+	case FormatJSON:
+		data, err := JSON(f)
+		return data, "application/feed+json; charset=utf-8", err
+	default:
+		return nil, "", ErrUnsupportedFormat
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fakeFeed() *Feed {
+	published := time.Date(2022, 11, 20, 8, 30, 0, 0, time.UTC)
+
+	return &Feed{
+		Title:   "belm's posts",
+		Link:    "https://blog.example.com/v1/users/belm/posts",
+		FeedURL: "https://blog.example.com/v1/users/belm/feed.atom",
+		Author:  "belm",
+		Updated: published.Add(time.Hour),
+		Items: []*Item{{
+			ID:          "https://blog.example.com/v1/public/posts/post-1",
+			Title:       "Hello & welcome",
+			Link:        "https://blog.example.com/v1/public/belm/hello",
+			ContentHTML: "<p>Hello <b>world</b></p>",
+			Author:      "belm",
+			Tags:        []string{"go", "web"},
+			Published:   published,
+			Updated:     published.Add(time.Hour),
+		}},
+	}
+}
+
+func TestRSS(t *testing.T) {
+	data, err := RSS(fakeFeed())
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `<?xml version="1.0" encoding="UTF-8"?>`)
+	assert.Contains(t, string(data), `<atom:link href="https://blog.example.com/v1/users/belm/feed.atom" rel="self" type="application/rss+xml"></atom:link>`)
+
+	var doc struct {
+		Version string `xml:"version,attr"`
+		Channel struct {
+			Title         string `xml:"title"`
+			Description   string `xml:"description"`
+			LastBuildDate string `xml:"lastBuildDate"`
+			Items         []struct {
+				Title       string   `xml:"title"`
+				GUID        string   `xml:"guid"`
+				PubDate     string   `xml:"pubDate"`
+				Categories  []string `xml:"category"`
+				Description string   `xml:"description"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	assert.Nil(t, xml.Unmarshal(data, &doc))
+	assert.Equal(t, "2.0", doc.Version)
+	assert.Equal(t, "belm's posts", doc.Channel.Description)
+	assert.Equal(t, "Sun, 20 Nov 2022 09:30:00 +0000", doc.Channel.LastBuildDate)
+	if assert.Len(t, doc.Channel.Items, 1) {
+		item := doc.Channel.Items[0]
+		assert.Equal(t, "Hello & welcome", item.Title)
+		assert.Equal(t, "https://blog.example.com/v1/public/posts/post-1", item.GUID)
+		assert.Equal(t, "Sun, 20 Nov 2022 08:30:00 +0000", item.PubDate)
+		assert.Equal(t, []string{"go", "web"}, item.Categories)
+		assert.Equal(t, "<p>Hello <b>world</b></p>", item.Description)
+	}
+}
+
+func TestAtom(t *testing.T) {
+	data, err := Atom(fakeFeed())
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `<feed xmlns="http://www.w3.org/2005/Atom">`)
+
+	var doc struct {
+		ID      string `xml:"id"`
+		Updated string `xml:"updated"`
+		Author  string `xml:"author>name"`
+		Entries []struct {
+			ID        string `xml:"id"`
+			Published string `xml:"published"`
+			Updated   string `xml:"updated"`
+			Author    string `xml:"author>name"`
+			Content   struct {
+				Type  string `xml:"type,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"content"`
+		} `xml:"entry"`
+	}
+	assert.Nil(t, xml.Unmarshal(data, &doc))
+	assert.Equal(t, "https://blog.example.com/v1/users/belm/feed.atom", doc.ID)
+	assert.Equal(t, "2022-11-20T09:30:00Z", doc.Updated)
+	assert.Equal(t, "belm", doc.Author)
+	if assert.Len(t, doc.Entries, 1) {
+		entry := doc.Entries[0]
+		assert.Equal(t, "2022-11-20T08:30:00Z", entry.Published)
+		assert.Equal(t, "2022-11-20T09:30:00Z", entry.Updated)
+		assert.Empty(t, entry.Author)
+		assert.Equal(t, "html", entry.Content.Type)
+		assert.Equal(t, "<p>Hello <b>world</b></p>", entry.Content.Value)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	data, err := JSON(fakeFeed())
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"content_html": "<p>Hello <b>world</b></p>"`)
+
+	var doc map[string]interface{}
+	assert.Nil(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, "https://jsonfeed.org/version/1.1", doc["version"])
+	assert.Equal(t, "https://blog.example.com/v1/users/belm/feed.atom", doc["feed_url"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "belm"}}, doc["authors"])
+
+	items := doc["items"].([]interface{})
+	if assert.Len(t, items, 1) {
+		item := items[0].(map[string]interface{})
+		assert.Equal(t, "https://blog.example.com/v1/public/posts/post-1", item["id"])
+		assert.Equal(t, "2022-11-20T08:30:00Z", item["date_published"])
+		assert.Equal(t, []interface{}{"go", "web"}, item["tags"])
+	}
+
+	// An empty feed has an empty list of items.
+	data, _ = JSON(&Feed{Title: "empty"})
+	assert.Contains(t, string(data), `"items": []`)
+}
+
+func TestRender(t *testing.T) {
+	for format, contentType := range map[string]string{
+		FormatRSS:  "application/rss+xml; charset=utf-8",
+		FormatAtom: "application/atom+xml; charset=utf-8",
+		FormatJSON: "application/feed+json; charset=utf-8",
+	} {
+		data, got, err := Render(fakeFeed(), format)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, data)
+		assert.Equal(t, contentType, got)
+	}
+
+	_, _, err := Render(fakeFeed(), "xml")
+	assert.Equal(t, ErrUnsupportedFormat, err)
+}
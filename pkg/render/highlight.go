@@ -0,0 +1,238 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package render
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+// language 定义了一种编程语言用于语法高亮的词法规则.
+type language struct {
+	keywords     map[string]bool
+	literals     map[string]bool
+	lineComments []string
+	blockComment [2]string
+	quotes       string
+	// ignoreCase 为 true 时关键字不区分大小写.
+	ignoreCase bool
+}
+
+// languages 是支持语法高亮的语言，key 为代码块的语言名（小写）.
+var languages = map[string]*language{}
+
+func init() {
+	cStyle := [2]string{"/*", "*/"}
+
+	register(&language{
+		keywords: words("break case chan const continue default defer else fallthrough for func go goto if import " +
+			"interface map package range return select struct switch type var"),
+		literals:     words("true false nil iota"),
+		lineComments: []string{"//"},
+		blockComment: cStyle,
+		quotes:       "\"'`",
+	}, "go", "golang")
+
+	register(&language{
+		keywords: words("async await break case catch class const continue debugger default delete do else enum " +
+			"export extends finally for function if implements import in instanceof interface let new of return " +
+			"super switch this throw try type typeof var void while with yield"),
+		literals:     words("true false null undefined NaN"),
+		lineComments: []string{"//"},
+		blockComment: cStyle,
+		quotes:       "\"'`",
+	}, "javascript", "js", "typescript", "ts")
+
+	register(&language{
+		keywords: words("and as assert async await break class continue def del elif else except finally for " +
+			"from global if import in is lambda nonlocal not or pass raise return try while with yield"),
+		literals:     words("True False None"),
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	}, "python", "py")
+
+	register(&language{
+		keywords:     words("if then else elif fi case esac for while until do done in function return local export"),
+		literals:     words("true false"),
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	}, "shell", "sh", "bash", "zsh")
+
+	register(&language{
+		keywords: words("abstract assert boolean break byte case catch char class const continue default do " +
+			"double else enum extends final finally float for goto if implements import instanceof int interface " +
+			"long native new package private protected public return short static super switch synchronized this " +
+			"throw throws transient try var void volatile while"),
+		literals:     words("true false null"),
+		lineComments: []string{"//"},
+		blockComment: cStyle,
+		quotes:       "\"'",
+	}, "java")
+
+	register(&language{
+		keywords: words("auto bool break case char class const continue default delete do double else enum " +
+			"extern float for goto if inline int long namespace new private protected public register return " +
+			"short signed sizeof static struct switch template typedef typename union unsigned using virtual void " +
+			"volatile while"),
+		literals:     words("true false NULL nullptr"),
+		lineComments: []string{"//"},
+		blockComment: cStyle,
+		quotes:       "\"'",
+	}, "c", "h", "cpp", "c++", "cc")
+
+	register(&language{
+		keywords: words("as async await break const continue crate dyn else enum extern fn for if impl in let " +
+			"loop match mod move mut pub ref return self Self static struct super trait type unsafe use where while"),
+		literals:     words("true false None Some Ok Err"),
+		lineComments: []string{"//"},
+		blockComment: cStyle,
+		quotes:       "\"",
+	}, "rust", "rs")
+
+	register(&language{
+		keywords: words("select from where insert into values update set delete create table drop alter add " +
+			"index primary key foreign references not and or on join left right inner outer group by order having " +
+			"limit offset as distinct union all in is like between case when then else end default unique"),
+		literals:     words("null true false"),
+		lineComments: []string{"--"},
+		blockComment: cStyle,
+		quotes:       "\"'`",
+		ignoreCase:   true,
+	}, "sql", "mysql")
+
+	register(&language{
+		literals: words("true false null"),
+		quotes:   "\"",
+	}, "json")
+
+	register(&language{
+		literals:     words("true false null yes no"),
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	}, "yaml", "yml")
+}
+
+// register 注册语言 l，names 为语言的名称和别名.
+func register(l *language, names ...string) {
+	for _, name := range names {
+		languages[name] = l
+	}
+}
+
+// words 将空白分隔的单词转换为集合.
+func words(s string) map[string]bool {
+	m := map[string]bool{}
+	for _, w := range strings.Fields(s) {
+		m[w] = true
+	}
+
+	return m
+}
+
+// highlight 对 lang 语言的代码进行语法高亮，返回转义后的 HTML.
+// 注释、字符串、数字、关键字和字面量分别使用 class 为 hl-comment、hl-string、hl-number、hl-keyword 和 hl-literal 的 <span> 包裹.
+// 不支持的语言只做 HTML 转义.
+func highlight(lang, code string) string {
+	l := languages[strings.ToLower(lang)]
+	if l == nil {
+		return html.EscapeString(code)
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(code); {
+		rest := code[i:]
+		switch c := rest[0]; {
+		case hasAnyPrefix(rest, l.lineComments):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+
+			span(&b, "comment", rest[:end])
+			i += end
+		case l.blockComment[0] != "" && strings.HasPrefix(rest, l.blockComment[0]):
+			end := len(rest)
+			if e := strings.Index(rest[len(l.blockComment[0]):], l.blockComment[1]); e >= 0 {
+				end = len(l.blockComment[0]) + e + len(l.blockComment[1])
+			}
+
+			span(&b, "comment", rest[:end])
+			i += end
+		case strings.IndexByte(l.quotes, c) >= 0:
+			end := stringEnd(rest)
+			span(&b, "string", rest[:end])
+			i += end
+		case c >= '0' && c <= '9':
+			end := 1
+			for end < len(rest) && (isWordChar(rest[end]) || rest[end] == '.') && rest[end] < 0x80 {
+				end++
+			}
+
+			span(&b, "number", rest[:end])
+			i += end
+		case c < 0x80 && isWordChar(c):
+			end := 1
+			for end < len(rest) && rest[end] < 0x80 && isWordChar(rest[end]) {
+				end++
+			}
+
+			word, key := rest[:end], rest[:end]
+			if l.ignoreCase {
+				key = strings.ToLower(key)
+			}
+
+			switch {
+			case l.keywords[key]:
+				span(&b, "keyword", word)
+			case l.literals[key]:
+				span(&b, "literal", word)
+			default:
+				b.WriteString(html.EscapeString(word))
+			}
+
+			i += end
+		default:
+			_, size := utf8.DecodeRuneInString(rest)
+			b.WriteString(html.EscapeString(rest[:size]))
+			i += size
+		}
+	}
+
+	return b.String()
+}
+
+// stringEnd 返回以 s[0] 为引号的字符串字面量的长度. 除反引号外，字符串不能跨行；反斜杠转义下一个字符.
+func stringEnd(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote != '`':
+			i++
+		case s[i] == quote:
+			return i + 1
+		case s[i] == '\n' && quote != '`':
+			return i
+		}
+	}
+
+	return len(s)
+}
+
+// span 写入使用 hl-class 高亮的文本.
+func span(b *strings.Builder, class, text string) {
+	b.WriteString(`<span class="hl-` + class + `">` + html.EscapeString(text) + "</span>")
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,370 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package render
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	entityPattern     = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[A-Za-z][A-Za-z0-9]{1,31});`)
+	autolinkPattern   = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9+.-]{1,31}:[^<>\x00-\x20]*)>`)
+	emailPattern      = regexp.MustCompile(`^<([A-Za-z0-9.!#$%&'*+/=?^_{|}~-]+@[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?(?:\.[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*)>`)
+	inlineHTMLPattern = regexp.MustCompile(`^(?:<!--[\s\S]*?-->|</?[A-Za-z][A-Za-z0-9-]*(?:\s+[A-Za-z_:][\w.:-]*(?:\s*=\s*(?:[^\s"'=<>` + "`" + `]+|'[^']*'|"[^"]*"))?)*\s*/?>)`)
+	bareURLPattern    = regexp.MustCompile(`^https?://[^\s<]+`)
+)
+
+// inline 渲染行内元素：代码、强调、删除线、链接、图片、自动链接、HTML 标签、转义字符和硬换行.
+// inLink 为 true 时表示正在渲染链接文本，此时不再识别链接，避免生成嵌套的链接.
+func inline(s string, inLink bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			b.WriteString(html.EscapeString(s[i+1 : i+2]))
+			i += 2
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			b.WriteString("<br>\n")
+			i += 2
+		case c == '`':
+			n := runLength(s, i)
+			end := codeSpanEnd(s, i)
+			if end < 0 {
+				b.WriteString(s[i : i+n])
+				i += n
+
+				continue
+			}
+
+			code := strings.ReplaceAll(s[i+n:end-n], "\n", " ")
+			if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+				code = code[1 : len(code)-1]
+			}
+
+			b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+			i = end
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			text, dest, title, end, ok := parseLink(s, i+1)
+			if !ok {
+				b.WriteByte(c)
+				i++
+
+				continue
+			}
+
+			alt := html.UnescapeString(tagPattern.ReplaceAllString(inline(text, true), ""))
+			b.WriteString(`<img src="` + html.EscapeString(dest) + `" alt="` + html.EscapeString(alt) + `"` + titleAttr(title) + ">")
+			i = end
+		case c == '[' && !inLink:
+			text, dest, title, end, ok := parseLink(s, i)
+			if !ok {
+				b.WriteByte(c)
+				i++
+
+				continue
+			}
+
+			b.WriteString(`<a href="` + html.EscapeString(dest) + `"` + titleAttr(title) + ">" + inline(text, true) + "</a>")
+			i = end
+		case c == '<':
+			if m := autolinkPattern.FindStringSubmatch(s[i:]); m != nil && !inLink {
+				b.WriteString(`<a href="` + html.EscapeString(m[1]) + `">` + html.EscapeString(m[1]) + "</a>")
+				i += len(m[0])
+			} else if m := emailPattern.FindStringSubmatch(s[i:]); m != nil && !inLink {
+				b.WriteString(`<a href="mailto:` + html.EscapeString(m[1]) + `">` + html.EscapeString(m[1]) + "</a>")
+				i += len(m[0])
+			} else if m := inlineHTMLPattern.FindString(s[i:]); m != "" {
+				// 原样输出 HTML 标签，最终由 Sanitize 过滤
+				b.WriteString(m)
+				i += len(m)
+			} else {
+				b.WriteString("&lt;")
+				i++
+			}
+		case c == '&':
+			if m := entityPattern.FindString(s[i:]); m != "" {
+				b.WriteString(m)
+				i += len(m)
+			} else {
+				b.WriteString("&amp;")
+				i++
+			}
+		case c == '*' || c == '_' || c == '~':
+			if out, end, ok := emphasis(s, i, inLink); ok {
+				b.WriteString(out)
+				i = end
+			} else {
+				n := runLength(s, i)
+				b.WriteString(s[i : i+n])
+				i += n
+			}
+		case c == ' ':
+			// 行尾的两个及以上空格表示硬换行
+			n := runLength(s, i)
+			switch {
+			case i+n < len(s) && s[i+n] == '\n' && n >= 2:
+				b.WriteString("<br>\n")
+				i += n + 1
+			case i+n < len(s) && s[i+n] == '\n':
+				i += n
+			default:
+				b.WriteString(s[i : i+n])
+				i += n
+			}
+		case c == 'h' && !inLink && (i == 0 || strings.IndexByte(" \n(*_~", s[i-1]) >= 0) && bareURLPattern.MatchString(s[i:]):
+			u := trimURL(bareURLPattern.FindString(s[i:]))
+			b.WriteString(`<a href="` + html.EscapeString(u) + `">` + html.EscapeString(u) + "</a>")
+			i += len(u)
+		case c == '>' || c == '"':
+			b.WriteString(html.EscapeString(s[i : i+1]))
+			i++
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	return b.String()
+}
+
+// emphasis 渲染从 s[i] 开始的强调（`*`、`_`）、加粗（`**`、`__`）或删除线（`~~`），返回渲染结果和之后的位置.
+func emphasis(s string, i int, inLink bool) (string, int, bool) {
+	c, n := s[i], runLength(s, i)
+
+	// 开始的分隔符后面不能是空白，`_` 不能出现在单词内部
+	if i+n >= len(s) || isSpace(s[i+n]) || (c == '_' && i > 0 && isWordChar(s[i-1])) {
+		return "", 0, false
+	}
+
+	var candidates []int
+	switch {
+	case c == '~' && n == 2:
+		candidates = []int{2}
+	case c == '~':
+		return "", 0, false
+	case n >= 2:
+		candidates = []int{2, 1}
+	default:
+		candidates = []int{1}
+	}
+
+	tags := map[int]string{1: "em", 2: "strong"}
+	if c == '~' {
+		tags[2] = "del"
+	}
+
+	for _, d := range candidates {
+		end, m := closingDelimiter(s, i+n, c, d)
+		if end < 0 || end == i+n {
+			continue
+		}
+
+		// 结束分隔符不少于开始分隔符时，多出的开始分隔符属于内容，例如 `***a***` 渲染为 <strong><em>a</em></strong>；
+		// 否则多出的开始分隔符原样输出，例如 `**a*` 渲染为 *<em>a</em>
+		prefix, content := "", s[i+d:end]
+		if m < n {
+			prefix, content = s[i:i+n-d], s[i+n:end]
+		}
+
+		return prefix + "<" + tags[d] + ">" + inline(content, inLink) + "</" + tags[d] + ">", end + d, true
+	}
+
+	return "", 0, false
+}
+
+// closingDelimiter 从 s[from] 开始查找 d 个 c 组成的结束分隔符，返回结束分隔符的位置和所在分隔符序列的长度，找不到时返回 -1.
+// 结束分隔符前面不能是空白，`_` 不能出现在单词内部，代码中的字符会被跳过.
+func closingDelimiter(s string, from int, c byte, d int) (int, int) {
+	for j := from; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '`':
+			if end := codeSpanEnd(s, j); end > 0 {
+				j = end - 1
+			} else {
+				j += runLength(s, j) - 1
+			}
+		case c:
+			m := runLength(s, j)
+			if m >= d && !isSpace(s[j-1]) && (c != '_' || j+m >= len(s) || !isWordChar(s[j+m])) {
+				return j + m - d, m
+			}
+
+			j += m - 1
+		}
+	}
+
+	return -1, 0
+}
+
+// codeSpanEnd 返回从 s[i] 开始的行内代码结束之后的位置，没有相同长度的结束反引号时返回 -1.
+func codeSpanEnd(s string, i int) int {
+	n := runLength(s, i)
+	for j := i + n; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+
+		m := runLength(s, j)
+		if m == n {
+			return j + m
+		}
+
+		j += m
+	}
+
+	return -1
+}
+
+// parseLink 解析从 s[i]（`[`）开始的 `[text](dest "title")`，返回链接文本、地址、标题和链接之后的位置.
+func parseLink(s string, i int) (text, dest, title string, end int, ok bool) {
+	depth, closing := 0, -1
+	for j := i; j < len(s) && closing < 0; j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '`':
+			if e := codeSpanEnd(s, j); e > 0 {
+				j = e - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closing = j
+			}
+		}
+	}
+
+	if closing < 0 || closing+1 >= len(s) || s[closing+1] != '(' {
+		return "", "", "", 0, false
+	}
+
+	k := skipSpaces(s, closing+2)
+	if k < len(s) && s[k] == '<' {
+		e := strings.IndexAny(s[k:], ">\n")
+		if e < 0 || s[k+e] != '>' {
+			return "", "", "", 0, false
+		}
+
+		dest, k = s[k+1:k+e], k+e+1
+	} else {
+		start, parens := k, 0
+		for ; k < len(s) && s[k] > ' '; k++ {
+			if s[k] == '\\' && k+1 < len(s) {
+				k++
+			} else if s[k] == '(' {
+				parens++
+			} else if s[k] == ')' {
+				if parens == 0 {
+					break
+				}
+
+				parens--
+			}
+		}
+
+		dest = s[start:k]
+	}
+
+	k = skipSpaces(s, k)
+	if k < len(s) && strings.IndexByte(`"'(`, s[k]) >= 0 && k > closing+2 {
+		closer := s[k]
+		if closer == '(' {
+			closer = ')'
+		}
+
+		e := strings.IndexByte(s[k+1:], closer)
+		if e < 0 {
+			return "", "", "", 0, false
+		}
+
+		title, k = s[k+1:k+1+e], skipSpaces(s, k+e+2)
+	}
+
+	if k >= len(s) || s[k] != ')' {
+		return "", "", "", 0, false
+	}
+
+	return s[i+1 : closing], unescape(dest), unescape(title), k + 1, true
+}
+
+// titleAttr 返回链接或图片的 title 属性.
+func titleAttr(title string) string {
+	if title == "" {
+		return ""
+	}
+
+	return ` title="` + html.EscapeString(title) + `"`
+}
+
+// trimURL 删除自动识别的链接末尾的标点，以及没有对应左括号的右括号.
+func trimURL(u string) string {
+	for len(u) > 0 {
+		last := u[len(u)-1]
+		if strings.IndexByte(`.,:;!?'"*_~`, last) >= 0 ||
+			(last == ')' && strings.Count(u, ")") > strings.Count(u, "(")) {
+			u = u[:len(u)-1]
+
+			continue
+		}
+
+		break
+	}
+
+	return u
+}
+
+// unescape 处理反斜杠转义和 HTML 实体.
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isPunct(s[i+1]) {
+			i++
+		}
+
+		b.WriteByte(s[i])
+	}
+
+	return html.UnescapeString(b.String())
+}
+
+// runLength 返回从 s[i] 开始连续相同字符的个数.
+func runLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+
+	return n
+}
+
+// skipSpaces 返回从 s[i] 开始第一个不是空格或换行的位置.
+func skipSpaces(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\n') {
+		i++
+	}
+
+	return i
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t'
+}
+
+func isWordChar(c byte) bool {
+	return c >= 0x80 || c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
@@ -0,0 +1,520 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package render
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	atxHeadingPattern    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	setextH1Pattern      = regexp.MustCompile(`^ {0,3}=+[ \t]*$`)
+	setextH2Pattern      = regexp.MustCompile(`^ {0,3}-+[ \t]*$`)
+	thematicBreakPattern = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fencePattern         = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})(.*)$")
+	blockquotePattern    = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	listItemPattern      = regexp.MustCompile(`^( {0,3})([-*+]|[0-9]{1,9}[.)])(?:( {1,4})(.*))?$`)
+	htmlBlockPattern     = regexp.MustCompile(`^ {0,3}<(?:[A-Za-z][A-Za-z0-9-]*|/[A-Za-z][A-Za-z0-9-]*|!--)`)
+	tableDelimPattern    = regexp.MustCompile(`^ {0,3}\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	tagPattern           = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Markdown 将 Markdown 渲染为经过 Sanitize 过滤的 HTML.
+// 支持 CommonMark 的常用语法以及 GFM 的表格、删除线和自动链接. 代码块根据语言进行语法高亮，
+// 标题带有根据标题文本生成的 id 和指向自身的锚点链接.
+func Markdown(src string) string {
+	r := &markdown{anchors: map[string]bool{}}
+	r.blocks(strings.Split(expandTabs(normalizeNewlines(src)), "\n"), false)
+
+	return Sanitize(r.b.String())
+}
+
+// markdown 保存渲染一篇 Markdown 文档的状态.
+type markdown struct {
+	b strings.Builder
+	// anchors 记录已经使用的标题 id，保证同一篇文档中的 id 不重复.
+	anchors map[string]bool
+}
+
+// blocks 渲染块级元素. tight 为 true 时段落不使用 <p> 包裹，用于紧凑列表中的列表项.
+func (r *markdown) blocks(lines []string, tight bool) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+		case isFence(line):
+			i = r.fencedCode(lines, i)
+		case atxHeadingPattern.MatchString(line):
+			m := atxHeadingPattern.FindStringSubmatch(line)
+			r.heading(len(m[1]), m[2])
+			i++
+		case thematicBreakPattern.MatchString(line):
+			r.b.WriteString("<hr>\n")
+			i++
+		case blockquotePattern.MatchString(line):
+			i = r.blockquote(lines, i)
+		case listItemPattern.MatchString(line):
+			i = r.list(lines, i)
+		case indentOf(line) >= 4:
+			i = r.indentedCode(lines, i)
+		case htmlBlockPattern.MatchString(line):
+			i = r.htmlBlock(lines, i)
+		case isTable(lines, i):
+			i = r.table(lines, i)
+		default:
+			i = r.paragraph(lines, i, tight)
+		}
+	}
+}
+
+// paragraph 渲染从 lines[i] 开始的段落，段落后面是 `===` 或 `---` 时渲染为标题. 返回段落之后的行号.
+func (r *markdown) paragraph(lines []string, i int, tight bool) int {
+	var text []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if isBlank(line) {
+			break
+		}
+
+		if len(text) > 0 {
+			if setextH1Pattern.MatchString(line) {
+				r.heading(1, strings.Join(text, "\n"))
+				return i + 1
+			}
+
+			if setextH2Pattern.MatchString(line) {
+				r.heading(2, strings.Join(text, "\n"))
+				return i + 1
+			}
+
+			if interruptsParagraph(line) {
+				break
+			}
+		}
+
+		text = append(text, strings.TrimLeft(line, " "))
+	}
+
+	content := inline(strings.TrimRight(strings.Join(text, "\n"), " "), false)
+	if tight {
+		r.b.WriteString(content + "\n")
+	} else {
+		r.b.WriteString("<p>" + content + "</p>\n")
+	}
+
+	return i
+}
+
+// heading 渲染标题，标题的 id 由标题文本生成.
+func (r *markdown) heading(level int, text string) {
+	content := inline(strings.TrimSpace(text), false)
+	id := r.anchor(content)
+	fmt.Fprintf(&r.b, `<h%d id="%s"><a class="anchor" href="#%s"></a>%s</h%d>`+"\n", level, id, id, content, level)
+}
+
+// anchor 根据标题的 HTML 内容生成在文档中唯一的 id：保留字母、数字、`-` 和 `_`，空白替换为 `-`，字母转换为小写.
+func (r *markdown) anchor(content string) string {
+	text := html.UnescapeString(tagPattern.ReplaceAllString(content, ""))
+
+	var b strings.Builder
+	for _, c := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_':
+			b.WriteRune(c)
+		case c == '-' || unicode.IsSpace(c):
+			b.WriteRune('-')
+		}
+	}
+
+	slug := strings.Trim(b.String(), "-_")
+	for strings.Contains(slug, "--") {
+		slug = strings.ReplaceAll(slug, "--", "-")
+	}
+
+	if slug == "" {
+		slug = "section"
+	}
+
+	id := slug
+	for n := 1; r.anchors[id]; n++ {
+		id = slug + "-" + strconv.Itoa(n)
+	}
+
+	r.anchors[id] = true
+
+	return id
+}
+
+// fencedCode 渲染从 lines[i] 开始的围栏代码块，返回代码块之后的行号.
+func (r *markdown) fencedCode(lines []string, i int) int {
+	m := fencePattern.FindStringSubmatch(lines[i])
+	indent, fence, info := len(m[1]), m[2], strings.Fields(m[3])
+
+	var code []string
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		if trimmed := strings.TrimSpace(line); indentOf(line) <= 3 && len(trimmed) >= len(fence) &&
+			strings.Trim(trimmed, fence[:1]) == "" {
+			i++
+			break
+		}
+
+		// 删除与开始围栏相同数量的缩进
+		n := indentOf(line)
+		if n > indent {
+			n = indent
+		}
+
+		code = append(code, line[n:])
+	}
+
+	var lang string
+	if len(info) > 0 {
+		lang = info[0]
+	}
+
+	r.code(lang, strings.Join(code, "\n"))
+
+	return i
+}
+
+// indentedCode 渲染从 lines[i] 开始的缩进代码块，返回代码块之后的行号.
+func (r *markdown) indentedCode(lines []string, i int) int {
+	var code []string
+	for ; i < len(lines) && (isBlank(lines[i]) || indentOf(lines[i]) >= 4); i++ {
+		if isBlank(lines[i]) {
+			code = append(code, "")
+		} else {
+			code = append(code, lines[i][4:])
+		}
+	}
+
+	for len(code) > 0 && code[len(code)-1] == "" {
+		code = code[:len(code)-1]
+	}
+
+	r.code("", strings.Join(code, "\n"))
+
+	return i
+}
+
+// code 渲染代码块，lang 不为空时对代码进行语法高亮.
+func (r *markdown) code(lang, code string) {
+	r.b.WriteString("<pre><code")
+	if lang != "" {
+		r.b.WriteString(` class="language-` + html.EscapeString(lang) + `"`)
+	}
+
+	r.b.WriteString(">" + highlight(lang, code))
+	if code != "" {
+		r.b.WriteString("\n")
+	}
+
+	r.b.WriteString("</code></pre>\n")
+}
+
+// blockquote 渲染从 lines[i] 开始的引用，返回引用之后的行号.
+func (r *markdown) blockquote(lines []string, i int) int {
+	var inner []string
+	for ; i < len(lines); i++ {
+		if m := blockquotePattern.FindStringSubmatch(lines[i]); m != nil {
+			inner = append(inner, m[1])
+			continue
+		}
+
+		// 段落的后续行可以省略 `>`
+		if isBlank(lines[i]) || len(inner) == 0 || isBlank(inner[len(inner)-1]) || interruptsParagraph(lines[i]) {
+			break
+		}
+
+		inner = append(inner, lines[i])
+	}
+
+	r.b.WriteString("<blockquote>\n")
+	r.blocks(inner, false)
+	r.b.WriteString("</blockquote>\n")
+
+	return i
+}
+
+// list 渲染从 lines[i] 开始的列表，返回列表之后的行号.
+// 列表项之间或列表项内部有空行时为松散列表，列表项中的段落使用 <p> 包裹.
+func (r *markdown) list(lines []string, i int) int {
+	first := listItemPattern.FindStringSubmatch(lines[i])
+	marker := first[2]
+	ordered := marker[0] >= '0' && marker[0] <= '9'
+
+	var (
+		items [][]string
+		loose bool
+	)
+	for i < len(lines) {
+		m := listItemPattern.FindStringSubmatch(lines[i])
+		if m == nil || thematicBreakPattern.MatchString(lines[i]) || !sameList(marker, m[2]) {
+			break
+		}
+
+		// 列表项的内容从列表标记之后的第一个非空白字符开始，后续行需要缩进到相同的位置
+		contentIndent := len(m[1]) + len(m[2]) + len(m[3])
+		if m[3] == "" {
+			contentIndent++
+		}
+
+		item := []string{m[4]}
+		for i++; i < len(lines); {
+			line := lines[i]
+			if isBlank(line) {
+				j := nextNonBlank(lines, i)
+				if j == len(lines) || indentOf(lines[j]) < contentIndent {
+					break
+				}
+
+				for ; i < j; i++ {
+					item = append(item, "")
+				}
+
+				loose = true
+
+				continue
+			}
+
+			if indentOf(line) >= contentIndent {
+				item = append(item, line[contentIndent:])
+				i++
+
+				continue
+			}
+
+			if listItemPattern.MatchString(line) || interruptsParagraph(line) || isBlank(item[len(item)-1]) {
+				break
+			}
+
+			// 段落的后续行可以不缩进
+			item = append(item, strings.TrimLeft(line, " "))
+			i++
+		}
+
+		items = append(items, item)
+
+		if i < len(lines) && isBlank(lines[i]) {
+			j := nextNonBlank(lines, i)
+			if j == len(lines) {
+				break
+			}
+
+			m := listItemPattern.FindStringSubmatch(lines[j])
+			if m == nil || !sameList(marker, m[2]) || thematicBreakPattern.MatchString(lines[j]) {
+				break
+			}
+
+			loose, i = true, j
+		}
+	}
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+
+	r.b.WriteString("<" + tag)
+	if start, _ := strconv.Atoi(strings.TrimRight(marker, ".)")); ordered && start != 1 {
+		r.b.WriteString(` start="` + strconv.Itoa(start) + `"`)
+	}
+
+	r.b.WriteString(">\n")
+	for _, item := range items {
+		sub := &markdown{anchors: r.anchors}
+		sub.blocks(item, !loose)
+		r.b.WriteString("<li>" + strings.TrimSuffix(sub.b.String(), "\n") + "</li>\n")
+	}
+
+	r.b.WriteString("</" + tag + ">\n")
+
+	return i
+}
+
+// htmlBlock 原样输出从 lines[i] 开始到空行为止的 HTML，这些 HTML 最终会被 Sanitize 过滤. 返回 HTML 块之后的行号.
+func (r *markdown) htmlBlock(lines []string, i int) int {
+	for ; i < len(lines) && !isBlank(lines[i]); i++ {
+		r.b.WriteString(lines[i] + "\n")
+	}
+
+	return i
+}
+
+// table 渲染从 lines[i] 开始的 GFM 表格，返回表格之后的行号.
+func (r *markdown) table(lines []string, i int) int {
+	header := splitRow(lines[i])
+
+	var aligns []string
+	for _, cell := range splitRow(lines[i+1]) {
+		switch {
+		case strings.HasPrefix(cell, ":") && strings.HasSuffix(cell, ":"):
+			aligns = append(aligns, "center")
+		case strings.HasPrefix(cell, ":"):
+			aligns = append(aligns, "left")
+		case strings.HasSuffix(cell, ":"):
+			aligns = append(aligns, "right")
+		default:
+			aligns = append(aligns, "")
+		}
+	}
+
+	row := func(tag string, cells []string) {
+		r.b.WriteString("<tr>\n")
+		for n := range aligns {
+			r.b.WriteString("<" + tag)
+			if aligns[n] != "" {
+				r.b.WriteString(` align="` + aligns[n] + `"`)
+			}
+
+			var cell string
+			if n < len(cells) {
+				cell = inline(cells[n], false)
+			}
+
+			r.b.WriteString(">" + cell + "</" + tag + ">\n")
+		}
+
+		r.b.WriteString("</tr>\n")
+	}
+
+	r.b.WriteString("<table>\n<thead>\n")
+	row("th", header)
+	r.b.WriteString("</thead>\n<tbody>\n")
+
+	for i += 2; i < len(lines) && !isBlank(lines[i]) && !interruptsParagraph(lines[i]); i++ {
+		row("td", splitRow(lines[i]))
+	}
+
+	r.b.WriteString("</tbody>\n</table>\n")
+
+	return i
+}
+
+// isTable 判断 lines[i] 是否为表格的表头，即下一行是列数相同的分隔行.
+func isTable(lines []string, i int) bool {
+	return i+1 < len(lines) && strings.Contains(lines[i], "|") && tableDelimPattern.MatchString(lines[i+1]) &&
+		len(splitRow(lines[i])) == len(splitRow(lines[i+1]))
+}
+
+// splitRow 将表格的一行拆分为单元格，`\|` 表示单元格中的 `|`.
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var (
+		cells []string
+		cell  strings.Builder
+	)
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// isFence 判断 line 是否为围栏代码块的开始，使用反引号的围栏其信息字符串中不能包含反引号.
+func isFence(line string) bool {
+	m := fencePattern.FindStringSubmatch(line)
+
+	return m != nil && (m[2][0] != '`' || !strings.Contains(m[3], "`"))
+}
+
+// interruptsParagraph 判断 line 是否会结束前面的段落.
+func interruptsParagraph(line string) bool {
+	if isFence(line) || atxHeadingPattern.MatchString(line) || thematicBreakPattern.MatchString(line) ||
+		blockquotePattern.MatchString(line) || htmlBlockPattern.MatchString(line) {
+		return true
+	}
+
+	// 只有非空的无序列表项和从 1 开始的有序列表项可以结束段落
+	m := listItemPattern.FindStringSubmatch(line)
+
+	return m != nil && strings.TrimSpace(m[4]) != "" && (len(m[2]) == 1 || strings.TrimRight(m[2], ".)") == "1")
+}
+
+// sameList 判断列表标记 a 和 b 是否属于同一个列表：无序列表使用相同的符号，有序列表使用相同的分隔符.
+func sameList(a, b string) bool {
+	aOrdered, bOrdered := a[0] >= '0' && a[0] <= '9', b[0] >= '0' && b[0] <= '9'
+	if aOrdered != bOrdered {
+		return false
+	}
+
+	if aOrdered {
+		return a[len(a)-1] == b[len(b)-1]
+	}
+
+	return a == b
+}
+
+// nextNonBlank 返回 lines[i] 及之后第一个非空行的行号，不存在时返回 len(lines).
+func nextNonBlank(lines []string, i int) int {
+	for i < len(lines) && isBlank(lines[i]) {
+		i++
+	}
+
+	return i
+}
+
+// isBlank 判断 line 是否只包含空白字符.
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// indentOf 返回 line 开头的空格数.
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// expandTabs 将每行开头的制表符替换为空格，制表位的宽度为 4.
+func expandTabs(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if !strings.Contains(line, "\t") {
+			continue
+		}
+
+		var b strings.Builder
+		col := 0
+		for j := 0; j < len(line); j++ {
+			switch line[j] {
+			case '\t':
+				n := 4 - col%4
+				b.WriteString(strings.Repeat(" ", n))
+				col += n
+			case ' ':
+				b.WriteByte(' ')
+				col++
+			default:
+				b.WriteString(line[j:])
+				j = len(line)
+			}
+		}
+
+		lines[i] = b.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
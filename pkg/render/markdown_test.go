@@ -0,0 +1,99 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "emphasis",
+			src:  "Some **bold**, _em_, ***both*** and ~~gone~~ in snake_case_word.",
+			want: "<p>Some <strong>bold</strong>, <em>em</em>, <strong><em>both</em></strong> and <del>gone</del> in snake_case_word.</p>\n",
+		},
+		{
+			name: "heading anchors",
+			src:  "# Hello *World*\n\n## Hello World\n\nIntro\n---",
+			want: `<h1 id="hello-world"><a class="anchor" href="#hello-world"></a>Hello <em>World</em></h1>` + "\n" +
+				`<h2 id="hello-world-1"><a class="anchor" href="#hello-world-1"></a>Hello World</h2>` + "\n" +
+				`<h2 id="intro"><a class="anchor" href="#intro"></a>Intro</h2>` + "\n",
+		},
+		{
+			name: "code block",
+			src:  "```go\nif x := \"<b>\"; x != nil { // check\n}\n```",
+			want: `<pre><code class="language-go"><span class="hl-keyword">if</span> x := <span class="hl-string">&#34;&lt;b&gt;&#34;</span>; ` +
+				`x != <span class="hl-literal">nil</span> { <span class="hl-comment">// check</span>` + "\n}\n</code></pre>\n",
+		},
+		{
+			name: "unknown language",
+			src:  "```brainfuck\n<+>\n```",
+			want: `<pre><code class="language-brainfuck">&lt;+&gt;` + "\n</code></pre>\n",
+		},
+		{
+			name: "inline code",
+			src:  "Use `<script>` and `` a`b ``.",
+			want: "<p>Use <code>&lt;script&gt;</code> and <code>a`b</code>.</p>\n",
+		},
+		{
+			name: "lists",
+			src:  "- a\n- b\n  - c\n\n3. x\n4. y",
+			want: "<ul>\n<li>a</li>\n<li>b\n<ul>\n<li>c</li>\n</ul></li>\n</ul>\n<ol start=\"3\">\n<li>x</li>\n<li>y</li>\n</ol>\n",
+		},
+		{
+			name: "loose list",
+			src:  "- a\n\n- b",
+			want: "<ul>\n<li><p>a</p></li>\n<li><p>b</p></li>\n</ul>\n",
+		},
+		{
+			name: "blockquote and break",
+			src:  "> quote\nlazy\n\n***",
+			want: "<blockquote>\n<p>quote\nlazy</p>\n</blockquote>\n<hr>\n",
+		},
+		{
+			name: "links",
+			src:  `[a](/posts "Posts") ![img](https://x.com/a.png) <https://x.com> see https://x.com/b).`,
+			want: `<p><a href="/posts" title="Posts">a</a> <img src="https://x.com/a.png" alt="img"> ` +
+				`<a href="https://x.com" rel="nofollow noopener noreferrer">https://x.com</a> ` +
+				`see <a href="https://x.com/b" rel="nofollow noopener noreferrer">https://x.com/b</a>).</p>` + "\n",
+		},
+		{
+			name: "table",
+			src:  "| a | b |\n|:--|---|\n| 1 | `x\\|y` |",
+			want: "<table>\n<thead>\n<tr>\n<th align=\"left\">a</th>\n<th>b</th>\n</tr>\n</thead>\n<tbody>\n" +
+				"<tr>\n<td align=\"left\">1</td>\n<td><code>x|y</code></td>\n</tr>\n</tbody>\n</table>\n",
+		},
+		{
+			name: "hard break and escapes",
+			src:  "a  \nb\\\nc \\*d\\* 1 < 2 & 3",
+			want: "<p>a<br>\nb<br>\nc *d* 1 &lt; 2 &amp; 3</p>\n",
+		},
+		{
+			name: "xss",
+			src:  "[x](javascript:alert(1)) <img src=x onerror=alert(1)> <script>alert(1)</script>\n\n<iframe src=\"https://x.com\"></iframe>",
+			want: "<p><a>x</a> <img src=\"x\"> </p>\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Markdown(tt.src))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	assert.Equal(t, "<p>a &lt;b&gt;<br>\nc</p>\n<p>d</p>\n", Render(FormatPlain, "a <b>\nc\n\n\nd"))
+	assert.Equal(t, "<p><em>a</em></p>\n", Render(FormatMarkdown, "*a*"))
+	assert.Equal(t, "<p>a</p>", Render(FormatHTML, "<p onclick=\"x\">a</p>"))
+	assert.Equal(t, Render(FormatPlain, "*a*"), Render("unknown", "*a*"))
+}
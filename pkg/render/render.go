@@ -0,0 +1,53 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Package render 将博客内容渲染为可以直接嵌入页面的安全 HTML.
+package render
+
+import (
+	"html"
+	"strings"
+)
+
+// 支持的内容格式.
+const (
+	FormatPlain    = "plain"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// Render 将 format 格式的 content 渲染为经过过滤的 HTML，未知的格式按 FormatPlain 处理.
+func Render(format, content string) string {
+	switch format {
+	case FormatMarkdown:
+		return Markdown(content)
+	case FormatHTML:
+		return Sanitize(content)
+	default:
+		return Plain(content)
+	}
+}
+
+// Plain 将纯文本渲染为 HTML：空行分隔的文本渲染为段落，段落中的换行渲染为 <br>.
+func Plain(text string) string {
+	var b strings.Builder
+	for _, para := range strings.Split(normalizeNewlines(text), "\n\n") {
+		para = strings.Trim(para, "\n")
+		if strings.TrimSpace(para) == "" {
+			continue
+		}
+
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(para), "\n", "<br>\n"))
+		b.WriteString("</p>\n")
+	}
+
+	return b.String()
+}
+
+// normalizeNewlines 将 \r\n 和 \r 统一替换为 \n.
+func normalizeNewlines(s string) string {
+	return strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(s)
+}
@@ -0,0 +1,264 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package render
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+)
+
+// allowedTags 是允许出现在渲染结果中的标签以及每个标签允许的属性，其余标签会被移除，但保留标签中的文本.
+var allowedTags = map[string][]string{
+	"a":          {"href", "title", "class"},
+	"abbr":       {"title"},
+	"b":          nil,
+	"blockquote": nil,
+	"br":         nil,
+	"code":       {"class"},
+	"dd":         nil,
+	"del":        nil,
+	"div":        nil,
+	"dl":         nil,
+	"dt":         nil,
+	"em":         nil,
+	"h1":         {"id"},
+	"h2":         {"id"},
+	"h3":         {"id"},
+	"h4":         {"id"},
+	"h5":         {"id"},
+	"h6":         {"id"},
+	"hr":         nil,
+	"i":          nil,
+	"img":        {"src", "alt", "title", "width", "height"},
+	"kbd":        nil,
+	"li":         nil,
+	"mark":       nil,
+	"ol":         {"start"},
+	"p":          nil,
+	"pre":        nil,
+	"s":          nil,
+	"span":       {"class"},
+	"strong":     nil,
+	"sub":        nil,
+	"sup":        nil,
+	"table":      nil,
+	"tbody":      nil,
+	"td":         {"align"},
+	"th":         {"align"},
+	"thead":      nil,
+	"tr":         nil,
+	"u":          nil,
+	"ul":         nil,
+}
+
+// droppedTags 是连同其中的内容一起被移除的标签.
+var droppedTags = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"iframe":   true,
+	"object":   true,
+	"embed":    true,
+	"noscript": true,
+	"template": true,
+	"textarea": true,
+	"title":    true,
+	"svg":      true,
+	"math":     true,
+	"select":   true,
+}
+
+// voidTags 是没有结束标签的标签.
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true}
+
+var (
+	// idPattern 限制标题 id 的格式，与 slug 生成的锚点格式一致.
+	idPattern = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N}_-]*$`)
+
+	// classPattern 是允许的 class，只用于代码高亮和标题锚点.
+	classPattern = regexp.MustCompile(`^(language-[\w+#-]+|hl-[a-z]+|anchor)$`)
+
+	numberPattern = regexp.MustCompile(`^[0-9]{1,9}$`)
+)
+
+// Sanitize 按照白名单过滤 HTML：只保留 allowedTags 中的标签和属性，链接只允许 http、https、mailto 和相对地址，
+// 图片只允许 http、https 和相对地址. 返回的 HTML 中所有标签都是闭合的，可以安全地嵌入页面.
+func Sanitize(s string) string {
+	var (
+		b    strings.Builder
+		open []string // 尚未闭合的标签
+		skip []string // 正在跳过其内容的标签
+	)
+
+	z := nethtml.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		// 输入结束（io.EOF）或者无法继续解析
+		if tt == nethtml.ErrorToken {
+			break
+		}
+
+		tok := z.Token()
+		switch tt {
+		case nethtml.TextToken:
+			if len(skip) == 0 {
+				b.WriteString(html.EscapeString(tok.Data))
+			}
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			if droppedTags[tok.Data] {
+				if tt == nethtml.StartTagToken {
+					skip = append(skip, tok.Data)
+				}
+
+				continue
+			}
+
+			if _, ok := allowedTags[tok.Data]; !ok || len(skip) > 0 {
+				continue
+			}
+
+			writeStartTag(&b, tok)
+			if !voidTags[tok.Data] {
+				if tt == nethtml.SelfClosingTagToken {
+					b.WriteString("</" + tok.Data + ">")
+				} else {
+					open = append(open, tok.Data)
+				}
+			}
+		case nethtml.EndTagToken:
+			if len(skip) > 0 {
+				if skip[len(skip)-1] == tok.Data {
+					skip = skip[:len(skip)-1]
+				}
+
+				continue
+			}
+
+			// 闭合最近一个同名标签以及在它之后打开的标签，没有对应开始标签的结束标签被忽略
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != tok.Data {
+					continue
+				}
+
+				for j := len(open) - 1; j >= i; j-- {
+					b.WriteString("</" + open[j] + ">")
+				}
+
+				open = open[:i]
+
+				break
+			}
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</" + open[i] + ">")
+	}
+
+	return b.String()
+}
+
+// writeStartTag 写入只包含允许属性的开始标签.
+func writeStartTag(b *strings.Builder, tok nethtml.Token) {
+	b.WriteString("<" + tok.Data)
+
+	external := false
+	for _, attr := range tok.Attr {
+		if attr.Namespace != "" || !allowedAttr(tok.Data, attr.Key) {
+			continue
+		}
+
+		val, ok := sanitizeAttr(attr.Key, attr.Val)
+		if !ok {
+			continue
+		}
+
+		if tok.Data == "a" && attr.Key == "href" && hasScheme(val) {
+			external = true
+		}
+
+		b.WriteString(" " + attr.Key + `="` + html.EscapeString(val) + `"`)
+	}
+
+	if external {
+		b.WriteString(` rel="nofollow noopener noreferrer"`)
+	}
+
+	b.WriteString(">")
+}
+
+// allowedAttr 判断 tag 是否允许属性 key.
+func allowedAttr(tag, key string) bool {
+	for _, k := range allowedTags[tag] {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sanitizeAttr 检查并返回过滤后的属性值，返回 false 表示应移除该属性.
+func sanitizeAttr(key, val string) (string, bool) {
+	val = strings.TrimSpace(val)
+	switch key {
+	case "href":
+		return val, safeURL(val, "http", "https", "mailto")
+	case "src":
+		return val, safeURL(val, "http", "https")
+	case "id":
+		return val, idPattern.MatchString(val)
+	case "class":
+		var classes []string
+		for _, class := range strings.Fields(val) {
+			if classPattern.MatchString(class) {
+				classes = append(classes, class)
+			}
+		}
+
+		return strings.Join(classes, " "), len(classes) > 0
+	case "align":
+		return val, val == "left" || val == "center" || val == "right"
+	case "start", "width", "height":
+		return val, numberPattern.MatchString(val)
+	default:
+		return val, true
+	}
+}
+
+// safeURL 判断 u 是否为相对地址或使用 schemes 中的协议.
+// 浏览器会忽略协议中的空白和控制字符，因此检查协议前先移除这些字符，避免 `java\tscript:` 之类的绕过.
+func safeURL(u string, schemes ...string) bool {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+
+		return r
+	}, u)
+
+	if !hasScheme(cleaned) {
+		return true
+	}
+
+	scheme := strings.ToLower(cleaned[:strings.IndexByte(cleaned, ':')])
+	for _, s := range schemes {
+		if scheme == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasScheme 判断 u 是否带有协议，即第一个 `:` 出现在 `/`、`?` 和 `#` 之前.
+func hasScheme(u string) bool {
+	i := strings.IndexAny(u, ":/?#")
+
+	return i > 0 && u[i] == ':'
+}
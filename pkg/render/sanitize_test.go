@@ -0,0 +1,40 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{name: "allowed", html: `<p>a <strong>b</strong><br/>c</p>`, want: `<p>a <strong>b</strong><br>c</p>`},
+		{name: "dropped content", html: `a<script>alert("<p>")</script><style>p{}</style>b`, want: "ab"},
+		{name: "unknown tags keep text", html: `<form><button>ok</button></form>`, want: "ok"},
+		{name: "event handlers", html: `<img src="/a.png" onerror="alert(1)">`, want: `<img src="/a.png">`},
+		{name: "javascript url", html: `<a href="javascript:alert(1)">a</a>`, want: "<a>a</a>"},
+		{name: "obfuscated url", html: `<a href="  JaVa&#09;Script:alert(1)">a</a>`, want: "<a>a</a>"},
+		{name: "data image", html: `<img src="data:image/svg+xml;base64,xxx">`, want: "<img>"},
+		{name: "mailto", html: `<a href="mailto:a@b.com">a</a>`, want: `<a href="mailto:a@b.com" rel="nofollow noopener noreferrer">a</a>`},
+		{name: "relative url", html: `<a href="/posts?a=1&amp;b=2#c">a</a>`, want: `<a href="/posts?a=1&amp;b=2#c">a</a>`},
+		{name: "class", html: `<code class="language-go evil hl-x">x</code>`, want: `<code class="language-go hl-x">x</code>`},
+		{name: "id", html: `<h2 id="intro">a</h2><h2 id="&quot;x">b</h2>`, want: `<h2 id="intro">a</h2><h2>b</h2>`},
+		{name: "unbalanced", html: `<em><strong>a</em>b</strong></p><ul><li>c`, want: "<em><strong>a</strong></em>b<ul><li>c</li></ul>"},
+		{name: "comments", html: `a<!-- <script>x</script> -->b`, want: "ab"},
+		{name: "text escaping", html: `<p title="x">1 &lt; 2 & "q"</p>`, want: "<p>1 &lt; 2 &amp; &#34;q&#34;</p>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Sanitize(tt.html))
+		})
+	}
+}
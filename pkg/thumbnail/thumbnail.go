@@ -0,0 +1,180 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Package thumbnail 解析图片的尺寸并生成缩略图，支持 PNG、JPEG 和 GIF 格式.
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	// 注册 GIF 解码器，PNG 和 JPEG 的解码器通过上面的导入注册.
+	_ "image/gif"
+)
+
+// MaxPixels 是允许解码的图片的最大像素数，防止很小的文件解码后占用过多内存.
+const MaxPixels = 40000000
+
+// jpegQuality 是 JPEG 格式缩略图的编码质量.
+const jpegQuality = 85
+
+// ErrTooLarge 表示图片的像素数超过了 MaxPixels.
+var ErrTooLarge = errors.New("thumbnail: image is too large")
+
+// DecodeConfig 返回 data 中图片的格式（例如 `png`）和尺寸，不解码像素数据.
+func DecodeConfig(data []byte) (format string, width, height int, err error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	return format, cfg.Width, cfg.Height, nil
+}
+
+// Make 为 data 中的图片生成长边为 size 像素的缩略图，返回缩略图的内容和 Content-Type.
+// 不透明的图片编码为 JPEG，带透明像素的图片编码为 PNG. 图片的长边不超过 size 时不需要缩略图，返回的内容为 nil.
+// GIF 动画只使用第一帧.
+func Make(data []byte, size int) ([]byte, string, error) {
+	_, width, height, err := DecodeConfig(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if width <= size && height <= size {
+		return nil, "", nil
+	}
+
+	if int64(width)*int64(height) > MaxPixels {
+		return nil, "", ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return encode(Scale(src, size))
+}
+
+// Square 截取 data 中图片居中的正方形区域并缩小到边长为 size 像素，返回图片的内容和 Content-Type，用于生成头像.
+// 与 Make 不同，正方形区域的边长不超过 size 时也会重新编码，编码格式与 Make 相同.
+func Square(data []byte, size int) ([]byte, string, error) {
+	_, width, height, err := DecodeConfig(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if int64(width)*int64(height) > MaxPixels {
+		return nil, "", ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+
+	b := src.Bounds()
+	side := b.Dx()
+	if b.Dy() < side {
+		side = b.Dy()
+	}
+
+	// 标准库解码得到的图片都实现了 SubImage，截取的区域与原图共享像素
+	origin := b.Min.Add(image.Pt((b.Dx()-side)/2, (b.Dy()-side)/2))
+	if sub, ok := src.(interface {
+		SubImage(r image.Rectangle) image.Image
+	}); ok {
+		src = sub.SubImage(image.Rectangle{Min: origin, Max: origin.Add(image.Pt(side, side))})
+	}
+
+	return encode(Scale(src, size))
+}
+
+// encode 将不透明的图片编码为 JPEG，带透明像素的图片编码为 PNG，返回图片的内容和 Content-Type.
+func encode(thumb *image.RGBA) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if thumb.Opaque() {
+		if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, "", err
+		}
+
+		return buf.Bytes(), "image/jpeg", nil
+	}
+
+	if err := png.Encode(&buf, thumb); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), "image/png", nil
+}
+
+// Scale 将 src 等比例缩小到长边为 size 像素，每个目标像素取其覆盖的所有源像素的平均值，
+// 相比最近邻采样可以避免缩小倍数较大时出现的锯齿和噪点. src 的长边不超过 size 时只复制像素.
+func Scale(src image.Image, size int) *image.RGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dw, dh := sw, sh
+	if sw > size || sh > size {
+		if sw >= sh {
+			dw, dh = size, sh*size/sw
+		} else {
+			dw, dh = sw*size/sh, size
+		}
+	}
+
+	if dw < 1 {
+		dw = 1
+	}
+
+	if dh < 1 {
+		dh = 1
+	}
+
+	// 先转换为 RGBA（预乘 alpha），透明像素的颜色不会影响平均值
+	rgba := image.NewRGBA(image.Rect(0, 0, sw, sh))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for dy := 0; dy < dh; dy++ {
+		y0, y1 := span(dy, dh, sh)
+		for dx := 0; dx < dw; dx++ {
+			x0, x1 := span(dx, dw, sw)
+
+			var sum [4]int
+			for y := y0; y < y1; y++ {
+				row := rgba.Pix[y*rgba.Stride+x0*4 : y*rgba.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+
+			n := (x1 - x0) * (y1 - y0)
+			off := dy*dst.Stride + dx*4
+			for c := 0; c < 4; c++ {
+				dst.Pix[off+c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+
+	return dst
+}
+
+// span 返回第 i 个（共 n 个）目标像素覆盖的源像素范围 [lo, hi)，源长度为 total，范围至少包含一个像素.
+func span(i, n, total int) (lo, hi int) {
+	lo = i * total / n
+	hi = (i + 1) * total / n
+	if hi <= lo {
+		hi = lo + 1
+	}
+
+	return lo, hi
+}
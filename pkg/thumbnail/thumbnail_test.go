@@ -0,0 +1,113 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodePNG(img image.Image) []byte {
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, img)
+
+	return buf.Bytes()
+}
+
+func TestScale(t *testing.T) {
+	// A 4x2 image with black and white columns is averaged to grey.
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 2; y++ {
+			if x%2 == 0 {
+				src.Set(x, y, color.White)
+			} else {
+				src.Set(x, y, color.Black)
+			}
+		}
+	}
+
+	dst := Scale(src, 2)
+	assert.Equal(t, image.Rect(0, 0, 2, 1), dst.Bounds())
+	assert.Equal(t, color.RGBA{R: 128, G: 128, B: 128, A: 255}, dst.RGBAAt(0, 0))
+
+	// Images smaller than size are kept as is.
+	assert.Equal(t, image.Rect(0, 0, 4, 2), Scale(src, 10).Bounds())
+}
+
+func TestMake(t *testing.T) {
+	opaque := image.NewRGBA(image.Rect(0, 0, 300, 100))
+	draw := func(img *image.RGBA, c color.Color) {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			for y := 0; y < img.Bounds().Dy(); y++ {
+				img.Set(x, y, c)
+			}
+		}
+	}
+	draw(opaque, color.RGBA{R: 200, A: 255})
+
+	format, width, height, err := DecodeConfig(encodePNG(opaque))
+	assert.Nil(t, err)
+	assert.Equal(t, "png", format)
+	assert.Equal(t, 300, width)
+	assert.Equal(t, 100, height)
+
+	thumb, contentType, err := Make(encodePNG(opaque), 150)
+	assert.Nil(t, err)
+	assert.Equal(t, "image/jpeg", contentType)
+	_, width, height, _ = DecodeConfig(thumb)
+	assert.Equal(t, 150, width)
+	assert.Equal(t, 50, height)
+
+	transparent := image.NewRGBA(image.Rect(0, 0, 100, 300))
+	_, contentType, err = Make(encodePNG(transparent), 150)
+	assert.Nil(t, err)
+	assert.Equal(t, "image/png", contentType)
+
+	thumb, _, err = Make(encodePNG(opaque), 300)
+	assert.Nil(t, err)
+	assert.Nil(t, thumb)
+
+	_, _, err = Make([]byte("not an image"), 150)
+	assert.NotNil(t, err)
+}
+
+func TestSquare(t *testing.T) {
+	// The left and right quarters are cropped, only the red center is kept.
+	img := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for x := 0; x < 400; x++ {
+		for y := 0; y < 200; y++ {
+			img.Set(x, y, color.RGBA{B: 200, A: 255})
+			if x >= 100 && x < 300 {
+				img.Set(x, y, color.RGBA{R: 200, A: 255})
+			}
+		}
+	}
+
+	data, contentType, err := Square(encodePNG(img), 100)
+	assert.Nil(t, err)
+	assert.Equal(t, "image/jpeg", contentType)
+
+	avatar, _, err := image.Decode(bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 100, 100), avatar.Bounds())
+	for _, p := range []image.Point{{0, 0}, {99, 99}} {
+		r, _, b, _ := avatar.At(p.X, p.Y).RGBA()
+		assert.True(t, r > b, p)
+	}
+
+	// Small images are encoded without being enlarged.
+	data, _, err = Square(encodePNG(image.NewRGBA(image.Rect(0, 0, 30, 50))), 100)
+	assert.Nil(t, err)
+	_, width, height, _ := DecodeConfig(data)
+	assert.Equal(t, 30, width)
+	assert.Equal(t, 30, height)
+}
@@ -43,6 +43,13 @@ func Init(key string, identityKey string) {
 
 // Parse 使用指定的密钥 key 解析 token，解析成功返回 token 上下文，否则报错.
 func Parse(tokenString string, key string) (string, error) {
+	identityKey, _, err := parse(tokenString, key)
+
+	return identityKey, err
+}
+
+// parse 使用指定的密钥 key 解析 token，解析成功返回 token 上下文和 token 的签发时间，否则报错.
+func parse(tokenString string, key string) (string, time.Time, error) {
 	// 解析 token
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		// 确保 token 加密算法是预期的加密算法
@@ -54,31 +61,44 @@ func Parse(tokenString string, key string) (string, error) {
 	})
 	// 解析失败
 	if err != nil {
-		return "", err
+		return "", time.Time{}, err
 	}
 
-	var identityKey string
-	// 如果解析成功，从 token 中取出 token 的主题
+	var (
+		identityKey string
+		issuedAt    time.Time
+	)
+	// 如果解析成功，从 token 中取出 token 的主题和签发时间
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		identityKey = claims[config.identityKey].(string)
+		if iat, ok := claims["iat"].(float64); ok {
+			issuedAt = time.Unix(int64(iat), 0)
+		}
 	}
 
-	return identityKey, nil
+	return identityKey, issuedAt, nil
 }
 
 // ParseRequest 从请求头中获取令牌，并将其传递给 Parse 函数以解析令牌.
 func ParseRequest(c *gin.Context) (string, error) {
+	identityKey, _, err := ParseRequestWithIssuedAt(c)
+
+	return identityKey, err
+}
+
+// ParseRequestWithIssuedAt 与 ParseRequest 相同，但同时返回令牌的签发时间，用于判断令牌是否已被吊销.
+func ParseRequestWithIssuedAt(c *gin.Context) (string, time.Time, error) {
 	header := c.Request.Header.Get("Authorization")
 
 	if len(header) == 0 {
-		return "", ErrMissingHeader
+		return "", time.Time{}, ErrMissingHeader
 	}
 
 	var t string
 	// 从请求头中取出 token
 	fmt.Sscanf(header, "Bearer %s", &t)
 
-	return Parse(t, config.key)
+	return parse(t, config.key)
 }
 
 // Sign 使用 jwtSecret 签发 token，token 的 claims 中会存放传入的 subject.
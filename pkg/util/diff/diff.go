@@ -0,0 +1,262 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Package diff 计算两段文本之间按行比较的差异.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op 是差异中一行的操作类型.
+type Op byte
+
+const (
+	Equal  Op = ' ' // 两段文本中都有的行
+	Delete Op = '-' // 只在旧文本中出现的行
+	Insert Op = '+' // 只在新文本中出现的行
+)
+
+// Edit 是差异中的一行，Line 包含行尾的换行符（最后一行没有换行符时除外）.
+type Edit struct {
+	Op   Op
+	Line string
+}
+
+const (
+	// context 是 unified 格式中每处修改前后保留的上下文行数.
+	context = 3
+
+	// maxEditDistance 限制 Myers 算法搜索的编辑距离，超过时不再寻找最短差异，而是删除所有旧行再插入所有新行，
+	// 避免两段文本差异很大时消耗过多的内存.
+	maxEditDistance = 2000
+)
+
+// Lines 返回将 a 修改为 b 的最短行编辑序列.
+func Lines(a, b []string) []Edit {
+	// 相同的前缀和后缀不需要参与比较
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]Edit, 0, len(a)+len(b)-prefix-suffix)
+	for _, line := range a[:prefix] {
+		edits = append(edits, Edit{Equal, line})
+	}
+
+	edits = append(edits, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, Edit{Equal, line})
+	}
+
+	return edits
+}
+
+// myers 使用 Myers 差异算法计算将 a 修改为 b 的最短编辑序列.
+func myers(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return replace(a, b)
+	}
+
+	// v[k+offset] 是在第 k 条对角线上能到达的最远的 x，trace[d] 保存第 d 轮开始时 v 在 [-d-1, d+1] 范围内的值
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		if d > maxEditDistance {
+			return replace(a, b)
+		}
+
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b)
+			}
+		}
+	}
+
+	return replace(a, b)
+}
+
+// backtrack 根据 myers 保存的每轮搜索状态，从终点反向还原编辑序列.
+func backtrack(trace [][]int, a, b []string) []Edit {
+	var edits []Edit
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		at := func(k int) int { return v[k+d+1] }
+
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		}
+
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			edits = append(edits, Edit{Equal, a[x-1]})
+			x--
+			y--
+		}
+
+		if d == 0 {
+			break
+		}
+
+		if x == prevX {
+			edits = append(edits, Edit{Insert, b[y-1]})
+		} else {
+			edits = append(edits, Edit{Delete, a[x-1]})
+		}
+
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+
+	return edits
+}
+
+// replace 返回删除 a 中所有行再插入 b 中所有行的编辑序列.
+func replace(a, b []string) []Edit {
+	edits := make([]Edit, 0, len(a)+len(b))
+	for _, line := range a {
+		edits = append(edits, Edit{Delete, line})
+	}
+
+	for _, line := range b {
+		edits = append(edits, Edit{Insert, line})
+	}
+
+	return edits
+}
+
+// SplitLines 将文本按行拆分，每行保留行尾的换行符.
+func SplitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// Unified 返回将文本 a 修改为 b 的 unified 格式差异，fromName 和 toName 是差异头中的名称. a 和 b 相同时返回空字符串.
+func Unified(fromName, toName, a, b string) string {
+	edits := Lines(SplitLines(a), SplitLines(b))
+
+	// pos[i] 是 edits[i] 之前旧文本和新文本的行数
+	pos := make([][2]int, len(edits)+1)
+	for i, e := range edits {
+		pos[i+1] = pos[i]
+		if e.Op != Insert {
+			pos[i+1][0]++
+		}
+
+		if e.Op != Delete {
+			pos[i+1][1]++
+		}
+	}
+
+	var buf strings.Builder
+	for start := 0; start < len(edits); {
+		first := start
+		for first < len(edits) && edits[first].Op == Equal {
+			first++
+		}
+
+		if first == len(edits) {
+			break
+		}
+
+		// 相邻修改之间的相同行不超过两倍上下文时合并为一个 hunk
+		end := first
+		for {
+			for end < len(edits) && edits[end].Op != Equal {
+				end++
+			}
+
+			next := end
+			for next < len(edits) && edits[next].Op == Equal {
+				next++
+			}
+
+			if next == len(edits) || next-end > 2*context {
+				break
+			}
+
+			end = next
+		}
+
+		from, to := first-context, end+context
+		if from < start {
+			from = start
+		}
+
+		if to > len(edits) {
+			to = len(edits)
+		}
+
+		if buf.Len() == 0 {
+			fmt.Fprintf(&buf, "--- %s\n+++ %s\n", fromName, toName)
+		}
+
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n",
+			hunkRange(pos[from][0], pos[to][0]-pos[from][0]), hunkRange(pos[from][1], pos[to][1]-pos[from][1]))
+		for _, e := range edits[from:to] {
+			buf.WriteByte(byte(e.Op))
+			buf.WriteString(e.Line)
+			if !strings.HasSuffix(e.Line, "\n") {
+				buf.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		start = to
+	}
+
+	return buf.String()
+}
+
+// hunkRange 返回 hunk 头中的行范围，start 是范围之前的行数.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
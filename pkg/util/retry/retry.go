@@ -0,0 +1,39 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Package retry 提供了失败后按指数退避重试的任务（例如 webhook 投递和领域事件分发）共用的辅助函数.
+package retry
+
+import (
+	"bytes"
+	"time"
+	"unicode/utf8"
+)
+
+// Backoff 返回已经尝试了 attempts 次的任务距离下一次尝试的间隔. 第一次失败后等待 initial，
+// 之后每次失败等待的时间加倍，最长不超过 limit.
+func Backoff(attempts int, initial, limit time.Duration) time.Duration {
+	delay := initial
+	for i := 1; i < attempts && delay < limit; i++ {
+		delay *= 2
+	}
+
+	if delay > limit {
+		return limit
+	}
+
+	return delay
+}
+
+// Truncate 将 s 截断为最多 n 个字节，用于保存失败原因等长度受限的内容. 不会截断多字节的 UTF-8 字符，无效的字符会被替换.
+func Truncate(s string, n int) string {
+	s = string(bytes.ToValidUTF8([]byte(s), []byte("�")))
+	for len(s) > n {
+		_, size := utf8.DecodeLastRuneInString(s)
+		s = s[:len(s)-size]
+	}
+
+	return s
+}
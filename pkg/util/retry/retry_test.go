@@ -0,0 +1,30 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Minute, Backoff(0, time.Minute, time.Hour))
+	assert.Equal(t, time.Minute, Backoff(1, time.Minute, time.Hour))
+	assert.Equal(t, 2*time.Minute, Backoff(2, time.Minute, time.Hour))
+	assert.Equal(t, 32*time.Minute, Backoff(6, time.Minute, time.Hour))
+	assert.Equal(t, time.Hour, Backoff(7, time.Minute, time.Hour))
+	assert.Equal(t, time.Hour, Backoff(1000, time.Minute, time.Hour))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "hello", Truncate("hello", 10))
+	assert.Equal(t, "he", Truncate("hello", 2))
+	assert.Equal(t, "你", Truncate("你好", 4))
+	assert.Equal(t, "", Truncate("你好", 2))
+	assert.Equal(t, "a�", Truncate("a\xff", 10))
+}
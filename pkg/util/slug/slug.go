@@ -0,0 +1,91 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Package slug 根据标题生成适合出现在 URL 中的 slug.
+package slug
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength 是 slug 的最大长度（字符数）.
+const MaxLength = 80
+
+// transliterations 是无法通过去除变音符号转换为 ASCII 的字母的转写规则.
+var transliterations = map[rune]string{
+	// 拉丁字母
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'ł': "l", 'þ': "th", 'ı': "i", 'ŋ': "ng",
+	// 希腊字母
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i", 'κ': "k",
+	'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t",
+	'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+	// 西里尔字母
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+}
+
+// Make 根据 s 生成 slug：字母转换为小写，带变音符号的拉丁字母以及希腊字母和西里尔字母转写为 ASCII，
+// 没有转写规则的其它文字（例如中文）保持不变，其余字符替换为 `-`. 结果最长为 MaxLength 个字符，s 中没有字母和数字时返回空字符串.
+func Make(s string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range norm.NFKD.String(strings.ToLower(s)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+
+		if t, ok := transliterations[r]; ok {
+			if t != "" {
+				b.WriteString(t)
+				hyphen = false
+			}
+
+			continue
+		}
+
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			b.WriteRune(r)
+			hyphen = false
+
+			continue
+		}
+
+		if !hyphen && b.Len() > 0 {
+			b.WriteByte('-')
+			hyphen = true
+		}
+	}
+
+	return truncate(strings.TrimRight(b.String(), "-"))
+}
+
+// truncate 将 slug 截断为最多 MaxLength 个字符，尽量在 `-` 处截断以保留完整的单词.
+func truncate(s string) string {
+	runes := []rune(s)
+	if len(runes) <= MaxLength {
+		return s
+	}
+
+	cut := string(runes[:MaxLength])
+	if runes[MaxLength] == '-' {
+		return cut
+	}
+
+	if i := strings.LastIndexByte(cut, '-'); i > 0 {
+		return cut[:i]
+	}
+
+	return cut
+}
+
+// Valid 判断 s 是否为合法的 slug，即 s 不为空且 Make(s) 不会修改 s.
+func Valid(s string) bool {
+	return s != "" && Make(s) == s
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package slug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "Hello, World!", want: "hello-world"},
+		{title: "  Go 1.19 -- what's new?  ", want: "go-1-19-what-s-new"},
+		{title: "Crème brûlée à la française", want: "creme-brulee-a-la-francaise"},
+		{title: "Straße und Øl", want: "strasse-und-ol"},
+		{title: "Привет, мир", want: "privet-mir"},
+		{title: "Καλημέρα", want: "kalimera"},
+		{title: "ｆｕｌｌｗｉｄｔｈ ①", want: "fullwidth-1"},
+		{title: "Go 语言入门", want: "go-语言入门"},
+		{title: "!!! ???", want: ""},
+		{title: strings.Repeat("word ", 20), want: strings.TrimSuffix(strings.Repeat("word-", 16), "-")},
+		{title: strings.Repeat("a", 100), want: strings.Repeat("a", MaxLength)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.want, Make(tt.title))
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, Valid("hello-world"))
+	assert.True(t, Valid("go-语言"))
+	assert.False(t, Valid(""))
+	assert.False(t, Valid("Hello"))
+	assert.False(t, Valid("hello--world"))
+	assert.False(t, Valid("-hello"))
+	assert.False(t, Valid("a/b"))
+}
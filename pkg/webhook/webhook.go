@@ -0,0 +1,106 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+// Package webhook 实现了 webhook 请求的签名和投递 webhook 使用的 HTTP 客户端.
+//
+// 每个请求的请求体使用 webhook 的密钥计算 HMAC-SHA256 签名，以 `sha256=<hex>` 的格式放在
+// X-Miniblog-Signature 请求头中，接收方使用相同的密钥调用 Verify 校验请求是否来自 miniblog.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// webhook 请求的请求头.
+const (
+	// HeaderSignature 是请求体的 HMAC-SHA256 签名.
+	HeaderSignature = "X-Miniblog-Signature"
+	// HeaderEvent 是触发请求的事件类型.
+	HeaderEvent = "X-Miniblog-Event"
+	// HeaderDelivery 是投递的唯一 ID，重新投递和重试时不变，接收方可以用来去重.
+	HeaderDelivery = "X-Miniblog-Delivery"
+)
+
+// signaturePrefix 是签名的前缀，表示签名使用的算法.
+const signaturePrefix = "sha256="
+
+// ErrPrivateAddress 表示 webhook 的地址解析到了回环地址、私有地址等内部网络的地址.
+var ErrPrivateAddress = errors.New("webhook address resolves to a private network")
+
+// Sign 使用 secret 计算 body 的签名.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 校验 signature 是否是使用 secret 计算的 body 的签名.
+func Verify(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// GenerateSecret 生成一个随机的密钥，用于用户创建 webhook 时没有指定密钥的情况.
+func GenerateSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// NewClient 创建投递 webhook 使用的 HTTP 客户端，timeout 是每个请求的超时时间. 客户端不跟随重定向.
+// allowPrivate 为 false 时，客户端拒绝连接内部网络的地址，避免用户通过 webhook 访问服务端所在的内网.
+func NewClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		// 在建立连接时检查 IP 地址，而不是在解析域名时，避免 DNS 重绑定绕过检查
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || IsPrivateIP(ip) {
+				return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+			}
+
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// IsPrivateIP 返回 ip 是否是回环地址、私有地址、链路本地地址或未指定地址.
+func IsPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified()
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 Innkeeper Jayflow <jxs121@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/marmotedu/miniblog.
+
+package webhook
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"post.created"}`)
+
+	// echo -n '{"event":"post.created"}' | openssl dgst -sha256 -hmac secret
+	signature := Sign("secret", body)
+	assert.Equal(t, "sha256=5bfab6fc075cfd13eb347eb022171d1fd85adce64716a0568bf15f9feb55c258", signature)
+
+	assert.True(t, Verify("secret", body, signature))
+	assert.False(t, Verify("other", body, signature))
+	assert.False(t, Verify("secret", []byte(`{}`), signature))
+	assert.False(t, Verify("secret", body, signature[len("sha256="):]))
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	assert.Nil(t, err)
+	assert.Len(t, a, 48)
+
+	b, _ := GenerateSecret()
+	assert.NotEqual(t, a, b)
+}
+
+func TestIsPrivateIP(t *testing.T) {
+	for _, ip := range []string{"127.0.0.1", "10.0.0.1", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0", "::1", "fd00::1", "fe80::1"} {
+		assert.True(t, IsPrivateIP(net.ParseIP(ip)), ip)
+	}
+
+	for _, ip := range []string{"8.8.8.8", "1.1.1.1", "2001:4860:4860::8888"} {
+		assert.False(t, IsPrivateIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(time.Second, false).Get(srv.URL)
+	assert.True(t, errors.Is(err, ErrPrivateAddress))
+
+	resp, err := NewClient(time.Second, true).Get(srv.URL)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+}